@@ -0,0 +1,115 @@
+package fixpoint
+
+import "math"
+
+// Accumulator64 sums products of Q24 numbers in an int64, and rounds only once
+// when the result is extracted. Summing MulRound results instead rounds every
+// term, so the error grows with the number of terms: a sum of 1000 products
+// can be off by up to 500 ULPs, while the result of an Accumulator64 is within
+// half an ULP. Use it for dot products, norms, variances, filter taps and
+// other reductions of many terms.
+//
+// The zero value is an empty sum with 48 fractional bits, which keeps the
+// products exact.
+type Accumulator64 struct {
+	// Frac is the number of fractional bits of the sum, between 24 and 48.
+	// Zero means 48. With 48 bits every product is exact, but the sum must
+	// stay within ±32768, which is for example enough for 2^15 products of
+	// unit values. Every bit less doubles that range, at the cost of
+	// rounding each product to the given number of bits: a Frac of 32 can
+	// sum about 2^31 in magnitude, like a Q32. Change it only while the sum
+	// is empty.
+	Frac uint
+
+	sum int64
+}
+
+// frac returns the number of fractional bits of the sum.
+func (a *Accumulator64) frac() uint {
+	if a.Frac == 0 || a.Frac > 48 {
+		return 48
+	}
+	if a.Frac < 24 {
+		return 24
+	}
+	return a.Frac
+}
+
+// Reset empties the sum, keeping the number of fractional bits.
+func (a *Accumulator64) Reset() {
+	a.sum = 0
+}
+
+// Add adds a Q24 number to the sum, which is always exact.
+func (a *Accumulator64) Add(x Q24) {
+	a.sum += int64(x.N) << (a.frac() - 24)
+}
+
+// AddProduct adds the product of x and y to the sum.
+func (a *Accumulator64) AddProduct(x, y Q24) {
+	a.sum += roundShift(int64(x.N)*int64(y.N), 48-a.frac())
+}
+
+// SubProduct subtracts the product of x and y from the sum, for example for
+// determinants and cross products.
+func (a *Accumulator64) SubProduct(x, y Q24) {
+	a.sum -= roundShift(int64(x.N)*int64(y.N), 48-a.frac())
+}
+
+// AddSquare adds the square of x to the sum.
+func (a *Accumulator64) AddSquare(x Q24) {
+	a.AddProduct(x, x)
+}
+
+// AddDot adds the dot product of two vectors to the sum.
+func (a *Accumulator64) AddDot(v1, v2 Vec3Q24) {
+	a.AddProduct(v1.X, v2.X)
+	a.AddProduct(v1.Y, v2.Y)
+	a.AddProduct(v1.Z, v2.Z)
+}
+
+// Q24 returns the sum rounded to the nearest Q24 and saturated to the Q24
+// range.
+func (a *Accumulator64) Q24() Q24 {
+	return saturate(roundShift(a.sum, a.frac()-24))
+}
+
+// Q32 returns the sum as a Q32, rounded to the nearest value when the sum has
+// more than 32 fractional bits and saturated when it has fewer and doesn't fit.
+func (a *Accumulator64) Q32() Q32 {
+	frac := a.frac()
+	if frac >= 32 {
+		return Q32{roundShift(a.sum, frac-32)}
+	}
+	return Q32{saturateShift(a.sum, 32-frac)}
+}
+
+// Q48x16 returns the sum as a fixed point number with 48 fractional bits and
+// 16 integer bits (including the sign) in an int64, without any rounding. It
+// is exact with the default of 48 fractional bits, and saturates for sums
+// outside ±32768 with fewer bits. Use it to continue the calculation with the
+// full precision of the sum, for example to compare two sums of squares.
+func (a *Accumulator64) Q48x16() int64 {
+	return saturateShift(a.sum, 48-a.frac())
+}
+
+// roundShift returns n shifted right by s bits, rounded to the nearest value
+// (with ties rounded up, like MulRound).
+func roundShift(n int64, s uint) int64 {
+	if s == 0 {
+		return n
+	}
+	return (n + 1<<(s-1)) >> s
+}
+
+// saturateShift returns n shifted left by s bits, saturated to the range of
+// an int64.
+func saturateShift(n int64, s uint) int64 {
+	if n > math.MaxInt64>>s {
+		return math.MaxInt64
+	}
+	if n < math.MinInt64>>s {
+		return math.MinInt64
+	}
+	return n << s
+}
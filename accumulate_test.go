@@ -0,0 +1,122 @@
+package fixpoint
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccumulator64(t *testing.T) {
+	var acc Accumulator64
+	assert.Equal(t, Q24{}, acc.Q24())
+	assert.Equal(t, int64(0), acc.Q48x16())
+
+	acc.AddProduct(Q24FromFloat(1.5), Q24FromFloat(-2))
+	acc.AddSquare(Q24FromInt32(2))
+	acc.Add(Q24FromFloat(0.25))
+	acc.SubProduct(Q24FromFloat(0.5), Q24FromFloat(0.5))
+	assert.Equal(t, Q24FromInt32(1), acc.Q24())
+	assert.Equal(t, Q32FromInt32(1), acc.Q32())
+	assert.Equal(t, int64(1)<<48, acc.Q48x16())
+
+	acc.Reset()
+	acc.AddDot(Vec3Q24FromFloat(1, 2, 3), Vec3Q24FromFloat(4, -5, 6))
+	assert.Equal(t, Q24FromInt32(12), acc.Q24())
+
+	// Products below the resolution of a Q24 still add up: the square of
+	// 2^-13 is 2^-26, which Mul rounds down to zero, but 2^16 of them are
+	// exactly 2^-10.
+	acc.Reset()
+	var rounded Q24
+	tiny := Q24{1 << 11}
+	for i := 0; i < 1<<16; i++ {
+		acc.AddSquare(tiny)
+		rounded = rounded.Add(tiny.Mul(tiny))
+	}
+	assert.Equal(t, Q24{1 << 14}, acc.Q24())
+	assert.Equal(t, Q24{}, rounded)
+
+	// The result is rounded to the nearest value, and saturates.
+	acc.Reset()
+	acc.AddProduct(Q24{3}, Q24{1 << 23}) // 1.5 ULP
+	assert.Equal(t, Q24{2}, acc.Q24())
+	acc.Reset()
+	acc.AddProduct(Q24{-3}, Q24{1 << 23})
+	assert.Equal(t, Q24{-1}, acc.Q24())
+	acc.Reset()
+	acc.AddProduct(Q24FromInt32(100), Q24FromInt32(100))
+	assert.Equal(t, MaxQ24, acc.Q24())
+	assert.Equal(t, Q32FromInt32(10000), acc.Q32())
+	acc.SubProduct(Q24FromInt32(100), Q24FromInt32(100))
+	acc.SubProduct(Q24FromInt32(100), Q24FromInt32(100))
+	assert.Equal(t, MinQ24, acc.Q24())
+}
+
+func TestAccumulator64Frac(t *testing.T) {
+	// With fewer fractional bits, much larger sums fit.
+	acc := Accumulator64{Frac: 32}
+	for i := 0; i < 1000; i++ {
+		acc.AddSquare(MaxQ24)
+	}
+	assert.InDelta(t, 1000*MaxQ24.Float64()*MaxQ24.Float64(), acc.Q32().Float64(), 1e-6)
+	assert.Equal(t, MaxQ24, acc.Q24())
+	assert.Equal(t, int64(math.MaxInt64), acc.Q48x16())
+	acc.Reset()
+	for i := 0; i < 3; i++ {
+		acc.SubProduct(MaxQ24, MaxQ24)
+	}
+	assert.Equal(t, int64(math.MinInt64), acc.Q48x16())
+
+	acc.Reset()
+	acc.Add(Q24FromFloat(0.75))
+	acc.AddProduct(Q24FromFloat(0.5), Q24FromFloat(0.5))
+	assert.Equal(t, Q24FromInt32(1), acc.Q24())
+	assert.Equal(t, int64(1)<<48, acc.Q48x16())
+
+	// The number of fractional bits is limited to [24, 48].
+	acc = Accumulator64{Frac: 8}
+	acc.AddProduct(Q24{3}, Q24{1 << 23})
+	assert.Equal(t, Q24{2}, acc.Q24())
+	assert.Equal(t, Q32{2 << 8}, acc.Q32())
+	acc = Accumulator64{Frac: 60}
+	acc.AddProduct(Q24{3}, Q24{1 << 23})
+	assert.Equal(t, int64(3)<<23, acc.Q48x16())
+}
+
+// TestAccumulator64Error compares a long dot product against the exact result,
+// and against the sum of rounded products.
+func TestAccumulator64Error(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	exact := new(big.Int)
+	var acc Accumulator64
+	var rounded Q24
+	for i := 0; i < 1000; i++ {
+		a := Q24{int32(r.Int63n(2<<24) - 1<<24)}
+		b := Q24{int32(r.Int63n(2<<24) - 1<<24)}
+		exact.Add(exact, big.NewInt(int64(a.N)*int64(b.N)))
+		acc.AddProduct(a, b)
+		rounded = rounded.Add(a.MulRound(b))
+	}
+	assert.Equal(t, exact.Int64(), acc.Q48x16())
+
+	expected, _ := new(big.Float).SetInt(exact).Float64()
+	expected /= 1 << 24
+	if err := math.Abs(float64(acc.Q24().N) - expected); err > 0.5 {
+		t.Errorf("error of %.3f ULP is above half an ULP", err)
+	}
+	if err := math.Abs(float64(rounded.N) - expected); err <= 0.5 {
+		t.Errorf("expected the sum of rounded products to be less precise, got an error of %.3f ULP", err)
+	}
+}
+
+func BenchmarkAccumulator64(b *testing.B) {
+	v := Vec3Q24FromFloat(0.5, -0.25, 0.75)
+	var acc Accumulator64
+	for i := 0; i < b.N; i++ {
+		acc.AddDot(v, v)
+	}
+	_ = acc.Q24()
+}
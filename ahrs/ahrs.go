@@ -0,0 +1,75 @@
+// Package ahrs implements attitude and heading reference systems: sensor
+// fusion filters that estimate the orientation of a device from gyroscope,
+// accelerometer and (optionally) magnetometer readings. All arithmetic is done
+// in fixed point, which makes them suitable for microcontrollers without an
+// FPU.
+//
+// All filters use the same conventions. The orientation is a unit quaternion
+// that rotates vectors from the body frame of the sensor to the earth frame.
+// The earth frame has the X axis pointing north and the Z axis pointing up, so
+// an accelerometer at rest measures gravity as (0, 0, 1) when the device is
+// level. Gyroscope readings are in radians per second and dt is in seconds.
+// Accelerometer and magnetometer readings may have any unit, as they are
+// normalized before use.
+//
+// The Variometer estimates altitude and vertical speed instead, from a
+// barometer and the vertical acceleration. The GravityEstimator only
+// estimates the direction of gravity, trusting the accelerometer less while
+// the device accelerates.
+package ahrs
+
+import (
+	"math"
+
+	"github.com/aykevl/fixpoint"
+)
+
+// orientation returns q, or the identity quaternion if q is the zero value
+// (which is not a valid orientation).
+func orientation(q fixpoint.QuatQ24) fixpoint.QuatQ24 {
+	if q == (fixpoint.QuatQ24{}) {
+		return fixpoint.QuatIdent()
+	}
+	return q
+}
+
+// integrate returns q + ½q⊗(0, gyro)·dt, which is q rotated by the angular
+// rate gyro during dt, to first order. The result is not normalized.
+func integrate(q fixpoint.QuatQ24, gyro fixpoint.Vec3Q24, dt fixpoint.Q24) fixpoint.QuatQ24 {
+	half := fixpoint.Vec3Q24{X: halfMul(gyro.X, dt), Y: halfMul(gyro.Y, dt), Z: halfMul(gyro.Z, dt)}
+	dq := q.Mul(fixpoint.QuatQ24{V: half})
+	return fixpoint.QuatQ24{W: q.W.Add(dq.W), V: q.V.Add(dq.V)}
+}
+
+// halfMul returns a*b/2 with a single rounding step.
+func halfMul(a, b fixpoint.Q24) fixpoint.Q24 {
+	return fixpoint.Q24{N: int32((int64(a.N)*int64(b.N) + 1<<24) >> 25)}
+}
+
+// gravity returns the direction of gravity in the body frame as estimated by
+// the orientation q. This is the earth Z axis rotated by the inverse of q.
+func gravity(q fixpoint.QuatQ24) fixpoint.Vec3Q24 {
+	w, x, y, z := q.W, q.V.X, q.V.Y, q.V.Z
+	two := fixpoint.Q24FromInt32(2)
+	return fixpoint.Vec3Q24{
+		X: two.Mul(x.MulRound(z).Sub(w.MulRound(y))),
+		Y: two.Mul(w.MulRound(x).Add(y.MulRound(z))),
+		Z: w.MulRound(w).Sub(x.MulRound(x)).Sub(y.MulRound(y)).Add(z.MulRound(z)),
+	}
+}
+
+// IntegrationErrorBound returns a worst-case bound in radians on the
+// orientation error that rounding adds while integrating the gyroscope for the
+// given number of updates. This is how much the heading may drift because of
+// the fixed point arithmetic alone, when it isn't corrected. In practice the
+// rounding errors partially cancel out, so the typical drift is a lot smaller.
+func IntegrationErrorBound(updates int) float64 {
+	// Per quaternion element: the rounding of the half angle (propagated
+	// through the three products of each element), the three rounded
+	// products of the quaternion multiplication, and normalization.
+	perElement := 0.5*math.Sqrt(3) + 3*fixpoint.MulRoundErrorULP + fixpoint.NormalizeErrorULP
+	// An error of e in each of the four elements changes the quaternion by at
+	// most 2e, which rotates by at most twice that angle.
+	perUpdate := 4 * perElement / (1 << 24)
+	return perUpdate * float64(updates)
+}
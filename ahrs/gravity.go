@@ -0,0 +1,103 @@
+package ahrs
+
+import (
+	"github.com/aykevl/fixpoint"
+)
+
+// GravityEstimator estimates the direction of gravity in the body frame by
+// low-pass filtering the accelerometer, while the gyroscope (when available)
+// keeps the estimate up to date during rotations. Unlike a low-pass filter
+// with a fixed gain, it trusts the accelerometer less when the magnitude of
+// the acceleration deviates from 1g, which means the device is accelerating
+// and the accelerometer doesn't measure gravity alone. The accelerometer must
+// be in units of standard gravity (g).
+//
+// The zero value trusts every accelerometer reading, but it only integrates
+// the gyroscope: set Gain to enable the correction.
+type GravityEstimator struct {
+	// Gain is the fraction of the difference between the measured and
+	// estimated direction of gravity that is corrected per second, when the
+	// accelerometer is fully trusted. A typical value is 1 to 5.
+	Gain fixpoint.Q24
+
+	// Tolerance is the difference between the magnitude of the acceleration
+	// and 1g at which the accelerometer isn't trusted at all, for example
+	// 0.2. The trust decreases linearly from 1 at 1g to 0 at this
+	// difference. A zero Tolerance trusts every reading, like a fixed gain.
+	Tolerance fixpoint.Q24
+
+	initialized bool
+	g           fixpoint.Vec3Q24
+	trust       fixpoint.Q24
+}
+
+// Gravity returns the estimated direction of gravity in the body frame as a
+// unit vector, or (0, 0, 1) before the first update.
+func (f *GravityEstimator) Gravity() fixpoint.Vec3Q24 {
+	if !f.initialized {
+		return fixpoint.Vec3Q24{Z: fixpoint.Q24FromInt32(1)}
+	}
+	return f.g
+}
+
+// Trust returns how much the last accelerometer reading was trusted, between
+// 0 and 1. The gain of the last update was Gain multiplied by this factor.
+func (f *GravityEstimator) Trust() fixpoint.Q24 {
+	return f.trust
+}
+
+// Update updates the estimate with a gyroscope and accelerometer reading
+// taken dt seconds after the previous one. Pass a zero gyroscope reading when
+// there is no gyroscope. The first update with a nonzero acceleration starts
+// the estimate at its direction.
+func (f *GravityEstimator) Update(gyro, accel fixpoint.Vec3Q24, dt fixpoint.Q24) {
+	f.trust = AccelTrust(accel, f.Tolerance)
+	if !f.initialized {
+		if accel != (fixpoint.Vec3Q24{}) {
+			f.g = accel.Normalize()
+			f.initialized = true
+		}
+		return
+	}
+
+	// Gravity is fixed in the earth frame, so in the body frame it rotates
+	// the opposite way of the device.
+	g := f.g.Add(f.g.Cross(gyro.Mul(dt)))
+
+	if f.trust.N != 0 {
+		k := f.Gain.MulRound(dt).MulRound(f.trust)
+		if k.N > 1<<24 {
+			k = fixpoint.Q24FromInt32(1)
+		}
+		g = g.Add(accel.Normalize().Sub(g).Mul(k))
+	}
+	f.g = g.Normalize()
+}
+
+// Reset restarts the estimate at the next accelerometer reading.
+func (f *GravityEstimator) Reset() {
+	*f = GravityEstimator{Gain: f.Gain, Tolerance: f.Tolerance}
+}
+
+// AccelTrust returns how much an accelerometer reading in units of standard
+// gravity (g) can be trusted to measure the direction of gravity, between 0
+// and 1. It is 1 when the magnitude of the acceleration is 1g and decreases
+// linearly to 0 when it differs from 1g by tolerance or more, for example to
+// scale the gain of an accelerometer correction. A zero tolerance trusts
+// every reading, except a zero acceleration (for example in free fall).
+func AccelTrust(accel fixpoint.Vec3Q24, tolerance fixpoint.Q24) fixpoint.Q24 {
+	if accel == (fixpoint.Vec3Q24{}) {
+		return fixpoint.Q24{}
+	}
+	if tolerance.N <= 0 {
+		return fixpoint.Q24FromInt32(1)
+	}
+	diff := int64(accel.Len().N) - 1<<24
+	if diff < 0 {
+		diff = -diff
+	}
+	if diff >= int64(tolerance.N) {
+		return fixpoint.Q24{}
+	}
+	return fixpoint.Q24{N: int32(1<<24 - (diff<<24+int64(tolerance.N)/2)/int64(tolerance.N))}
+}
@@ -0,0 +1,79 @@
+package ahrs
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGravityEstimator(t *testing.T) {
+	dt := fixpoint.Q24FromFloat(0.01)
+	f := GravityEstimator{Gain: fixpoint.Q24FromInt32(2), Tolerance: fixpoint.Q24FromFloat(0.2)}
+	assert.Equal(t, fixpoint.Vec3Q24FromFloat(0, 0, 1), f.Gravity())
+
+	// The first reading starts the estimate.
+	f.Update(fixpoint.Vec3Q24{}, fixpoint.Vec3Q24FromFloat(0, 0, 0.5), dt)
+	assert.Equal(t, fixpoint.Vec3Q24FromFloat(0, 0, 1), f.Gravity())
+	assert.Equal(t, fixpoint.Q24{}, f.Trust())
+
+	// Tilted by 30° around X, at rest: the estimate converges.
+	sin, cos := float32(math.Sin(math.Pi/6)), float32(math.Cos(math.Pi/6))
+	tilted := fixpoint.Vec3Q24FromFloat(0, sin, cos)
+	for i := 0; i < 500; i++ {
+		f.Update(fixpoint.Vec3Q24{}, tilted, dt)
+	}
+	assert.Equal(t, fixpoint.Q24FromInt32(1).N, f.Trust().N)
+	assert.InDelta(t, sin, f.Gravity().Y.Float(), 1e-3)
+	assert.InDelta(t, cos, f.Gravity().Z.Float(), 1e-3)
+
+	// A strong acceleration sideways isn't trusted at all, so the estimate
+	// doesn't move.
+	before := f.Gravity()
+	for i := 0; i < 100; i++ {
+		f.Update(fixpoint.Vec3Q24{}, tilted.Add(fixpoint.Vec3Q24FromFloat(0.8, 0, 0)), dt)
+	}
+	assert.Equal(t, fixpoint.Q24{}, f.Trust())
+	assert.Equal(t, before, f.Gravity())
+
+	// Rotating back to level with the gyroscope only (the accelerometer is
+	// in free fall) makes gravity point up in the body frame again.
+	rate := fixpoint.Vec3Q24FromFloat(-math.Pi/6, 0, 0)
+	for i := 0; i < 100; i++ {
+		f.Update(rate, fixpoint.Vec3Q24{}, dt)
+	}
+	assert.InDelta(t, 0, f.Gravity().Y.Float(), 2e-3)
+	assert.InDelta(t, 1, f.Gravity().Z.Float(), 1e-3)
+
+	f.Reset()
+	assert.Equal(t, fixpoint.Vec3Q24FromFloat(0, 0, 1), f.Gravity())
+	assert.Equal(t, fixpoint.Q24FromInt32(2), f.Gain)
+}
+
+func TestAccelTrust(t *testing.T) {
+	tolerance := fixpoint.Q24FromFloat(0.2)
+	for _, tc := range []struct {
+		accel fixpoint.Vec3Q24
+		trust float32
+	}{
+		{fixpoint.Vec3Q24FromFloat(0, 0, 1), 1},
+		{fixpoint.Vec3Q24FromFloat(0, 0, -1), 1},
+		{fixpoint.Vec3Q24FromFloat(0, 0.6, 0.8), 1},
+		{fixpoint.Vec3Q24FromFloat(0, 0, 1.1), 0.5},
+		{fixpoint.Vec3Q24FromFloat(0.95, 0, 0), 0.75},
+		{fixpoint.Vec3Q24FromFloat(0, 0, 1.2), 0},
+		{fixpoint.Vec3Q24FromFloat(0, 0, 0.5), 0},
+		{fixpoint.Vec3Q24FromFloat(0, 0, 20), 0},
+		{fixpoint.Vec3Q24{}, 0},
+	} {
+		trust := AccelTrust(tc.accel, tolerance)
+		if math.Abs(float64(trust.Float()-tc.trust)) > 1e-5 {
+			t.Errorf("AccelTrust(%v): expected %f, got %f", tc.accel, tc.trust, trust.Float())
+		}
+	}
+
+	// Without tolerance, every nonzero reading is trusted.
+	assert.Equal(t, fixpoint.Q24FromInt32(1), AccelTrust(fixpoint.Vec3Q24FromFloat(0, 0, 3), fixpoint.Q24{}))
+	assert.Equal(t, fixpoint.Q24{}, AccelTrust(fixpoint.Vec3Q24{}, fixpoint.Q24{}))
+}
@@ -0,0 +1,80 @@
+package ahrs
+
+import (
+	"github.com/aykevl/fixpoint"
+)
+
+// Madgwick is the gradient descent orientation filter by Sebastian Madgwick.
+// It corrects the integrated gyroscope rate with a gradient descent step
+// towards the orientation that matches the measured direction of gravity.
+// This implementation uses the gyroscope and accelerometer only, so the
+// heading is not corrected and will drift.
+//
+// The zero value is a valid filter starting at the identity orientation, but
+// it only integrates the gyroscope: set Beta to enable the correction.
+//
+// See: https://x-io.co.uk/open-source-imu-and-ahrs-algorithms/
+type Madgwick struct {
+	// Beta is the gain of the gradient descent step, which should be about
+	// the gyroscope measurement error in rad/s. A typical value is 0.1.
+	Beta fixpoint.Q24
+
+	q fixpoint.QuatQ24
+}
+
+// Orientation returns the current orientation estimate.
+func (f *Madgwick) Orientation() fixpoint.QuatQ24 {
+	return orientation(f.q)
+}
+
+// SetOrientation sets the orientation estimate, for example from an initial
+// reading or a known starting position.
+func (f *Madgwick) SetOrientation(q fixpoint.QuatQ24) {
+	f.q = q
+}
+
+// Update updates the orientation estimate with a new gyroscope and
+// accelerometer reading taken dt seconds after the previous one. An
+// accelerometer reading of zero (for example in free fall) only integrates the
+// gyroscope.
+func (f *Madgwick) Update(gyro, accel fixpoint.Vec3Q24, dt fixpoint.Q24) {
+	q := orientation(f.q)
+	next := integrate(q, gyro, dt)
+
+	if accel != (fixpoint.Vec3Q24{}) && f.Beta.N != 0 {
+		// Objective function: the estimated minus the measured direction of
+		// gravity, in the body frame.
+		a := accel.Normalize()
+		g := gravity(q)
+		f1, f2, f3 := g.X.Sub(a.X), g.Y.Sub(a.Y), g.Z.Sub(a.Z)
+
+		// Gradient: the transposed Jacobian of the objective function
+		// multiplied by the objective function.
+		w, x, y, z := q.W, q.V.X, q.V.Y, q.V.Z
+		two := fixpoint.Q24FromInt32(2)
+		step := fixpoint.QuatQ24{
+			W: x.MulRound(f2).Sub(y.MulRound(f1)),
+			V: fixpoint.Vec3Q24{
+				X: z.MulRound(f1).Add(w.MulRound(f2)).Sub(two.Mul(x.MulRound(f3))),
+				Y: z.MulRound(f2).Sub(w.MulRound(f1)).Sub(two.Mul(y.MulRound(f3))),
+				Z: x.MulRound(f1).Add(y.MulRound(f2)),
+			},
+		}
+		// The constant factor 2 of the Jacobian doesn't matter, as the step
+		// is normalized.
+		if step != (fixpoint.QuatQ24{}) {
+			step = step.Normalize()
+			gain := f.Beta.MulRound(dt)
+			next = fixpoint.QuatQ24{
+				W: next.W.Sub(step.W.MulRound(gain)),
+				V: fixpoint.Vec3Q24{
+					X: next.V.X.Sub(step.V.X.MulRound(gain)),
+					Y: next.V.Y.Sub(step.V.Y.MulRound(gain)),
+					Z: next.V.Z.Sub(step.V.Z.MulRound(gain)),
+				},
+			}
+		}
+	}
+
+	f.q = next.Normalize()
+}
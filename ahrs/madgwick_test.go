@@ -0,0 +1,68 @@
+package ahrs
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMadgwick(t *testing.T) {
+	dt := fixpoint.Q24FromFloat(0.01)
+
+	// Without correction, the gyroscope is integrated.
+	var f Madgwick
+	assert.Equal(t, fixpoint.QuatIdent(), f.Orientation())
+	gyro := fixpoint.Vec3Q24FromFloat(0.5, -1, 0.25)
+	for i := 0; i < 100; i++ {
+		f.Update(gyro, fixpoint.Vec3Q24{}, dt)
+	}
+	want := fixpoint.QuatFromAxisAngle(gyro, gyro.Len())
+	assert.InDelta(t, 0, angleBetween(want, f.Orientation()), 0.0002)
+
+	// The tilt converges to the measured direction of gravity.
+	truth := fixpoint.QuatFromEuler(fixpoint.Q24FromFloat(-1), fixpoint.Q24FromFloat(0.7), fixpoint.Q24FromFloat(0.5))
+	accel, _ := readings(truth)
+	f = Madgwick{Beta: fixpoint.Q24FromFloat(0.5)}
+	for i := 0; i < 2000; i++ {
+		f.Update(fixpoint.Vec3Q24{}, accel, dt)
+	}
+	g := gravity(f.Orientation())
+	a := accel.Normalize()
+	assert.InDelta(t, a.X.Float(), g.X.Float(), 0.001)
+	assert.InDelta(t, a.Y.Float(), g.Y.Float(), 0.001)
+	assert.InDelta(t, a.Z.Float(), g.Z.Float(), 0.001)
+
+	f.SetOrientation(truth)
+	assert.Equal(t, truth, f.Orientation())
+}
+
+func TestIntegrationErrorBound(t *testing.T) {
+	assert.Equal(t, 0.0, IntegrationErrorBound(0))
+	assert.InDelta(t, 7.1e-7, IntegrationErrorBound(1), 0.1e-7)
+
+	// Integrate the same rotation rate in floating point.
+	const n = 5000
+	dt := fixpoint.Q24FromFloat(0.002)
+	gyro := fixpoint.Vec3Q24FromFloat(2.5, -1, 0.75)
+	var f Madgwick
+	w, x, y, z := 1.0, 0.0, 0.0, 0.0
+	hx, hy, hz := gyro.X.Float64()*dt.Float64()/2, gyro.Y.Float64()*dt.Float64()/2, gyro.Z.Float64()*dt.Float64()/2
+	for i := 0; i < n; i++ {
+		f.Update(gyro, fixpoint.Vec3Q24{}, dt)
+		w, x, y, z = w-x*hx-y*hy-z*hz, x+w*hx+y*hz-z*hy, y+w*hy+z*hx-x*hz, z+w*hz+x*hy-y*hx
+		l := math.Sqrt(w*w + x*x + y*y + z*z)
+		w, x, y, z = w/l, x/l, y/l, z/l
+	}
+
+	// The angle of the rotation between both orientations.
+	q := f.Orientation()
+	qw, qx, qy, qz := q.W.Float64(), q.V.X.Float64(), q.V.Y.Float64(), q.V.Z.Float64()
+	ex := w*qx - x*qw - y*qz + z*qy
+	ey := w*qy - y*qw - z*qx + x*qz
+	ez := w*qz - z*qw - x*qy + y*qx
+	angle := 2 * math.Asin(math.Sqrt(ex*ex+ey*ey+ez*ez))
+	assert.True(t, angle <= IntegrationErrorBound(n), "angle %v above bound %v", angle, IntegrationErrorBound(n))
+	assert.True(t, angle > 0)
+}
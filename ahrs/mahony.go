@@ -0,0 +1,89 @@
+package ahrs
+
+import (
+	"github.com/aykevl/fixpoint"
+)
+
+// Mahony is the nonlinear complementary filter by Robert Mahony et al. It
+// corrects the integrated gyroscope rate with a PI controller on the error
+// between the measured and estimated directions of gravity (and, optionally,
+// of the magnetic field). The integral term compensates for gyroscope bias.
+//
+// The zero value is a valid filter starting at the identity orientation, but
+// it only integrates the gyroscope: set Kp to enable the correction.
+//
+// See: https://hal.archives-ouvertes.fr/hal-00488376/document
+type Mahony struct {
+	// Kp is the proportional gain. A typical value is 1.
+	Kp fixpoint.Q24
+
+	// Ki is the integral gain, used to estimate gyroscope bias. Zero disables
+	// bias estimation.
+	Ki fixpoint.Q24
+
+	q        fixpoint.QuatQ24
+	integral fixpoint.Vec3Q24
+}
+
+// Orientation returns the current orientation estimate.
+func (f *Mahony) Orientation() fixpoint.QuatQ24 {
+	return orientation(f.q)
+}
+
+// SetOrientation sets the orientation estimate, for example from an initial
+// reading or a known starting position.
+func (f *Mahony) SetOrientation(q fixpoint.QuatQ24) {
+	f.q = q
+}
+
+// Bias returns the gyroscope bias estimated by the integral term, in radians
+// per second.
+func (f *Mahony) Bias() fixpoint.Vec3Q24 {
+	return f.integral.Neg()
+}
+
+// Update updates the orientation estimate with a new gyroscope and
+// accelerometer reading taken dt seconds after the previous one. An
+// accelerometer reading of zero (for example in free fall) only integrates the
+// gyroscope.
+func (f *Mahony) Update(gyro, accel fixpoint.Vec3Q24, dt fixpoint.Q24) {
+	f.update(gyro, accel, fixpoint.Vec3Q24{}, dt)
+}
+
+// UpdateMag is like Update, but also corrects the heading with a
+// magnetometer reading. Only the horizontal component of the magnetic field is
+// used for the heading, so magnetic inclination does not influence pitch and
+// roll. A magnetometer reading of zero is ignored.
+func (f *Mahony) UpdateMag(gyro, accel, mag fixpoint.Vec3Q24, dt fixpoint.Q24) {
+	f.update(gyro, accel, mag, dt)
+}
+
+func (f *Mahony) update(gyro, accel, mag fixpoint.Vec3Q24, dt fixpoint.Q24) {
+	q := orientation(f.q)
+
+	var e fixpoint.Vec3Q24
+	if accel != (fixpoint.Vec3Q24{}) {
+		// Error between the measured and the estimated direction of gravity.
+		e = accel.Normalize().Cross(gravity(q))
+
+		if mag != (fixpoint.Vec3Q24{}) {
+			// Rotate the magnetic field to the earth frame, remove its east
+			// component and rotate it back to get the expected field.
+			m := mag.Normalize()
+			h := q.Rotate(m)
+			b := fixpoint.Vec3Q24{
+				X: fixpoint.Vec2Q24{X: h.X, Y: h.Y}.Len(),
+				Z: h.Z,
+			}
+			e = e.Add(m.Cross(q.Conjugate().Rotate(b)))
+		}
+
+		if f.Ki.N != 0 {
+			f.integral = f.integral.Add(e.Mul(f.Ki.MulRound(dt)))
+			gyro = gyro.Add(f.integral)
+		}
+		gyro = gyro.Add(e.Mul(f.Kp))
+	}
+
+	f.q = integrate(q, gyro, dt).Normalize()
+}
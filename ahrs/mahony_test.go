@@ -0,0 +1,65 @@
+package ahrs
+
+import (
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+// angleBetween returns the rotation angle between two orientations.
+func angleBetween(q1, q2 fixpoint.QuatQ24) float32 {
+	_, angle := q1.Conjugate().Mul(q2).ToAxisAngle()
+	return angle.Float()
+}
+
+// readings returns the accelerometer and magnetometer readings of a device
+// at rest in the given orientation.
+func readings(q fixpoint.QuatQ24) (accel, mag fixpoint.Vec3Q24) {
+	inv := q.Conjugate()
+	accel = inv.Rotate(fixpoint.Vec3Q24FromFloat(0, 0, 9.81))
+	mag = inv.Rotate(fixpoint.Vec3Q24FromFloat(0.2, 0, -0.45)) // gauss, with inclination
+	return
+}
+
+func TestMahony(t *testing.T) {
+	dt := fixpoint.Q24FromFloat(0.01)
+
+	// Without correction, the gyroscope is integrated.
+	var f Mahony
+	assert.Equal(t, fixpoint.QuatIdent(), f.Orientation())
+	gyro := fixpoint.Vec3Q24FromFloat(0, 0, 1)
+	for i := 0; i < 100; i++ {
+		f.Update(gyro, fixpoint.Vec3Q24{}, dt)
+	}
+	want := fixpoint.QuatFromAxisAngle(fixpoint.Vec3Q24FromFloat(0, 0, 1), fixpoint.Q24FromInt32(1))
+	assert.InDelta(t, 0, angleBetween(want, f.Orientation()), 0.0002)
+
+	// Converge to the true orientation with magnetometer, and estimate a
+	// gyroscope bias.
+	truth := fixpoint.QuatFromEuler(fixpoint.Q24FromFloat(0.3), fixpoint.Q24FromFloat(-0.5), fixpoint.Q24FromFloat(2))
+	accel, mag := readings(truth)
+	bias := fixpoint.Vec3Q24FromFloat(0.01, -0.02, 0.015)
+	f = Mahony{Kp: fixpoint.Q24FromInt32(2), Ki: fixpoint.Q24FromFloat(0.5)}
+	for i := 0; i < 12000; i++ {
+		f.UpdateMag(bias, accel, mag, dt)
+	}
+	assert.InDelta(t, 0, angleBetween(truth, f.Orientation()), 0.001)
+	assert.InDelta(t, bias.X.Float(), f.Bias().X.Float(), 0.001)
+	assert.InDelta(t, bias.Y.Float(), f.Bias().Y.Float(), 0.001)
+	assert.InDelta(t, bias.Z.Float(), f.Bias().Z.Float(), 0.001)
+
+	// Without magnetometer, only the tilt is corrected.
+	f = Mahony{Kp: fixpoint.Q24FromInt32(2)}
+	for i := 0; i < 1000; i++ {
+		f.Update(fixpoint.Vec3Q24{}, accel, dt)
+	}
+	g := gravity(f.Orientation())
+	a := accel.Normalize()
+	assert.InDelta(t, a.X.Float(), g.X.Float(), 0.0001)
+	assert.InDelta(t, a.Y.Float(), g.Y.Float(), 0.0001)
+	assert.InDelta(t, a.Z.Float(), g.Z.Float(), 0.0001)
+
+	f.SetOrientation(truth)
+	assert.Equal(t, truth, f.Orientation())
+}
@@ -0,0 +1,160 @@
+package ahrs
+
+import (
+	"strings"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/fixmath"
+)
+
+// Fault is a set of problems found by a SelfTest.
+type Fault uint8
+
+// Problems found by a SelfTest.
+const (
+	// FaultNoData means that no samples were added.
+	FaultNoData Fault = 1 << iota
+
+	// FaultAccel means that the magnitude of the average acceleration is not
+	// close to 1g, for example because the accelerometer is broken,
+	// misconfigured or the device was moving during the test.
+	FaultAccel
+
+	// FaultGyroBias means that the average rate of at least one gyroscope
+	// axis is too large for a device at rest.
+	FaultGyroBias
+
+	// FaultMag means that the magnitude of the average magnetic field is
+	// outside of the expected range, for example because of a nearby magnet
+	// or a large piece of iron.
+	FaultMag
+)
+
+// String returns a human readable description of the faults, such as
+// "accel, gyro bias", or "ok" if there are none.
+func (f Fault) String() string {
+	if f == 0 {
+		return "ok"
+	}
+	var names []string
+	for i, name := range [...]string{"no data", "accel", "gyro bias", "mag"} {
+		if f&(1<<uint(i)) != 0 {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// Diagnostics is the result of a SelfTest.
+type Diagnostics struct {
+	// Faults contains the problems that were found, zero if none.
+	Faults Fault
+
+	// Samples is the number of samples the averages are calculated from.
+	Samples int
+
+	// AccelMagnitude is the magnitude of the average acceleration in g.
+	AccelMagnitude fixpoint.Q24
+
+	// GyroBias is the average gyroscope rate in radians per second.
+	GyroBias fixpoint.Vec3Q24
+
+	// MagMagnitude is the magnitude of the average magnetic field, zero if
+	// the magnetometer isn't checked.
+	MagMagnitude fixpoint.Q24
+}
+
+// OK returns whether no faults were found.
+func (d Diagnostics) OK() bool {
+	return d.Faults == 0
+}
+
+// SelfTest checks whether the sensor readings of a device at rest are
+// plausible, for example before arming a drone. It averages a number of
+// readings, so that the result doesn't depend on the noise of a single one.
+// The accelerometer must be in units of standard gravity (g) and the
+// gyroscope in radians per second.
+//
+// The zero value is not usable: at least AccelTolerance and GyroBiasMax must
+// be set.
+type SelfTest struct {
+	// AccelTolerance is the largest allowed difference between the magnitude
+	// of the average acceleration and 1g, for example 0.05.
+	AccelTolerance fixpoint.Q24
+
+	// GyroBiasMax is the largest allowed average rate of each gyroscope
+	// axis, in radians per second.
+	GyroBiasMax fixpoint.Q24
+
+	// MagMin and MagMax are the range of the magnitude of the average
+	// magnetic field, in the unit of the magnetometer. The magnetometer is
+	// only checked when MagMax is set.
+	MagMin fixpoint.Q24
+	MagMax fixpoint.Q24
+
+	count int64
+	gyro  [3]int64
+	accel [3]int64
+	mag   [3]int64
+}
+
+// Add adds a reading to the averages. Pass a zero magnetometer reading when
+// there is no magnetometer.
+func (s *SelfTest) Add(gyro, accel, mag fixpoint.Vec3Q24) {
+	s.count++
+	addVec(&s.gyro, gyro)
+	addVec(&s.accel, accel)
+	addVec(&s.mag, mag)
+}
+
+func addVec(sum *[3]int64, v fixpoint.Vec3Q24) {
+	sum[0] += int64(v.X.N)
+	sum[1] += int64(v.Y.N)
+	sum[2] += int64(v.Z.N)
+}
+
+// Result checks the averages of the readings added so far.
+func (s *SelfTest) Result() Diagnostics {
+	d := Diagnostics{Samples: int(s.count)}
+	if s.count == 0 {
+		d.Faults = FaultNoData
+		return d
+	}
+	d.GyroBias = s.mean(&s.gyro)
+	d.AccelMagnitude = s.mean(&s.accel).Len()
+	if diff := int64(d.AccelMagnitude.N) - 1<<24; diff > int64(s.AccelTolerance.N) || -diff > int64(s.AccelTolerance.N) {
+		d.Faults |= FaultAccel
+	}
+	for _, n := range [...]int32{d.GyroBias.X.N, d.GyroBias.Y.N, d.GyroBias.Z.N} {
+		if n > s.GyroBiasMax.N || -int64(n) > int64(s.GyroBiasMax.N) {
+			d.Faults |= FaultGyroBias
+		}
+	}
+	if s.MagMax.N != 0 {
+		d.MagMagnitude = s.mean(&s.mag).Len()
+		if d.MagMagnitude.N < s.MagMin.N || d.MagMagnitude.N > s.MagMax.N {
+			d.Faults |= FaultMag
+		}
+	}
+	return d
+}
+
+// Reset removes all readings, to start a new test.
+func (s *SelfTest) Reset() {
+	s.count = 0
+	s.gyro, s.accel, s.mag = [3]int64{}, [3]int64{}, [3]int64{}
+}
+
+// mean returns the average of the given sums, rounded to the nearest value.
+func (s *SelfTest) mean(sum *[3]int64) fixpoint.Vec3Q24 {
+	var v [3]fixpoint.Q24
+	for i, n := range sum {
+		if n < 0 {
+			n -= s.count / 2
+		} else {
+			n += s.count / 2
+		}
+		v[i] = fixpoint.Q24{N: fixmath.Saturate(n / s.count)}
+	}
+	return fixpoint.Vec3Q24{X: v[0], Y: v[1], Z: v[2]}
+}
@@ -0,0 +1,56 @@
+package ahrs
+
+import (
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelfTest(t *testing.T) {
+	s := SelfTest{
+		AccelTolerance: fixpoint.Q24FromFloat(0.05),
+		GyroBiasMax:    fixpoint.Q24FromFloat(0.02),
+		MagMin:         fixpoint.Q24FromFloat(0.25),
+		MagMax:         fixpoint.Q24FromFloat(0.65),
+	}
+	d := s.Result()
+	assert.False(t, d.OK())
+	assert.Equal(t, FaultNoData, d.Faults)
+	assert.Equal(t, "no data", d.Faults.String())
+
+	// A device at rest, tilted, with noisy readings.
+	gyro := fixpoint.Vec3Q24FromFloat(0.005, -0.01, 0.002)
+	accel := fixpoint.Vec3Q24FromFloat(0.6, 0, 0.8)
+	mag := fixpoint.Vec3Q24FromFloat(0.2, 0.1, -0.4)
+	noise := fixpoint.Vec3Q24FromFloat(0.01, -0.03, 0.02)
+	for i := 0; i < 100; i++ {
+		if i%2 == 0 {
+			s.Add(gyro.Add(noise), accel.Add(noise), mag.Add(noise))
+		} else {
+			s.Add(gyro.Sub(noise), accel.Sub(noise), mag.Sub(noise))
+		}
+	}
+	d = s.Result()
+	assert.True(t, d.OK(), d.Faults.String())
+	assert.Equal(t, "ok", d.Faults.String())
+	assert.Equal(t, 100, d.Samples)
+	assert.Equal(t, gyro, d.GyroBias)
+	assert.InDelta(t, 1, d.AccelMagnitude.Float(), 1e-6)
+	assert.InDelta(t, mag.Len().Float(), d.MagMagnitude.Float(), 1e-6)
+
+	// Bad sensors.
+	s.Reset()
+	s.Add(fixpoint.Vec3Q24FromFloat(0, -0.1, 0), fixpoint.Vec3Q24FromFloat(0, 0, 0.5), fixpoint.Vec3Q24FromFloat(2, 0, 0))
+	d = s.Result()
+	assert.Equal(t, FaultAccel|FaultGyroBias|FaultMag, d.Faults)
+	assert.Equal(t, "accel, gyro bias, mag", d.Faults.String())
+
+	// The magnetometer isn't checked without a range.
+	s.MagMax = fixpoint.Q24{}
+	s.Reset()
+	s.Add(gyro, accel, fixpoint.Vec3Q24{})
+	d = s.Result()
+	assert.True(t, d.OK(), d.Faults.String())
+	assert.Equal(t, fixpoint.Q24{}, d.MagMagnitude)
+}
@@ -0,0 +1,158 @@
+package ahrs
+
+import (
+	"errors"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/fixmath"
+	"github.com/aykevl/fixpoint/internal/le"
+)
+
+// Filter state
+//
+// The filters have a State method that returns their internal state (the
+// orientation estimate and, for Mahony, the estimated gyroscope bias, the
+// altitude, speed and bias for the Variometer, the direction of gravity for
+// the GravityEstimator, or the correction of the YawCorrector) as a byte
+// slice, and a Restore method that sets it again. That way the filter doesn't
+// need to converge again after a deep sleep or a reset.
+
+var errInvalidState = errors.New("ahrs: invalid state")
+
+// State returns the internal state of the filter.
+func (f *Madgwick) State() []byte {
+	return f.AppendState(make([]byte, 0, 16))
+}
+
+// AppendState appends the state returned by State to dst and returns the
+// extended slice.
+func (f *Madgwick) AppendState(dst []byte) []byte {
+	return appendQuat(dst, f.q)
+}
+
+// Restore sets the internal state of the filter to a state returned by State.
+func (f *Madgwick) Restore(state []byte) error {
+	if len(state) != 16 {
+		return errInvalidState
+	}
+	f.q = quatLE(state)
+	return nil
+}
+
+// State returns the internal state of the filter.
+func (f *Mahony) State() []byte {
+	return f.AppendState(make([]byte, 0, 28))
+}
+
+// AppendState appends the state returned by State to dst and returns the
+// extended slice.
+func (f *Mahony) AppendState(dst []byte) []byte {
+	buf := appendQuat(dst, f.q)
+	for _, n := range [...]int32{f.integral.X.N, f.integral.Y.N, f.integral.Z.N} {
+		buf = le.AppendInt32(buf, n)
+	}
+	return buf
+}
+
+// Restore sets the internal state of the filter to a state returned by State.
+func (f *Mahony) Restore(state []byte) error {
+	if len(state) != 28 {
+		return errInvalidState
+	}
+	f.q = quatLE(state)
+	f.integral.X.N = le.Int32(state[16:])
+	f.integral.Y.N = le.Int32(state[20:])
+	f.integral.Z.N = le.Int32(state[24:])
+	return nil
+}
+
+// State returns the internal state of the filter.
+func (f *Variometer) State() []byte {
+	return f.AppendState(make([]byte, 0, 25))
+}
+
+// AppendState appends the state returned by State to dst and returns the
+// extended slice.
+func (f *Variometer) AppendState(dst []byte) []byte {
+	buf := dst
+	buf = le.AppendInt64(buf, f.altitude)
+	buf = le.AppendInt64(buf, f.speed)
+	buf = le.AppendInt64(buf, f.bias)
+	return le.AppendBool(buf, f.initialized)
+}
+
+// Restore sets the internal state of the filter to a state returned by State.
+func (f *Variometer) Restore(state []byte) error {
+	if len(state) != 25 {
+		return errInvalidState
+	}
+	f.altitude = le.Int64(state)
+	f.speed = le.Int64(state[8:])
+	f.bias = le.Int64(state[16:])
+	f.initialized = state[24] != 0
+	return nil
+}
+
+// State returns the internal state of the filter.
+func (f *GravityEstimator) State() []byte {
+	return f.AppendState(make([]byte, 0, 17))
+}
+
+// AppendState appends the state returned by State to dst and returns the
+// extended slice.
+func (f *GravityEstimator) AppendState(dst []byte) []byte {
+	buf := dst
+	for _, n := range [...]int32{f.g.X.N, f.g.Y.N, f.g.Z.N, f.trust.N} {
+		buf = le.AppendInt32(buf, n)
+	}
+	return le.AppendBool(buf, f.initialized)
+}
+
+// Restore sets the internal state of the filter to a state returned by State.
+func (f *GravityEstimator) Restore(state []byte) error {
+	if len(state) != 17 {
+		return errInvalidState
+	}
+	f.g.X.N = le.Int32(state)
+	f.g.Y.N = le.Int32(state[4:])
+	f.g.Z.N = le.Int32(state[8:])
+	f.trust.N = le.Int32(state[12:])
+	f.initialized = state[16] != 0
+	return nil
+}
+
+// State returns the yaw correction.
+func (c *YawCorrector) State() []byte {
+	return c.AppendState(make([]byte, 0, 8))
+}
+
+// AppendState appends the state returned by State to dst and returns the
+// extended slice.
+func (c *YawCorrector) AppendState(dst []byte) []byte {
+	return le.AppendInt64(dst, c.offset)
+}
+
+// Restore sets the yaw correction to a state returned by State.
+func (c *YawCorrector) Restore(state []byte) error {
+	if len(state) != 8 {
+		return errInvalidState
+	}
+	c.offset = fixmath.WrapAngleQ48(le.Int64(state))
+	return nil
+}
+
+func appendQuat(buf []byte, q fixpoint.QuatQ24) []byte {
+	for _, n := range [...]int32{q.W.N, q.V.X.N, q.V.Y.N, q.V.Z.N} {
+		buf = le.AppendInt32(buf, n)
+	}
+	return buf
+}
+
+func quatLE(data []byte) fixpoint.QuatQ24 {
+	var q fixpoint.QuatQ24
+	q.W.N = le.Int32(data)
+	q.V.X.N = le.Int32(data[4:])
+	q.V.Y.N = le.Int32(data[8:])
+	q.V.Z.N = le.Int32(data[12:])
+	return q
+}
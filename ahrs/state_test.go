@@ -0,0 +1,84 @@
+package ahrs
+
+import (
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/statetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestState(t *testing.T) {
+	dt := fixpoint.Q24FromFloat(0.01)
+	gyro := fixpoint.Vec3Q24FromFloat(0.5, -1, 0.25)
+	accel := fixpoint.Vec3Q24FromFloat(0.1, 0.2, 1)
+	mag := fixpoint.Vec3Q24FromFloat(0.4, 0, -0.3)
+
+	m := Madgwick{Beta: fixpoint.Q24FromFloat(0.1)}
+	for i := 0; i < 10; i++ {
+		m.Update(gyro, accel, dt)
+	}
+	m2 := Madgwick{Beta: m.Beta}
+	assert.NoError(t, m2.Restore(m.State()))
+	assert.Equal(t, m.Orientation(), m2.Orientation())
+	m.Update(gyro, accel, dt)
+	m2.Update(gyro, accel, dt)
+	assert.Equal(t, m.Orientation(), m2.Orientation())
+	assert.Error(t, m2.Restore(nil))
+
+	f := Mahony{Kp: fixpoint.Q24FromInt32(1), Ki: fixpoint.Q24FromFloat(0.1)}
+	for i := 0; i < 10; i++ {
+		f.UpdateMag(gyro, accel, mag, dt)
+	}
+	f2 := Mahony{Kp: f.Kp, Ki: f.Ki}
+	assert.NoError(t, f2.Restore(f.State()))
+	assert.Equal(t, f.Orientation(), f2.Orientation())
+	assert.Equal(t, f.Bias(), f2.Bias())
+	assert.NotEqual(t, fixpoint.Vec3Q24{}, f2.Bias())
+	f.UpdateMag(gyro, accel, mag, dt)
+	f2.UpdateMag(gyro, accel, mag, dt)
+	assert.Equal(t, f.Orientation(), f2.Orientation())
+	assert.Error(t, f2.Restore(m.State()))
+
+	v := Variometer{Omega: fixpoint.Q24FromInt32(1)}
+	for i := 0; i < 10; i++ {
+		v.Update(fixpoint.Q16FromInt32(int32(100+i)), fixpoint.Q24FromFloat(0.2), dt)
+	}
+	v2 := Variometer{Omega: v.Omega}
+	assert.NoError(t, v2.Restore(v.State()))
+	v.Update(fixpoint.Q16FromInt32(111), fixpoint.Q24{}, dt)
+	v2.Update(fixpoint.Q16FromInt32(111), fixpoint.Q24{}, dt)
+	assert.Equal(t, v.Altitude(), v2.Altitude())
+	assert.Equal(t, v.VerticalSpeed(), v2.VerticalSpeed())
+	assert.Equal(t, v.Bias(), v2.Bias())
+	assert.Error(t, v2.Restore(m.State()))
+
+	g := GravityEstimator{Gain: fixpoint.Q24FromInt32(2), Tolerance: fixpoint.Q24FromFloat(0.2)}
+	g.Update(fixpoint.Vec3Q24{}, fixpoint.Vec3Q24FromFloat(0, 0.5, 1), dt)
+	g.Update(fixpoint.Vec3Q24FromFloat(0.1, 0, 0), fixpoint.Vec3Q24FromFloat(0, 0, 1.1), dt)
+	g2 := GravityEstimator{Gain: g.Gain, Tolerance: g.Tolerance}
+	assert.NoError(t, g2.Restore(g.State()))
+	assert.Equal(t, g, g2)
+	assert.Error(t, g2.Restore(m.State()))
+
+	y := YawCorrector{Gain: fixpoint.Q24FromFloat(0.1)}
+	y.Update(fixpoint.Q24{}, fixpoint.Q24FromFloat(1), fixpoint.Q24FromInt32(5), dt)
+	y2 := YawCorrector{Gain: y.Gain}
+	assert.NoError(t, y2.Restore(y.State()))
+	assert.Equal(t, y, y2)
+	assert.Error(t, y2.Restore(m.State()))
+}
+
+func TestAppendState(t *testing.T) {
+	m := &Madgwick{Beta: fixpoint.Q24FromFloat(0.1)}
+	m.Update(fixpoint.Vec3Q24FromFloat(0.5, -1, 0.25), fixpoint.Vec3Q24FromFloat(0.1, 0.2, 1), fixpoint.Q24FromFloat(0.01))
+	for _, s := range []statetest.Appender{
+		m,
+		&Mahony{},
+		&Variometer{},
+		&GravityEstimator{},
+		&YawCorrector{},
+	} {
+		statetest.CheckAppend(t, s)
+	}
+}
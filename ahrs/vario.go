@@ -0,0 +1,88 @@
+package ahrs
+
+import (
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/fixmath"
+)
+
+// standardGravity is the standard acceleration of gravity, 9.80665m/s², in Q24
+// format.
+const standardGravity = 164528285
+
+// Variometer estimates the altitude and vertical speed of a device by fusing a
+// barometric altitude with the vertical acceleration, using a third-order
+// complementary filter. The barometer is accurate over a long time but noisy
+// and slow, while the accelerometer responds immediately but drifts when it is
+// integrated twice: the filter uses the accelerometer for fast changes and the
+// barometer to correct the drift. It also estimates the accelerometer bias.
+//
+// Altitudes are in meters (as Q16, for a range of ±32km), the vertical speed
+// is in meters per second and accelerations in meters per second squared.
+//
+// The zero value only integrates the accelerometer: set Omega to use the
+// barometer as well.
+type Variometer struct {
+	// Omega is the crossover frequency of the filter in radians per second.
+	// Below it, the barometer dominates the estimate, above it the
+	// accelerometer. A typical value is 0.5 to 2. The feedback gains are 3ω,
+	// 3ω² and ω³, which places all poles of the filter at -ω.
+	Omega fixpoint.Q24
+
+	initialized bool
+	altitude    int64 // Q32
+	speed       int64 // Q32
+	bias        int64 // Q32
+}
+
+// Altitude returns the estimated altitude in meters.
+func (f *Variometer) Altitude() fixpoint.Q16 {
+	return fixpoint.Q16{N: fixmath.Saturate((f.altitude + 1<<15) >> 16)}
+}
+
+// VerticalSpeed returns the estimated vertical speed in meters per second,
+// positive when climbing.
+func (f *Variometer) VerticalSpeed() fixpoint.Q24 {
+	return fixpoint.Q24{N: fixmath.Saturate((f.speed + 1<<7) >> 8)}
+}
+
+// Bias returns the estimated bias of the vertical acceleration in meters per
+// second squared: the estimate is corrected by subtracting it from the
+// acceleration.
+func (f *Variometer) Bias() fixpoint.Q24 {
+	return fixpoint.Q24{N: fixmath.Saturate((-f.bias + 1<<7) >> 8)}
+}
+
+// Update updates the estimate with a barometric altitude and a vertical
+// acceleration (gravity removed, see VerticalAcceleration) taken dt seconds
+// after the previous one. The first update starts at the barometric altitude.
+func (f *Variometer) Update(altitude fixpoint.Q16, accel, dt fixpoint.Q24) {
+	baro := int64(altitude.N) << 16
+	if !f.initialized {
+		f.altitude = baro
+		f.initialized = true
+	}
+	omega := int64(f.Omega.N)
+	k1 := 3 * omega
+	k2 := 3 * ((omega*omega + 1<<23) >> 24)
+	k3 := (((omega*omega+1<<23)>>24)*omega + 1<<23) >> 24
+
+	e := baro - f.altitude
+	f.bias += fixmath.MulQ24(fixmath.MulQ24(e, k3), int64(dt.N))
+	f.speed += fixmath.MulQ24(int64(accel.N)<<8+f.bias+fixmath.MulQ24(e, k2), int64(dt.N))
+	f.altitude += fixmath.MulQ24(f.speed+fixmath.MulQ24(e, k1), int64(dt.N))
+}
+
+// Reset restarts the estimate at the next barometric altitude, with zero
+// vertical speed and bias.
+func (f *Variometer) Reset() {
+	*f = Variometer{Omega: f.Omega}
+}
+
+// VerticalAcceleration returns the vertical acceleration in meters per second
+// squared, with gravity removed, from an accelerometer reading in units of
+// standard gravity (g) and the orientation of the device as estimated by one
+// of the AHRS filters.
+func VerticalAcceleration(q fixpoint.QuatQ24, accel fixpoint.Vec3Q24) fixpoint.Q24 {
+	up := int64(q.Rotate(accel).Z.N) - 1<<24
+	return fixpoint.Q24{N: fixmath.Saturate((up*standardGravity + 1<<23) >> 24)}
+}
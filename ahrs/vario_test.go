@@ -0,0 +1,68 @@
+package ahrs
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVariometer(t *testing.T) {
+	// Climb at 2m/s for 10 seconds, with a noisy barometer and a biased
+	// accelerometer, sampled at 50Hz.
+	var r fixpoint.Rand
+	r.Seed(1)
+	dt := 0.02
+	f := Variometer{Omega: fixpoint.Q24FromFloat(0.5)}
+	altitude, speed := 300.0, 0.0
+	for i := 0; i < 50*60; i++ {
+		now := float64(i) * dt
+		accel := 0.0
+		if now >= 5 && now < 9 {
+			accel = 0.5
+		} else if now >= 19 && now < 23 {
+			accel = -0.5
+		}
+		speed += accel * dt
+		altitude += speed * dt
+
+		noise := r.Q24Range(fixpoint.Q24FromFloat(-0.5), fixpoint.Q24FromFloat(0.5)).Float64()
+		baro := fixpoint.Q16FromFloat(float32(altitude + noise))
+		f.Update(baro, fixpoint.Q24FromFloat64(accel+0.05), fixpoint.Q24FromFloat64(dt))
+		if now > 14 && now < 15 || now > 40 {
+			if math.Abs(f.VerticalSpeed().Float64()-speed) > 0.15 {
+				t.Fatalf("t=%.2f: speed %f, expected %f", now, f.VerticalSpeed().Float64(), speed)
+			}
+			if math.Abs(float64(f.Altitude().Float())-altitude) > 0.3 {
+				t.Fatalf("t=%.2f: altitude %f, expected %f", now, float64(f.Altitude().Float()), altitude)
+			}
+		}
+	}
+	assert.InDelta(t, 0.05, f.Bias().Float64(), 0.02)
+
+	// The first update starts at the barometric altitude.
+	f.Reset()
+	f.Update(fixpoint.Q16FromInt32(1000), fixpoint.Q24{}, fixpoint.Q24FromFloat64(dt))
+	assert.Equal(t, fixpoint.Q16FromInt32(1000), f.Altitude())
+	assert.Equal(t, fixpoint.Q24{}, f.VerticalSpeed())
+
+	// Without Omega, the acceleration is integrated.
+	var g Variometer
+	for i := 0; i < 50; i++ {
+		g.Update(fixpoint.Q16{}, fixpoint.Q24FromInt32(1), fixpoint.Q24FromFloat64(dt))
+	}
+	assert.InDelta(t, 1, g.VerticalSpeed().Float64(), 1e-6)
+	assert.InDelta(t, 0.51, float64(g.Altitude().Float()), 1e-4)
+}
+
+func TestVerticalAcceleration(t *testing.T) {
+	assert.Equal(t, fixpoint.Q24{}, VerticalAcceleration(fixpoint.QuatIdent(), fixpoint.Vec3Q24FromFloat(0.3, -0.2, 1)))
+	assert.InDelta(t, 9.80665, VerticalAcceleration(fixpoint.QuatIdent(), fixpoint.Vec3Q24FromFloat(0, 0, 2)).Float64(), 1e-6)
+
+	// The device is rotated 90° around the X axis, so that its Y axis points
+	// up.
+	q := fixpoint.QuatFromAxisAngle(fixpoint.Vec3Q24FromFloat(1, 0, 0), fixpoint.HalfPi)
+	assert.InDelta(t, 0, VerticalAcceleration(q, fixpoint.Vec3Q24FromFloat(0, 1, 0)).Float64(), 1e-5)
+	assert.InDelta(t, -9.80665, VerticalAcceleration(q, fixpoint.Vec3Q24{}).Float64(), 1e-6)
+}
@@ -0,0 +1,67 @@
+package ahrs
+
+import (
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/fixmath"
+)
+
+// YawCorrector slowly corrects the drift of the yaw (heading) of an
+// orientation estimate with the course over ground of a GPS receiver. This
+// only works while the vehicle moves forward, like a rover or a fixed-wing
+// aircraft: below MinSpeed (or when the course is unreliable for another
+// reason) the correction is held.
+//
+// The yaw follows the conventions of this package: it is the rotation around
+// the earth Z axis (pointing up), counterclockwise from north. The GPS course
+// is clockwise from north like a compass, so a course of π/2 (east)
+// corresponds to a yaw of -π/2. All angles are in radians.
+//
+// The zero value doesn't correct anything: set Gain to enable the
+// correction.
+type YawCorrector struct {
+	// Gain is the fraction of the yaw error that is corrected per second,
+	// the inverse of the time constant of the correction. A typical value is
+	// 0.1 or lower, so that the GPS noise averages out.
+	Gain fixpoint.Q24
+
+	// MinSpeed is the speed in meters per second below which the GPS course
+	// is ignored.
+	MinSpeed fixpoint.Q24
+
+	offset int64 // Q48, to accumulate small corrections
+}
+
+// Update corrects the yaw of the orientation estimate with a GPS course and
+// ground speed taken dt seconds after the previous update, and returns the
+// corrected yaw in the range [-π, π).
+func (c *YawCorrector) Update(yaw, course, speed, dt fixpoint.Q24) fixpoint.Q24 {
+	if speed.N >= c.MinSpeed.N {
+		corrected := fixmath.WrapAngleQ48(int64(yaw.N)<<24 + c.offset)
+		err := fixmath.WrapAngleQ48(-int64(course.N)<<24 - corrected)
+		step := fixmath.MulQ24((err>>24)*int64(c.Gain.N), int64(dt.N))
+		c.offset = fixmath.WrapAngleQ48(c.offset + step)
+	}
+	return roundQ48(fixmath.WrapAngleQ48(int64(yaw.N)<<24 + c.offset))
+}
+
+// Offset returns the current yaw correction, in the range [-π, π).
+func (c *YawCorrector) Offset() fixpoint.Q24 {
+	return roundQ48(c.offset)
+}
+
+// Correct returns the orientation q with its yaw corrected: rotated around
+// the earth Z axis by Offset.
+func (c *YawCorrector) Correct(q fixpoint.QuatQ24) fixpoint.QuatQ24 {
+	sin, cos := fixpoint.SinCos(fixpoint.Q24{N: c.Offset().N / 2})
+	return fixpoint.QuatQ24{W: cos, V: fixpoint.Vec3Q24{Z: sin}}.Mul(q)
+}
+
+// Reset clears the correction.
+func (c *YawCorrector) Reset() {
+	c.offset = 0
+}
+
+// roundQ48 returns the Q48 number n as a Q24, rounded to the nearest value.
+func roundQ48(n int64) fixpoint.Q24 {
+	return fixpoint.Q24{N: int32((n + 1<<23) >> 24)}
+}
@@ -0,0 +1,49 @@
+package ahrs
+
+import (
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestYawCorrector(t *testing.T) {
+	c := YawCorrector{Gain: fixpoint.Q24FromFloat(0.2), MinSpeed: fixpoint.Q24FromInt32(2)}
+	dt := fixpoint.Q24FromFloat(0.1)
+	deg := func(d float32) fixpoint.Q24 { return fixpoint.Q24FromFloat(d * 3.14159265 / 180) }
+
+	// Driving east (course 90°, yaw -90°) while the estimate drifted to a yaw
+	// of 170°: the error of 100° wraps around through 180°.
+	yaw := deg(170)
+	course := deg(90)
+	for i := 0; i < 600; i++ {
+		c.Update(yaw, course, fixpoint.Q24FromInt32(5), dt)
+	}
+	assert.InDelta(t, -90, c.Update(yaw, course, fixpoint.Q24FromInt32(5), dt).Float()*180/3.14159265, 0.01)
+	assert.InDelta(t, 100, c.Offset().Float()*180/3.14159265, 0.01)
+
+	// The correction is applied to the orientation as well.
+	q := fixpoint.QuatFromAxisAngle(fixpoint.Vec3Q24FromFloat(0, 0, 1), yaw)
+	_, _, corrected := c.Correct(q).Euler()
+	assert.InDelta(t, -90, corrected.Float()*180/3.14159265, 0.01)
+
+	// Standing still, the correction is held.
+	offset := c.Offset()
+	assert.InDelta(t, deg(40).Add(offset).N, c.Update(deg(40), deg(0), fixpoint.Q24FromInt32(1), dt).N, 1)
+	assert.Equal(t, offset, c.Offset())
+
+	// The correction is slow: after one second, only about 20% of a 10°
+	// error is corrected.
+	c.Reset()
+	for i := 0; i < 10; i++ {
+		c.Update(deg(0), deg(-10), fixpoint.Q24FromInt32(3), dt)
+	}
+	assert.InDelta(t, 10*(1-0.98*0.98*0.98*0.98*0.98*0.98*0.98*0.98*0.98*0.98), c.Offset().Float()*180/3.14159265, 0.01)
+
+	// Small errors are corrected too, with a tiny gain.
+	c = YawCorrector{Gain: fixpoint.Q24FromFloat(0.01)}
+	for i := 0; i < 10000; i++ {
+		c.Update(fixpoint.Q24{}, fixpoint.Q24{N: -1000}, fixpoint.Q24{}, fixpoint.Q24FromFloat(0.01))
+	}
+	assert.InDelta(t, 1000*(1-0.3679), c.Offset().N, 10)
+}
@@ -0,0 +1,143 @@
+package audio
+
+import (
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/filters"
+)
+
+// Block is an audio processing step that modifies a buffer of samples in
+// place.
+type Block interface {
+	// Process processes the samples in buf, continuing from the state left by
+	// the previous call.
+	Process(buf []fixpoint.Q15)
+
+	// Reset clears the state, as if the block only received silence so far.
+	Reset()
+}
+
+// Stage is a block in a FilterChain.
+type Stage struct {
+	Block Block
+
+	// Bypass skips this block. It keeps its state while bypassed, so it may
+	// need to be reset when enabling it again.
+	Bypass bool
+}
+
+// FilterChain processes a buffer by a number of blocks in order.
+//
+// The zero value passes audio through unmodified.
+type FilterChain struct {
+	Stages []Stage
+}
+
+// Process passes buf through each block that is not bypassed.
+func (c *FilterChain) Process(buf []fixpoint.Q15) {
+	for _, s := range c.Stages {
+		if !s.Bypass {
+			s.Block.Process(buf)
+		}
+	}
+}
+
+// Reset resets all blocks, including the ones that are bypassed.
+func (c *FilterChain) Reset() {
+	for _, s := range c.Stages {
+		s.Block.Reset()
+	}
+}
+
+// BiquadCascade is a number of biquads in series, for example to make a
+// higher order filter. Samples are passed between the sections in Q24 format,
+// so rounding to Q15 happens only once.
+//
+// The zero value passes audio through unmodified.
+type BiquadCascade struct {
+	Sections []filters.Biquad
+}
+
+// Process filters the samples in buf.
+func (b *BiquadCascade) Process(buf []fixpoint.Q15) {
+	for i, x := range buf {
+		v := x.Q24()
+		for j := range b.Sections {
+			v = b.Sections[j].Update(v)
+		}
+		buf[i] = v.Q15()
+	}
+}
+
+// Reset clears the state of all sections.
+func (b *BiquadCascade) Reset() {
+	for i := range b.Sections {
+		b.Sections[i].Reset(fixpoint.Q24{})
+	}
+}
+
+// FIR is a block that applies a FIR filter.
+type FIR struct {
+	Filter filters.FIR
+}
+
+// Process filters the samples in buf.
+func (f *FIR) Process(buf []fixpoint.Q15) {
+	for i, x := range buf {
+		buf[i] = f.Filter.Update(x.Q24()).Q15()
+	}
+}
+
+// Reset clears the sample history of the filter.
+func (f *FIR) Reset() {
+	f.Filter.Reset()
+}
+
+// Gain multiplies all samples by a constant factor, saturating on overflow.
+//
+// The zero value mutes the signal.
+type Gain struct {
+	Gain fixpoint.Q24
+}
+
+// Process amplifies the samples in buf.
+func (g *Gain) Process(buf []fixpoint.Q15) {
+	for i, x := range buf {
+		n := (int64(x.N)*int64(g.Gain.N) + 1<<23) >> 24
+		if n > 1<<15-1 {
+			n = 1<<15 - 1
+		} else if n < -1<<15 {
+			n = -1 << 15
+		}
+		buf[i].N = int16(n)
+	}
+}
+
+// Reset does nothing, as Gain has no state.
+func (g *Gain) Reset() {}
+
+// Limiter clips samples to the range [-Threshold, Threshold]. It is meant to
+// protect downstream hardware from overload; use a compressor for a more
+// gentle level reduction.
+//
+// The zero value doesn't limit.
+type Limiter struct {
+	Threshold fixpoint.Q15
+}
+
+// Process clips the samples in buf.
+func (l *Limiter) Process(buf []fixpoint.Q15) {
+	t := l.Threshold.N
+	if t <= 0 {
+		return
+	}
+	for i, x := range buf {
+		if x.N > t {
+			buf[i].N = t
+		} else if x.N < -t {
+			buf[i].N = -t
+		}
+	}
+}
+
+// Reset does nothing, as Limiter has no state.
+func (l *Limiter) Reset() {}
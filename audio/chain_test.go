@@ -0,0 +1,69 @@
+package audio
+
+import (
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/filters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterChain(t *testing.T) {
+	buf := []fixpoint.Q15{{N: 1000}, {N: -20000}, {N: 30000}}
+
+	// The zero value passes audio through.
+	var chain FilterChain
+	chain.Process(buf)
+	assert.Equal(t, []fixpoint.Q15{{N: 1000}, {N: -20000}, {N: 30000}}, buf)
+
+	gain := &Gain{Gain: fixpoint.Q24FromFloat(2)}
+	chain = FilterChain{Stages: []Stage{
+		{Block: gain},
+		{Block: &Limiter{Threshold: fixpoint.Q15{N: 16384}}},
+	}}
+	chain.Process(buf)
+	assert.Equal(t, []fixpoint.Q15{{N: 2000}, {N: -16384}, {N: 16384}}, buf)
+
+	// Bypass the limiter, so the gain saturates.
+	chain.Stages[1].Bypass = true
+	buf = []fixpoint.Q15{{N: 1000}, {N: -20000}, {N: 30000}}
+	chain.Process(buf)
+	assert.Equal(t, []fixpoint.Q15{{N: 2000}, {N: -32768}, {N: 32767}}, buf)
+}
+
+func TestBiquadCascade(t *testing.T) {
+	// A fourth order low-pass filter passes DC and blocks the Nyquist
+	// frequency.
+	coeffs := filters.BiquadLowPass(fixpoint.Q24FromFloat(0.05), filters.Butterworth)
+	cascade := &BiquadCascade{Sections: []filters.Biquad{{Coeffs: coeffs}, {Coeffs: coeffs}}}
+	chain := FilterChain{Stages: []Stage{{Block: cascade}}}
+	buf := make([]fixpoint.Q15, 256)
+	for i := range buf {
+		buf[i].N = 10000
+	}
+	chain.Process(buf)
+	assert.Equal(t, int16(10000), buf[len(buf)-1].N)
+
+	chain.Reset()
+	for i := range buf {
+		buf[i].N = 10000
+		if i%2 == 1 {
+			buf[i].N = -10000
+		}
+	}
+	chain.Process(buf)
+	assert.InDelta(t, 0, buf[len(buf)-1].N, 2)
+}
+
+func TestFIRBlock(t *testing.T) {
+	third := fixpoint.Q24FromInt32(1).DivRound(fixpoint.Q24FromInt32(3))
+	fir := &FIR{Filter: filters.FIR{Taps: []fixpoint.Q24{third, third, third}}}
+	buf := []fixpoint.Q15{{N: 300}, {N: 600}, {N: 900}, {N: 0}}
+	fir.Process(buf)
+	assert.Equal(t, []fixpoint.Q15{{N: 100}, {N: 300}, {N: 600}, {N: 500}}, buf)
+
+	fir.Reset()
+	buf = []fixpoint.Q15{{N: 300}}
+	fir.Process(buf)
+	assert.Equal(t, int16(100), buf[0].N)
+}
@@ -0,0 +1,154 @@
+package audio
+
+import (
+	"github.com/aykevl/fixpoint"
+)
+
+// Interpolation is the method used to read a DelayLine between samples.
+type Interpolation uint8
+
+const (
+	// Linear interpolation is cheap, but attenuates high frequencies
+	// slightly for fractional delays.
+	Linear Interpolation = iota
+
+	// Hermite uses 4-point cubic Hermite interpolation, which has a lot less
+	// high frequency loss and aliasing. It is a better choice for pitch
+	// shifting.
+	Hermite
+)
+
+// DelayLine is a circular buffer of samples that can be read at any
+// fractional delay, for effects like echo, chorus, flanging and pitch
+// shifting.
+//
+// The zero value is not usable: Buf must be set. Its length determines the
+// longest possible delay.
+type DelayLine struct {
+	Buf []fixpoint.Q15
+
+	Interpolation Interpolation
+
+	index int // position of the next write
+}
+
+// Write adds a sample to the delay line, overwriting the oldest one.
+func (d *DelayLine) Write(x fixpoint.Q15) {
+	d.Buf[d.index] = x
+	d.index++
+	if d.index == len(d.Buf) {
+		d.index = 0
+	}
+}
+
+// Read returns the sample that was written the given number of samples ago,
+// where a delay of 0 is the most recent sample. The delay is clamped to the
+// length of the buffer.
+func (d *DelayLine) Read(delay fixpoint.Q16) fixpoint.Q15 {
+	return d.read(int64(delay.N) << 8)
+}
+
+// Reset fills the delay line with silence.
+func (d *DelayLine) Reset() {
+	for i := range d.Buf {
+		d.Buf[i] = fixpoint.Q15{}
+	}
+	d.index = 0
+}
+
+// at returns the sample with the given integer delay, clamped to the buffer.
+func (d *DelayLine) at(delay int) int64 {
+	if delay < 0 {
+		delay = 0
+	} else if delay >= len(d.Buf) {
+		delay = len(d.Buf) - 1
+	}
+	i := d.index - 1 - delay
+	if i < 0 {
+		i += len(d.Buf)
+	}
+	return int64(d.Buf[i].N)
+}
+
+// read returns the interpolated sample at a delay in Q24 format.
+func (d *DelayLine) read(delay int64) fixpoint.Q15 {
+	max := int64(len(d.Buf)-1) << 24
+	if delay < 0 {
+		delay = 0
+	} else if delay > max {
+		delay = max
+	}
+	k := int(delay >> 24)
+	f := delay & (1<<24 - 1)
+	x0, x1 := d.at(k), d.at(k+1)
+	var y int64
+	if d.Interpolation == Hermite {
+		xm1, x2 := d.at(k-1), d.at(k+2)
+		if k == 0 {
+			// There is no newer sample, extrapolate it.
+			xm1 = 2*x0 - x1
+		}
+		// Coefficients of the cubic polynomial, times 2 to avoid fractions.
+		c1 := x1 - xm1
+		c2 := 2*xm1 - 5*x0 + 4*x1 - x2
+		c3 := x2 - xm1 + 3*(x0-x1)
+		p := (c3*f)>>24 + c2
+		p = (p*f)>>24 + c1
+		p = (p*f)>>24 + 2*x0
+		y = (p + 1) >> 1
+	} else {
+		y = x0 + ((x1-x0)*f+1<<23)>>24
+	}
+	if y > 1<<15-1 {
+		y = 1<<15 - 1
+	} else if y < -1<<15 {
+		y = -1 << 15
+	}
+	return fixpoint.Q15{N: int16(y)}
+}
+
+// Tap reads from a DelayLine with a delay that changes at a constant rate, for
+// pitch shifting and Doppler effects. The delay is kept with 24 fractional
+// bits of precision.
+//
+// When the delay runs past either end of the delay line it wraps around,
+// which causes a discontinuity. Glitch-free pitch shifters typically use two
+// taps half a buffer apart and crossfade between them.
+//
+// The zero value reads the most recent sample.
+type Tap struct {
+	// Rate is the playback speed: 1 keeps the delay constant, 2 shifts the
+	// pitch an octave up, and 0.5 an octave down.
+	Rate fixpoint.Q24
+
+	delay int64 // Q24
+}
+
+// Delay returns the current delay in samples.
+func (t *Tap) Delay() fixpoint.Q16 {
+	return fixpoint.Q16{N: int32((t.delay + 1<<7) >> 8)}
+}
+
+// SetDelay sets the current delay in samples.
+func (t *Tap) SetDelay(delay fixpoint.Q16) {
+	t.delay = int64(delay.N) << 8
+}
+
+// Next reads a sample from the delay line and updates the delay. It should be
+// called once for every sample written to the delay line.
+func (t *Tap) Next(d *DelayLine) fixpoint.Q15 {
+	y := d.read(t.delay)
+	// The write position moves ahead by one sample and the read position by
+	// Rate samples, so the delay changes by 1-Rate.
+	t.delay += 1<<24 - int64(t.Rate.N)
+	length := int64(len(d.Buf)-1) << 24
+	if length > 0 {
+		for t.delay < 0 {
+			t.delay += length
+		}
+		for t.delay >= length {
+			t.delay -= length
+		}
+	}
+	return y
+}
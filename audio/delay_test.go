@@ -0,0 +1,92 @@
+package audio
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelayLine(t *testing.T) {
+	d := DelayLine{Buf: make([]fixpoint.Q15, 8)}
+	for i := 1; i <= 10; i++ {
+		d.Write(fixpoint.Q15{N: int16(i * 100)})
+	}
+	assert.Equal(t, int16(1000), d.Read(fixpoint.Q16{}).N)
+	assert.Equal(t, int16(700), d.Read(fixpoint.Q16FromInt32(3)).N)
+	assert.Equal(t, int16(650), d.Read(fixpoint.Q16FromFloat(3.5)).N)
+	assert.Equal(t, int16(925), d.Read(fixpoint.Q16FromFloat(0.75)).N)
+
+	// Delays are clamped to the buffer.
+	assert.Equal(t, int16(300), d.Read(fixpoint.Q16FromInt32(7)).N)
+	assert.Equal(t, int16(300), d.Read(fixpoint.Q16FromInt32(100)).N)
+	assert.Equal(t, int16(1000), d.Read(fixpoint.Q16FromInt32(-1)).N)
+
+	// Cubic interpolation is exact for a straight line.
+	d.Interpolation = Hermite
+	assert.Equal(t, int16(650), d.Read(fixpoint.Q16FromFloat(3.5)).N)
+	assert.Equal(t, int16(925), d.Read(fixpoint.Q16FromFloat(0.75)).N)
+
+	d.Reset()
+	assert.Equal(t, int16(0), d.Read(fixpoint.Q16FromInt32(3)).N)
+}
+
+func TestDelayLineInterpolation(t *testing.T) {
+	// Read a high frequency sine wave between samples: Hermite interpolation
+	// must be a lot more accurate than linear interpolation.
+	const freq = 0.2 // cycles per sample
+	maxErr := func(interpolation Interpolation) float64 {
+		d := DelayLine{Buf: make([]fixpoint.Q15, 16), Interpolation: interpolation}
+		for i := 0; i < 16; i++ {
+			d.Write(fixpoint.Q15{N: int16(30000 * math.Sin(2*math.Pi*freq*float64(i)))})
+		}
+		worst := 0.0
+		for delay := 1.0; delay < 12; delay += 0.1 {
+			expected := 30000 * math.Sin(2*math.Pi*freq*(15-delay))
+			actual := float64(d.Read(fixpoint.Q16FromFloat(float32(delay))).N)
+			worst = math.Max(worst, math.Abs(expected-actual))
+		}
+		return worst
+	}
+	linear, hermite := maxErr(Linear), maxErr(Hermite)
+	assert.True(t, hermite < linear/3, "linear: %f, hermite: %f", linear, hermite)
+}
+
+func TestTap(t *testing.T) {
+	d := DelayLine{Buf: make([]fixpoint.Q15, 64)}
+
+	// With a rate of 1, the delay stays constant.
+	tap := Tap{Rate: fixpoint.Q24FromInt32(1)}
+	tap.SetDelay(fixpoint.Q16FromInt32(10))
+	for i := 0; i < 100; i++ {
+		d.Write(fixpoint.Q15{N: int16(i)})
+		y := tap.Next(&d)
+		if i >= 10 {
+			assert.Equal(t, int16(i-10), y.N)
+		}
+	}
+	assert.Equal(t, fixpoint.Q16FromInt32(10), tap.Delay())
+
+	// With a rate of 2, a ramp is played back twice as fast, so its slope
+	// doubles.
+	tap = Tap{Rate: fixpoint.Q24FromInt32(2)}
+	tap.SetDelay(fixpoint.Q16FromInt32(40))
+	var prev int16
+	for i := 100; i < 130; i++ {
+		d.Write(fixpoint.Q15{N: int16(i)})
+		y := tap.Next(&d)
+		if i > 100 {
+			assert.Equal(t, int16(2), y.N-prev)
+		}
+		prev = y.N
+	}
+	assert.Equal(t, fixpoint.Q16FromInt32(10), tap.Delay())
+
+	// The delay wraps around at the ends of the buffer.
+	for i := 0; i < 20; i++ {
+		d.Write(fixpoint.Q15{})
+		tap.Next(&d)
+	}
+	assert.Equal(t, fixpoint.Q16FromInt32(53), tap.Delay())
+}
@@ -0,0 +1,149 @@
+package audio
+
+import (
+	"math/bits"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/fixmath"
+)
+
+// EnvelopeFollower tracks the peak level of a signal. It follows rising levels
+// with the Attack coefficient and falling levels with the Release coefficient,
+// like a first-order low-pass filter on the absolute value of the signal. The
+// level is kept with 24 extra bits of precision so that it doesn't get stuck
+// with slow release times.
+//
+// The zero value doesn't follow anything: Attack and Release must be set, for
+// example with TimeCoefficient.
+type EnvelopeFollower struct {
+	// Attack and Release are smoothing factors in the range (0, 1]. A value of
+	// 1 follows the signal immediately.
+	Attack  fixpoint.Q24
+	Release fixpoint.Q24
+
+	level int64 // Q39
+}
+
+// TimeCoefficient returns the smoothing factor for an envelope follower with
+// the given time constant in seconds, at the given sample rate in Hz. After one
+// time constant, the level has moved about 63% of the way to the input level.
+//
+// It is calculated as 1/(n+0.5) for a time constant of n samples, which is a
+// close approximation of the exact 1-exp(-1/n).
+func TimeCoefficient(time fixpoint.Q16, sampleRate uint32) fixpoint.Q24 {
+	n := int64(time.N)*int64(sampleRate) + 1<<15 // Q16
+	if n <= 1<<16 {
+		return fixpoint.Q24{N: 1 << 24}
+	}
+	return fixpoint.Q24{N: int32((1<<40 + n/2) / n)}
+}
+
+// Update updates the envelope with a new sample and returns the new level.
+func (e *EnvelopeFollower) Update(x fixpoint.Q15) fixpoint.Q15 {
+	target := int64(x.N) << 24
+	if target < 0 {
+		target = -target
+	}
+	if target > e.level {
+		e.level += fixmath.MulQ24(target-e.level, int64(e.Attack.N))
+	} else {
+		e.level += fixmath.MulQ24(target-e.level, int64(e.Release.N))
+	}
+	return e.Level()
+}
+
+// Level returns the current level, saturated to the Q15 range.
+func (e *EnvelopeFollower) Level() fixpoint.Q15 {
+	n := (e.level + 1<<23) >> 24
+	if n > 1<<15-1 {
+		n = 1<<15 - 1
+	}
+	return fixpoint.Q15{N: int16(n)}
+}
+
+// Reset sets the level to zero.
+func (e *EnvelopeFollower) Reset() {
+	e.level = 0
+}
+
+// Compressor is a dynamic range compressor: it reduces the gain when the
+// level of the signal, as measured by the envelope follower, is above the
+// threshold. With an infinite ratio it is a limiter that keeps the level
+// close to the threshold, which is useful to protect small speakers. Unlike
+// the Limiter block, it changes the gain smoothly, which avoids the harsh
+// distortion of clipping.
+//
+// The gain is calculated in the logarithmic domain, with an error of about
+// 0.01dB.
+//
+// The zero value doesn't compress: Threshold and the envelope coefficients
+// must be set.
+type Compressor struct {
+	// Threshold is the level above which the gain is reduced.
+	Threshold fixpoint.Q15
+
+	// Ratio is the compression ratio, for example 4 for a 4:1 ratio where a
+	// level increase of 4dB above the threshold results in an output increase
+	// of 1dB. Zero means an infinite ratio. Ratios below 1 are treated as 1,
+	// which doesn't compress.
+	Ratio fixpoint.Q16
+
+	Envelope EnvelopeFollower
+}
+
+// Process compresses the samples in buf.
+func (c *Compressor) Process(buf []fixpoint.Q15) {
+	if c.Threshold.N <= 0 {
+		return
+	}
+	// The slope of the gain reduction: 1 - 1/ratio.
+	slope := int64(1 << 24)
+	if c.Ratio.N > 0 {
+		slope -= (1 << 40) / int64(c.Ratio.N)
+		if slope < 0 {
+			slope = 0
+		}
+	}
+	threshold := int64(c.Threshold.N) << 24
+	logThreshold := log2(uint64(threshold))
+	for i, x := range buf {
+		c.Envelope.Update(x)
+		if c.Envelope.level <= threshold {
+			continue
+		}
+		over := log2(uint64(c.Envelope.level)) - logThreshold
+		gain := exp2(-fixmath.MulQ24(over, slope))
+		buf[i].N = int16((int64(x.N)*gain + 1<<23) >> 24)
+	}
+}
+
+// Reset resets the envelope follower.
+func (c *Compressor) Reset() {
+	c.Envelope.Reset()
+}
+
+// log2 returns the base 2 logarithm of n, which must not be zero, in Q24
+// format.
+func log2(n uint64) int64 {
+	e := uint(bits.Len64(n)) - 1
+	// Fractional part of the mantissa, in the range [0, 1).
+	f := int64(n<<(63-e)>>39) - 1<<24
+	// Cubic approximation of log2(1+f) with a maximum error of 0.001.
+	p := fixmath.MulQ24(f, 2623724) - 9684659
+	p = fixmath.MulQ24(f, p) + 23838151
+	return int64(e)<<24 + fixmath.MulQ24(f, p)
+}
+
+// exp2 returns 2^y for y <= 0 in Q24 format.
+func exp2(y int64) int64 {
+	i := uint(-(y >> 24)) // -floor(y)
+	if i > 24 {
+		return 0
+	}
+	f := y & (1<<24 - 1)
+	// Cubic approximation of 2^f-1 with a maximum error of 0.00017.
+	p := fixmath.MulQ24(f, 1327501) + 3773973
+	p = fixmath.MulQ24(f, p) + 11675742
+	p = 1<<24 + fixmath.MulQ24(f, p)
+	return (p + 1<<i>>1) >> i
+}
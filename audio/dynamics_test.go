@@ -0,0 +1,81 @@
+package audio
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeCoefficient(t *testing.T) {
+	// 10ms at 48kHz is 480 samples.
+	alpha := TimeCoefficient(fixpoint.Q16FromFloat(0.01), 48000)
+	assert.InDelta(t, 1-math.Exp(-1.0/480), alpha.Float64(), 2e-6)
+	assert.Equal(t, fixpoint.Q24FromInt32(1), TimeCoefficient(fixpoint.Q16{}, 48000))
+}
+
+func TestEnvelopeFollower(t *testing.T) {
+	e := EnvelopeFollower{Attack: fixpoint.Q24FromFloat(0.5), Release: fixpoint.Q24FromFloat(0.01)}
+	assert.Equal(t, int16(8000), e.Update(fixpoint.Q15{N: -16000}).N)
+	assert.Equal(t, int16(12000), e.Update(fixpoint.Q15{N: 16000}).N)
+	for i := 0; i < 30; i++ {
+		e.Update(fixpoint.Q15{N: 16000})
+	}
+	assert.Equal(t, int16(16000), e.Level().N)
+
+	// Releases slowly: after 100 samples of silence, 0.99^100 = 37% is left.
+	for i := 0; i < 100; i++ {
+		e.Update(fixpoint.Q15{})
+	}
+	assert.InDelta(t, 16000*math.Pow(0.99, 100), float64(e.Level().N), 2)
+
+	e.Reset()
+	assert.Equal(t, int16(0), e.Level().N)
+}
+
+func TestLog2Exp2(t *testing.T) {
+	for _, x := range []float64{1, 1.5, 3, 1000.25, 1 << 30} {
+		n := uint64(x * (1 << 24))
+		assert.InDelta(t, math.Log2(x)+24, float64(log2(n))/(1<<24), 0.0011, "log2(%v)", x)
+	}
+	for _, y := range []float64{0, -0.25, -1, -3.7, -20} {
+		assert.InDelta(t, math.Exp2(y), float64(exp2(int64(y*(1<<24))))/(1<<24), math.Exp2(y)*0.0002, "exp2(%v)", y)
+	}
+	assert.Equal(t, int64(0), exp2(-30<<24))
+}
+
+func TestCompressor(t *testing.T) {
+	constant := func(c *Compressor, n int16) int16 {
+		buf := make([]fixpoint.Q15, 64)
+		for i := range buf {
+			buf[i].N = n
+		}
+		c.Process(buf)
+		return buf[len(buf)-1].N
+	}
+	instant := EnvelopeFollower{Attack: fixpoint.Q24FromInt32(1), Release: fixpoint.Q24FromInt32(1)}
+
+	// The zero value doesn't compress.
+	assert.Equal(t, int16(20000), constant(&Compressor{}, 20000))
+
+	// 4:1 ratio, with an input 2 octaves (12dB) above the threshold, the
+	// output is 0.5 octave (3dB) above it.
+	c := &Compressor{Threshold: fixpoint.Q15{N: 6000}, Ratio: fixpoint.Q16FromInt32(4), Envelope: instant}
+	assert.InDelta(t, 6000*math.Sqrt2, constant(c, 24000), 6000*math.Sqrt2*0.002)
+	assert.InDelta(t, -6000*math.Sqrt2, constant(c, -24000), 6000*math.Sqrt2*0.002)
+	assert.Equal(t, int16(5000), constant(c, 5000))
+
+	// As a limiter, the output stays at the threshold.
+	c.Ratio = fixpoint.Q16{}
+	assert.InDelta(t, 6000, constant(c, 32000), 10)
+	assert.InDelta(t, 6000, constant(c, 12000), 10)
+
+	// With a slow attack, the start of a loud signal passes through.
+	c.Envelope = EnvelopeFollower{Attack: fixpoint.Q24FromFloat(0.001), Release: fixpoint.Q24FromFloat(0.001)}
+	buf := []fixpoint.Q15{{N: 30000}}
+	c.Process(buf)
+	assert.Equal(t, int16(30000), buf[0].N)
+	c.Reset()
+	assert.Equal(t, int16(0), c.Envelope.Level().N)
+}
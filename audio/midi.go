@@ -0,0 +1,33 @@
+package audio
+
+import (
+	"github.com/aykevl/fixpoint"
+)
+
+// FrequencyFromMIDINote returns the frequency in Hz of the given MIDI note
+// number, detuned by the given number of cents (1/100 of a semitone). It uses
+// equal temperament with A4 (note 69) at 440Hz. The result saturates for
+// frequencies of 32768Hz and above, far above the highest MIDI note (127).
+func FrequencyFromMIDINote(note, cents int) fixpoint.Q16 {
+	// The number of octaves relative to A4: (cents relative to A4) / 1200.
+	c := int64(note-69)*100 + int64(cents)
+	num := c << 24
+	if num < 0 {
+		num -= 600
+	} else {
+		num += 600
+	}
+	octaves := num / 1200
+	if octaves < -128<<24 || octaves >= 128<<24 {
+		if octaves < 0 {
+			return fixpoint.Q16{}
+		}
+		return fixpoint.Q16{N: 1<<31 - 1}
+	}
+	// 440 * 2^octaves, converted from Q24 to Q16.
+	f := (int64(fixpoint.Exp2(fixpoint.Q24{N: int32(octaves)}).N)*440 + 1<<7) >> 8
+	if f > 1<<31-1 {
+		f = 1<<31 - 1
+	}
+	return fixpoint.Q16{N: int32(f)}
+}
@@ -0,0 +1,27 @@
+package audio
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrequencyFromMIDINote(t *testing.T) {
+	assert.Equal(t, fixpoint.Q16FromInt32(440), FrequencyFromMIDINote(69, 0))
+	assert.Equal(t, fixpoint.Q16FromInt32(880), FrequencyFromMIDINote(81, 0))
+	assert.Equal(t, fixpoint.Q16FromInt32(220), FrequencyFromMIDINote(69, -1200))
+	for note := 0; note < 128; note++ {
+		for _, cents := range []int{-50, -7, 0, 13, 50} {
+			expected := 440 * math.Exp2((float64(note-69)+float64(cents)/100)/12)
+			actual := float64(FrequencyFromMIDINote(note, cents).N) / (1 << 16)
+			assert.InDelta(t, expected, actual, expected*1e-6+1.0/(1<<16), "note %d cents %d", note, cents)
+		}
+	}
+
+	// Saturates far outside the MIDI range.
+	assert.Equal(t, fixpoint.Q16{N: 1<<31 - 1}, FrequencyFromMIDINote(250, 0))
+	assert.Equal(t, fixpoint.Q16{N: 1<<31 - 1}, FrequencyFromMIDINote(1000000, 0))
+	assert.Equal(t, fixpoint.Q16{}, FrequencyFromMIDINote(-1000000, 0))
+}
@@ -0,0 +1,227 @@
+// Package audio implements audio signal processing in fixed point, for audio
+// pipelines on microcontrollers (for example with I2S peripherals). Samples are
+// in the Q15 or Q31 format of the fixpoint package, with a range of [-1, 1).
+package audio
+
+import (
+	"github.com/aykevl/fixpoint"
+)
+
+// Format is a packed PCM sample format, as used in I2S buffers and WAV files.
+type Format uint8
+
+// Supported sample formats. The zero value is S16LE, the most common format.
+const (
+	S16LE Format = iota // signed 16-bit, little endian
+	S16BE               // signed 16-bit, big endian
+	S24LE               // signed 24-bit packed in 3 bytes, little endian
+	S24BE               // signed 24-bit packed in 3 bytes, big endian
+	S8                  // signed 8-bit
+	U8                  // unsigned 8-bit with an offset of 128, as in WAV files
+)
+
+// Bits returns the number of bits per sample.
+func (f Format) Bits() uint {
+	switch f {
+	case S16LE, S16BE:
+		return 16
+	case S24LE, S24BE:
+		return 24
+	default:
+		return 8
+	}
+}
+
+// Size returns the number of bytes per sample.
+func (f Format) Size() int {
+	return int(f.Bits() / 8)
+}
+
+// load returns the sample at the start of buf as a Q31.
+func (f Format) load(buf []byte) int32 {
+	switch f {
+	case S16LE:
+		return int32(uint32(buf[0])<<16 | uint32(buf[1])<<24)
+	case S16BE:
+		return int32(uint32(buf[1])<<16 | uint32(buf[0])<<24)
+	case S24LE:
+		return int32(uint32(buf[0])<<8 | uint32(buf[1])<<16 | uint32(buf[2])<<24)
+	case S24BE:
+		return int32(uint32(buf[2])<<8 | uint32(buf[1])<<16 | uint32(buf[0])<<24)
+	case S8:
+		return int32(int8(buf[0])) << 24
+	default: // U8
+		return int32(int8(buf[0]^0x80)) << 24
+	}
+}
+
+// store stores a sample, which must already be in the range of the format, at
+// the start of buf.
+func (f Format) store(buf []byte, n int32) {
+	switch f {
+	case S16LE:
+		buf[0], buf[1] = byte(n), byte(n>>8)
+	case S16BE:
+		buf[0], buf[1] = byte(n>>8), byte(n)
+	case S24LE:
+		buf[0], buf[1], buf[2] = byte(n), byte(n>>8), byte(n>>16)
+	case S24BE:
+		buf[0], buf[1], buf[2] = byte(n>>16), byte(n>>8), byte(n)
+	case S8:
+		buf[0] = byte(n)
+	default: // U8
+		buf[0] = byte(n) ^ 0x80
+	}
+}
+
+// DecodeQ31 converts packed PCM samples in src to Q31 samples in dst. It
+// returns the number of samples converted, which is the smaller of len(dst)
+// and the number of whole samples in src.
+func DecodeQ31(dst []fixpoint.Q31, src []byte, format Format) int {
+	size := format.Size()
+	n := len(src) / size
+	if n > len(dst) {
+		n = len(dst)
+	}
+	for i := 0; i < n; i++ {
+		dst[i].N = format.load(src[i*size:])
+	}
+	return n
+}
+
+// DecodeQ15 converts packed PCM samples in src to Q15 samples in dst. Samples
+// with more than 16 bits are rounded to the nearest value. It returns the
+// number of samples converted, which is the smaller of len(dst) and the number
+// of whole samples in src.
+func DecodeQ15(dst []fixpoint.Q15, src []byte, format Format) int {
+	size := format.Size()
+	n := len(src) / size
+	if n > len(dst) {
+		n = len(dst)
+	}
+	for i := 0; i < n; i++ {
+		dst[i] = fixpoint.Q31{N: format.load(src[i*size:])}.Q15()
+	}
+	return n
+}
+
+// Dither determines how an Encoder reduces the precision of samples when the
+// output format has fewer bits than the input.
+type Dither uint8
+
+const (
+	// NoDither rounds to the nearest value. The rounding error is correlated
+	// with the signal, which is audible as distortion for quiet signals.
+	NoDither Dither = iota
+
+	// TPDF adds triangular noise of ±1 LSB before rounding, which makes the
+	// rounding error independent of the signal at the cost of a slightly
+	// higher noise floor. Samples that are exactly representable in the
+	// output format are not dithered.
+	TPDF
+
+	// NoiseShaping feeds the rounding error of each sample back into the
+	// next. This moves the noise to higher frequencies, where it is less
+	// audible.
+	NoiseShaping
+)
+
+// Encoder converts Q15 or Q31 samples to packed PCM samples. It keeps state
+// between calls for dithering, so a single encoder should be used per channel.
+//
+// The zero value encodes to S16LE without dithering.
+type Encoder struct {
+	Format Format
+	Dither Dither
+
+	rand uint32 // state of the xorshift random number generator
+	err  int64  // rounding error of the previous sample, in Q31
+}
+
+// EncodeQ31 converts Q31 samples in src to packed PCM samples in dst. Samples
+// are saturated to the range of the output format. It returns the number of
+// samples converted, which is the smaller of len(src) and the number of whole
+// samples that fit in dst.
+func (e *Encoder) EncodeQ31(dst []byte, src []fixpoint.Q31) int {
+	size := e.Format.Size()
+	n := len(dst) / size
+	if n > len(src) {
+		n = len(src)
+	}
+	for i := 0; i < n; i++ {
+		e.Format.store(dst[i*size:], e.quantize(int64(src[i].N)))
+	}
+	return n
+}
+
+// EncodeQ15 converts Q15 samples in src to packed PCM samples in dst, like
+// EncodeQ31.
+func (e *Encoder) EncodeQ15(dst []byte, src []fixpoint.Q15) int {
+	size := e.Format.Size()
+	n := len(dst) / size
+	if n > len(src) {
+		n = len(src)
+	}
+	for i := 0; i < n; i++ {
+		// Q15 samples only have 16 significant bits, so they don't need to
+		// be dithered for 16-bit and 24-bit outputs.
+		v := int64(src[i].N) << 16
+		if e.Format.Bits() >= 16 {
+			e.Format.store(dst[i*size:], int32(v>>(32-e.Format.Bits())))
+		} else {
+			e.Format.store(dst[i*size:], e.quantize(v))
+		}
+	}
+	return n
+}
+
+// quantize reduces the Q31 sample v to the number of bits of the output
+// format, with dithering.
+func (e *Encoder) quantize(v int64) int32 {
+	shift := 32 - e.Format.Bits()
+	switch e.Dither {
+	case TPDF:
+		if v&(1<<shift-1) == 0 {
+			// Already representable, so there is no rounding error to
+			// decorrelate. This also keeps digital silence silent.
+			break
+		}
+		// The sum of two uniform random values of ±0.5 LSB each.
+		r1, r2 := int64(e.random()>>(32-shift)), int64(e.random()>>(32-shift))
+		v += r1 + r2 - 1<<shift
+	case NoiseShaping:
+		v -= e.err
+	}
+	max := int64(1)<<(e.Format.Bits()-1) - 1
+	q := (v + 1<<(shift-1)) >> shift
+	clipped := true
+	if q > max {
+		q = max
+	} else if q < -max-1 {
+		q = -max - 1
+	} else {
+		clipped = false
+	}
+	if e.Dither == NoiseShaping {
+		// Don't feed back clipping errors: they can be much larger than one
+		// LSB and would make the output oscillate.
+		e.err = 0
+		if !clipped {
+			e.err = q<<shift - v
+		}
+	}
+	return int32(q)
+}
+
+// random returns a pseudo-random number using xorshift32.
+func (e *Encoder) random() uint32 {
+	x := e.rand
+	if x == 0 {
+		x = 2463534242
+	}
+	x ^= x << 13
+	x ^= x >> 17
+	x ^= x << 5
+	e.rand = x
+	return x
+}
@@ -0,0 +1,107 @@
+package audio
+
+import (
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecode(t *testing.T) {
+	for _, tc := range []struct {
+		format Format
+		buf    []byte
+		q31    []int32
+		q15    []int16
+	}{
+		{S16LE, []byte{0x34, 0x12, 0x00, 0x80, 0xff}, []int32{0x12340000, -0x80000000}, []int16{0x1234, -0x8000}},
+		{S16BE, []byte{0x12, 0x34, 0xff, 0xff}, []int32{0x12340000, -0x10000}, []int16{0x1234, -1}},
+		{S24LE, []byte{0x56, 0x34, 0x12, 0x80, 0xff, 0xff}, []int32{0x12345600, -0x8000}, []int16{0x1234, 0}},
+		{S24BE, []byte{0x12, 0x34, 0x56, 0x7f, 0xff, 0xff}, []int32{0x12345600, 0x7fffff00}, []int16{0x1234, 0x7fff}},
+		{S8, []byte{0x12, 0x80}, []int32{0x12000000, -0x80000000}, []int16{0x1200, -0x8000}},
+		{U8, []byte{0x80, 0x00, 0xff}, []int32{0, -0x80000000, 0x7f000000}, []int16{0, -0x8000, 0x7f00}},
+	} {
+		q31 := make([]fixpoint.Q31, 4)
+		n := DecodeQ31(q31, tc.buf, tc.format)
+		if !assert.Equal(t, len(tc.q31), n, "format %d", tc.format) {
+			continue
+		}
+		q15 := make([]fixpoint.Q15, 4)
+		assert.Equal(t, n, DecodeQ15(q15, tc.buf, tc.format))
+		for i := 0; i < n; i++ {
+			assert.Equal(t, tc.q31[i], q31[i].N, "format %d sample %d", tc.format, i)
+			assert.Equal(t, tc.q15[i], q15[i].N, "format %d sample %d", tc.format, i)
+		}
+	}
+
+	// The destination limits the number of samples.
+	assert.Equal(t, 1, DecodeQ31(make([]fixpoint.Q31, 1), make([]byte, 6), S16LE))
+}
+
+func TestEncodeRoundtrip(t *testing.T) {
+	for _, format := range []Format{S16LE, S16BE, S24LE, S24BE, S8, U8} {
+		buf := make([]byte, 256*format.Size())
+		for i := range buf {
+			buf[i] = byte(i * 7)
+		}
+		q31 := make([]fixpoint.Q31, 256)
+		n := DecodeQ31(q31, buf, format)
+		for _, dither := range []Dither{NoDither, TPDF, NoiseShaping} {
+			// Samples that are exactly representable must not be dithered.
+			e := Encoder{Format: format, Dither: dither}
+			out := make([]byte, len(buf))
+			assert.Equal(t, n, e.EncodeQ31(out, q31[:n]))
+			assert.Equal(t, buf, out, "format %d dither %d", format, dither)
+		}
+		if format.Bits() > 16 {
+			continue
+		}
+		q15 := make([]fixpoint.Q15, 256)
+		DecodeQ15(q15, buf, format)
+		e := Encoder{Format: format, Dither: TPDF}
+		out := make([]byte, len(buf))
+		e.EncodeQ15(out, q15)
+		assert.Equal(t, buf, out, "format %d", format)
+	}
+}
+
+func TestEncodeSaturate(t *testing.T) {
+	var e Encoder
+	out := make([]byte, 4)
+	e.EncodeQ31(out, []fixpoint.Q31{{N: 0x7fffffff}, {N: -0x80000000}})
+	assert.Equal(t, []byte{0xff, 0x7f, 0x00, 0x80}, out)
+
+	e = Encoder{Format: S8}
+	e.EncodeQ15(out[:2], []fixpoint.Q15{{N: 0x7fff}, {N: 0x0080}})
+	assert.Equal(t, []byte{0x7f, 0x01}, out[:2])
+}
+
+func TestDither(t *testing.T) {
+	// A constant signal of 0.25 LSB of the output format.
+	src := make([]fixpoint.Q31, 4096)
+	for i := range src {
+		src[i].N = 1 << 22
+	}
+	for _, tc := range []struct {
+		dither Dither
+		mean   float64
+	}{
+		{NoDither, 0},
+		{TPDF, 0.25},
+		{NoiseShaping, 0.25},
+	} {
+		e := Encoder{Format: S8, Dither: tc.dither}
+		out := make([]byte, len(src))
+		e.EncodeQ31(out, src)
+		sum := 0
+		for _, b := range out {
+			sum += int(int8(b))
+		}
+		mean := float64(sum) / float64(len(out))
+		if tc.dither == TPDF {
+			assert.InDelta(t, tc.mean, mean, 0.05, "dither %d", tc.dither)
+		} else {
+			assert.InDelta(t, tc.mean, mean, 0.001, "dither %d", tc.dither)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package audio
+
+import (
+	"github.com/aykevl/fixpoint"
+)
+
+// Default frequency range of a PitchDetector, which covers most instruments.
+const (
+	defaultMinFrequency = 40
+	defaultMaxFrequency = 2000
+)
+
+// PitchDetector estimates the fundamental frequency of a signal, for example
+// for an instrument tuner. It uses the squared difference function, which is
+// closely related to autocorrelation: for each candidate period, it sums the
+// squared difference between the signal and the signal delayed by that period.
+// The period is the first delay with a difference close to the smallest one
+// (to avoid picking a multiple of the period), refined with parabolic
+// interpolation.
+//
+// The zero value is not usable: SampleRate must be set. A buffer for the
+// difference function is allocated on the first call to Detect.
+type PitchDetector struct {
+	// SampleRate is the sample rate in Hz.
+	SampleRate uint32
+
+	// MinFrequency and MaxFrequency limit the range of detected frequencies
+	// in Hz. They default to 40Hz and 2kHz when zero.
+	MinFrequency fixpoint.Q16
+	MaxFrequency fixpoint.Q16
+
+	diffs []uint64 // difference function for each lag, allocated on first use
+}
+
+// Detect returns the fundamental frequency in Hz of the signal in buf. It
+// returns false if no pitch could be found, for example because the signal is
+// silent or noise. The buffer must contain at least two periods of the lowest
+// frequency; shorter buffers limit the range of detected frequencies.
+func (d *PitchDetector) Detect(buf []fixpoint.Q15) (fixpoint.Q16, bool) {
+	minFreq, maxFreq := int64(d.MinFrequency.N), int64(d.MaxFrequency.N)
+	if minFreq <= 0 {
+		minFreq = defaultMinFrequency << 16
+	}
+	if maxFreq <= 0 {
+		maxFreq = defaultMaxFrequency << 16
+	}
+	rate := int64(d.SampleRate) << 16
+	minLag := int(rate / maxFreq)
+	if minLag < 2 {
+		minLag = 2
+	}
+	maxLag := int((rate + minFreq - 1) / minFreq)
+	if maxLag > len(buf)/2 {
+		maxLag = len(buf) / 2
+	}
+	if maxLag-minLag < 2 {
+		return fixpoint.Q16{}, false
+	}
+
+	// Calculate the difference function for all lags from minLag-1 to
+	// maxLag+1, so that parabolic interpolation is possible at both ends.
+	if cap(d.diffs) < maxLag+2 {
+		d.diffs = make([]uint64, maxLag+2)
+	}
+	diffs := d.diffs[:maxLag+2]
+	n := len(buf) - maxLag - 1
+	minDiff, maxDiff := ^uint64(0), uint64(0)
+	for lag := minLag - 1; lag <= maxLag+1; lag++ {
+		var sum uint64
+		delayed := buf[lag : lag+n]
+		for i, x := range buf[:n] {
+			diff := int32(x.N) - int32(delayed[i].N)
+			// The square may overflow an int32, but not a uint32.
+			sum += uint64(uint32(diff * diff))
+		}
+		diffs[lag] = sum
+		if lag < minLag || lag > maxLag {
+			continue
+		}
+		if sum < minDiff {
+			minDiff = sum
+		}
+		if sum > maxDiff {
+			maxDiff = sum
+		}
+	}
+	if maxDiff == 0 || minDiff > maxDiff/2 {
+		// Silence, or no clear periodicity.
+		return fixpoint.Q16{}, false
+	}
+
+	// Pick the first local minimum that is close to the global minimum.
+	// Multiples of the period have minimums that are about as deep, so
+	// picking the deepest one would often give a subharmonic.
+	threshold := minDiff + (maxDiff-minDiff)/8
+	lag := minLag
+	for ; lag <= maxLag; lag++ {
+		if diffs[lag] <= threshold && diffs[lag] <= diffs[lag-1] && diffs[lag] <= diffs[lag+1] {
+			break
+		}
+	}
+	if lag > maxLag {
+		// The minimum is at the edge of the frequency range.
+		return fixpoint.Q16{}, false
+	}
+
+	// Fit a parabola through the minimum and its neighbors, and use the
+	// position of its vertex as the period (in Q24).
+	left, mid, right := diffs[lag-1], diffs[lag], diffs[lag+1]
+	for left >= 1<<38 || right >= 1<<38 {
+		// Avoid overflow in the division below.
+		left, mid, right = left>>1, mid>>1, right>>1
+	}
+	period := int64(lag) << 24
+	if den := 2 * (int64(left) - 2*int64(mid) + int64(right)); den > 0 {
+		period += ((int64(left) - int64(right)) << 24) / den
+	}
+	return fixpoint.Q16{N: int32((rate<<24 + period/2) / period)}, true
+}
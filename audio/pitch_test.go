@@ -0,0 +1,63 @@
+package audio
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPitchDetector(t *testing.T) {
+	d := PitchDetector{SampleRate: 16000}
+	buf := make([]fixpoint.Q15, 1024)
+
+	// Sine waves, including frequencies that are not a whole number of
+	// samples per period.
+	for _, freq := range []float64{82.41, 110, 261.63, 440, 1000, 1975.5} {
+		for i := range buf {
+			buf[i].N = int16(32000 * math.Sin(2*math.Pi*freq*float64(i)/16000))
+		}
+		actual, ok := d.Detect(buf)
+		if assert.True(t, ok, "%.2fHz", freq) {
+			assert.InDelta(t, freq, float64(actual.N)/(1<<16), freq*0.002, "%.2fHz", freq)
+		}
+	}
+
+	// A sawtooth wave has strong harmonics, which must not be detected as
+	// the fundamental.
+	osc := Oscillator{Waveform: Saw, Increment: Increment(fixpoint.Q16FromInt32(196), 16000)}
+	osc.Process(buf)
+	actual, ok := d.Detect(buf)
+	assert.True(t, ok)
+	assert.InDelta(t, 196, float64(actual.N)/(1<<16), 196*0.002)
+
+	// The frequency range can be limited.
+	d.MinFrequency = fixpoint.Q16FromInt32(300)
+	_, ok = d.Detect(buf)
+	assert.False(t, ok)
+	d.MinFrequency = fixpoint.Q16{}
+
+	// Silence and noise have no pitch.
+	for i := range buf {
+		buf[i].N = 0
+	}
+	_, ok = d.Detect(buf)
+	assert.False(t, ok)
+	r := rand.New(rand.NewSource(1))
+	for i := range buf {
+		buf[i].N = int16(r.Intn(20000) - 10000)
+	}
+	_, ok = d.Detect(buf)
+	assert.False(t, ok)
+
+	// Too short buffers.
+	_, ok = d.Detect(buf[:8])
+	assert.False(t, ok)
+
+	// The buffer for the differences is reused.
+	if allocs := testing.AllocsPerRun(10, func() { d.Detect(buf) }); allocs != 0 {
+		t.Errorf("Detect allocates %v times", allocs)
+	}
+}
@@ -0,0 +1,130 @@
+package audio
+
+import (
+	"errors"
+
+	"github.com/aykevl/fixpoint/internal/le"
+)
+
+// Block state
+//
+// Blocks with internal state have a State method that returns it (but not
+// their configuration) as a byte slice, and a Restore method that sets it
+// again, for example to resume after a deep sleep without a click. The biquad
+// sections of a BiquadCascade and the filter of a FIR block can be saved
+// directly, see the filters package.
+
+var errInvalidState = errors.New("audio: invalid state")
+
+// State returns the internal state of the envelope follower.
+func (e *EnvelopeFollower) State() []byte {
+	return e.AppendState(make([]byte, 0, 8))
+}
+
+// AppendState appends the state returned by State to dst and returns the
+// extended slice.
+func (e *EnvelopeFollower) AppendState(dst []byte) []byte {
+	return le.AppendInt64(dst, e.level)
+}
+
+// Restore sets the internal state of the envelope follower to a state
+// returned by State.
+func (e *EnvelopeFollower) Restore(state []byte) error {
+	if len(state) != 8 {
+		return errInvalidState
+	}
+	e.level = le.Int64(state)
+	return nil
+}
+
+// State returns the internal state of the compressor, which is the state of
+// its envelope follower.
+func (c *Compressor) State() []byte {
+	return c.Envelope.State()
+}
+
+// AppendState appends the state returned by State to dst and returns the
+// extended slice.
+func (c *Compressor) AppendState(dst []byte) []byte {
+	return c.Envelope.AppendState(dst)
+}
+
+// Restore sets the internal state of the compressor to a state returned by
+// State.
+func (c *Compressor) Restore(state []byte) error {
+	return c.Envelope.Restore(state)
+}
+
+// State returns the internal state of the oscillator, which is its phase.
+func (o *Oscillator) State() []byte {
+	return o.AppendState(make([]byte, 0, 4))
+}
+
+// AppendState appends the state returned by State to dst and returns the
+// extended slice.
+func (o *Oscillator) AppendState(dst []byte) []byte {
+	return le.AppendInt32(dst, int32(o.Phase))
+}
+
+// Restore sets the internal state of the oscillator to a state returned by
+// State.
+func (o *Oscillator) Restore(state []byte) error {
+	if len(state) != 4 {
+		return errInvalidState
+	}
+	o.Phase = uint32(le.Int32(state))
+	return nil
+}
+
+// State returns the internal state of the delay line, which includes the
+// contents of Buf.
+func (d *DelayLine) State() []byte {
+	return d.AppendState(make([]byte, 0, 4+2*len(d.Buf)))
+}
+
+// AppendState appends the state returned by State to dst and returns the
+// extended slice.
+func (d *DelayLine) AppendState(dst []byte) []byte {
+	buf := le.AppendInt32(dst, int32(d.index))
+	for _, x := range d.Buf {
+		buf = append(buf, byte(x.N), byte(x.N>>8))
+	}
+	return buf
+}
+
+// Restore sets the internal state of the delay line to a state returned by
+// State. Buf must have the same length as when the state was saved.
+func (d *DelayLine) Restore(state []byte) error {
+	if len(state) != 4+2*len(d.Buf) {
+		return errInvalidState
+	}
+	index := int(le.Int32(state))
+	if index < 0 || index >= len(d.Buf) {
+		return errInvalidState
+	}
+	d.index = index
+	for i := range d.Buf {
+		d.Buf[i].N = int16(uint16(state[4+2*i]) | uint16(state[5+2*i])<<8)
+	}
+	return nil
+}
+
+// State returns the internal state of the tap, which is its exact delay.
+func (t *Tap) State() []byte {
+	return t.AppendState(make([]byte, 0, 8))
+}
+
+// AppendState appends the state returned by State to dst and returns the
+// extended slice.
+func (t *Tap) AppendState(dst []byte) []byte {
+	return le.AppendInt64(dst, t.delay)
+}
+
+// Restore sets the internal state of the tap to a state returned by State.
+func (t *Tap) Restore(state []byte) error {
+	if len(state) != 8 {
+		return errInvalidState
+	}
+	t.delay = le.Int64(state)
+	return nil
+}
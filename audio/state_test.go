@@ -0,0 +1,66 @@
+package audio
+
+import (
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/statetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestState(t *testing.T) {
+	c := Compressor{
+		Threshold: fixpoint.Q15{N: 6000},
+		Ratio:     fixpoint.Q16FromInt32(4),
+		Envelope:  EnvelopeFollower{Attack: fixpoint.Q24FromFloat(0.1), Release: fixpoint.Q24FromFloat(0.01)},
+	}
+	buf := make([]fixpoint.Q15, 16)
+	for i := range buf {
+		buf[i].N = 20000
+	}
+	c.Process(buf)
+	c2 := Compressor{Threshold: c.Threshold, Ratio: c.Ratio, Envelope: EnvelopeFollower{Attack: c.Envelope.Attack, Release: c.Envelope.Release}}
+	assert.NoError(t, c2.Restore(c.State()))
+	assert.Equal(t, c.Envelope.Level(), c2.Envelope.Level())
+	assert.Error(t, c2.Restore(nil))
+
+	o := Oscillator{Waveform: Saw, Increment: Increment(fixpoint.Q16FromInt32(440), 48000)}
+	o.Next()
+	o2 := Oscillator{Waveform: Saw, Increment: o.Increment}
+	assert.NoError(t, o2.Restore(o.State()))
+	assert.Equal(t, o.Next(), o2.Next())
+	assert.Error(t, o2.Restore(nil))
+
+	d := DelayLine{Buf: make([]fixpoint.Q15, 8)}
+	for i := 0; i < 11; i++ {
+		d.Write(fixpoint.Q15{N: int16(i*1000 - 3000)})
+	}
+	d2 := DelayLine{Buf: make([]fixpoint.Q15, 8)}
+	assert.NoError(t, d2.Restore(d.State()))
+	for i := 0; i < 8; i++ {
+		delay := fixpoint.Q16FromInt32(int32(i))
+		assert.Equal(t, d.Read(delay), d2.Read(delay))
+	}
+	assert.Error(t, (&DelayLine{Buf: make([]fixpoint.Q15, 7)}).Restore(d.State()))
+
+	tap := Tap{Rate: fixpoint.Q24FromFloat(1.5)}
+	tap.Next(&d)
+	tap2 := Tap{Rate: tap.Rate}
+	assert.NoError(t, tap2.Restore(tap.State()))
+	assert.Equal(t, tap.Delay(), tap2.Delay())
+	assert.Error(t, tap2.Restore(nil))
+}
+
+func TestAppendState(t *testing.T) {
+	d := &DelayLine{Buf: make([]fixpoint.Q15, 8)}
+	d.Write(fixpoint.Q15{N: 1000})
+	for _, s := range []statetest.Appender{
+		&EnvelopeFollower{},
+		&Compressor{},
+		&Oscillator{Phase: 12345},
+		d,
+		&Tap{},
+	} {
+		statetest.CheckAppend(t, s)
+	}
+}
@@ -0,0 +1,107 @@
+package audio
+
+import (
+	"math/bits"
+
+	"github.com/aykevl/fixpoint"
+)
+
+//go:generate go run ../cmd/fixgen -wavetables -package audio -o wavetables.go
+
+// Waveform is the waveform of an Oscillator.
+type Waveform uint8
+
+// Waveforms supported by Oscillator.
+const (
+	Saw Waveform = iota
+	Square
+	Triangle
+)
+
+// Oscillator is a wavetable oscillator. To avoid aliasing, it uses
+// band-limited tables with fewer harmonics for higher frequencies: for a given
+// frequency, it uses the table with the most harmonics that stay below the
+// Nyquist frequency. Samples between table entries are linearly interpolated.
+//
+// The phase is a 32-bit number where 2^32 is one full cycle, so it wraps
+// around naturally.
+//
+// The zero value outputs silence, as Increment is zero.
+type Oscillator struct {
+	Waveform Waveform
+
+	// Increment is the phase increment per sample, see Increment.
+	Increment uint32
+
+	// Phase is the current phase of the oscillator.
+	Phase uint32
+}
+
+// Increment returns the phase increment for an oscillator with the given
+// frequency and sample rate, both in Hz. The frequency must be below the
+// sample rate.
+func Increment(freq fixpoint.Q16, sampleRate uint32) uint32 {
+	return uint32((uint64(freq.N) << 16) / uint64(sampleRate))
+}
+
+// Next returns the next sample and advances the phase.
+func (o *Oscillator) Next() fixpoint.Q15 {
+	if o.Increment == 0 {
+		return fixpoint.Q15{}
+	}
+	table := o.table()
+	v := interpolate(table, o.Phase)
+	o.Phase += o.Increment
+	return fixpoint.Q15{N: v}
+}
+
+// Process overwrites the samples in buf with the output of the oscillator,
+// which makes it usable as the first block of a FilterChain.
+func (o *Oscillator) Process(buf []fixpoint.Q15) {
+	if o.Increment == 0 {
+		for i := range buf {
+			buf[i] = fixpoint.Q15{}
+		}
+		return
+	}
+	table := o.table()
+	for i := range buf {
+		buf[i].N = interpolate(table, o.Phase)
+		o.Phase += o.Increment
+	}
+}
+
+// Reset sets the phase to zero.
+func (o *Oscillator) Reset() {
+	o.Phase = 0
+}
+
+// table returns the wavetable for the current waveform and frequency.
+func (o *Oscillator) table() *[wavetableSize]int16 {
+	// The Nyquist frequency allows 2^31/Increment harmonics, so a table with
+	// 2^level harmonics can be used for levels up to 31-log2(Increment).
+	level := 31 - bits.Len32(o.Increment)
+	if level < 0 {
+		level = 0
+	}
+	if level >= wavetableLevels {
+		level = wavetableLevels - 1
+	}
+	switch o.Waveform {
+	case Square:
+		return &squareTables[level]
+	case Triangle:
+		return &triangleTables[level]
+	default:
+		return &sawTables[level]
+	}
+}
+
+// interpolate returns the value of the table at the given phase.
+func interpolate(table *[wavetableSize]int16, phase uint32) int16 {
+	i := phase >> 24
+	frac := int32(phase>>9) & 0x7fff
+	a := int32(table[i])
+	b := int32(table[(i+1)%wavetableSize])
+	return int16(a + ((b-a)*frac+1<<14)>>15)
+}
@@ -0,0 +1,64 @@
+package audio
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncrement(t *testing.T) {
+	assert.Equal(t, uint32(1<<30), Increment(fixpoint.Q16FromInt32(12000), 48000))
+	assert.InDelta(t, 440.0/44100*(1<<32), float64(Increment(fixpoint.Q16FromInt32(440), 44100)), 1<<16)
+}
+
+func TestOscillator(t *testing.T) {
+	// The zero value outputs silence.
+	var o Oscillator
+	buf := []fixpoint.Q15{{N: 100}, {N: 200}}
+	o.Process(buf)
+	assert.Equal(t, []fixpoint.Q15{{}, {}}, buf)
+
+	// A low frequency saw uses the table with the most harmonics. It rises
+	// linearly in the middle of the period, and the overshoot at the edge is
+	// at full scale.
+	o = Oscillator{Waveform: Saw, Increment: 1 << 24}
+	buf = make([]fixpoint.Q15, 256)
+	o.Process(buf)
+	assert.Equal(t, uint32(0), o.Phase)
+	assert.Equal(t, int16(0), buf[0].N)
+	assert.InDelta(t, -buf[64].N, buf[192].N, 2)
+	assert.InDelta(t, 3*int32(buf[64].N)/2, buf[96].N, 300)
+	max := int16(0)
+	for _, v := range buf {
+		if v.N > max {
+			max = v.N
+		}
+	}
+	assert.True(t, max > 32000, "max is %d", max)
+
+	// Next gives the same output as Process.
+	o.Reset()
+	for i := 0; i < 256; i++ {
+		assert.Equal(t, buf[i], o.Next())
+	}
+
+	// A triangle with a frequency of 1/8 of the sample rate only uses the
+	// fundamental (harmonic 3 would be above Nyquist), with the same amplitude
+	// as in the full triangle wave.
+	o = Oscillator{Waveform: Triangle, Increment: 1 << 29, Phase: 1 << 29}
+	buf = make([]fixpoint.Q15, 8)
+	o.Process(buf)
+	for i, v := range buf {
+		expected := 32767 * 8 / (math.Pi * math.Pi) * math.Sin(2*math.Pi*float64(i+1)/8)
+		assert.InDelta(t, expected, v.N, 200, "sample %d", i)
+	}
+
+	// Square waves stay within range.
+	o = Oscillator{Waveform: Square, Increment: Increment(fixpoint.Q16FromInt32(1000), 48000)}
+	for i := 0; i < 1000; i++ {
+		v := o.Next()
+		assert.True(t, v.N > -32767 && v.N <= 32767)
+	}
+}
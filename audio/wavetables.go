@@ -0,0 +1,397 @@
+// Code generated by fixgen -wavetables; DO NOT EDIT.
+
+package audio
+
+// Band-limited wavetables with one cycle of each waveform in Q15 format.
+// Table i contains the harmonics 1 to 2^i.
+const (
+	wavetableSize   = 256
+	wavetableLevels = 7
+)
+
+var sawTables = [wavetableLevels][wavetableSize]int16{
+	{
+		0, 440, 880, 1319, 1758, 2195, 2631, 3066, 3498, 3929, 4357, 4783, 5205, 5625, 6041, 6454,
+		6862, 7267, 7667, 8062, 8453, 8838, 9219, 9593, 9962, 10325, 10682, 11032, 11376, 11712, 12042, 12365,
+		12680, 12987, 13286, 13578, 13861, 14136, 14403, 14661, 14910, 15150, 15380, 15602, 15814, 16017, 16210, 16393,
+		16567, 16730, 16883, 17027, 17160, 17282, 17394, 17496, 17587, 17668, 17738, 17797, 17845, 17883, 17910, 17926,
+		17932, 17926, 17910, 17883, 17845, 17797, 17738, 17668, 17587, 17496, 17394, 17282, 17160, 17027, 16883, 16730,
+		16567, 16393, 16210, 16017, 15814, 15602, 15380, 15150, 14910, 14661, 14403, 14136, 13861, 13578, 13286, 12987,
+		12680, 12365, 12042, 11712, 11376, 11032, 10682, 10325, 9962, 9593, 9219, 8838, 8453, 8062, 7667, 7267,
+		6862, 6454, 6041, 5625, 5205, 4783, 4357, 3929, 3498, 3066, 2631, 2195, 1758, 1319, 880, 440,
+		0, -440, -880, -1319, -1758, -2195, -2631, -3066, -3498, -3929, -4357, -4783, -5205, -5625, -6041, -6454,
+		-6862, -7267, -7667, -8062, -8453, -8838, -9219, -9593, -9962, -10325, -10682, -11032, -11376, -11712, -12042, -12365,
+		-12680, -12987, -13286, -13578, -13861, -14136, -14403, -14661, -14910, -15150, -15380, -15602, -15814, -16017, -16210, -16393,
+		-16567, -16730, -16883, -17027, -17160, -17282, -17394, -17496, -17587, -17668, -17738, -17797, -17845, -17883, -17910, -17926,
+		-17932, -17926, -17910, -17883, -17845, -17797, -17738, -17668, -17587, -17496, -17394, -17282, -17160, -17027, -16883, -16730,
+		-16567, -16393, -16210, -16017, -15814, -15602, -15380, -15150, -14910, -14661, -14403, -14136, -13861, -13578, -13286, -12987,
+		-12680, -12365, -12042, -11712, -11376, -11032, -10682, -10325, -9962, -9593, -9219, -8838, -8453, -8062, -7667, -7267,
+		-6862, -6454, -6041, -5625, -5205, -4783, -4357, -3929, -3498, -3066, -2631, -2195, -1758, -1319, -880, -440,
+	},
+	{
+		0, 0, 1, 4, 8, 17, 28, 45, 67, 95, 131, 173, 224, 284, 353, 432,
+		522, 623, 736, 861, 998, 1148, 1312, 1488, 1679, 1883, 2102, 2335, 2582, 2844, 3120, 3410,
+		3714, 4032, 4364, 4709, 5068, 5439, 5823, 6219, 6626, 7045, 7473, 7912, 8359, 8816, 9279, 9750,
+		10227, 10709, 11196, 11686, 12178, 12673, 13168, 13663, 14156, 14647, 15135, 15618, 16096, 16567, 17031, 17486,
+		17932, 18366, 18789, 19199, 19594, 19975, 20340, 20688, 21018, 21329, 21621, 21891, 22141, 22368, 22571, 22751,
+		22906, 23037, 23141, 23218, 23269, 23292, 23288, 23255, 23193, 23102, 22983, 22833, 22655, 22447, 22209, 21942,
+		21645, 21320, 20965, 20581, 20169, 19729, 19262, 18767, 18246, 17698, 17126, 16529, 15908, 15264, 14597, 13910,
+		13202, 12475, 11729, 10966, 10186, 9392, 8583, 7762, 6929, 6086, 5234, 4374, 3507, 2635, 1759, 880,
+		0, -880, -1759, -2635, -3507, -4374, -5234, -6086, -6929, -7762, -8583, -9392, -10186, -10966, -11729, -12475,
+		-13202, -13910, -14597, -15264, -15908, -16529, -17126, -17698, -18246, -18767, -19262, -19729, -20169, -20581, -20965, -21320,
+		-21645, -21942, -22209, -22447, -22655, -22833, -22983, -23102, -23193, -23255, -23288, -23292, -23269, -23218, -23141, -23037,
+		-22906, -22751, -22571, -22368, -22141, -21891, -21621, -21329, -21018, -20688, -20340, -19975, -19594, -19199, -18789, -18366,
+		-17932, -17486, -17031, -16567, -16096, -15618, -15135, -14647, -14156, -13663, -13168, -12673, -12178, -11686, -11196, -10709,
+		-10227, -9750, -9279, -8816, -8359, -7912, -7473, -7045, -6626, -6219, -5823, -5439, -5068, -4709, -4364, -4032,
+		-3714, -3410, -3120, -2844, -2582, -2335, -2102, -1883, -1679, -1488, -1312, -1148, -998, -861, -736, -623,
+		-522, -432, -353, -284, -224, -173, -131, -95, -67, -45, -28, -17, -8, -4, -1, 0,
+	},
+	{
+		0, 0, 4, 12, 28, 54, 93, 147, 218, 307, 417, 549, 703, 881, 1083, 1310,
+		1562, 1838, 2137, 2460, 2805, 3170, 3554, 3955, 4371, 4800, 5239, 5686, 6138, 6592, 7046, 7496,
+		7940, 8375, 8799, 9208, 9601, 9975, 10327, 10657, 10962, 11242, 11494, 11719, 11915, 12084, 12224, 12336,
+		12422, 12483, 12519, 12534, 12528, 12505, 12466, 12416, 12356, 12290, 12222, 12155, 12092, 12037, 11993, 11965,
+		11954, 11966, 12002, 12065, 12159, 12285, 12446, 12644, 12878, 13152, 13464, 13816, 14207, 14636, 15102, 15602,
+		16136, 16700, 17292, 17907, 18542, 19192, 19854, 20521, 21189, 21853, 22506, 23142, 23757, 24343, 24895, 25407,
+		25872, 26285, 26640, 26932, 27156, 27307, 27380, 27372, 27278, 27096, 26823, 26458, 25998, 25443, 24792, 24047,
+		23207, 22275, 21252, 20143, 18949, 17674, 16325, 14905, 13420, 11876, 10280, 8638, 6957, 5246, 3510, 1759,
+		0, -1759, -3510, -5246, -6957, -8638, -10280, -11876, -13420, -14905, -16325, -17674, -18949, -20143, -21252, -22275,
+		-23207, -24047, -24792, -25443, -25998, -26458, -26823, -27096, -27278, -27372, -27380, -27307, -27156, -26932, -26640, -26285,
+		-25872, -25407, -24895, -24343, -23757, -23142, -22506, -21853, -21189, -20521, -19854, -19192, -18542, -17907, -17292, -16700,
+		-16136, -15602, -15102, -14636, -14207, -13816, -13464, -13152, -12878, -12644, -12446, -12285, -12159, -12065, -12002, -11966,
+		-11954, -11965, -11993, -12037, -12092, -12155, -12222, -12290, -12356, -12416, -12466, -12505, -12528, -12534, -12519, -12483,
+		-12422, -12336, -12224, -12084, -11915, -11719, -11494, -11242, -10962, -10657, -10327, -9975, -9601, -9208, -8799, -8375,
+		-7940, -7496, -7046, -6592, -6138, -5686, -5239, -4800, -4371, -3955, -3554, -3170, -2805, -2460, -2137, -1838,
+		-1562, -1310, -1083, -881, -703, -549, -417, -307, -218, -147, -93, -54, -28, -12, -4, 0,
+	},
+	{
+		0, 2, 13, 42, 98, 188, 318, 491, 710, 974, 1283, 1631, 2015, 2426, 2858, 3299,
+		3742, 4176, 4593, 4982, 5338, 5654, 5925, 6150, 6326, 6457, 6545, 6596, 6618, 6617, 6604, 6589,
+		6582, 6592, 6630, 6703, 6818, 6980, 7193, 7457, 7770, 8130, 8532, 8967, 9426, 9901, 10379, 10851,
+		11303, 11727, 12113, 12453, 12741, 12973, 13147, 13265, 13329, 13346, 13323, 13270, 13199, 13121, 13050, 12999,
+		12979, 13002, 13078, 13215, 13417, 13688, 14026, 14429, 14891, 15402, 15952, 16527, 17112, 17693, 18251, 18773,
+		19244, 19650, 19980, 20228, 20389, 20461, 20447, 20354, 20193, 19976, 19721, 19447, 19174, 18925, 18722, 18585,
+		18536, 18591, 18763, 19063, 19496, 20061, 20752, 21559, 22462, 23441, 24467, 25508, 26527, 27488, 28350, 29071,
+		29614, 29940, 30014, 29806, 29293, 28455, 27281, 25767, 23917, 21744, 19267, 16513, 13518, 10322, 6970, 3512,
+		0, -3512, -6970, -10322, -13518, -16513, -19267, -21744, -23917, -25767, -27281, -28455, -29293, -29806, -30014, -29940,
+		-29614, -29071, -28350, -27488, -26527, -25508, -24467, -23441, -22462, -21559, -20752, -20061, -19496, -19063, -18763, -18591,
+		-18536, -18585, -18722, -18925, -19174, -19447, -19721, -19976, -20193, -20354, -20447, -20461, -20389, -20228, -19980, -19650,
+		-19244, -18773, -18251, -17693, -17112, -16527, -15952, -15402, -14891, -14429, -14026, -13688, -13417, -13215, -13078, -13002,
+		-12979, -12999, -13050, -13121, -13199, -13270, -13323, -13346, -13329, -13265, -13147, -12973, -12741, -12453, -12113, -11727,
+		-11303, -10851, -10379, -9901, -9426, -8967, -8532, -8130, -7770, -7457, -7193, -6980, -6818, -6703, -6630, -6592,
+		-6582, -6589, -6604, -6617, -6618, -6596, -6545, -6457, -6326, -6150, -5925, -5654, -5338, -4982, -4593, -4176,
+		-3742, -3299, -2858, -2426, -2015, -1631, -1283, -974, -710, -491, -318, -188, -98, -42, -13, -2,
+	},
+	{
+		0, 6, 47, 151, 337, 612, 966, 1377, 1816, 2245, 2633, 2951, 3183, 3327, 3396, 3412,
+		3410, 3424, 3489, 3630, 3859, 4177, 4568, 5004, 5451, 5872, 6235, 6515, 6702, 6801, 6831, 6822,
+		6810, 6834, 6925, 7106, 7383, 7749, 8180, 8644, 9101, 9512, 9846, 10083, 10219, 10266, 10251, 10212,
+		10189, 10224, 10347, 10575, 10909, 11333, 11815, 12313, 12781, 13180, 13479, 13661, 13732, 13713, 13640, 13562,
+		13525, 13574, 13739, 14031, 14442, 14944, 15494, 16038, 16523, 16905, 17153, 17259, 17236, 17120, 16964, 16828,
+		16771, 16841, 17069, 17459, 17989, 18615, 19272, 19892, 20404, 20757, 20921, 20896, 20712, 20429, 20124, 19884,
+		19789, 19900, 20248, 20827, 21593, 22467, 23347, 24123, 24695, 24984, 24956, 24624, 24052, 23351, 22663, 22146,
+		21946, 22175, 22892, 24080, 25646, 27418, 29160, 30595, 31429, 31391, 30259, 27893, 24254, 19417, 13565, 6976,
+		0, -6976, -13565, -19417, -24254, -27893, -30259, -31391, -31429, -30595, -29160, -27418, -25646, -24080, -22892, -22175,
+		-21946, -22146, -22663, -23351, -24052, -24624, -24956, -24984, -24695, -24123, -23347, -22467, -21593, -20827, -20248, -19900,
+		-19789, -19884, -20124, -20429, -20712, -20896, -20921, -20757, -20404, -19892, -19272, -18615, -17989, -17459, -17069, -16841,
+		-16771, -16828, -16964, -17120, -17236, -17259, -17153, -16905, -16523, -16038, -15494, -14944, -14442, -14031, -13739, -13574,
+		-13525, -13562, -13640, -13713, -13732, -13661, -13479, -13180, -12781, -12313, -11815, -11333, -10909, -10575, -10347, -10224,
+		-10189, -10212, -10251, -10266, -10219, -10083, -9846, -9512, -9101, -8644, -8180, -7749, -7383, -7106, -6925, -6834,
+		-6810, -6822, -6831, -6801, -6702, -6515, -6235, -5872, -5451, -5004, -4568, -4177, -3859, -3630, -3489, -3424,
+		-3410, -3412, -3396, -3327, -3183, -2951, -2633, -2245, -1816, -1377, -966, -612, -337, -151, -47, -6,
+	},
+	{
+		0, 23, 164, 472, 894, 1307, 1596, 1718, 1733, 1764, 1925, 2253, 2682, 3088, 3356, 3459,
+		3465, 3504, 3685, 4033, 4471, 4868, 5117, 5199, 5196, 5244, 5446, 5815, 6262, 6650, 6877, 6937,
+		6926, 6982, 7207, 7598, 8054, 8433, 8637, 8674, 8653, 8719, 8968, 9384, 9850, 10218, 10397, 10407,
+		10376, 10453, 10729, 11172, 11650, 12007, 12156, 12138, 12093, 12184, 12491, 12966, 13457, 13800, 13915, 13862,
+		13804, 13909, 14253, 14765, 15272, 15599, 15674, 15579, 15503, 15627, 16015, 16574, 17101, 17407, 17431, 17284,
+		17186, 17333, 17779, 18399, 18951, 19230, 19187, 18970, 18842, 19020, 19546, 20248, 20836, 21077, 20939, 20623,
+		20451, 20676, 21319, 22143, 22785, 22968, 22682, 22208, 21967, 22268, 23106, 24141, 24876, 24954, 24397, 23628,
+		23254, 23707, 24949, 26434, 27377, 27209, 25977, 24426, 23679, 24610, 27242, 30468, 32323, 30731, 24419, 13588,
+		0, -13588, -24419, -30731, -32323, -30468, -27242, -24610, -23679, -24426, -25977, -27209, -27377, -26434, -24949, -23707,
+		-23254, -23628, -24397, -24954, -24876, -24141, -23106, -22268, -21967, -22208, -22682, -22968, -22785, -22143, -21319, -20676,
+		-20451, -20623, -20939, -21077, -20836, -20248, -19546, -19020, -18842, -18970, -19187, -19230, -18951, -18399, -17779, -17333,
+		-17186, -17284, -17431, -17407, -17101, -16574, -16015, -15627, -15503, -15579, -15674, -15599, -15272, -14765, -14253, -13909,
+		-13804, -13862, -13915, -13800, -13457, -12966, -12491, -12184, -12093, -12138, -12156, -12007, -11650, -11172, -10729, -10453,
+		-10376, -10407, -10397, -10218, -9850, -9384, -8968, -8719, -8653, -8674, -8637, -8433, -8054, -7598, -7207, -6982,
+		-6926, -6937, -6877, -6650, -6262, -5815, -5446, -5244, -5196, -5199, -5117, -4868, -4471, -4033, -3685, -3504,
+		-3465, -3459, -3356, -3088, -2682, -2253, -1925, -1764, -1733, -1718, -1596, -1307, -894, -472, -164, -23,
+	},
+	{
+		0, 81, 444, 799, 873, 961, 1331, 1679, 1747, 1842, 2218, 2560, 2620, 2722, 3105, 3440,
+		3493, 3602, 3992, 4320, 4366, 4482, 4880, 5200, 5239, 5362, 5768, 6080, 6111, 6243, 6656, 6961,
+		6984, 7123, 7544, 7841, 7856, 8003, 8433, 8721, 8727, 8884, 9322, 9601, 9598, 9764, 10211, 10481,
+		10469, 10644, 11101, 11361, 11339, 11525, 11992, 12241, 12208, 12405, 12884, 13121, 13076, 13285, 13777, 14001,
+		13943, 14166, 14671, 14881, 14809, 15046, 15566, 15761, 15673, 15927, 16463, 16641, 16535, 16807, 17363, 17521,
+		17395, 17688, 18266, 18401, 18251, 18569, 19172, 19280, 19103, 19450, 20083, 20159, 19949, 20331, 21001, 21038,
+		20787, 21213, 21928, 21916, 21614, 22096, 22869, 22794, 22424, 22980, 23831, 23669, 23208, 23866, 24828, 24541,
+		23944, 24758, 25890, 25404, 24587, 25666, 27093, 26239, 25001, 26629, 28707, 26938, 24552, 28041, 32767, 24500,
+		0, -24500, -32767, -28041, -24552, -26938, -28707, -26629, -25001, -26239, -27093, -25666, -24587, -25404, -25890, -24758,
+		-23944, -24541, -24828, -23866, -23208, -23669, -23831, -22980, -22424, -22794, -22869, -22096, -21614, -21916, -21928, -21213,
+		-20787, -21038, -21001, -20331, -19949, -20159, -20083, -19450, -19103, -19280, -19172, -18569, -18251, -18401, -18266, -17688,
+		-17395, -17521, -17363, -16807, -16535, -16641, -16463, -15927, -15673, -15761, -15566, -15046, -14809, -14881, -14671, -14166,
+		-13943, -14001, -13777, -13285, -13076, -13121, -12884, -12405, -12208, -12241, -11992, -11525, -11339, -11361, -11101, -10644,
+		-10469, -10481, -10211, -9764, -9598, -9601, -9322, -8884, -8727, -8721, -8433, -8003, -7856, -7841, -7544, -7123,
+		-6984, -6961, -6656, -6243, -6111, -6080, -5768, -5362, -5239, -5200, -4880, -4482, -4366, -4320, -3992, -3602,
+		-3493, -3440, -3105, -2722, -2620, -2560, -2218, -1842, -1747, -1679, -1331, -961, -873, -799, -444, -81,
+	},
+}
+
+var squareTables = [wavetableLevels][wavetableSize]int16{
+	{
+		0, 804, 1608, 2410, 3212, 4011, 4808, 5602, 6393, 7179, 7962, 8739, 9512, 10278, 11039, 11793,
+		12539, 13279, 14010, 14732, 15446, 16151, 16846, 17530, 18204, 18868, 19519, 20159, 20787, 21403, 22005, 22594,
+		23170, 23731, 24279, 24811, 25329, 25832, 26319, 26790, 27245, 27683, 28105, 28510, 28898, 29268, 29621, 29956,
+		30273, 30571, 30852, 31113, 31356, 31580, 31785, 31971, 32137, 32285, 32412, 32521, 32609, 32678, 32728, 32757,
+		32767, 32757, 32728, 32678, 32609, 32521, 32412, 32285, 32137, 31971, 31785, 31580, 31356, 31113, 30852, 30571,
+		30273, 29956, 29621, 29268, 28898, 28510, 28105, 27683, 27245, 26790, 26319, 25832, 25329, 24811, 24279, 23731,
+		23170, 22594, 22005, 21403, 20787, 20159, 19519, 18868, 18204, 17530, 16846, 16151, 15446, 14732, 14010, 13279,
+		12539, 11793, 11039, 10278, 9512, 8739, 7962, 7179, 6393, 5602, 4808, 4011, 3212, 2410, 1608, 804,
+		0, -804, -1608, -2410, -3212, -4011, -4808, -5602, -6393, -7179, -7962, -8739, -9512, -10278, -11039, -11793,
+		-12539, -13279, -14010, -14732, -15446, -16151, -16846, -17530, -18204, -18868, -19519, -20159, -20787, -21403, -22005, -22594,
+		-23170, -23731, -24279, -24811, -25329, -25832, -26319, -26790, -27245, -27683, -28105, -28510, -28898, -29268, -29621, -29956,
+		-30273, -30571, -30852, -31113, -31356, -31580, -31785, -31971, -32137, -32285, -32412, -32521, -32609, -32678, -32728, -32757,
+		-32767, -32757, -32728, -32678, -32609, -32521, -32412, -32285, -32137, -31971, -31785, -31580, -31356, -31113, -30852, -30571,
+		-30273, -29956, -29621, -29268, -28898, -28510, -28105, -27683, -27245, -26790, -26319, -25832, -25329, -24811, -24279, -23731,
+		-23170, -22594, -22005, -21403, -20787, -20159, -19519, -18868, -18204, -17530, -16846, -16151, -15446, -14732, -14010, -13279,
+		-12539, -11793, -11039, -10278, -9512, -8739, -7962, -7179, -6393, -5602, -4808, -4011, -3212, -2410, -1608, -804,
+	},
+	{
+		0, 804, 1608, 2410, 3212, 4011, 4808, 5602, 6393, 7179, 7962, 8739, 9512, 10278, 11039, 11793,
+		12539, 13279, 14010, 14732, 15446, 16151, 16846, 17530, 18204, 18868, 19519, 20159, 20787, 21403, 22005, 22594,
+		23170, 23731, 24279, 24811, 25329, 25832, 26319, 26790, 27245, 27683, 28105, 28510, 28898, 29268, 29621, 29956,
+		30273, 30571, 30852, 31113, 31356, 31580, 31785, 31971, 32137, 32285, 32412, 32521, 32609, 32678, 32728, 32757,
+		32767, 32757, 32728, 32678, 32609, 32521, 32412, 32285, 32137, 31971, 31785, 31580, 31356, 31113, 30852, 30571,
+		30273, 29956, 29621, 29268, 28898, 28510, 28105, 27683, 27245, 26790, 26319, 25832, 25329, 24811, 24279, 23731,
+		23170, 22594, 22005, 21403, 20787, 20159, 19519, 18868, 18204, 17530, 16846, 16151, 15446, 14732, 14010, 13279,
+		12539, 11793, 11039, 10278, 9512, 8739, 7962, 7179, 6393, 5602, 4808, 4011, 3212, 2410, 1608, 804,
+		0, -804, -1608, -2410, -3212, -4011, -4808, -5602, -6393, -7179, -7962, -8739, -9512, -10278, -11039, -11793,
+		-12539, -13279, -14010, -14732, -15446, -16151, -16846, -17530, -18204, -18868, -19519, -20159, -20787, -21403, -22005, -22594,
+		-23170, -23731, -24279, -24811, -25329, -25832, -26319, -26790, -27245, -27683, -28105, -28510, -28898, -29268, -29621, -29956,
+		-30273, -30571, -30852, -31113, -31356, -31580, -31785, -31971, -32137, -32285, -32412, -32521, -32609, -32678, -32728, -32757,
+		-32767, -32757, -32728, -32678, -32609, -32521, -32412, -32285, -32137, -31971, -31785, -31580, -31356, -31113, -30852, -30571,
+		-30273, -29956, -29621, -29268, -28898, -28510, -28105, -27683, -27245, -26790, -26319, -25832, -25329, -24811, -24279, -23731,
+		-23170, -22594, -22005, -21403, -20787, -20159, -19519, -18868, -18204, -17530, -16846, -16151, -15446, -14732, -14010, -13279,
+		-12539, -11793, -11039, -10278, -9512, -8739, -7962, -7179, -6393, -5602, -4808, -4011, -3212, -2410, -1608, -804,
+	},
+	{
+		0, 1608, 3210, 4804, 6382, 7942, 9478, 10986, 12461, 13899, 15297, 16650, 17955, 19208, 20407, 21549,
+		22630, 23650, 24605, 25494, 26316, 27070, 27755, 28370, 28917, 29394, 29803, 30145, 30420, 30630, 30778, 30865,
+		30893, 30866, 30785, 30655, 30478, 30258, 29998, 29703, 29376, 29020, 28641, 28242, 27827, 27401, 26967, 26530,
+		26093, 25661, 25236, 24824, 24427, 24049, 23692, 23360, 23056, 22781, 22539, 22330, 22157, 22021, 21923, 21864,
+		21845, 21864, 21923, 22021, 22157, 22330, 22539, 22781, 23056, 23360, 23692, 24049, 24427, 24824, 25236, 25661,
+		26093, 26530, 26967, 27401, 27827, 28242, 28641, 29020, 29376, 29703, 29998, 30258, 30478, 30655, 30785, 30866,
+		30893, 30865, 30778, 30630, 30420, 30145, 29803, 29394, 28917, 28370, 27755, 27070, 26316, 25494, 24605, 23650,
+		22630, 21549, 20407, 19208, 17955, 16650, 15297, 13899, 12461, 10986, 9478, 7942, 6382, 4804, 3210, 1608,
+		0, -1608, -3210, -4804, -6382, -7942, -9478, -10986, -12461, -13899, -15297, -16650, -17955, -19208, -20407, -21549,
+		-22630, -23650, -24605, -25494, -26316, -27070, -27755, -28370, -28917, -29394, -29803, -30145, -30420, -30630, -30778, -30865,
+		-30893, -30866, -30785, -30655, -30478, -30258, -29998, -29703, -29376, -29020, -28641, -28242, -27827, -27401, -26967, -26530,
+		-26093, -25661, -25236, -24824, -24427, -24049, -23692, -23360, -23056, -22781, -22539, -22330, -22157, -22021, -21923, -21864,
+		-21845, -21864, -21923, -22021, -22157, -22330, -22539, -22781, -23056, -23360, -23692, -24049, -24427, -24824, -25236, -25661,
+		-26093, -26530, -26967, -27401, -27827, -28242, -28641, -29020, -29376, -29703, -29998, -30258, -30478, -30655, -30785, -30866,
+		-30893, -30865, -30778, -30630, -30420, -30145, -29803, -29394, -28917, -28370, -27755, -27070, -26316, -25494, -24605, -23650,
+		-22630, -21549, -20407, -19208, -17955, -16650, -15297, -13899, -12461, -10986, -9478, -7942, -6382, -4804, -3210, -1608,
+	},
+	{
+		0, 3210, 6380, 9469, 12441, 15260, 17894, 20315, 22501, 24432, 26097, 27489, 28605, 29450, 30033, 30369,
+		30476, 30377, 30098, 29667, 29115, 28471, 27768, 27036, 26303, 25597, 24941, 24356, 23859, 23463, 23177, 23006,
+		22949, 23004, 23163, 23415, 23748, 24145, 24590, 25064, 25549, 26025, 26477, 26887, 27241, 27528, 27739, 27867,
+		27910, 27867, 27743, 27543, 27276, 26953, 26587, 26192, 25783, 25377, 24988, 24631, 24318, 24063, 23873, 23756,
+		23717, 23756, 23873, 24063, 24318, 24631, 24988, 25377, 25783, 26192, 26587, 26953, 27276, 27543, 27743, 27867,
+		27910, 27867, 27739, 27528, 27241, 26887, 26477, 26025, 25549, 25064, 24590, 24145, 23748, 23415, 23163, 23004,
+		22949, 23006, 23177, 23463, 23859, 24356, 24941, 25597, 26303, 27036, 27768, 28471, 29115, 29667, 30098, 30377,
+		30476, 30369, 30033, 29450, 28605, 27489, 26097, 24432, 22501, 20315, 17894, 15260, 12441, 9469, 6380, 3210,
+		0, -3210, -6380, -9469, -12441, -15260, -17894, -20315, -22501, -24432, -26097, -27489, -28605, -29450, -30033, -30369,
+		-30476, -30377, -30098, -29667, -29115, -28471, -27768, -27036, -26303, -25597, -24941, -24356, -23859, -23463, -23177, -23006,
+		-22949, -23004, -23163, -23415, -23748, -24145, -24590, -25064, -25549, -26025, -26477, -26887, -27241, -27528, -27739, -27867,
+		-27910, -27867, -27743, -27543, -27276, -26953, -26587, -26192, -25783, -25377, -24988, -24631, -24318, -24063, -23873, -23756,
+		-23717, -23756, -23873, -24063, -24318, -24631, -24988, -25377, -25783, -26192, -26587, -26953, -27276, -27543, -27743, -27867,
+		-27910, -27867, -27739, -27528, -27241, -26887, -26477, -26025, -25549, -25064, -24590, -24145, -23748, -23415, -23163, -23004,
+		-22949, -23006, -23177, -23463, -23859, -24356, -24941, -25597, -26303, -27036, -27768, -28471, -29115, -29667, -30098, -30377,
+		-30476, -30369, -30033, -29450, -28605, -27489, -26097, -24432, -22501, -20315, -17894, -15260, -12441, -9469, -6380, -3210,
+	},
+	{
+		0, 6379, 12436, 17879, 22469, 26044, 28529, 29939, 30375, 30005, 29049, 27747, 26340, 25041, 24018, 23378,
+		23166, 23362, 23894, 24651, 25502, 26315, 26975, 27399, 27543, 27406, 27028, 26479, 25852, 25243, 24742, 24415,
+		24303, 24411, 24714, 25157, 25669, 26171, 26589, 26863, 26958, 26865, 26605, 26220, 25773, 25331, 24962, 24717,
+		24632, 24716, 24953, 25304, 25715, 26123, 26467, 26695, 26775, 26696, 26471, 26136, 25741, 25348, 25015, 24793,
+		24715, 24793, 25015, 25348, 25741, 26136, 26471, 26696, 26775, 26695, 26467, 26123, 25715, 25304, 24953, 24716,
+		24632, 24717, 24962, 25331, 25773, 26220, 26605, 26865, 26958, 26863, 26589, 26171, 25669, 25157, 24714, 24411,
+		24303, 24415, 24742, 25243, 25852, 26479, 27028, 27406, 27543, 27399, 26975, 26315, 25502, 24651, 23894, 23362,
+		23166, 23378, 24018, 25041, 26340, 27747, 29049, 30005, 30375, 29939, 28529, 26044, 22469, 17879, 12436, 6379,
+		0, -6379, -12436, -17879, -22469, -26044, -28529, -29939, -30375, -30005, -29049, -27747, -26340, -25041, -24018, -23378,
+		-23166, -23362, -23894, -24651, -25502, -26315, -26975, -27399, -27543, -27406, -27028, -26479, -25852, -25243, -24742, -24415,
+		-24303, -24411, -24714, -25157, -25669, -26171, -26589, -26863, -26958, -26865, -26605, -26220, -25773, -25331, -24962, -24717,
+		-24632, -24716, -24953, -25304, -25715, -26123, -26467, -26695, -26775, -26696, -26471, -26136, -25741, -25348, -25015, -24793,
+		-24715, -24793, -25015, -25348, -25741, -26136, -26471, -26696, -26775, -26695, -26467, -26123, -25715, -25304, -24953, -24716,
+		-24632, -24717, -24962, -25331, -25773, -26220, -26605, -26865, -26958, -26863, -26589, -26171, -25669, -25157, -24714, -24411,
+		-24303, -24415, -24742, -25243, -25852, -26479, -27028, -27406, -27543, -27399, -26975, -26315, -25502, -24651, -23894, -23362,
+		-23166, -23378, -24018, -25041, -26340, -27747, -29049, -30005, -30375, -29939, -28529, -26044, -22469, -17879, -12436, -6379,
+	},
+	{
+		0, 12435, 22461, 28509, 30350, 29032, 26348, 24055, 23217, 23929, 25493, 26918, 27464, 26973, 25862, 24821,
+		24413, 24790, 25658, 26485, 26813, 26504, 25786, 25095, 24818, 25082, 25699, 26298, 26539, 26307, 25761, 25229,
+		25013, 25222, 25716, 26199, 26396, 26204, 25750, 25303, 25121, 25299, 25724, 26143, 26315, 26146, 25743, 25345,
+		25182, 25343, 25729, 26112, 26270, 26114, 25739, 25367, 25214, 25366, 25733, 26098, 26249, 26099, 25736, 25374,
+		25224, 25374, 25736, 26099, 26249, 26098, 25733, 25366, 25214, 25367, 25739, 26114, 26270, 26112, 25729, 25343,
+		25182, 25345, 25743, 26146, 26315, 26143, 25724, 25299, 25121, 25303, 25750, 26204, 26396, 26199, 25716, 25222,
+		25013, 25229, 25761, 26307, 26539, 26298, 25699, 25082, 24818, 25095, 25786, 26504, 26813, 26485, 25658, 24790,
+		24413, 24821, 25862, 26973, 27464, 26918, 25493, 23929, 23217, 24055, 26348, 29032, 30350, 28509, 22461, 12435,
+		0, -12435, -22461, -28509, -30350, -29032, -26348, -24055, -23217, -23929, -25493, -26918, -27464, -26973, -25862, -24821,
+		-24413, -24790, -25658, -26485, -26813, -26504, -25786, -25095, -24818, -25082, -25699, -26298, -26539, -26307, -25761, -25229,
+		-25013, -25222, -25716, -26199, -26396, -26204, -25750, -25303, -25121, -25299, -25724, -26143, -26315, -26146, -25743, -25345,
+		-25182, -25343, -25729, -26112, -26270, -26114, -25739, -25367, -25214, -25366, -25733, -26098, -26249, -26099, -25736, -25374,
+		-25224, -25374, -25736, -26099, -26249, -26098, -25733, -25366, -25214, -25367, -25739, -26114, -26270, -26112, -25729, -25343,
+		-25182, -25345, -25743, -26146, -26315, -26143, -25724, -25299, -25121, -25303, -25750, -26204, -26396, -26199, -25716, -25222,
+		-25013, -25229, -25761, -26307, -26539, -26298, -25699, -25082, -24818, -25095, -25786, -26504, -26813, -26485, -25658, -24790,
+		-24413, -24821, -25862, -26973, -27464, -26918, -25493, -23929, -23217, -24055, -26348, -29032, -30350, -28509, -22461, -12435,
+	},
+	{
+		0, 22459, 30343, 26350, 23230, 25491, 27445, 25864, 24438, 25656, 26781, 25788, 24858, 25697, 26492, 25764,
+		25068, 25713, 26332, 25753, 25193, 25721, 26232, 25747, 25275, 25725, 26164, 25743, 25332, 25728, 26116, 25741,
+		25373, 25730, 26080, 25740, 25404, 25731, 26054, 25739, 25427, 25732, 26033, 25738, 25445, 25733, 26018, 25737,
+		25458, 25733, 26007, 25737, 25468, 25734, 25999, 25736, 25474, 25734, 25994, 25736, 25478, 25735, 25991, 25735,
+		25479, 25735, 25991, 25735, 25478, 25736, 25994, 25734, 25474, 25736, 25999, 25734, 25468, 25737, 26007, 25733,
+		25458, 25737, 26018, 25733, 25445, 25738, 26033, 25732, 25427, 25739, 26054, 25731, 25404, 25740, 26080, 25730,
+		25373, 25741, 26116, 25728, 25332, 25743, 26164, 25725, 25275, 25747, 26232, 25721, 25193, 25753, 26332, 25713,
+		25068, 25764, 26492, 25697, 24858, 25788, 26781, 25656, 24438, 25864, 27445, 25491, 23230, 26350, 30343, 22459,
+		0, -22459, -30343, -26350, -23230, -25491, -27445, -25864, -24438, -25656, -26781, -25788, -24858, -25697, -26492, -25764,
+		-25068, -25713, -26332, -25753, -25193, -25721, -26232, -25747, -25275, -25725, -26164, -25743, -25332, -25728, -26116, -25741,
+		-25373, -25730, -26080, -25740, -25404, -25731, -26054, -25739, -25427, -25732, -26033, -25738, -25445, -25733, -26018, -25737,
+		-25458, -25733, -26007, -25737, -25468, -25734, -25999, -25736, -25474, -25734, -25994, -25736, -25478, -25735, -25991, -25735,
+		-25479, -25735, -25991, -25735, -25478, -25736, -25994, -25734, -25474, -25736, -25999, -25734, -25468, -25737, -26007, -25733,
+		-25458, -25737, -26018, -25733, -25445, -25738, -26033, -25732, -25427, -25739, -26054, -25731, -25404, -25740, -26080, -25730,
+		-25373, -25741, -26116, -25728, -25332, -25743, -26164, -25725, -25275, -25747, -26232, -25721, -25193, -25753, -26332, -25713,
+		-25068, -25764, -26492, -25697, -24858, -25788, -26781, -25656, -24438, -25864, -27445, -25491, -23230, -26350, -30343, -22459,
+	},
+}
+
+var triangleTables = [wavetableLevels][wavetableSize]int16{
+	{
+		0, 656, 1312, 1966, 2620, 3272, 3922, 4570, 5215, 5856, 6495, 7129, 7759, 8384, 9005, 9620,
+		10229, 10832, 11428, 12018, 12600, 13175, 13742, 14300, 14850, 15391, 15923, 16445, 16957, 17459, 17950, 18431,
+		18900, 19359, 19805, 20240, 20662, 21072, 21469, 21853, 22225, 22582, 22926, 23257, 23573, 23875, 24163, 24436,
+		24695, 24938, 25167, 25380, 25578, 25761, 25928, 26080, 26216, 26336, 26440, 26528, 26600, 26657, 26697, 26721,
+		26729, 26721, 26697, 26657, 26600, 26528, 26440, 26336, 26216, 26080, 25928, 25761, 25578, 25380, 25167, 24938,
+		24695, 24436, 24163, 23875, 23573, 23257, 22926, 22582, 22225, 21853, 21469, 21072, 20662, 20240, 19805, 19359,
+		18900, 18431, 17950, 17459, 16957, 16445, 15923, 15391, 14850, 14300, 13742, 13175, 12600, 12018, 11428, 10832,
+		10229, 9620, 9005, 8384, 7759, 7129, 6495, 5856, 5215, 4570, 3922, 3272, 2620, 1966, 1312, 656,
+		0, -656, -1312, -1966, -2620, -3272, -3922, -4570, -5215, -5856, -6495, -7129, -7759, -8384, -9005, -9620,
+		-10229, -10832, -11428, -12018, -12600, -13175, -13742, -14300, -14850, -15391, -15923, -16445, -16957, -17459, -17950, -18431,
+		-18900, -19359, -19805, -20240, -20662, -21072, -21469, -21853, -22225, -22582, -22926, -23257, -23573, -23875, -24163, -24436,
+		-24695, -24938, -25167, -25380, -25578, -25761, -25928, -26080, -26216, -26336, -26440, -26528, -26600, -26657, -26697, -26721,
+		-26729, -26721, -26697, -26657, -26600, -26528, -26440, -26336, -26216, -26080, -25928, -25761, -25578, -25380, -25167, -24938,
+		-24695, -24436, -24163, -23875, -23573, -23257, -22926, -22582, -22225, -21853, -21469, -21072, -20662, -20240, -19805, -19359,
+		-18900, -18431, -17950, -17459, -16957, -16445, -15923, -15391, -14850, -14300, -13742, -13175, -12600, -12018, -11428, -10832,
+		-10229, -9620, -9005, -8384, -7759, -7129, -6495, -5856, -5215, -4570, -3922, -3272, -2620, -1966, -1312, -656,
+	},
+	{
+		0, 656, 1312, 1966, 2620, 3272, 3922, 4570, 5215, 5856, 6495, 7129, 7759, 8384, 9005, 9620,
+		10229, 10832, 11428, 12018, 12600, 13175, 13742, 14300, 14850, 15391, 15923, 16445, 16957, 17459, 17950, 18431,
+		18900, 19359, 19805, 20240, 20662, 21072, 21469, 21853, 22225, 22582, 22926, 23257, 23573, 23875, 24163, 24436,
+		24695, 24938, 25167, 25380, 25578, 25761, 25928, 26080, 26216, 26336, 26440, 26528, 26600, 26657, 26697, 26721,
+		26729, 26721, 26697, 26657, 26600, 26528, 26440, 26336, 26216, 26080, 25928, 25761, 25578, 25380, 25167, 24938,
+		24695, 24436, 24163, 23875, 23573, 23257, 22926, 22582, 22225, 21853, 21469, 21072, 20662, 20240, 19805, 19359,
+		18900, 18431, 17950, 17459, 16957, 16445, 15923, 15391, 14850, 14300, 13742, 13175, 12600, 12018, 11428, 10832,
+		10229, 9620, 9005, 8384, 7759, 7129, 6495, 5856, 5215, 4570, 3922, 3272, 2620, 1966, 1312, 656,
+		0, -656, -1312, -1966, -2620, -3272, -3922, -4570, -5215, -5856, -6495, -7129, -7759, -8384, -9005, -9620,
+		-10229, -10832, -11428, -12018, -12600, -13175, -13742, -14300, -14850, -15391, -15923, -16445, -16957, -17459, -17950, -18431,
+		-18900, -19359, -19805, -20240, -20662, -21072, -21469, -21853, -22225, -22582, -22926, -23257, -23573, -23875, -24163, -24436,
+		-24695, -24938, -25167, -25380, -25578, -25761, -25928, -26080, -26216, -26336, -26440, -26528, -26600, -26657, -26697, -26721,
+		-26729, -26721, -26697, -26657, -26600, -26528, -26440, -26336, -26216, -26080, -25928, -25761, -25578, -25380, -25167, -24938,
+		-24695, -24436, -24163, -23875, -23573, -23257, -22926, -22582, -22225, -21853, -21469, -21072, -20662, -20240, -19805, -19359,
+		-18900, -18431, -17950, -17459, -16957, -16445, -15923, -15391, -14850, -14300, -13742, -13175, -12600, -12018, -11428, -10832,
+		-10229, -9620, -9005, -8384, -7759, -7129, -6495, -5856, -5215, -4570, -3922, -3272, -2620, -1966, -1312, -656,
+	},
+	{
+		0, 437, 876, 1316, 1758, 2203, 2652, 3106, 3565, 4029, 4500, 4978, 5463, 5956, 6457, 6967,
+		7485, 8012, 8547, 9092, 9644, 10206, 10775, 11352, 11937, 12529, 13126, 13730, 14338, 14950, 15565, 16182,
+		16800, 17419, 18036, 18651, 19262, 19868, 20469, 21061, 21645, 22219, 22781, 23330, 23864, 24383, 24885, 25368,
+		25831, 26273, 26694, 27090, 27462, 27809, 28129, 28421, 28685, 28920, 29125, 29299, 29442, 29555, 29635, 29683,
+		29699, 29683, 29635, 29555, 29442, 29299, 29125, 28920, 28685, 28421, 28129, 27809, 27462, 27090, 26694, 26273,
+		25831, 25368, 24885, 24383, 23864, 23330, 22781, 22219, 21645, 21061, 20469, 19868, 19262, 18651, 18036, 17419,
+		16800, 16182, 15565, 14950, 14338, 13730, 13126, 12529, 11937, 11352, 10775, 10206, 9644, 9092, 8547, 8012,
+		7485, 6967, 6457, 5956, 5463, 4978, 4500, 4029, 3565, 3106, 2652, 2203, 1758, 1316, 876, 437,
+		0, -437, -876, -1316, -1758, -2203, -2652, -3106, -3565, -4029, -4500, -4978, -5463, -5956, -6457, -6967,
+		-7485, -8012, -8547, -9092, -9644, -10206, -10775, -11352, -11937, -12529, -13126, -13730, -14338, -14950, -15565, -16182,
+		-16800, -17419, -18036, -18651, -19262, -19868, -20469, -21061, -21645, -22219, -22781, -23330, -23864, -24383, -24885, -25368,
+		-25831, -26273, -26694, -27090, -27462, -27809, -28129, -28421, -28685, -28920, -29125, -29299, -29442, -29555, -29635, -29683,
+		-29699, -29683, -29635, -29555, -29442, -29299, -29125, -28920, -28685, -28421, -28129, -27809, -27462, -27090, -26694, -26273,
+		-25831, -25368, -24885, -24383, -23864, -23330, -22781, -22219, -21645, -21061, -20469, -19868, -19262, -18651, -18036, -17419,
+		-16800, -16182, -15565, -14950, -14338, -13730, -13126, -12529, -11937, -11352, -10775, -10206, -9644, -9092, -8547, -8012,
+		-7485, -6967, -6457, -5956, -5463, -4978, -4500, -4029, -3565, -3106, -2652, -2203, -1758, -1316, -876, -437,
+	},
+	{
+		0, 475, 952, 1432, 1916, 2406, 2902, 3406, 3919, 4439, 4967, 5503, 6046, 6595, 7149, 7706,
+		8264, 8823, 9379, 9933, 10481, 11023, 11557, 12083, 12599, 13106, 13603, 14091, 14570, 15042, 15508, 15970,
+		16430, 16890, 17352, 17819, 18292, 18775, 19268, 19774, 20293, 20827, 21376, 21939, 22516, 23104, 23703, 24308,
+		24918, 25527, 26132, 26728, 27310, 27872, 28409, 28915, 29385, 29814, 30197, 30530, 30807, 31027, 31185, 31282,
+		31314, 31282, 31185, 31027, 30807, 30530, 30197, 29814, 29385, 28915, 28409, 27872, 27310, 26728, 26132, 25527,
+		24918, 24308, 23703, 23104, 22516, 21939, 21376, 20827, 20293, 19774, 19268, 18775, 18292, 17819, 17352, 16890,
+		16430, 15970, 15508, 15042, 14570, 14091, 13603, 13106, 12599, 12083, 11557, 11023, 10481, 9933, 9379, 8823,
+		8264, 7706, 7149, 6595, 6046, 5503, 4967, 4439, 3919, 3406, 2902, 2406, 1916, 1432, 952, 475,
+		0, -475, -952, -1432, -1916, -2406, -2902, -3406, -3919, -4439, -4967, -5503, -6046, -6595, -7149, -7706,
+		-8264, -8823, -9379, -9933, -10481, -11023, -11557, -12083, -12599, -13106, -13603, -14091, -14570, -15042, -15508, -15970,
+		-16430, -16890, -17352, -17819, -18292, -18775, -19268, -19774, -20293, -20827, -21376, -21939, -22516, -23104, -23703, -24308,
+		-24918, -25527, -26132, -26728, -27310, -27872, -28409, -28915, -29385, -29814, -30197, -30530, -30807, -31027, -31185, -31282,
+		-31314, -31282, -31185, -31027, -30807, -30530, -30197, -29814, -29385, -28915, -28409, -27872, -27310, -26728, -26132, -25527,
+		-24918, -24308, -23703, -23104, -22516, -21939, -21376, -20827, -20293, -19774, -19268, -18775, -18292, -17819, -17352, -16890,
+		-16430, -15970, -15508, -15042, -14570, -14091, -13603, -13106, -12599, -12083, -11557, -11023, -10481, -9933, -9379, -8823,
+		-8264, -7706, -7149, -6595, -6046, -5503, -4967, -4439, -3919, -3406, -2902, -2406, -1916, -1432, -952, -475,
+	},
+	{
+		0, 495, 994, 1498, 2009, 2529, 3055, 3588, 4123, 4659, 5191, 5718, 6237, 6747, 7250, 7747,
+		8241, 8735, 9232, 9735, 10247, 10767, 11296, 11832, 12371, 12910, 13445, 13974, 14493, 15001, 15501, 15992,
+		16479, 16967, 17458, 17959, 18470, 18994, 19530, 20075, 20625, 21176, 21721, 22255, 22773, 23275, 23761, 24234,
+		24699, 25164, 25638, 26128, 26642, 27184, 27753, 28345, 28950, 29556, 30143, 30691, 31178, 31584, 31888, 32077,
+		32142, 32077, 31888, 31584, 31178, 30691, 30143, 29556, 28950, 28345, 27753, 27184, 26642, 26128, 25638, 25164,
+		24699, 24234, 23761, 23275, 22773, 22255, 21721, 21176, 20625, 20075, 19530, 18994, 18470, 17959, 17458, 16967,
+		16479, 15992, 15501, 15001, 14493, 13974, 13445, 12910, 12371, 11832, 11296, 10767, 10247, 9735, 9232, 8735,
+		8241, 7747, 7250, 6747, 6237, 5718, 5191, 4659, 4123, 3588, 3055, 2529, 2009, 1498, 994, 495,
+		0, -495, -994, -1498, -2009, -2529, -3055, -3588, -4123, -4659, -5191, -5718, -6237, -6747, -7250, -7747,
+		-8241, -8735, -9232, -9735, -10247, -10767, -11296, -11832, -12371, -12910, -13445, -13974, -14493, -15001, -15501, -15992,
+		-16479, -16967, -17458, -17959, -18470, -18994, -19530, -20075, -20625, -21176, -21721, -22255, -22773, -23275, -23761, -24234,
+		-24699, -25164, -25638, -26128, -26642, -27184, -27753, -28345, -28950, -29556, -30143, -30691, -31178, -31584, -31888, -32077,
+		-32142, -32077, -31888, -31584, -31178, -30691, -30143, -29556, -28950, -28345, -27753, -27184, -26642, -26128, -25638, -25164,
+		-24699, -24234, -23761, -23275, -22773, -22255, -21721, -21176, -20625, -20075, -19530, -18994, -18470, -17959, -17458, -16967,
+		-16479, -15992, -15501, -15001, -14493, -13974, -13445, -12910, -12371, -11832, -11296, -10767, -10247, -9735, -9232, -8735,
+		-8241, -7747, -7250, -6747, -6237, -5718, -5191, -4659, -4123, -3588, -3055, -2529, -2009, -1498, -994, -495,
+	},
+	{
+		0, 506, 1017, 1537, 2061, 2586, 3105, 3616, 4122, 4628, 5139, 5658, 6183, 6708, 7227, 7738,
+		8244, 8749, 9260, 9780, 10305, 10831, 11351, 11861, 12365, 12869, 13380, 13901, 14428, 14955, 15475, 15985,
+		16487, 16989, 17499, 18021, 18550, 19080, 19601, 20109, 20608, 21107, 21615, 22139, 22674, 23209, 23731, 24236,
+		24727, 25219, 25725, 26255, 26801, 27347, 27873, 28367, 28837, 29307, 29810, 30365, 30963, 31559, 32077, 32432,
+		32558, 32432, 32077, 31559, 30963, 30365, 29810, 29307, 28837, 28367, 27873, 27347, 26801, 26255, 25725, 25219,
+		24727, 24236, 23731, 23209, 22674, 22139, 21615, 21107, 20608, 20109, 19601, 19080, 18550, 18021, 17499, 16989,
+		16487, 15985, 15475, 14955, 14428, 13901, 13380, 12869, 12365, 11861, 11351, 10831, 10305, 9780, 9260, 8749,
+		8244, 7738, 7227, 6708, 6183, 5658, 5139, 4628, 4122, 3616, 3105, 2586, 2061, 1537, 1017, 506,
+		0, -506, -1017, -1537, -2061, -2586, -3105, -3616, -4122, -4628, -5139, -5658, -6183, -6708, -7227, -7738,
+		-8244, -8749, -9260, -9780, -10305, -10831, -11351, -11861, -12365, -12869, -13380, -13901, -14428, -14955, -15475, -15985,
+		-16487, -16989, -17499, -18021, -18550, -19080, -19601, -20109, -20608, -21107, -21615, -22139, -22674, -23209, -23731, -24236,
+		-24727, -25219, -25725, -26255, -26801, -27347, -27873, -28367, -28837, -29307, -29810, -30365, -30963, -31559, -32077, -32432,
+		-32558, -32432, -32077, -31559, -30963, -30365, -29810, -29307, -28837, -28367, -27873, -27347, -26801, -26255, -25725, -25219,
+		-24727, -24236, -23731, -23209, -22674, -22139, -21615, -21107, -20608, -20109, -19601, -19080, -18550, -18021, -17499, -16989,
+		-16487, -15985, -15475, -14955, -14428, -13901, -13380, -12869, -12365, -11861, -11351, -10831, -10305, -9780, -9260, -8749,
+		-8244, -7738, -7227, -6708, -6183, -5658, -5139, -4628, -4122, -3616, -3105, -2586, -2061, -1537, -1017, -506,
+	},
+	{
+		0, 512, 1030, 1549, 2061, 2573, 3091, 3610, 4122, 4634, 5152, 5671, 6183, 6695, 7213, 7732,
+		8244, 8756, 9274, 9793, 10305, 10816, 11336, 11855, 12366, 12877, 13397, 13916, 14427, 14938, 15458, 15977,
+		16488, 16998, 17519, 18039, 18549, 19059, 19580, 20100, 20610, 21119, 21641, 22162, 22670, 23179, 23702, 24225,
+		24731, 25238, 25763, 26288, 26792, 27296, 27825, 28353, 28851, 29351, 29889, 30424, 30906, 31393, 31970, 32526,
+		32767, 32526, 31970, 31393, 30906, 30424, 29889, 29351, 28851, 28353, 27825, 27296, 26792, 26288, 25763, 25238,
+		24731, 24225, 23702, 23179, 22670, 22162, 21641, 21119, 20610, 20100, 19580, 19059, 18549, 18039, 17519, 16998,
+		16488, 15977, 15458, 14938, 14427, 13916, 13397, 12877, 12366, 11855, 11336, 10816, 10305, 9793, 9274, 8756,
+		8244, 7732, 7213, 6695, 6183, 5671, 5152, 4634, 4122, 3610, 3091, 2573, 2061, 1549, 1030, 512,
+		0, -512, -1030, -1549, -2061, -2573, -3091, -3610, -4122, -4634, -5152, -5671, -6183, -6695, -7213, -7732,
+		-8244, -8756, -9274, -9793, -10305, -10816, -11336, -11855, -12366, -12877, -13397, -13916, -14427, -14938, -15458, -15977,
+		-16488, -16998, -17519, -18039, -18549, -19059, -19580, -20100, -20610, -21119, -21641, -22162, -22670, -23179, -23702, -24225,
+		-24731, -25238, -25763, -26288, -26792, -27296, -27825, -28353, -28851, -29351, -29889, -30424, -30906, -31393, -31970, -32526,
+		-32767, -32526, -31970, -31393, -30906, -30424, -29889, -29351, -28851, -28353, -27825, -27296, -26792, -26288, -25763, -25238,
+		-24731, -24225, -23702, -23179, -22670, -22162, -21641, -21119, -20610, -20100, -19580, -19059, -18549, -18039, -17519, -16998,
+		-16488, -15977, -15458, -14938, -14427, -13916, -13397, -12877, -12366, -11855, -11336, -10816, -10305, -9793, -9274, -8756,
+		-8244, -7732, -7213, -6695, -6183, -5671, -5152, -4634, -4122, -3610, -3091, -2573, -2061, -1549, -1030, -512,
+	},
+}
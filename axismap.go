@@ -0,0 +1,206 @@
+package fixpoint
+
+// Axis is a signed axis of a sensor, for use with AxisMapFromAxes.
+type Axis int8
+
+// The axes of a sensor, in the positive and negative direction.
+const (
+	PosX Axis = 1
+	PosY Axis = 2
+	PosZ Axis = 3
+	NegX Axis = -1
+	NegY Axis = -2
+	NegZ Axis = -3
+)
+
+// AxisMap maps the axes of a sensor to the axes of the device it is mounted
+// in, for sensors that are mounted rotated by a multiple of 90° (or mirrored).
+// Each axis of the device is one of the axes of the sensor, possibly negated,
+// so applying the map is exact.
+//
+// The zero value is the identity map.
+type AxisMap struct {
+	// For each device axis i, the sensor axis is (i+perm[i])%3. This way the
+	// zero value is the identity.
+	perm [3]uint8
+	neg  [3]bool
+}
+
+// AxisMapFromAxes returns the map where the X, Y and Z axes of the device are
+// measured by the given sensor axes. For example, a sensor that is mounted
+// upside down (rotated 180° around its X axis) is mapped by
+// AxisMapFromAxes(PosX, NegY, NegZ). It returns false if an axis is used
+// twice or isn't valid.
+func AxisMapFromAxes(x, y, z Axis) (AxisMap, bool) {
+	var m AxisMap
+	var used [3]bool
+	for i, a := range [3]Axis{x, y, z} {
+		neg := a < 0
+		if neg {
+			a = -a
+		}
+		if a < PosX || a > PosZ || used[a-1] {
+			return AxisMap{}, false
+		}
+		used[a-1] = true
+		m.perm[i] = uint8((int(a) - 1 - i + 3) % 3)
+		m.neg[i] = neg
+	}
+	return m, true
+}
+
+// AxisMapFromQuat returns the map that rotates like the given unit
+// quaternion, which must rotate vectors from the sensor frame to the device
+// frame. It returns false if the rotation doesn't map each axis to another
+// axis (within about 8°).
+func AxisMapFromQuat(q QuatQ24) (AxisMap, bool) {
+	var axes [3]Axis
+	var found [3]bool
+	for j, e := range [3]Vec3Q24{{X: Q24FromInt32(1)}, {Y: Q24FromInt32(1)}, {Z: Q24FromInt32(1)}} {
+		r := q.Rotate(e)
+		for i, n := range [3]int32{r.X.N, r.Y.N, r.Z.N} {
+			if n > 1<<24*99/100 || n < -1<<24*99/100 {
+				if found[i] {
+					return AxisMap{}, false
+				}
+				found[i] = true
+				axes[i] = Axis(j + 1)
+				if n < 0 {
+					axes[i] = -axes[i]
+				}
+			}
+		}
+	}
+	if !found[0] || !found[1] || !found[2] {
+		return AxisMap{}, false
+	}
+	return AxisMapFromAxes(axes[0], axes[1], axes[2])
+}
+
+// axis returns the sensor axis of device axis i, and whether it is negated.
+func (m AxisMap) axis(i int) (src int, neg bool) {
+	return (i + int(m.perm[i])) % 3, m.neg[i]
+}
+
+// Apply returns the sensor reading v in the device frame.
+func (m AxisMap) Apply(v Vec3Q24) Vec3Q24 {
+	in := [3]Q24{v.X, v.Y, v.Z}
+	var out [3]Q24
+	for i := range out {
+		src, neg := m.axis(i)
+		out[i] = in[src]
+		if neg {
+			out[i] = out[i].Neg()
+		}
+	}
+	return Vec3Q24{out[0], out[1], out[2]}
+}
+
+// Mul returns the composition of this map and the argument: the result
+// applies m2 first and then m1, like the quaternion multiplication. For
+// example, m1 may describe how a sensor board is mounted in the device and m2
+// how the sensor is placed on the board.
+func (m1 AxisMap) Mul(m2 AxisMap) AxisMap {
+	var m AxisMap
+	for i := 0; i < 3; i++ {
+		src1, neg1 := m1.axis(i)
+		src2, neg2 := m2.axis(src1)
+		m.perm[i] = uint8((src2 - i + 3) % 3)
+		m.neg[i] = neg1 != neg2
+	}
+	return m
+}
+
+// Inverse returns the map that maps the device axes back to the sensor axes.
+func (m AxisMap) Inverse() AxisMap {
+	var inv AxisMap
+	for i := 0; i < 3; i++ {
+		src, neg := m.axis(i)
+		inv.perm[src] = uint8((i - src + 3) % 3)
+		inv.neg[src] = neg
+	}
+	return inv
+}
+
+// Mat3 returns the map as a matrix, for example to combine it with a
+// calibration matrix.
+func (m AxisMap) Mat3() Mat3Q24 {
+	var mat Mat3Q24
+	for i := 0; i < 3; i++ {
+		src, neg := m.axis(i)
+		mat[src*3+i] = Q24FromInt32(1)
+		if neg {
+			mat[src*3+i] = Q24FromInt32(-1)
+		}
+	}
+	return mat
+}
+
+// Mirrored returns whether this map mirrors the axes (it has a determinant of
+// -1), for example when a single axis is negated. Such a map is not a rotation.
+func (m AxisMap) Mirrored() bool {
+	mirrored := false
+	var src [3]int
+	for i := range src {
+		var neg bool
+		src[i], neg = m.axis(i)
+		mirrored = mirrored != neg
+	}
+	// An odd permutation mirrors as well. A permutation of three elements
+	// is odd when it swaps two of them, leaving one in place.
+	if src[0] == 0 && src[1] == 2 || src[1] == 1 && src[0] == 2 || src[2] == 2 && src[0] == 1 {
+		mirrored = !mirrored
+	}
+	return mirrored
+}
+
+// Quat returns the rotation of this map as a unit quaternion. It returns
+// false if the map is mirrored, as a quaternion can't represent that.
+func (m AxisMap) Quat() (QuatQ24, bool) {
+	if m.Mirrored() {
+		return QuatQ24{}, false
+	}
+	// Convert the rotation matrix r (with r[i][j] the contribution of sensor
+	// axis j to device axis i) with the method by Shepperd, which divides by
+	// the largest of the four quaternion elements.
+	var r [3][3]int32
+	for i := 0; i < 3; i++ {
+		src, neg := m.axis(i)
+		r[i][src] = 1
+		if neg {
+			r[i][src] = -1
+		}
+	}
+	half := func(n int32) Q24 {
+		// sqrt(n)/2
+		return Q24FromInt32(n).Sqrt().Mul(Q24{1 << 23})
+	}
+	div := func(n int32, d Q24) Q24 {
+		// n/(4d)
+		return Q24FromInt32(n).DivRound(d.Mul(Q24FromInt32(4)))
+	}
+	var q QuatQ24
+	switch trace := r[0][0] + r[1][1] + r[2][2]; {
+	case trace > 0:
+		q.W = half(1 + trace)
+		q.V.X = div(r[2][1]-r[1][2], q.W)
+		q.V.Y = div(r[0][2]-r[2][0], q.W)
+		q.V.Z = div(r[1][0]-r[0][1], q.W)
+	case r[0][0] >= r[1][1] && r[0][0] >= r[2][2]:
+		q.V.X = half(1 + r[0][0] - r[1][1] - r[2][2])
+		q.W = div(r[2][1]-r[1][2], q.V.X)
+		q.V.Y = div(r[0][1]+r[1][0], q.V.X)
+		q.V.Z = div(r[0][2]+r[2][0], q.V.X)
+	case r[1][1] >= r[2][2]:
+		q.V.Y = half(1 - r[0][0] + r[1][1] - r[2][2])
+		q.W = div(r[0][2]-r[2][0], q.V.Y)
+		q.V.X = div(r[0][1]+r[1][0], q.V.Y)
+		q.V.Z = div(r[1][2]+r[2][1], q.V.Y)
+	default:
+		q.V.Z = half(1 - r[0][0] - r[1][1] + r[2][2])
+		q.W = div(r[1][0]-r[0][1], q.V.Z)
+		q.V.X = div(r[0][2]+r[2][0], q.V.Z)
+		q.V.Y = div(r[1][2]+r[2][1], q.V.Z)
+	}
+	return q, true
+}
@@ -0,0 +1,97 @@
+package fixpoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// allAxisMaps returns all 48 axis maps: 6 permutations with 8 combinations of
+// signs each.
+func allAxisMaps() []AxisMap {
+	var maps []AxisMap
+	for _, perm := range [][3]Axis{{1, 2, 3}, {1, 3, 2}, {2, 1, 3}, {2, 3, 1}, {3, 1, 2}, {3, 2, 1}} {
+		for signs := 0; signs < 8; signs++ {
+			axes := perm
+			for i := range axes {
+				if signs&(1<<uint(i)) != 0 {
+					axes[i] = -axes[i]
+				}
+			}
+			m, ok := AxisMapFromAxes(axes[0], axes[1], axes[2])
+			if !ok {
+				panic("invalid axis map")
+			}
+			maps = append(maps, m)
+		}
+	}
+	return maps
+}
+
+func TestAxisMap(t *testing.T) {
+	v := Vec3Q24FromFloat(0.5, -2, 3)
+
+	// The zero value is the identity.
+	assert.Equal(t, v, AxisMap{}.Apply(v))
+	ident, ok := AxisMapFromAxes(PosX, PosY, PosZ)
+	assert.True(t, ok)
+	assert.Equal(t, AxisMap{}, ident)
+
+	// A sensor mounted upside down and rotated by 90°.
+	m, ok := AxisMapFromAxes(NegY, NegX, NegZ)
+	assert.True(t, ok)
+	assert.Equal(t, Vec3Q24FromFloat(2, -0.5, -3), m.Apply(v))
+
+	// Invalid maps.
+	_, ok = AxisMapFromAxes(PosX, NegX, PosZ)
+	assert.False(t, ok)
+	_, ok = AxisMapFromAxes(PosX, PosY, 4)
+	assert.False(t, ok)
+	_, ok = AxisMapFromAxes(PosX, PosY, 0)
+	assert.False(t, ok)
+
+	maps := allAxisMaps()
+	mirrored := 0
+	for _, m := range maps {
+		// The matrix does the same as Apply.
+		mat := m.Mat3()
+		assert.Equal(t, m.Apply(v), mat.MulVec(v))
+		assert.Equal(t, v, m.Inverse().Apply(m.Apply(v)))
+		assert.Equal(t, AxisMap{}, m.Mul(m.Inverse()))
+
+		// Only maps with a negative determinant are mirrored.
+		det := mat.At(0, 0).Mul(mat.At(1, 1).Mul(mat.At(2, 2)).Sub(mat.At(1, 2).Mul(mat.At(2, 1)))).
+			Sub(mat.At(0, 1).Mul(mat.At(1, 0).Mul(mat.At(2, 2)).Sub(mat.At(1, 2).Mul(mat.At(2, 0))))).
+			Add(mat.At(0, 2).Mul(mat.At(1, 0).Mul(mat.At(2, 1)).Sub(mat.At(1, 1).Mul(mat.At(2, 0)))))
+		assert.Equal(t, det.N < 0, m.Mirrored(), "%v", m)
+		q, ok := m.Quat()
+		assert.Equal(t, !m.Mirrored(), ok)
+		if !ok {
+			mirrored++
+			continue
+		}
+
+		// Rotations convert to a quaternion and back.
+		assert.InDelta(t, 1, q.Len().Float(), 1e-6)
+		rotated := q.Rotate(v)
+		assert.InDelta(t, m.Apply(v).X.N, rotated.X.N, 8, "%v", m)
+		assert.InDelta(t, m.Apply(v).Y.N, rotated.Y.N, 8, "%v", m)
+		assert.InDelta(t, m.Apply(v).Z.N, rotated.Z.N, 8, "%v", m)
+		m2, ok := AxisMapFromQuat(q)
+		assert.True(t, ok)
+		assert.Equal(t, m, m2)
+
+		// Composition applies the argument first.
+		for _, m2 := range maps[:8] {
+			assert.Equal(t, m.Apply(m2.Apply(v)), m.Mul(m2).Apply(v))
+		}
+	}
+	assert.Equal(t, 24, mirrored)
+
+	// Rotations that don't align the axes.
+	_, ok = AxisMapFromQuat(QuatFromAxisAngle(Vec3Q24FromFloat(0, 0, 1), Q24FromFloat(0.5)))
+	assert.False(t, ok)
+	m, ok = AxisMapFromQuat(QuatFromAxisAngle(Vec3Q24FromFloat(0, 0, 1), Q24FromFloat(-0.05).Add(HalfPi)))
+	assert.True(t, ok)
+	assert.Equal(t, Vec3Q24FromFloat(2, 0.5, 3), m.Apply(v))
+}
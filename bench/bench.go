@@ -0,0 +1,171 @@
+// Package bench contains fixed point kernels that are representative of the
+// typical uses of this module, to measure how fast they run on the actual
+// target hardware and to compare implementation choices (such as Q16 against
+// Q24, or a matrix against a quaternion) objectively. Each kernel runs a
+// fixed workload n times on synthetic input. It returns a checksum of the
+// result, so that the compiler can't optimize the work away, and so that the
+// results of different targets can be compared: they must be the same.
+//
+// Time a kernel with whatever the target offers, such as time.Now or a cycle
+// counter:
+//
+//	start := time.Now()
+//	bench.AHRSStep(1000)
+//	println("AHRS step:", time.Since(start)/1000)
+//
+// Apart from the history of the FIR filter, the kernels don't allocate.
+package bench
+
+import (
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/ahrs"
+	"github.com/aykevl/fixpoint/filters"
+)
+
+// Kernel is a benchmark kernel with a name.
+type Kernel struct {
+	Name string
+	Run  func(n int) uint32
+}
+
+// Kernels lists all kernels in this package, for example to run them all in a
+// loop.
+var Kernels = []Kernel{
+	{"AHRSStep", AHRSStep},
+	{"FIRBlock", FIRBlock},
+	{"VertexTransformMat4", VertexTransformMat4},
+	{"VertexTransformQuat", VertexTransformQuat},
+	{"VertexTransformQ16", VertexTransformQ16},
+}
+
+// Sizes of the batches processed by the kernels.
+const (
+	BlockSize   = 64 // samples per FIRBlock iteration
+	VertexCount = 64 // vertices per VertexTransform iteration
+	FIRTaps     = 16 // taps of the FIRBlock filter
+)
+
+// AHRSStep runs n updates of a Madgwick filter, which is a typical sensor
+// fusion step of a flight controller or a motion tracker.
+func AHRSStep(n int) uint32 {
+	f := ahrs.Madgwick{Beta: fixpoint.Q24FromConstFloat(0.1)}
+	gyro := fixpoint.Vec3Q24{
+		X: fixpoint.Q24FromConstFloat(0.01),
+		Y: fixpoint.Q24FromConstFloat(-0.02),
+		Z: fixpoint.Q24FromConstFloat(0.3),
+	}
+	accel := fixpoint.Vec3Q24{
+		X: fixpoint.Q24FromConstFloat(0.05),
+		Y: fixpoint.Q24FromConstFloat(-0.03),
+		Z: fixpoint.Q24{N: fixpoint.One},
+	}
+	dt := fixpoint.Q24FromConstFloat(0.001)
+	for i := 0; i < n; i++ {
+		f.Update(gyro, accel, dt)
+	}
+	var c fixpoint.Checksum
+	c.AddQuatQ24(f.Orientation())
+	return c.Sum32()
+}
+
+// FIRBlock filters n blocks of BlockSize samples with a FIRTaps-tap FIR
+// filter, which is a typical signal processing step for sensor or audio data.
+func FIRBlock(n int) uint32 {
+	var taps [FIRTaps]fixpoint.Q24
+	for i := range taps {
+		taps[i] = fixpoint.Q24{N: fixpoint.One / FIRTaps}
+	}
+	f := filters.FIR{Taps: taps[:]}
+	var sum int64
+	for i := 0; i < n; i++ {
+		for j := 0; j < BlockSize; j++ {
+			// A sawtooth wave in the range [-0.5, 0.5), one period per
+			// block.
+			x := fixpoint.Q24{N: int32(j-BlockSize/2) << 24 / BlockSize}
+			sum += int64(f.Update(x).N)
+		}
+	}
+	var c fixpoint.Checksum
+	c.AddInt64(sum)
+	return c.Sum32()
+}
+
+// VertexTransformMat4 transforms n batches of VertexCount vertices by a 4×4
+// homogeneous matrix, like the vertex stage of a software renderer.
+func VertexTransformMat4(n int) uint32 {
+	src := vertices()
+	m := fixpoint.Mat4Translate(fixpoint.Vec3Q24{Z: fixpoint.Q24FromConstFloat(-3)}).Mul(fixpoint.Mat4Rotate(rotationAxis(), rotationAngle()))
+	var dst [VertexCount]fixpoint.Vec3Q24
+	for i := 0; i < n; i++ {
+		for j, v := range src {
+			dst[j] = m.MulPoint(v)
+		}
+	}
+	var c fixpoint.Checksum
+	c.AddVec3Q24s(dst[:])
+	return c.Sum32()
+}
+
+// VertexTransformQuat rotates n batches of VertexCount vertices by a
+// quaternion, to compare with VertexTransformMat4.
+func VertexTransformQuat(n int) uint32 {
+	src := vertices()
+	q := fixpoint.QuatFromAxisAngle(rotationAxis(), rotationAngle())
+	var dst [VertexCount]fixpoint.Vec3Q24
+	for i := 0; i < n; i++ {
+		fixpoint.RotateVec3s(dst[:], src[:], q)
+	}
+	var c fixpoint.Checksum
+	c.AddVec3Q24s(dst[:])
+	return c.Sum32()
+}
+
+// VertexTransformQ16 is like VertexTransformQuat, with Q16 vertices and a Q16
+// quaternion, to compare the fixed point formats.
+func VertexTransformQ16(n int) uint32 {
+	var src, dst [VertexCount]fixpoint.Vec3Q16
+	for i, v := range vertices() {
+		src[i] = v.Vec3Q16()
+	}
+	q := fixpoint.QuatFromAxisAngle(rotationAxis(), rotationAngle()).QuatQ16()
+	for i := 0; i < n; i++ {
+		for j, v := range src {
+			dst[j] = q.Rotate(v)
+		}
+	}
+	var c fixpoint.Checksum
+	for _, v := range dst {
+		c.Add(v)
+	}
+	return c.Sum32()
+}
+
+// rotationAxis and rotationAngle return the rotation used by the vertex
+// transform kernels.
+func rotationAxis() fixpoint.Vec3Q24 {
+	return fixpoint.Vec3Q24{X: fixpoint.Q24FromConstFloat(0.36), Y: fixpoint.Q24FromConstFloat(0.48), Z: fixpoint.Q24FromConstFloat(0.8)}
+}
+
+func rotationAngle() fixpoint.Q24 {
+	return fixpoint.Q24FromConstFloat(0.7)
+}
+
+// vertexBuffer holds the vertices for the vertex transform kernels. It is
+// filled when the package is initialized, so the kernels can run concurrently.
+var vertexBuffer = makeVertices()
+
+// vertices returns the vertices for the vertex transform kernels.
+func vertices() *[VertexCount]fixpoint.Vec3Q24 {
+	return &vertexBuffer
+}
+
+// makeVertices returns points on a spiral around the unit sphere.
+func makeVertices() (v [VertexCount]fixpoint.Vec3Q24) {
+	for i := range v {
+		z := fixpoint.Q24{N: int32(2*i+1-VertexCount) * (fixpoint.One / VertexCount)}
+		r := fixpoint.Q24{N: fixpoint.One}.Sub(z.MulRound(z)).Sqrt()
+		sin, cos := fixpoint.SinCos(fixpoint.Q24{N: int32(i) * fixpoint.Q24FromConstFloat(2.4).N})
+		v[i] = fixpoint.Vec3Q24{X: cos.MulRound(r), Y: sin.MulRound(r), Z: z}
+	}
+	return
+}
@@ -0,0 +1,66 @@
+package bench
+
+import (
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+)
+
+func TestKernels(t *testing.T) {
+	names := map[string]bool{}
+	for _, k := range Kernels {
+		if names[k.Name] {
+			t.Errorf("duplicate kernel %s", k.Name)
+		}
+		names[k.Name] = true
+
+		// The result only depends on the number of iterations.
+		if a, b := k.Run(3), k.Run(3); a != b {
+			t.Errorf("%s: not deterministic: %#x, %#x", k.Name, a, b)
+		}
+	}
+
+	// The AHRS kernel converges, so the orientation changes between
+	// different numbers of steps.
+	if AHRSStep(1) == AHRSStep(2) {
+		t.Error("AHRSStep: expected a different result for a different number of steps")
+	}
+
+	// The vertices are on the unit sphere.
+	for i, v := range vertices() {
+		if l := v.Len().Float64(); l < 0.9999 || l > 1.0001 {
+			t.Errorf("vertex %d is not on the unit sphere: %v", i, v)
+		}
+	}
+
+	// The matrix and quaternion transforms agree, apart from the
+	// translation.
+	src := vertices()
+	m := fixpoint.Mat4Rotate(rotationAxis(), rotationAngle())
+	q := fixpoint.QuatFromAxisAngle(rotationAxis(), rotationAngle())
+	for _, v := range src {
+		if d := m.MulPoint(v).Sub(q.Rotate(v)).Len().Float64(); d > 1e-6 {
+			t.Errorf("matrix and quaternion differ by %g for %v", d, v)
+		}
+	}
+}
+
+func BenchmarkAHRSStep(b *testing.B) {
+	AHRSStep(b.N)
+}
+
+func BenchmarkFIRBlock(b *testing.B) {
+	FIRBlock(b.N)
+}
+
+func BenchmarkVertexTransformMat4(b *testing.B) {
+	VertexTransformMat4(b.N)
+}
+
+func BenchmarkVertexTransformQuat(b *testing.B) {
+	VertexTransformQuat(b.N)
+}
+
+func BenchmarkVertexTransformQ16(b *testing.B) {
+	VertexTransformQ16(b.N)
+}
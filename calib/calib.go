@@ -0,0 +1,281 @@
+// Package calib stores calibration data in a small binary format, for example
+// in EEPROM or flash. The layout is defined by a Schema: a version number and a
+// fixed list of named fields. The encoded blob has a header and a CRC, so that
+// erased or corrupted storage and data written by an older version of the
+// firmware are detected instead of silently producing a bad calibration.
+//
+// The layout of a blob is, with all integers in little-endian byte order:
+//
+//	magic:   "FC" (2 bytes)
+//	version: uint16
+//	length:  uint16, the length of the values in bytes
+//	values:  the fields in schema order, each element as an int32
+//	crc:     CRC-32 (IEEE) of all bytes before it
+//
+// A Q24 or Q16 field takes 4 bytes, a Vec3Q24 12 bytes, a QuatQ24 16 bytes
+// and a Mat3Q24 36 bytes (in the order of its elements).
+//
+// The package also implements temperature compensation with coefficients that
+// are fit on the host, see TempCoeffs.
+package calib
+
+import (
+	"errors"
+	"hash/crc32"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/le"
+)
+
+// Errors returned when decoding a blob.
+var (
+	ErrFormat   = errors.New("calib: not a calibration blob")
+	ErrVersion  = errors.New("calib: unsupported version")
+	ErrLength   = errors.New("calib: length does not match schema")
+	ErrChecksum = errors.New("calib: checksum mismatch")
+)
+
+const headerSize = 6
+
+// kind is the type of a field.
+type kind uint8
+
+const (
+	kindQ24 kind = iota
+	kindQ16
+	kindVec3
+	kindQuat
+	kindMat3
+)
+
+// words returns the size of a field of this type in int32 words.
+func (k kind) words() int {
+	switch k {
+	case kindVec3:
+		return 3
+	case kindQuat:
+		return 4
+	case kindMat3:
+		return 9
+	default:
+		return 1
+	}
+}
+
+type field struct {
+	name   string
+	kind   kind
+	offset int // in words
+}
+
+// Schema is the layout of a calibration blob. It is created with NewSchema,
+// after which the fields are added in order:
+//
+//	schema := calib.NewSchema(1).
+//		Vec3("accel_bias").
+//		Mat3("accel_scale").
+//		Q16("temperature")
+//
+// Change the version whenever the fields change, so that blobs with the old
+// layout are rejected (or can be converted, see PeekVersion).
+type Schema struct {
+	version uint16
+	fields  []field
+	words   int
+}
+
+// NewSchema returns a new schema without fields.
+func NewSchema(version uint16) *Schema {
+	return &Schema{version: version}
+}
+
+// Version returns the version of the schema.
+func (s *Schema) Version() uint16 {
+	return s.version
+}
+
+// Size returns the size of an encoded blob in bytes.
+func (s *Schema) Size() int {
+	return headerSize + 4*s.words + 4
+}
+
+// Q24 adds a Q24 field.
+func (s *Schema) Q24(name string) *Schema {
+	return s.add(name, kindQ24)
+}
+
+// Q16 adds a Q16 field.
+func (s *Schema) Q16(name string) *Schema {
+	return s.add(name, kindQ16)
+}
+
+// Vec3 adds a Vec3Q24 field.
+func (s *Schema) Vec3(name string) *Schema {
+	return s.add(name, kindVec3)
+}
+
+// Quat adds a QuatQ24 field.
+func (s *Schema) Quat(name string) *Schema {
+	return s.add(name, kindQuat)
+}
+
+// Mat3 adds a Mat3Q24 field.
+func (s *Schema) Mat3(name string) *Schema {
+	return s.add(name, kindMat3)
+}
+
+func (s *Schema) add(name string, k kind) *Schema {
+	for _, f := range s.fields {
+		if f.name == name {
+			panic("calib: duplicate field " + name)
+		}
+	}
+	s.fields = append(s.fields, field{name, k, s.words})
+	s.words += k.words()
+	return s
+}
+
+// New returns a blob for this schema with all fields set to zero.
+func (s *Schema) New() *Blob {
+	return &Blob{schema: s, values: make([]int32, s.words)}
+}
+
+// PeekVersion returns the version of an encoded blob, without checking
+// anything else. Use it to select the schema to decode a blob with, for
+// example to convert calibration data written by older firmware.
+func PeekVersion(data []byte) (uint16, error) {
+	if len(data) < headerSize || data[0] != 'F' || data[1] != 'C' {
+		return 0, ErrFormat
+	}
+	return le.Uint16(data[2:]), nil
+}
+
+// Blob contains the values of a calibration blob. The getters and setters
+// panic when the schema doesn't have a field with the given name and type,
+// as that is a programming error.
+type Blob struct {
+	schema *Schema
+	values []int32
+}
+
+// Schema returns the schema of this blob.
+func (b *Blob) Schema() *Schema {
+	return b.schema
+}
+
+// field returns the values of the named field.
+func (b *Blob) field(name string, k kind) []int32 {
+	for _, f := range b.schema.fields {
+		if f.name == name {
+			if f.kind != k {
+				panic("calib: wrong type for field " + name)
+			}
+			return b.values[f.offset : f.offset+k.words()]
+		}
+	}
+	panic("calib: unknown field " + name)
+}
+
+// Q24 returns the value of a Q24 field.
+func (b *Blob) Q24(name string) fixpoint.Q24 {
+	return fixpoint.Q24{N: b.field(name, kindQ24)[0]}
+}
+
+// SetQ24 sets the value of a Q24 field.
+func (b *Blob) SetQ24(name string, q fixpoint.Q24) {
+	b.field(name, kindQ24)[0] = q.N
+}
+
+// Q16 returns the value of a Q16 field.
+func (b *Blob) Q16(name string) fixpoint.Q16 {
+	return fixpoint.Q16{N: b.field(name, kindQ16)[0]}
+}
+
+// SetQ16 sets the value of a Q16 field.
+func (b *Blob) SetQ16(name string, q fixpoint.Q16) {
+	b.field(name, kindQ16)[0] = q.N
+}
+
+// Vec3 returns the value of a Vec3Q24 field.
+func (b *Blob) Vec3(name string) fixpoint.Vec3Q24 {
+	w := b.field(name, kindVec3)
+	return fixpoint.Vec3Q24{X: fixpoint.Q24{N: w[0]}, Y: fixpoint.Q24{N: w[1]}, Z: fixpoint.Q24{N: w[2]}}
+}
+
+// SetVec3 sets the value of a Vec3Q24 field.
+func (b *Blob) SetVec3(name string, v fixpoint.Vec3Q24) {
+	w := b.field(name, kindVec3)
+	w[0], w[1], w[2] = v.X.N, v.Y.N, v.Z.N
+}
+
+// Quat returns the value of a QuatQ24 field.
+func (b *Blob) Quat(name string) fixpoint.QuatQ24 {
+	w := b.field(name, kindQuat)
+	return fixpoint.QuatQ24{
+		W: fixpoint.Q24{N: w[0]},
+		V: fixpoint.Vec3Q24{X: fixpoint.Q24{N: w[1]}, Y: fixpoint.Q24{N: w[2]}, Z: fixpoint.Q24{N: w[3]}},
+	}
+}
+
+// SetQuat sets the value of a QuatQ24 field.
+func (b *Blob) SetQuat(name string, q fixpoint.QuatQ24) {
+	w := b.field(name, kindQuat)
+	w[0], w[1], w[2], w[3] = q.W.N, q.V.X.N, q.V.Y.N, q.V.Z.N
+}
+
+// Mat3 returns the value of a Mat3Q24 field.
+func (b *Blob) Mat3(name string) fixpoint.Mat3Q24 {
+	var m fixpoint.Mat3Q24
+	for i, n := range b.field(name, kindMat3) {
+		m[i].N = n
+	}
+	return m
+}
+
+// SetMat3 sets the value of a Mat3Q24 field.
+func (b *Blob) SetMat3(name string, m fixpoint.Mat3Q24) {
+	w := b.field(name, kindMat3)
+	for i := range w {
+		w[i] = m[i].N
+	}
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The result is
+// Schema().Size() bytes long.
+func (b *Blob) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, b.schema.Size())
+	buf = append(buf, 'F', 'C')
+	buf = le.AppendUint16(buf, b.schema.version)
+	buf = le.AppendUint16(buf, uint16(4*len(b.values)))
+	for _, n := range b.values {
+		buf = le.AppendInt32(buf, n)
+	}
+	crc := crc32.ChecksumIEEE(buf)
+	return le.AppendUint32(buf, crc), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The blob must have
+// been created with Schema.New, and the data must have the same version and
+// length as the schema. Trailing bytes are ignored, so it is possible to pass
+// a whole page of flash. On error, the values are left unmodified.
+func (b *Blob) UnmarshalBinary(data []byte) error {
+	version, err := PeekVersion(data)
+	if err != nil {
+		return err
+	}
+	if version != b.schema.version {
+		return ErrVersion
+	}
+	size := b.schema.Size()
+	if int(le.Uint16(data[4:])) != 4*b.schema.words || len(data) < size {
+		return ErrLength
+	}
+	crc := crc32.ChecksumIEEE(data[:size-4])
+	if le.Uint32(data[size-4:]) != crc {
+		return ErrChecksum
+	}
+	for i := range b.values {
+		b.values[i] = le.Int32(data[headerSize+4*i:])
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package calib
+
+import (
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlob(t *testing.T) {
+	schema := NewSchema(3).
+		Vec3("accel_bias").
+		Mat3("accel_scale").
+		Quat("mounting").
+		Q24("gain").
+		Q16("temperature")
+	assert.Equal(t, uint16(3), schema.Version())
+	assert.Equal(t, 6+4*(3+9+4+1+1)+4, schema.Size())
+
+	b := schema.New()
+	assert.Equal(t, fixpoint.Vec3Q24{}, b.Vec3("accel_bias"))
+	bias := fixpoint.Vec3Q24FromFloat(0.01, -0.02, 0.03)
+	scale := fixpoint.Mat3Scale(fixpoint.Vec3Q24FromFloat(1.01, 0.99, 1))
+	mounting := fixpoint.QuatFromAxisAngle(fixpoint.Vec3Q24FromFloat(0, 0, 1), fixpoint.HalfPi)
+	b.SetVec3("accel_bias", bias)
+	b.SetMat3("accel_scale", scale)
+	b.SetQuat("mounting", mounting)
+	b.SetQ24("gain", fixpoint.Q24FromFloat(-1.5))
+	b.SetQ16("temperature", fixpoint.Q16FromFloat(23.5))
+	data, err := b.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, schema.Size(), len(data))
+	assert.Equal(t, []byte{'F', 'C', 3, 0, 72, 0}, data[:6])
+
+	// Decode, with trailing bytes like the rest of a flash page.
+	b2 := schema.New()
+	page := append(append([]byte{}, data...), 0xff, 0xff, 0xff)
+	assert.NoError(t, b2.UnmarshalBinary(page))
+	assert.Equal(t, bias, b2.Vec3("accel_bias"))
+	assert.Equal(t, scale, b2.Mat3("accel_scale"))
+	assert.Equal(t, mounting, b2.Quat("mounting"))
+	assert.Equal(t, fixpoint.Q24FromFloat(-1.5), b2.Q24("gain"))
+	assert.Equal(t, fixpoint.Q16FromFloat(23.5), b2.Q16("temperature"))
+	assert.Equal(t, schema, b2.Schema())
+
+	// Corrupted data is rejected and leaves the values unmodified.
+	for i := range data {
+		corrupt := append([]byte{}, data...)
+		corrupt[i] ^= 0x10
+		b3 := schema.New()
+		assert.Error(t, b3.UnmarshalBinary(corrupt), "byte %d", i)
+		assert.Equal(t, fixpoint.Q24{}, b3.Q24("gain"))
+	}
+	assert.Equal(t, ErrLength, b2.UnmarshalBinary(data[:len(data)-1]))
+
+	// Erased flash.
+	erased := make([]byte, 128)
+	for i := range erased {
+		erased[i] = 0xff
+	}
+	assert.Equal(t, ErrFormat, b2.UnmarshalBinary(erased))
+	_, err = PeekVersion(erased)
+	assert.Equal(t, ErrFormat, err)
+
+	// A blob written with another version of the schema.
+	old := NewSchema(2).Vec3("accel_bias").Mat3("accel_scale")
+	oldBlob := old.New()
+	oldBlob.SetVec3("accel_bias", bias)
+	data, _ = oldBlob.MarshalBinary()
+	assert.Equal(t, ErrVersion, b2.UnmarshalBinary(data))
+	version, err := PeekVersion(data)
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(2), version)
+
+	// The same version with a different layout.
+	assert.Equal(t, ErrLength, NewSchema(2).Vec3("accel_bias").New().UnmarshalBinary(data))
+
+	// Programming errors.
+	assert.Panics(t, func() { b.Q24("unknown") })
+	assert.Panics(t, func() { b.Q24("temperature") })
+	assert.Panics(t, func() { NewSchema(1).Q24("a").Vec3("a") })
+}
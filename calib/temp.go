@@ -0,0 +1,212 @@
+package calib
+
+import (
+	"math"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/fixmath"
+)
+
+// Temperature compensation
+//
+// Many sensor errors, like the bias of a gyroscope or the frequency error of
+// a crystal oscillator, depend on the temperature. They are usually
+// characterized on the host by logging the error at a range of temperatures
+// (for example while the device warms up), fitting a polynomial with
+// FitTempCoeffs, and storing the coefficients in a calibration blob. The
+// firmware then subtracts the predicted error with TempCompensate.
+//
+// Temperatures are in degrees Celsius as a Q16, but any unit works as long as
+// it is the same everywhere.
+
+// TempCoeffs is a polynomial of up to the third degree that predicts an error
+// from the temperature. The polynomial is evaluated at the normalized
+// temperature x = (temp - Ref) / Scale, so that the coefficients of the higher
+// degrees don't become too small to be represented in a Q24:
+//
+//	error = Poly[0] + Poly[1]·x + Poly[2]·x² + Poly[3]·x³
+//
+// The zero value predicts no error.
+type TempCoeffs struct {
+	// Ref is the reference temperature, usually the middle of the
+	// calibrated range.
+	Ref fixpoint.Q16
+
+	// Scale is the temperature difference that corresponds to x = 1,
+	// usually half the calibrated range. Zero is treated as 1.
+	Scale fixpoint.Q16
+
+	// Poly are the coefficients of the polynomial, lowest degree first.
+	Poly [4]fixpoint.Q24
+}
+
+// Error returns the error predicted at the given temperature, saturated to
+// the range of a Q24. Temperatures far outside of the calibrated range
+// extrapolate the polynomial, which quickly becomes inaccurate.
+func (c TempCoeffs) Error(temp fixpoint.Q16) fixpoint.Q24 {
+	scale := int64(c.Scale.N)
+	if scale == 0 {
+		scale = 1 << 16
+	}
+	// Normalized temperature in Q24, limited (like the intermediate sums) so
+	// that the polynomial can't overflow.
+	x := fixmath.Clamp(((int64(temp.N)-int64(c.Ref.N))<<24)/scale, -16<<24, 16<<24)
+	var sum int64
+	for i := len(c.Poly) - 1; i >= 0; i-- {
+		sum = fixmath.Clamp((sum*x+1<<23)>>24, -1<<34, 1<<34) + int64(c.Poly[i].N)
+	}
+	return fixpoint.Q24{N: int32(fixmath.Clamp(sum, math.MinInt32, math.MaxInt32))}
+}
+
+// TempCompensate returns value with the error predicted at the given
+// temperature subtracted.
+func TempCompensate(value fixpoint.Q24, temp fixpoint.Q16, coeffs TempCoeffs) fixpoint.Q24 {
+	return fixpoint.Q24{N: int32(fixmath.Clamp(int64(value.N)-int64(coeffs.Error(temp).N), math.MinInt32, math.MaxInt32))}
+}
+
+// TempCompensateVec3 is like TempCompensate, but for a vector with separate
+// coefficients for each axis, like the bias of a 3-axis gyroscope.
+func TempCompensateVec3(v fixpoint.Vec3Q24, temp fixpoint.Q16, coeffs [3]TempCoeffs) fixpoint.Vec3Q24 {
+	return fixpoint.Vec3Q24{
+		X: TempCompensate(v.X, temp, coeffs[0]),
+		Y: TempCompensate(v.Y, temp, coeffs[1]),
+		Z: TempCompensate(v.Z, temp, coeffs[2]),
+	}
+}
+
+// FitTempCoeffs fits a polynomial of the given degree (at most 3) to the
+// errors (values) measured at the given temperatures, with the method of least
+// squares. It is meant to be run on the host, which is why it uses floating
+// point. Ref and Scale are set to the middle and half the width of the range
+// of temperatures. It returns false if there are too few distinct
+// temperatures for the degree, or if a coefficient doesn't fit in a Q24.
+func FitTempCoeffs(temps, values []float64, degree int) (TempCoeffs, bool) {
+	if degree < 0 || degree > 3 || len(temps) != len(values) || len(temps) == 0 {
+		return TempCoeffs{}, false
+	}
+	min, max := temps[0], temps[0]
+	for _, t := range temps {
+		min = math.Min(min, t)
+		max = math.Max(max, t)
+	}
+	var c TempCoeffs
+	ref, scale := (min+max)/2, (max-min)/2
+	if scale == 0 {
+		if degree > 0 {
+			return TempCoeffs{}, false
+		}
+		scale = 1
+	}
+	var ok bool
+	if c.Ref, ok = q16FromFloat64(ref); !ok {
+		return TempCoeffs{}, false
+	}
+	if c.Scale, ok = q16FromFloat64(scale); !ok || c.Scale.N == 0 {
+		return TempCoeffs{}, false
+	}
+	// Normalize with the rounded values, which are the ones Error uses.
+	ref, scale = float64(c.Ref.N)/(1<<16), float64(c.Scale.N)/(1<<16)
+
+	// Set up the normal equations A·p = b, with A[i][j] = Σx^(i+j) and
+	// b[i] = Σx^i·value, as an augmented matrix.
+	n := degree + 1
+	var a [4][5]float64
+	for k, t := range temps {
+		x := (t - ref) / scale
+		pow := [7]float64{1}
+		for i := 1; i < len(pow); i++ {
+			pow[i] = pow[i-1] * x
+		}
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				a[i][j] += pow[i+j]
+			}
+			a[i][n] += pow[i] * values[k]
+		}
+	}
+
+	// Gaussian elimination with partial pivoting.
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-12*float64(len(temps)) {
+			return TempCoeffs{}, false
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		for row := col + 1; row < n; row++ {
+			f := a[row][col] / a[col][col]
+			for j := col; j <= n; j++ {
+				a[row][j] -= f * a[col][j]
+			}
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		sum := a[i][n]
+		for j := i + 1; j < n; j++ {
+			sum -= a[i][j] * float64(c.Poly[j].N) / (1 << 24)
+		}
+		if c.Poly[i], ok = fixpoint.Q24FromFloat64Checked(sum / a[i][i]); !ok {
+			return TempCoeffs{}, false
+		}
+	}
+	return c, true
+}
+
+// ThermalLag is a first-order model of the delay between the temperature that
+// a temperature sensor measures and the temperature of the part that causes
+// the error, for example when the sensor is on the other side of the board or
+// when the part heats itself. Apply it to the measured temperature before
+// TempCompensate.
+//
+// The zero value passes the temperature without delay.
+type ThermalLag struct {
+	// TimeConstant is the time constant of the thermal lag in seconds: the
+	// time after which the modeled temperature has followed 63% of a step.
+	TimeConstant fixpoint.Q16
+
+	initialized bool
+	temp        int64 // Q40
+}
+
+// Update updates the model with a temperature measured dt seconds after the
+// previous one, and returns the modeled temperature. The first update starts
+// the model at the measured temperature.
+func (l *ThermalLag) Update(temp fixpoint.Q16, dt fixpoint.Q24) fixpoint.Q16 {
+	measured := int64(temp.N) << 24
+	if !l.initialized || l.TimeConstant.N <= 0 {
+		l.temp = measured
+		l.initialized = true
+		return temp
+	}
+	// The fraction dt/TimeConstant of the difference is followed per update,
+	// but never more than all of it.
+	alpha := fixmath.Clamp((int64(dt.N)<<16)/int64(l.TimeConstant.N), 0, 1<<24)
+	diff := measured - l.temp
+	l.temp += (diff>>24)*alpha + ((diff&(1<<24-1))*alpha+1<<23)>>24
+	return l.Temperature()
+}
+
+// Temperature returns the modeled temperature.
+func (l *ThermalLag) Temperature() fixpoint.Q16 {
+	return fixpoint.Q16{N: int32((l.temp + 1<<23) >> 24)}
+}
+
+// Reset restarts the model at the next measured temperature.
+func (l *ThermalLag) Reset() {
+	l.temp = 0
+	l.initialized = false
+}
+
+// q16FromFloat64 converts x to the nearest Q16, and reports whether it is in
+// range.
+func q16FromFloat64(x float64) (fixpoint.Q16, bool) {
+	n := math.Floor(x*(1<<16) + 0.5)
+	if !(n >= math.MinInt32 && n <= math.MaxInt32) {
+		return fixpoint.Q16{}, false
+	}
+	return fixpoint.Q16{N: int32(n)}, true
+}
@@ -0,0 +1,111 @@
+package calib
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTempCompensate(t *testing.T) {
+	// A gyroscope bias of 0.01 + 0.0005·(T-25) rad/s.
+	c := TempCoeffs{
+		Ref:   fixpoint.Q16FromInt32(25),
+		Scale: fixpoint.Q16FromInt32(20),
+		Poly:  [4]fixpoint.Q24{fixpoint.Q24FromFloat64(0.01), fixpoint.Q24FromFloat64(0.01)},
+	}
+	assert.InDelta(t, 0.01, c.Error(fixpoint.Q16FromInt32(25)).Float64(), 1e-7)
+	assert.InDelta(t, 0.02, c.Error(fixpoint.Q16FromInt32(45)).Float64(), 1e-7)
+	assert.InDelta(t, 0, c.Error(fixpoint.Q16FromInt32(5)).Float64(), 1e-7)
+	assert.InDelta(t, 0.1, TempCompensate(fixpoint.Q24FromFloat64(0.12), fixpoint.Q16FromInt32(45), c).Float64(), 1e-7)
+
+	v := TempCompensateVec3(fixpoint.Vec3Q24FromFloat(0.02, 0.02, 0.02), fixpoint.Q16FromInt32(45), [3]TempCoeffs{c, {}, c})
+	assert.InDelta(t, 0, v.X.Float64(), 1e-7)
+	assert.Equal(t, fixpoint.Q24FromFloat(0.02), v.Y)
+
+	// The zero value predicts no error, and a zero scale is treated as 1.
+	assert.Equal(t, fixpoint.Q24{}, TempCoeffs{}.Error(fixpoint.Q16FromInt32(100)))
+	c = TempCoeffs{Poly: [4]fixpoint.Q24{{}, {}, fixpoint.Q24FromFloat64(0.5)}}
+	assert.Equal(t, fixpoint.Q24FromInt32(2), c.Error(fixpoint.Q16FromInt32(-2)))
+
+	// Extreme temperatures saturate instead of overflowing.
+	c = TempCoeffs{Scale: fixpoint.Q16FromFloat(0.01), Poly: [4]fixpoint.Q24{{}, {}, {}, fixpoint.Q24FromInt32(100)}}
+	assert.Equal(t, fixpoint.MaxQ24, c.Error(fixpoint.Q16FromInt32(30000)))
+	assert.Equal(t, fixpoint.MinQ24, c.Error(fixpoint.Q16FromInt32(-30000)))
+	assert.Equal(t, fixpoint.MaxQ24, TempCompensate(fixpoint.MaxQ24, fixpoint.Q16FromInt32(-30000), c))
+}
+
+func TestFitTempCoeffs(t *testing.T) {
+	// The frequency error of a crystal in ppm/100 is a parabola around its
+	// turnover temperature of 25°C.
+	f := func(temp float64) float64 { return -0.00034 * (temp - 25) * (temp - 25) }
+	var temps, values []float64
+	for temp := -20.0; temp <= 70; temp += 2.5 {
+		temps = append(temps, temp)
+		values = append(values, f(temp))
+	}
+	c, ok := FitTempCoeffs(temps, values, 2)
+	assert.True(t, ok)
+	assert.Equal(t, fixpoint.Q16FromInt32(25), c.Ref)
+	assert.Equal(t, fixpoint.Q16FromInt32(45), c.Scale)
+	for temp := -20.0; temp <= 70; temp += 0.5 {
+		got := c.Error(fixpoint.Q16{N: int32(temp * (1 << 16))}).Float64()
+		if math.Abs(got-f(temp)) > 1e-6 {
+			t.Errorf("error at %.1f°C: expected %f, got %f", temp, f(temp), got)
+		}
+	}
+
+	// A cubic is fit exactly, a line to a parabola is the best fit.
+	c, ok = FitTempCoeffs([]float64{0, 1, 2, 3, 4}, []float64{0, 1, 8, 27, 64}, 3)
+	assert.True(t, ok)
+	assert.InDelta(t, 27, c.Error(fixpoint.Q16FromInt32(3)).Float64(), 1e-5)
+	c, ok = FitTempCoeffs([]float64{-1, 0, 1}, []float64{1, 0, 1}, 1)
+	assert.True(t, ok)
+	assert.InDelta(t, 2.0/3, c.Poly[0].Float64(), 1e-7)
+	assert.Equal(t, fixpoint.Q24{}, c.Poly[1])
+
+	// Too few temperatures, invalid arguments or out of range.
+	_, ok = FitTempCoeffs([]float64{20, 20, 30}, []float64{1, 2, 3}, 2)
+	assert.False(t, ok)
+	_, ok = FitTempCoeffs([]float64{20, 20}, []float64{1, 2}, 1)
+	assert.False(t, ok)
+	_, ok = FitTempCoeffs([]float64{20}, []float64{1, 2}, 0)
+	assert.False(t, ok)
+	_, ok = FitTempCoeffs([]float64{20, 30}, []float64{1, 2}, 4)
+	assert.False(t, ok)
+	_, ok = FitTempCoeffs([]float64{20, 30}, []float64{0, 1000}, 1)
+	assert.False(t, ok)
+
+	// A single temperature gives a constant error.
+	c, ok = FitTempCoeffs([]float64{20, 20}, []float64{1, 2}, 0)
+	assert.True(t, ok)
+	assert.Equal(t, fixpoint.Q24FromFloat(1.5), c.Error(fixpoint.Q16FromInt32(80)))
+}
+
+func TestThermalLag(t *testing.T) {
+	l := ThermalLag{TimeConstant: fixpoint.Q16FromInt32(10)}
+	dt := fixpoint.Q24FromFloat(0.1)
+	assert.Equal(t, fixpoint.Q16FromInt32(20), l.Update(fixpoint.Q16FromInt32(20), dt))
+
+	// A step of 10°C: after one time constant, 63% of the step is followed.
+	for i := 0; i < 100; i++ {
+		l.Update(fixpoint.Q16FromInt32(30), dt)
+	}
+	assert.InDelta(t, 20+10*(1-math.Pow(0.99, 100)), l.Temperature().Float(), 1e-3)
+	for i := 0; i < 1000; i++ {
+		l.Update(fixpoint.Q16FromInt32(30), dt)
+	}
+	assert.InDelta(t, 30, l.Temperature().Float(), 1e-3)
+
+	// A large dt follows the step immediately instead of overshooting.
+	assert.Equal(t, fixpoint.Q16FromInt32(-10), l.Update(fixpoint.Q16FromInt32(-10), fixpoint.Q24FromInt32(100)))
+
+	l.Reset()
+	assert.Equal(t, fixpoint.Q16FromInt32(50), l.Update(fixpoint.Q16FromInt32(50), dt))
+
+	// The zero value doesn't delay.
+	var zero ThermalLag
+	zero.Update(fixpoint.Q16FromInt32(1), dt)
+	assert.Equal(t, fixpoint.Q16FromInt32(2), zero.Update(fixpoint.Q16FromInt32(2), dt))
+}
@@ -0,0 +1,202 @@
+package fixpoint
+
+// Checksums of simulation state, for example for lockstep multiplayer games
+// where each client runs the same simulation and the clients compare a digest
+// of their world state every tick to detect a desync. Because all arithmetic
+// in this package is deterministic, the digest is the same on every system as
+// long as the values are added in the same order.
+
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// Checksum is a 64-bit FNV-1a digest of a sequence of fixed point values. Each
+// value is hashed as the bytes of its underlying integers in little-endian
+// byte order, in the order of the fields of the value. For Q24, Vec3Q24 and
+// QuatQ24 these are exactly the bytes of the binary encoding (see
+// MarshalBinary).
+//
+// It is not a cryptographic hash: it detects accidental differences, not
+// deliberate ones.
+//
+// The zero value is an empty checksum.
+type Checksum struct {
+	h uint64 // the FNV-1a state, XOR the offset basis
+}
+
+// Reset clears the checksum to start a new digest.
+func (c *Checksum) Reset() {
+	c.h = 0
+}
+
+// Sum64 returns the digest of all values added so far.
+func (c *Checksum) Sum64() uint64 {
+	return c.h ^ fnvOffset64
+}
+
+// Sum32 returns the digest of all values added so far, folded to 32 bits.
+func (c *Checksum) Sum32() uint32 {
+	h := c.Sum64()
+	return uint32(h) ^ uint32(h>>32)
+}
+
+// AddInt32 adds a plain integer, such as a counter or a tick number.
+func (c *Checksum) AddInt32(n int32) {
+	c.add(uint64(uint32(n)), 4)
+}
+
+// AddInt64 adds a plain 64-bit integer.
+func (c *Checksum) AddInt64(n int64) {
+	c.add(uint64(n), 8)
+}
+
+// AddBool adds a boolean as a single byte, 1 for true.
+func (c *Checksum) AddBool(b bool) {
+	var n uint64
+	if b {
+		n = 1
+	}
+	c.add(n, 1)
+}
+
+// AddQ24 adds a Q24 number.
+func (c *Checksum) AddQ24(q Q24) {
+	c.AddInt32(q.N)
+}
+
+// AddQ24s adds all numbers in the slice, in order.
+func (c *Checksum) AddQ24s(s []Q24) {
+	for _, q := range s {
+		c.AddInt32(q.N)
+	}
+}
+
+// AddVec2Q24 adds a vector as X, Y.
+func (c *Checksum) AddVec2Q24(v Vec2Q24) {
+	c.AddInt32(v.X.N)
+	c.AddInt32(v.Y.N)
+}
+
+// AddVec2Q24s adds all vectors in the slice, in order.
+func (c *Checksum) AddVec2Q24s(s []Vec2Q24) {
+	for _, v := range s {
+		c.AddVec2Q24(v)
+	}
+}
+
+// AddVec3Q24 adds a vector as X, Y, Z.
+func (c *Checksum) AddVec3Q24(v Vec3Q24) {
+	c.AddInt32(v.X.N)
+	c.AddInt32(v.Y.N)
+	c.AddInt32(v.Z.N)
+}
+
+// AddVec3Q24s adds all vectors in the slice, in order.
+func (c *Checksum) AddVec3Q24s(s []Vec3Q24) {
+	for _, v := range s {
+		c.AddVec3Q24(v)
+	}
+}
+
+// AddQuatQ24 adds a quaternion as W, X, Y, Z.
+func (c *Checksum) AddQuatQ24(q QuatQ24) {
+	c.AddInt32(q.W.N)
+	c.AddVec3Q24(q.V)
+}
+
+// AddQ16 adds a Q16 number.
+func (c *Checksum) AddQ16(q Q16) {
+	c.AddInt32(q.N)
+}
+
+// AddQ32 adds a Q32 number as its 8 bytes.
+func (c *Checksum) AddQ32(q Q32) {
+	c.AddInt64(q.N)
+}
+
+// Add adds a value of any of the types of this package: Q15, Q16, Q24, Q31,
+// Q32, Vec2Q24, Vec3Q24, Vec4Q24, Vec3Q16, QuatQ24, QuatQ16, Mat3Q24 and
+// Mat4Q24, slices of Q24, Vec2Q24 and Vec3Q24, and int32, int64 and bool. It
+// panics for other types. The type switch makes it slower than the methods
+// for a specific type.
+func (c *Checksum) Add(value interface{}) {
+	switch v := value.(type) {
+	case Q15:
+		c.add(uint64(uint16(v.N)), 2)
+	case Q16:
+		c.AddQ16(v)
+	case Q24:
+		c.AddQ24(v)
+	case Q31:
+		c.AddInt32(v.N)
+	case Q32:
+		c.AddQ32(v)
+	case Vec2Q24:
+		c.AddVec2Q24(v)
+	case Vec3Q24:
+		c.AddVec3Q24(v)
+	case Vec4Q24:
+		c.AddInt32(v.X.N)
+		c.AddInt32(v.Y.N)
+		c.AddInt32(v.Z.N)
+		c.AddInt32(v.W.N)
+	case Vec3Q16:
+		c.AddInt32(v.X.N)
+		c.AddInt32(v.Y.N)
+		c.AddInt32(v.Z.N)
+	case QuatQ24:
+		c.AddQuatQ24(v)
+	case QuatQ16:
+		c.AddInt32(v.W.N)
+		c.Add(v.V)
+	case Mat3Q24:
+		c.AddQ24s(v[:])
+	case Mat4Q24:
+		c.AddQ24s(v[:])
+	case []Q24:
+		c.AddQ24s(v)
+	case []Vec2Q24:
+		c.AddVec2Q24s(v)
+	case []Vec3Q24:
+		c.AddVec3Q24s(v)
+	case int32:
+		c.AddInt32(v)
+	case int64:
+		c.AddInt64(v)
+	case bool:
+		c.AddBool(v)
+	default:
+		panic("fixpoint: unsupported type in Checksum.Add")
+	}
+}
+
+// add hashes the lowest n bytes of v, least significant byte first.
+func (c *Checksum) add(v uint64, n int) {
+	h := c.h ^ fnvOffset64
+	for i := 0; i < n; i++ {
+		h ^= v & 0xff
+		h *= fnvPrime64
+		v >>= 8
+	}
+	c.h = h ^ fnvOffset64
+}
+
+// ChecksumState returns the 64-bit digest of the given values, in the order
+// they're passed. See Checksum.Add for the supported types.
+func ChecksumState(values ...interface{}) uint64 {
+	var c Checksum
+	for _, v := range values {
+		c.Add(v)
+	}
+	return c.Sum64()
+}
+
+// ChecksumState32 is like ChecksumState, but returns a 32-bit digest.
+func ChecksumState32(values ...interface{}) uint32 {
+	var c Checksum
+	for _, v := range values {
+		c.Add(v)
+	}
+	return c.Sum32()
+}
@@ -0,0 +1,51 @@
+package fixpoint
+
+import (
+	"hash/fnv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecksum(t *testing.T) {
+	// An empty checksum is the FNV-1a offset basis.
+	var c Checksum
+	empty := fnv.New64a()
+	assert.Equal(t, empty.Sum64(), c.Sum64())
+
+	// The digest is FNV-1a over the binary encoding of the values.
+	q := Q24FromFloat(-0.75)
+	v := Vec3Q24FromFloat(0.1, -0.2, 3)
+	rot := QuatFromAxisAngle(Vec3Q24FromFloat(0, 0.6, 0.8), Q24FromFloat(1.2))
+	c.AddQ24(q)
+	c.AddVec3Q24(v)
+	c.AddQuatQ24(rot)
+	h := fnv.New64a()
+	for _, m := range []interface{ MarshalBinary() ([]byte, error) }{q, v, rot} {
+		data, _ := m.MarshalBinary()
+		h.Write(data)
+	}
+	assert.Equal(t, h.Sum64(), c.Sum64())
+	assert.Equal(t, uint32(c.Sum64())^uint32(c.Sum64()>>32), c.Sum32())
+
+	// ChecksumState gives the same result, and the order matters.
+	assert.Equal(t, c.Sum64(), ChecksumState(q, v, rot))
+	assert.Equal(t, c.Sum32(), ChecksumState32(q, v, rot))
+	assert.NotEqual(t, c.Sum64(), ChecksumState(v, q, rot))
+
+	// Slices and matrices are the same as their elements in order.
+	positions := []Vec2Q24{Vec2Q24FromFloat(1, 2), Vec2Q24FromFloat(-3, 0.5)}
+	assert.Equal(t, ChecksumState(positions[0], positions[1]), ChecksumState(positions))
+	m := Mat3Rotate(Vec3Q24FromFloat(1, 0, 0), Q24FromFloat(0.5))
+	assert.Equal(t, ChecksumState(m[:]), ChecksumState(m))
+
+	// A single ULP of difference changes the digest.
+	assert.NotEqual(t, ChecksumState(Q24{1}), ChecksumState(Q24{0}))
+	assert.NotEqual(t, ChecksumState(int32(1), true), ChecksumState(int32(1), false))
+
+	// Reset starts over.
+	c.Reset()
+	assert.Equal(t, empty.Sum64(), c.Sum64())
+
+	assert.Panics(t, func() { ChecksumState(1.5) })
+}
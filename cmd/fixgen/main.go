@@ -0,0 +1,137 @@
+// Command fixgen generates fixed point types with a given number of fractional
+// bits, along with 3-dimensional vector and quaternion types using them (unless
+// -scalar is given). The underlying integer is an int32, or an int16 with
+// -bits 16. This is how the fixpoint package implements the formats other than
+// Q24, and it can be used to add other formats to your own package.
+//
+// For example, this generates a Q2.29 type named Q29 in package foo:
+//
+//	fixgen -name Q29 -frac 29 -package foo -o q29.go
+//
+// And this generates a Q0.15 type named Q15, as commonly used for audio:
+//
+//	fixgen -name Q15 -frac 15 -bits 16 -scalar -o q15.go
+//
+// With -wavetables, it generates the band-limited wavetables used by the
+// oscillator in the audio package instead.
+//
+// With -vet, it doesn't generate anything but checks the given Go files and
+// directories for conversions of constant floats that happen at runtime, like
+// Q24FromFloat(0.5), which could use Q24FromConstFloat instead. It exits with
+// status 1 if it finds any, like go vet:
+//
+//	fixgen -vet ./...
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+)
+
+// params are the parameters passed to the template. The first block are the
+// options, the second block is derived from them by generate.
+type params struct {
+	Name    string // type name, like Q16
+	Package string // package name
+	Frac    int    // number of fractional bits
+	Bits    int    // size of the underlying integer: 16 or 32
+	Scalar  bool   // only generate the scalar type
+
+	IntBits int    // number of integer bits (excluding the sign bit)
+	Range   int64  // 2^IntBits
+	Int     string // underlying integer type
+	Wide    string // integer type to hold intermediate results
+	Narrow  bool   // whether Int is smaller than int32
+	Flags   string // non-default flags, for the header
+}
+
+func main() {
+	name := flag.String("name", "", "name of the fixed point type (for example Q16)")
+	frac := flag.Int("frac", 0, "number of fractional bits (1 to bits-1)")
+	bits := flag.Int("bits", 32, "size of the underlying integer (16 or 32)")
+	scalar := flag.Bool("scalar", false, "only generate the scalar type, no vector and quaternion types")
+	pkg := flag.String("package", "fixpoint", "package name of the generated file")
+	wavetables := flag.Bool("wavetables", false, "generate wavetables instead of a fixed point type")
+	output := flag.String("o", "", "output file (default stdout)")
+	vetFlag := flag.Bool("vet", false, "check the given files and directories for runtime conversions of constant floats")
+	flag.Parse()
+	if *vetFlag {
+		count, err := vet(os.Stderr, flag.Args())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "fixgen:", err)
+			os.Exit(1)
+		}
+		if count != 0 {
+			os.Exit(1)
+		}
+		return
+	}
+	if *name == "" && !*wavetables {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var source []byte
+	var err error
+	if *wavetables {
+		source, err = generateWavetables(*pkg)
+	} else {
+		source, err = generate(params{
+			Name:    *name,
+			Package: *pkg,
+			Frac:    *frac,
+			Bits:    *bits,
+			Scalar:  *scalar,
+		})
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "fixgen:", err)
+		os.Exit(1)
+	}
+	if *output == "" {
+		os.Stdout.Write(source)
+		return
+	}
+	err = ioutil.WriteFile(*output, source, 0666)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "fixgen:", err)
+		os.Exit(1)
+	}
+}
+
+// generate returns the formatted Go source code for the given fixed point
+// format.
+func generate(p params) ([]byte, error) {
+	switch p.Bits {
+	case 16:
+		p.Int, p.Wide, p.Narrow = "int16", "int32", true
+		p.Flags += " -bits 16"
+	case 32:
+		p.Int, p.Wide = "int32", "int64"
+	default:
+		return nil, errors.New("bits must be 16 or 32")
+	}
+	if p.Frac < 1 || p.Frac >= p.Bits {
+		return nil, fmt.Errorf("frac must be in the range 1-%d", p.Bits-1)
+	}
+	p.IntBits = p.Bits - 1 - p.Frac
+	p.Range = 1 << uint(p.IntBits)
+	if p.Scalar {
+		p.Flags += " -scalar"
+	} else if p.IntBits < 2 {
+		// Quaternion rotation needs to represent the number 2.
+		return nil, errors.New("vector and quaternion types need at least 2 integer bits, use -scalar")
+	}
+
+	buf := &bytes.Buffer{}
+	err := typeTemplate.Execute(buf, p)
+	if err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
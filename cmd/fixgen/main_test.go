@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+)
+
+// TestGenerated checks whether the generated files in the fixpoint package are
+// up to date.
+func TestGenerated(t *testing.T) {
+	for _, tc := range []struct {
+		params
+		file string
+	}{
+		{params{Name: "Q16", Frac: 16, Bits: 32}, "../../q16.go"},
+		{params{Name: "Q15", Frac: 15, Bits: 16, Scalar: true}, "../../q15.go"},
+		{params{Name: "Q31", Frac: 31, Bits: 32, Scalar: true}, "../../q31.go"},
+	} {
+		tc.Package = "fixpoint"
+		expected, err := generate(tc.params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual, err := ioutil.ReadFile(tc.file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(expected, actual) {
+			t.Errorf("%s is out of date, run go generate", tc.file)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	source, err := generate(params{Name: "Q29", Frac: 29, Bits: 32, Package: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{
+		"package foo\n",
+		"type Q29 struct",
+		"Q2.29 fixed point",
+		"range\n// [-4, 4).",
+		"func (q1 Q29) Mul(q2 Q29) Q29 {\n\treturn Q29{int32((int64(q1.N) * int64(q2.N)) >> 29)}",
+		"type QuatQ29 struct",
+		"const OneQ29 = 1 << 29",
+		"func Q29FromConstFloat(x float64) Q29 {",
+	} {
+		if !bytes.Contains(source, []byte(s)) {
+			t.Errorf("generated source does not contain %#v", s)
+		}
+	}
+}
+
+func TestGenerateNarrow(t *testing.T) {
+	source, err := generate(params{Name: "Q8", Frac: 8, Bits: 16, Package: "foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{
+		"// Code generated by fixgen -name Q8 -frac 8 -bits 16; DO NOT EDIT.",
+		"type Q8 struct {\n\tN int16\n}",
+		"Q7.8 fixed point",
+		"return Q8{int16(x) << 8}",
+		"return int32(q.N) / (1 << 8 / scale)",
+		"return Q8{int16((int32(q1.N) * int32(q2.N)) >> 8)}",
+		"type QuatQ8 struct",
+	} {
+		if !bytes.Contains(source, []byte(s)) {
+			t.Errorf("generated source does not contain %#v", s)
+		}
+	}
+
+	// Formats without enough integer bits can only be generated as scalar.
+	if _, err := generate(params{Name: "Q15", Frac: 15, Bits: 16}); err == nil {
+		t.Error("expected an error for Q15 with vector types")
+	}
+	if _, err := generate(params{Name: "Q16", Frac: 16, Bits: 16, Scalar: true}); err == nil {
+		t.Error("expected an error for a frac that's too big")
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import "text/template"
+
+// typeTemplate is the template for a fixed point type, optionally with vector
+// and quaternion types. It uses [[ ]] as delimiters, because {{ }} is valid Go.
+var typeTemplate = template.Must(template.New("type").Delims("[[", "]]").Parse(`// Code generated by fixgen -name [[.Name]] -frac [[.Frac]][[.Flags]]; DO NOT EDIT.
+
+package [[.Package]]
+
+// [[.Name]] is a Q[[.IntBits]].[[.Frac]] fixed point integer type that has [[.Frac]] bits of
+// precision to the right of the fixed point. It can hold numbers in the range
+// [-[[.Range]], [[.Range]]).
+type [[.Name]] struct {
+	N [[.Int]]
+}
+
+// [[.Name]]FromFloat converts a float32 to the same number in fixed point format.
+// Inverse of .Float().
+func [[.Name]]FromFloat(x float32) [[.Name]] {
+	return [[.Name]]{[[.Int]](x * (1 << [[.Frac]]))}
+}
+
+[[- if .IntBits]]
+
+// [[.Name]]FromInt32 returns a fixed point integer with all decimals set to zero.
+func [[.Name]]FromInt32(x int32) [[.Name]] {
+	return [[.Name]]{[[if .Narrow]][[.Int]](x)[[else]]x[[end]] << [[.Frac]]}
+}
+
+// One[[.Name]] is the underlying integer of the [[.Name]] number 1, as an untyped
+// constant that the compiler can fold into literals like [[.Name]]{One[[.Name]] / 2}.
+const One[[.Name]] = 1 << [[.Frac]]
+[[- end]]
+
+// [[.Name]]FromConstFloat converts a float64 to the nearest number in fixed point
+// format, with ties rounded away from zero. It is meant for constant
+// arguments: it is small enough to be inlined, after which the compiler folds
+// the conversion into a constant.
+func [[.Name]]FromConstFloat(x float64) [[.Name]] {
+	if x < 0 {
+		return [[.Name]]{[[.Int]](x*(1<<[[.Frac]]) - 0.5)}
+	}
+	return [[.Name]]{[[.Int]](x*(1<<[[.Frac]]) + 0.5)}
+}
+
+// Float returns the floating point version of this fixed point number. Inverse
+// of [[.Name]]FromFloat.
+func (q [[.Name]]) Float() float32 {
+	return float32(q.N) / (1 << [[.Frac]])
+}
+
+// Int32Scaled returns the underlying fixed point number multiplied by scale.
+func (q [[.Name]]) Int32Scaled(scale int32) int32 {
+[[- if eq .Frac 31]]
+	return int32((int64(q.N) * int64(scale)) >> [[.Frac]])
+[[- else]]
+	return [[if .Narrow]]int32(q.N)[[else]]q.N[[end]] / (1 << [[.Frac]] / scale)
+[[- end]]
+}
+
+// Add returns the argument plus this number.
+func (q1 [[.Name]]) Add(q2 [[.Name]]) [[.Name]] {
+	return [[.Name]]{q1.N + q2.N}
+}
+
+// Sub returns the argument minus this number.
+func (q1 [[.Name]]) Sub(q2 [[.Name]]) [[.Name]] {
+	return [[.Name]]{q1.N - q2.N}
+}
+
+// Neg returns the inverse of this number.
+func (q1 [[.Name]]) Neg() [[.Name]] {
+	return [[.Name]]{-q1.N}
+}
+
+// Mul returns this number multiplied by the argument.
+func (q1 [[.Name]]) Mul(q2 [[.Name]]) [[.Name]] {
+	return [[.Name]]{[[.Int]](([[.Wide]](q1.N) * [[.Wide]](q2.N)) >> [[.Frac]])}
+}
+
+// Div returns this number divided by the argument.
+func (q1 [[.Name]]) Div(q2 [[.Name]]) [[.Name]] {
+	return [[.Name]]{[[.Int]](([[.Wide]](q1.N) << [[.Frac]]) / [[.Wide]](q2.N))}
+}
+[[- if not .Scalar]]
+
+// Vec3[[.Name]] is a 3-dimensional vector with [[.Name]] fixed point elements.
+type Vec3[[.Name]] struct {
+	X [[.Name]]
+	Y [[.Name]]
+	Z [[.Name]]
+}
+
+// Vec3[[.Name]]FromFloat returns the fixed-point vector of the given 3 floats.
+func Vec3[[.Name]]FromFloat(x, y, z float32) Vec3[[.Name]] {
+	return Vec3[[.Name]]{[[.Name]]FromFloat(x), [[.Name]]FromFloat(y), [[.Name]]FromFloat(z)}
+}
+
+// Add returns this vector added to the argument.
+func (v1 Vec3[[.Name]]) Add(v2 Vec3[[.Name]]) Vec3[[.Name]] {
+	return Vec3[[.Name]]{v1.X.Add(v2.X), v1.Y.Add(v2.Y), v1.Z.Add(v2.Z)}
+}
+
+// Mul returns this vector multiplied by the argument.
+func (v1 Vec3[[.Name]]) Mul(c [[.Name]]) Vec3[[.Name]] {
+	return Vec3[[.Name]]{v1.X.Mul(c), v1.Y.Mul(c), v1.Z.Mul(c)}
+}
+
+// Dot returns the dot product between this vector and the argument.
+func (v1 Vec3[[.Name]]) Dot(v2 Vec3[[.Name]]) [[.Name]] {
+	return v1.X.Mul(v2.X).Add(v1.Y.Mul(v2.Y)).Add(v1.Z.Mul(v2.Z))
+}
+
+// Cross returns the cross product between this vector and the argument.
+func (v1 Vec3[[.Name]]) Cross(v2 Vec3[[.Name]]) Vec3[[.Name]] {
+	return Vec3[[.Name]]{v1.Y.Mul(v2.Z).Sub(v1.Z.Mul(v2.Y)), v1.Z.Mul(v2.X).Sub(v1.X.Mul(v2.Z)), v1.X.Mul(v2.Y).Sub(v1.Y.Mul(v2.X))}
+}
+
+// Quat[[.Name]] is a quaternion with [[.Name]] fixed point elements.
+type Quat[[.Name]] struct {
+	W [[.Name]]
+	V Vec3[[.Name]]
+}
+
+// Mul returns this quaternion multiplied by the argument.
+func (q1 Quat[[.Name]]) Mul(q2 Quat[[.Name]]) Quat[[.Name]] {
+	return Quat[[.Name]]{q1.W.Mul(q2.W).Sub(q1.V.Dot(q2.V)), q1.V.Cross(q2.V).Add(q2.V.Mul(q1.W)).Add(q1.V.Mul(q2.W))}
+}
+
+// Rotate returns the vector from the argument rotated by the rotation this
+// quaternion represents.
+func (q1 Quat[[.Name]]) Rotate(v Vec3[[.Name]]) Vec3[[.Name]] {
+	cross := q1.V.Cross(v)
+	// v + 2q_w * (q_v x v) + 2q_v x (q_v x v)
+	return v.Add(cross.Mul([[.Name]]FromInt32(2).Mul(q1.W))).Add(q1.V.Mul([[.Name]]FromInt32(2)).Cross(cross))
+}
+[[- end]]
+`))
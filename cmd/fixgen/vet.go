@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// floatConversion matches the names of the functions that convert a float to
+// a fixed point number at runtime, like Q24FromFloat and Q16FromFloat.
+var floatConversion = regexp.MustCompile(`^(Q[0-9]+)FromFloat(64)?$`)
+
+// vet reports the calls in the Go files of the given files and directories
+// that convert a constant float to a fixed point number at runtime, like
+// Q24FromFloat(0.5), and that could use the FromConstFloat variant instead so
+// that the compiler folds the conversion. A directory ending in "/..." is
+// searched recursively. Test files are skipped, as their speed rarely
+// matters. It returns the number of reported calls.
+func vet(w io.Writer, paths []string) (int, error) {
+	var files []string
+	for _, path := range paths {
+		recursive := strings.HasSuffix(path, "/...")
+		if recursive {
+			path = strings.TrimSuffix(path, "/...")
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return 0, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		err = filepath.Walk(path, func(name string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() && name != path && (!recursive || strings.HasPrefix(info.Name(), ".") || info.Name() == "testdata") {
+				return filepath.SkipDir
+			}
+			if !info.IsDir() && strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go") {
+				files = append(files, name)
+			}
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+	sort.Strings(files)
+
+	count := 0
+	fset := token.NewFileSet()
+	for _, name := range files {
+		file, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			return count, err
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) != 1 || !isConstant(call.Args[0]) {
+				return true
+			}
+			var fn string
+			switch f := call.Fun.(type) {
+			case *ast.Ident:
+				fn = f.Name
+			case *ast.SelectorExpr:
+				fn = f.Sel.Name
+			}
+			m := floatConversion.FindStringSubmatch(fn)
+			if m == nil {
+				return true
+			}
+			count++
+			fmt.Fprintf(w, "%s: %s with a constant argument is converted at runtime, use %sFromConstFloat\n", fset.Position(call.Pos()), fn, m[1])
+			return true
+		})
+	}
+	return count, nil
+}
+
+// isConstant returns whether the expression is a number literal or an
+// arithmetic expression of number literals, like -1.5 or (1 + 2) / 3. It
+// doesn't resolve named constants, as that would need type checking.
+func isConstant(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		return e.Kind == token.INT || e.Kind == token.FLOAT
+	case *ast.ParenExpr:
+		return isConstant(e.X)
+	case *ast.UnaryExpr:
+		return (e.Op == token.SUB || e.Op == token.ADD) && isConstant(e.X)
+	case *ast.BinaryExpr:
+		switch e.Op {
+		case token.ADD, token.SUB, token.MUL, token.QUO:
+			return isConstant(e.X) && isConstant(e.Y)
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVet(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fixgen")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	write := func(name, source string) {
+		path := filepath.Join(dir, name)
+		os.MkdirAll(filepath.Dir(path), 0777)
+		if err := ioutil.WriteFile(path, []byte(source), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("a.go", `package a
+
+import "github.com/aykevl/fixpoint"
+
+const gain = 0.5
+
+var (
+	a = fixpoint.Q24FromFloat(0.5)
+	b = fixpoint.Q16FromFloat64(-(1 + 2) / 3.0)
+	c = fixpoint.Q24FromConstFloat(0.5)
+	d = fixpoint.Q24FromFloat(gain)
+	e = Q29FromFloat(1e-3)
+)
+
+func f(x float32) fixpoint.Q24 {
+	return fixpoint.Q24FromFloat(x * 2)
+}
+`)
+	write("a_test.go", "package a\n\nvar t = Q29FromFloat(1)\n")
+	write("sub/b.go", "package sub\n\nvar b = Q24FromFloat(2)\n")
+
+	// Named constants and variables are not reported, as they can't be
+	// resolved without type checking. Test files are skipped.
+	buf := &bytes.Buffer{}
+	count, err := vet(buf, []string{dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := filepath.Join(dir, "a.go") + ":8:6: Q24FromFloat with a constant argument is converted at runtime, use Q24FromConstFloat\n" +
+		filepath.Join(dir, "a.go") + ":9:6: Q16FromFloat64 with a constant argument is converted at runtime, use Q16FromConstFloat\n" +
+		filepath.Join(dir, "a.go") + ":12:6: Q29FromFloat with a constant argument is converted at runtime, use Q29FromConstFloat\n"
+	if count != 3 || buf.String() != expected {
+		t.Errorf("unexpected output (%d calls):\n%s", count, buf.String())
+	}
+
+	// Subdirectories are only checked recursively.
+	buf.Reset()
+	count, err = vet(buf, []string{dir + "/..."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 4 {
+		t.Errorf("expected 4 calls, got %d:\n%s", count, buf.String())
+	}
+
+	// The fixpoint package itself doesn't convert constants at runtime.
+	buf.Reset()
+	count, err = vet(buf, []string{"../.."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Errorf("expected no calls in the fixpoint package, got:\n%s", buf.String())
+	}
+
+	if _, err := vet(buf, []string{filepath.Join(dir, "missing")}); err == nil {
+		t.Error("expected an error for a missing directory")
+	}
+}
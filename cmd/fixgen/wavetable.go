@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"math"
+)
+
+// Parameters of the band-limited wavetables. Table i of each waveform contains
+// the harmonics 1 to 2^i, so that the table can be chosen by octave.
+const (
+	wavetableSize   = 256
+	wavetableLevels = 7
+)
+
+// waveforms lists the generated wavetables with the amplitude of harmonic k
+// (as in sin(kx)) of the ideal waveform.
+var waveforms = []struct {
+	name      string
+	harmonics func(k int) float64
+}{
+	{"saw", func(k int) float64 {
+		// Rising from -1 to 1.
+		if k%2 == 0 {
+			return -2 / math.Pi / float64(k)
+		}
+		return 2 / math.Pi / float64(k)
+	}},
+	{"square", func(k int) float64 {
+		if k%2 == 0 {
+			return 0
+		}
+		return 4 / math.Pi / float64(k)
+	}},
+	{"triangle", func(k int) float64 {
+		switch k % 4 {
+		case 1:
+			return 8 / (math.Pi * math.Pi) / float64(k*k)
+		case 3:
+			return -8 / (math.Pi * math.Pi) / float64(k*k)
+		default:
+			return 0
+		}
+	}},
+}
+
+// wavetables returns the band-limited tables of a waveform. All tables are
+// scaled by the same factor, so that the largest peak (caused by the Gibbs
+// phenomenon) is at full scale.
+func wavetables(harmonics func(k int) float64) [wavetableLevels][wavetableSize]int16 {
+	var values [wavetableLevels][wavetableSize]float64
+	peak := 0.0
+	for level := range values {
+		for i := range values[level] {
+			x := 2 * math.Pi * float64(i) / wavetableSize
+			sum := 0.0
+			for k := 1; k <= 1<<uint(level); k++ {
+				sum += harmonics(k) * math.Sin(float64(k)*x)
+			}
+			values[level][i] = sum
+			peak = math.Max(peak, math.Abs(sum))
+		}
+	}
+	var tables [wavetableLevels][wavetableSize]int16
+	for level := range values {
+		for i, v := range values[level] {
+			tables[level][i] = int16(math.Floor(v/peak*32767 + 0.5))
+		}
+	}
+	return tables
+}
+
+// generateWavetables returns the formatted Go source code for the wavetables
+// of all waveforms.
+func generateWavetables(pkg string) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "// Code generated by fixgen -wavetables; DO NOT EDIT.\n\n")
+	fmt.Fprintf(buf, "package %s\n\n", pkg)
+	fmt.Fprintf(buf, "// Band-limited wavetables with one cycle of each waveform in Q15 format.\n")
+	fmt.Fprintf(buf, "// Table i contains the harmonics 1 to 2^i.\n")
+	fmt.Fprintf(buf, "const (\n\twavetableSize = %d\n\twavetableLevels = %d\n)\n", wavetableSize, wavetableLevels)
+	for _, w := range waveforms {
+		fmt.Fprintf(buf, "\nvar %sTables = [wavetableLevels][wavetableSize]int16{\n", w.name)
+		for _, table := range wavetables(w.harmonics) {
+			fmt.Fprintf(buf, "{\n")
+			for i, v := range table {
+				fmt.Fprintf(buf, "%d,", v)
+				if i%16 == 15 {
+					fmt.Fprintf(buf, "\n")
+				} else {
+					fmt.Fprintf(buf, " ")
+				}
+			}
+			fmt.Fprintf(buf, "},\n")
+		}
+		fmt.Fprintf(buf, "}\n")
+	}
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math"
+	"testing"
+)
+
+// TestWavetablesGenerated checks whether the wavetables in the audio package
+// are up to date.
+func TestWavetablesGenerated(t *testing.T) {
+	expected, err := generateWavetables("audio")
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, err := ioutil.ReadFile("../../audio/wavetables.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(expected, actual) {
+		t.Error("../../audio/wavetables.go is out of date, run go generate")
+	}
+}
+
+func TestWavetables(t *testing.T) {
+	for _, w := range waveforms {
+		tables := wavetables(w.harmonics)
+		max := 0
+		for level, table := range tables {
+			// Check the spectrum: each table must only contain the harmonics
+			// up to 2^level.
+			for k := 0; k < wavetableSize/2; k++ {
+				var re, im float64
+				for i, v := range table {
+					x := 2 * math.Pi * float64(k*i) / wavetableSize
+					re += float64(v) * math.Cos(x)
+					im += float64(v) * math.Sin(x)
+				}
+				amplitude := math.Hypot(re, im) / (wavetableSize / 2) / 32767
+				if k > 1<<uint(level) && amplitude > 0.0001 {
+					t.Errorf("%s table %d: harmonic %d has amplitude %f", w.name, level, k, amplitude)
+				}
+				if k == 1 && amplitude < 0.5 {
+					t.Errorf("%s table %d: fundamental too weak: %f", w.name, level, amplitude)
+				}
+			}
+			for _, v := range table {
+				if int(v) > max {
+					max = int(v)
+				}
+			}
+		}
+		if max != 32767 {
+			t.Errorf("%s: expected a peak at full scale, got %d", w.name, max)
+		}
+	}
+}
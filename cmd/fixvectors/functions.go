@@ -0,0 +1,230 @@
+package main
+
+import (
+	"math/rand"
+
+	"github.com/aykevl/fixpoint"
+)
+
+// function is a function that golden vectors are generated for. Inputs and
+// outputs are the raw underlying integers of the fixed point numbers, with
+// vectors and quaternions flattened (X, Y, Z and W, X, Y, Z).
+type function struct {
+	name  string
+	input func(r *rand.Rand) []int32
+	eval  func(in []int32) []int32
+}
+
+// Input generators. Random values are mixed with edge cases, which are the
+// ones most likely to differ in a port.
+
+// arbitrary returns n arbitrary numbers.
+func arbitrary(n int) func(r *rand.Rand) []int32 {
+	return func(r *rand.Rand) []int32 {
+		in := make([]int32, n)
+		for i := range in {
+			in[i] = randomN(r)
+		}
+		return in
+	}
+}
+
+// ranged returns n numbers in the range [min, max].
+func ranged(n int, min, max int32) func(r *rand.Rand) []int32 {
+	return func(r *rand.Rand) []int32 {
+		in := make([]int32, n)
+		for i := range in {
+			switch r.Intn(8) {
+			case 0:
+				in[i] = min
+			case 1:
+				in[i] = max
+			default:
+				in[i] = min + int32(r.Int63n(int64(max)-int64(min)+1))
+			}
+		}
+		return in
+	}
+}
+
+// nonZero is like arbitrary, but the last number is never zero (for divisors).
+func nonZero(n int) func(r *rand.Rand) []int32 {
+	return func(r *rand.Rand) []int32 {
+		in := arbitrary(n)(r)
+		for in[n-1] == 0 {
+			in[n-1] = randomN(r)
+		}
+		return in
+	}
+}
+
+// randomN returns a random number with a random magnitude, or an edge case.
+func randomN(r *rand.Rand) int32 {
+	switch r.Intn(16) {
+	case 0:
+		return 0
+	case 1:
+		return 1
+	case 2:
+		return -1
+	case 3:
+		return 1<<31 - 1
+	case 4:
+		return -1 << 31
+	default:
+		return int32(r.Uint32()) >> uint(r.Intn(31))
+	}
+}
+
+// Helpers to convert between raw integers and fixed point types.
+
+func q24(n int32) fixpoint.Q24 {
+	return fixpoint.Q24{N: n}
+}
+
+func vec3(in []int32) fixpoint.Vec3Q24 {
+	return fixpoint.Vec3Q24{X: q24(in[0]), Y: q24(in[1]), Z: q24(in[2])}
+}
+
+func quat(in []int32) fixpoint.QuatQ24 {
+	return fixpoint.QuatQ24{W: q24(in[0]), V: vec3(in[1:])}
+}
+
+func fromVec3(v fixpoint.Vec3Q24) []int32 {
+	return []int32{v.X.N, v.Y.N, v.Z.N}
+}
+
+func fromQuat(q fixpoint.QuatQ24) []int32 {
+	return []int32{q.W.N, q.V.X.N, q.V.Y.N, q.V.Z.N}
+}
+
+// unary and binary wrap scalar functions of the Q24 type.
+
+func unary(f func(q fixpoint.Q24) fixpoint.Q24) func(in []int32) []int32 {
+	return func(in []int32) []int32 {
+		return []int32{f(q24(in[0])).N}
+	}
+}
+
+func binary(f func(q1, q2 fixpoint.Q24) fixpoint.Q24) func(in []int32) []int32 {
+	return func(in []int32) []int32 {
+		return []int32{f(q24(in[0]), q24(in[1])).N}
+	}
+}
+
+// Range limits for functions that are only defined for part of the range.
+const (
+	one     = 1 << 24
+	maxQ24  = 1<<31 - 1
+	minQ24  = -1 << 31
+	unitMax = 2 * one // for vectors and quaternions close to unit length
+)
+
+// functions contains all functions per fixed point type.
+var functions = map[string][]function{
+	"q24": {
+		{"Add", arbitrary(2), binary(fixpoint.Q24.Add)},
+		{"Sub", arbitrary(2), binary(fixpoint.Q24.Sub)},
+		{"Neg", arbitrary(1), unary(fixpoint.Q24.Neg)},
+		{"Abs", arbitrary(1), unary(fixpoint.Q24.Abs)},
+		{"Mul", arbitrary(2), binary(fixpoint.Q24.Mul)},
+		{"MulRound", arbitrary(2), binary(fixpoint.Q24.MulRound)},
+		{"Div", nonZero(2), binary(fixpoint.Q24.Div)},
+		{"DivRound", nonZero(2), binary(fixpoint.Q24.DivRound)},
+		{"DivFast", arbitrary(2), binary(fixpoint.Q24.DivFast)},
+		{"Recip", arbitrary(1), unary(fixpoint.Q24.Recip)},
+		{"AddSat", arbitrary(2), binary(fixpoint.Q24.AddSat)},
+		{"SubSat", arbitrary(2), binary(fixpoint.Q24.SubSat)},
+		{"MulSat", arbitrary(2), binary(fixpoint.Q24.MulSat)},
+		{"DivSat", arbitrary(2), binary(fixpoint.Q24.DivSat)},
+		{"Sqrt", arbitrary(1), unary(fixpoint.Q24.Sqrt)},
+		{"InvSqrt", arbitrary(1), unary(fixpoint.Q24.InvSqrt)},
+		{"Exp2", arbitrary(1), unary(fixpoint.Exp2)},
+		{"Sin", arbitrary(1), unary(fixpoint.Sin)},
+		{"Cos", arbitrary(1), unary(fixpoint.Cos)},
+		{"Tan", ranged(1, -one*3/2, one*3/2), unary(fixpoint.Tan)},
+		{"Atan", arbitrary(1), unary(fixpoint.Atan)},
+		{"Atan2", arbitrary(2), binary(fixpoint.Atan2)},
+		{"Asin", ranged(1, -one, one), unary(fixpoint.Asin)},
+		{"Acos", ranged(1, -one, one), unary(fixpoint.Acos)},
+		{"Lerp", ranged(3, -64*one, 64*one), func(in []int32) []int32 {
+			return []int32{fixpoint.Lerp(q24(in[0]), q24(in[1]), q24(in[2])).N}
+		}},
+		{"Vec3.Dot", ranged(6, -unitMax, unitMax), func(in []int32) []int32 {
+			return []int32{vec3(in).Dot(vec3(in[3:])).N}
+		}},
+		{"Vec3.Cross", ranged(6, -unitMax, unitMax), func(in []int32) []int32 {
+			return fromVec3(vec3(in).Cross(vec3(in[3:])))
+		}},
+		{"Vec3.Len", arbitrary(3), func(in []int32) []int32 {
+			return []int32{vec3(in).Len().N}
+		}},
+		{"Vec3.Normalize", arbitrary(3), func(in []int32) []int32 {
+			return fromVec3(vec3(in).Normalize())
+		}},
+		{"Quat.Mul", ranged(8, -unitMax, unitMax), func(in []int32) []int32 {
+			return fromQuat(quat(in).Mul(quat(in[4:])))
+		}},
+		{"Quat.Rotate", ranged(7, -unitMax, unitMax), func(in []int32) []int32 {
+			return fromVec3(quat(in).Rotate(vec3(in[4:])))
+		}},
+		{"Quat.Normalize", arbitrary(4), func(in []int32) []int32 {
+			return fromQuat(quat(in).Normalize())
+		}},
+	},
+	"q16": {
+		{"Add", arbitrary(2), func(in []int32) []int32 {
+			return []int32{fixpoint.Q16{N: in[0]}.Add(fixpoint.Q16{N: in[1]}).N}
+		}},
+		{"Sub", arbitrary(2), func(in []int32) []int32 {
+			return []int32{fixpoint.Q16{N: in[0]}.Sub(fixpoint.Q16{N: in[1]}).N}
+		}},
+		{"Mul", arbitrary(2), func(in []int32) []int32 {
+			return []int32{fixpoint.Q16{N: in[0]}.Mul(fixpoint.Q16{N: in[1]}).N}
+		}},
+		{"Div", nonZero(2), func(in []int32) []int32 {
+			return []int32{fixpoint.Q16{N: in[0]}.Div(fixpoint.Q16{N: in[1]}).N}
+		}},
+		{"Q24", arbitrary(1), func(in []int32) []int32 {
+			return []int32{fixpoint.Q16{N: in[0]}.Q24().N}
+		}},
+	},
+	"q15": {
+		{"Add", ranged(2, -1<<15, 1<<15-1), func(in []int32) []int32 {
+			return []int32{int32(q15(in[0]).Add(q15(in[1])).N)}
+		}},
+		{"Sub", ranged(2, -1<<15, 1<<15-1), func(in []int32) []int32 {
+			return []int32{int32(q15(in[0]).Sub(q15(in[1])).N)}
+		}},
+		{"Mul", ranged(2, -1<<15, 1<<15-1), func(in []int32) []int32 {
+			return []int32{int32(q15(in[0]).Mul(q15(in[1])).N)}
+		}},
+		{"Q24", ranged(1, -1<<15, 1<<15-1), func(in []int32) []int32 {
+			return []int32{q15(in[0]).Q24().N}
+		}},
+		{"FromQ24", arbitrary(1), func(in []int32) []int32 {
+			return []int32{int32(q24(in[0]).Q15().N)}
+		}},
+	},
+	"q31": {
+		{"Add", arbitrary(2), func(in []int32) []int32 {
+			return []int32{fixpoint.Q31{N: in[0]}.Add(fixpoint.Q31{N: in[1]}).N}
+		}},
+		{"Sub", arbitrary(2), func(in []int32) []int32 {
+			return []int32{fixpoint.Q31{N: in[0]}.Sub(fixpoint.Q31{N: in[1]}).N}
+		}},
+		{"Mul", arbitrary(2), func(in []int32) []int32 {
+			return []int32{fixpoint.Q31{N: in[0]}.Mul(fixpoint.Q31{N: in[1]}).N}
+		}},
+		{"Q24", arbitrary(1), func(in []int32) []int32 {
+			return []int32{fixpoint.Q31{N: in[0]}.Q24().N}
+		}},
+		{"FromQ24", arbitrary(1), func(in []int32) []int32 {
+			return []int32{q24(in[0]).Q31().N}
+		}},
+	},
+}
+
+func q15(n int32) fixpoint.Q15 {
+	return fixpoint.Q15{N: int16(n)}
+}
@@ -0,0 +1,193 @@
+// Command fixvectors generates golden test vectors for the functions in the
+// fixpoint package. Each vector contains the inputs and outputs of a function
+// as the raw underlying integers, so that a port of this package (for example
+// to C or assembly) can be checked to be bit-exact.
+//
+// For example, this writes 1000 vectors for each Q24 function as JSON:
+//
+//	fixvectors -type q24 -n 1000 -o vectors.json
+//
+// And this writes the vectors of only Mul and Sqrt as CSV:
+//
+//	fixvectors -func Mul,Sqrt -format csv
+//
+// Vectors and quaternions are flattened into their elements, in the order X,
+// Y, Z for vectors and W, X, Y, Z for quaternions. The inputs are
+// random but include edge cases like zero and the extremes of the range. They
+// only depend on the seed, so they are stable between runs.
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// vector is a single golden vector.
+type vector struct {
+	In  []int32 `json:"in"`
+	Out []int32 `json:"out"`
+}
+
+// vectors are the golden vectors of a single function.
+type vectors struct {
+	Name    string   `json:"name"`
+	Vectors []vector `json:"vectors"`
+}
+
+func main() {
+	typ := flag.String("type", "q24", "fixed point type: "+strings.Join(types(), ", "))
+	n := flag.Int("n", 100, "number of vectors per function")
+	seed := flag.Int64("seed", 1, "random seed")
+	funcs := flag.String("func", "", "comma-separated list of functions (default all)")
+	format := flag.String("format", "json", "output format: json or csv")
+	output := flag.String("o", "", "output file (default stdout)")
+	flag.Parse()
+
+	var names []string
+	if *funcs != "" {
+		names = strings.Split(*funcs, ",")
+	}
+	result, err := generate(*typ, names, *n, *seed)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "fixvectors:", err)
+		os.Exit(2)
+	}
+
+	w := io.Writer(os.Stdout)
+	var f *os.File
+	if *output != "" {
+		f, err = os.Create(*output)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "fixvectors:", err)
+			os.Exit(1)
+		}
+		w = f
+	}
+	switch *format {
+	case "json":
+		err = writeJSON(w, *typ, *seed, result)
+	case "csv":
+		err = writeCSV(w, result)
+	default:
+		err = fmt.Errorf("unknown format: %s", *format)
+	}
+	// Close the file explicitly, as os.Exit doesn't run deferred calls, and
+	// report a failure to write the last data.
+	if f != nil {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "fixvectors:", err)
+		os.Exit(1)
+	}
+}
+
+// types returns the supported fixed point types, sorted.
+func types() []string {
+	var names []string
+	for name := range functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// generate returns n vectors for each of the given functions of a fixed point
+// type, or for all functions if names is empty.
+func generate(typ string, names []string, n int, seed int64) ([]vectors, error) {
+	funcs, ok := functions[typ]
+	if !ok {
+		return nil, fmt.Errorf("unknown type: %s", typ)
+	}
+	if len(names) != 0 {
+		var selected []function
+		for _, name := range names {
+			found := false
+			for _, f := range funcs {
+				if f.name == name {
+					selected = append(selected, f)
+					found = true
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("unknown function for type %s: %s", typ, name)
+			}
+		}
+		funcs = selected
+	}
+	if n <= 0 {
+		return nil, errors.New("number of vectors must be positive")
+	}
+
+	var result []vectors
+	for _, f := range funcs {
+		// Use a separate random source per function, so that the vectors of
+		// a function don't change when other functions are added.
+		r := rand.New(rand.NewSource(seed ^ int64(hash(typ+"."+f.name))))
+		v := vectors{Name: f.name, Vectors: make([]vector, n)}
+		for i := range v.Vectors {
+			in := f.input(r)
+			v.Vectors[i] = vector{In: in, Out: f.eval(in)}
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
+
+// hash returns the 32-bit FNV-1a hash of s.
+func hash(s string) uint32 {
+	h := uint32(2166136261)
+	for i := 0; i < len(s); i++ {
+		h ^= uint32(s[i])
+		h *= 16777619
+	}
+	return h
+}
+
+// writeJSON writes the vectors as a JSON object.
+func writeJSON(w io.Writer, typ string, seed int64, result []vectors) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(struct {
+		Type      string    `json:"type"`
+		Seed      int64     `json:"seed"`
+		Functions []vectors `json:"functions"`
+	}{typ, seed, result})
+}
+
+// writeCSV writes the vectors as CSV with one vector per line. The inputs and
+// outputs are separated by spaces, as their number depends on the function.
+func writeCSV(w io.Writer, result []vectors) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"function", "in", "out"})
+	for _, f := range result {
+		for _, v := range f.Vectors {
+			cw.Write([]string{f.Name, join(v.In), join(v.Out)})
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// join formats the numbers separated by spaces.
+func join(nums []int32) string {
+	buf := make([]byte, 0, len(nums)*12)
+	for i, n := range nums {
+		if i > 0 {
+			buf = append(buf, ' ')
+		}
+		buf = strconv.AppendInt(buf, int64(n), 10)
+	}
+	return string(buf)
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	for _, typ := range types() {
+		result, err := generate(typ, nil, 50, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, len(functions[typ]), len(result))
+		for _, f := range result {
+			assert.Len(t, f.Vectors, 50, "%s.%s", typ, f.Name)
+		}
+	}
+
+	// The vectors only depend on the seed.
+	a, _ := generate("q24", []string{"Sqrt"}, 10, 5)
+	b, _ := generate("q24", []string{"Mul", "Sqrt"}, 10, 5)
+	assert.Equal(t, a[0], b[1])
+	c, _ := generate("q24", []string{"Sqrt"}, 10, 6)
+	assert.NotEqual(t, a[0], c[0])
+
+	// Check the vectors against the package.
+	result, _ := generate("q24", []string{"MulRound", "Quat.Rotate"}, 100, 1)
+	for _, v := range result[0].Vectors {
+		assert.Equal(t, fixpoint.Q24{N: v.In[0]}.MulRound(fixpoint.Q24{N: v.In[1]}).N, v.Out[0])
+	}
+	for _, v := range result[1].Vectors {
+		assert.Len(t, v.In, 7)
+		assert.Len(t, v.Out, 3)
+	}
+
+	_, err := generate("q8", nil, 10, 1)
+	assert.Error(t, err)
+	_, err = generate("q24", []string{"Foo"}, 10, 1)
+	assert.Error(t, err)
+}
+
+func TestOutput(t *testing.T) {
+	result, _ := generate("q24", []string{"Add", "Vec3.Cross"}, 3, 1)
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, writeCSV(buf, result))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 7)
+	assert.Equal(t, "function,in,out", lines[0])
+	fields := strings.Split(lines[4], ",")
+	assert.Equal(t, "Vec3.Cross", fields[0])
+	assert.Len(t, strings.Fields(fields[1]), 6)
+	assert.Len(t, strings.Fields(fields[2]), 3)
+
+	buf.Reset()
+	assert.NoError(t, writeJSON(buf, "q24", 1, result))
+	var decoded struct {
+		Type      string
+		Seed      int64
+		Functions []vectors
+	}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, "q24", decoded.Type)
+	assert.Equal(t, result, decoded.Functions)
+}
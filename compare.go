@@ -0,0 +1,79 @@
+package fixpoint
+
+// Abs returns the absolute value of this number. Like Neg, it overflows for
+// the most negative number.
+func (q Q24) Abs() Q24 {
+	if q.N < 0 {
+		return Q24{-q.N}
+	}
+	return q
+}
+
+// Min returns the smaller of this number and the argument.
+func (q1 Q24) Min(q2 Q24) Q24 {
+	if q2.N < q1.N {
+		return q2
+	}
+	return q1
+}
+
+// Max returns the larger of this number and the argument.
+func (q1 Q24) Max(q2 Q24) Q24 {
+	if q2.N > q1.N {
+		return q2
+	}
+	return q1
+}
+
+// Clamp returns this number limited to the range [min, max].
+func (q Q24) Clamp(min, max Q24) Q24 {
+	if q.N < min.N {
+		return min
+	}
+	if q.N > max.N {
+		return max
+	}
+	return q
+}
+
+// Cmp compares this number to the argument and returns -1 if it is smaller, 0
+// if they are equal and 1 if it is larger.
+func (q1 Q24) Cmp(q2 Q24) int {
+	switch {
+	case q1.N < q2.N:
+		return -1
+	case q1.N > q2.N:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Lt returns whether this number is less than the argument.
+func (q1 Q24) Lt(q2 Q24) bool {
+	return q1.N < q2.N
+}
+
+// Gt returns whether this number is greater than the argument.
+func (q1 Q24) Gt(q2 Q24) bool {
+	return q1.N > q2.N
+}
+
+// Lerp returns the linear interpolation between a and b: a for t=0 and b for
+// t=1. Values of t outside [0, 1] extrapolate. The result is rounded to the
+// nearest value, and doesn't overflow when a and b are far apart.
+func Lerp(a, b, t Q24) Q24 {
+	return Q24{int32(int64(a.N) + ((int64(b.N)-int64(a.N))*int64(t.N)+1<<23)>>24)}
+}
+
+// Lerp returns the linear interpolation between this vector (t=0) and the
+// argument (t=1).
+func (v1 Vec3Q24) Lerp(v2 Vec3Q24, t Q24) Vec3Q24 {
+	return Vec3Q24{Lerp(v1.X, v2.X, t), Lerp(v1.Y, v2.Y, t), Lerp(v1.Z, v2.Z, t)}
+}
+
+// Clamp returns this vector with each component limited to the range given by
+// the corresponding components of min and max.
+func (v Vec3Q24) Clamp(min, max Vec3Q24) Vec3Q24 {
+	return Vec3Q24{v.X.Clamp(min.X, max.X), v.Y.Clamp(min.Y, max.Y), v.Z.Clamp(min.Z, max.Z)}
+}
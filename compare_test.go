@@ -0,0 +1,44 @@
+package fixpoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	a, b := Q24FromFloat(-1.5), Q24FromFloat(0.25)
+	assert.Equal(t, Q24FromFloat(1.5), a.Abs())
+	assert.Equal(t, b, b.Abs())
+	assert.Equal(t, a, a.Min(b))
+	assert.Equal(t, a, b.Min(a))
+	assert.Equal(t, b, a.Max(b))
+	assert.Equal(t, b, b.Max(a))
+	assert.Equal(t, -1, a.Cmp(b))
+	assert.Equal(t, 1, b.Cmp(a))
+	assert.Equal(t, 0, a.Cmp(a))
+	assert.True(t, a.Lt(b))
+	assert.False(t, b.Lt(a))
+	assert.True(t, b.Gt(a))
+	assert.False(t, a.Gt(a))
+
+	one := Q24FromInt32(1)
+	assert.Equal(t, one.Neg(), a.Clamp(one.Neg(), one))
+	assert.Equal(t, b, b.Clamp(one.Neg(), one))
+	assert.Equal(t, Q24FromFloat(0.125), b.Clamp(Q24{}, Q24FromFloat(0.125)))
+}
+
+func TestLerp(t *testing.T) {
+	a, b := Q24FromInt32(-2), Q24FromInt32(6)
+	assert.Equal(t, a, Lerp(a, b, Q24{}))
+	assert.Equal(t, b, Lerp(a, b, Q24FromInt32(1)))
+	assert.Equal(t, Q24FromInt32(0), Lerp(a, b, Q24FromFloat(0.25)))
+	assert.Equal(t, Q24FromInt32(10), Lerp(a, b, Q24FromFloat(1.5)))
+	assert.Equal(t, Q24{2}, Lerp(Q24{0}, Q24{3}, Q24FromFloat(0.5))) // 1.5, rounded
+	assert.Equal(t, Q24{maxN}, Lerp(Q24{minN}, Q24{maxN}, Q24FromInt32(1)))
+
+	v1 := Vec3Q24FromFloat(0, 1, -4)
+	v2 := Vec3Q24FromFloat(2, 1, 4)
+	assert.Equal(t, Vec3Q24FromFloat(0.5, 1, -2), v1.Lerp(v2, Q24FromFloat(0.25)))
+	assert.Equal(t, Vec3Q24FromFloat(0.5, 1, 0), v2.Clamp(Vec3Q24FromFloat(-1, -1, -1), Vec3Q24FromFloat(0.5, 1, 0)))
+}
@@ -0,0 +1,139 @@
+package fixpoint
+
+// Compact encodings of unit quaternions and unit vectors, for example for
+// sending orientation data over a low-bandwidth radio link.
+
+// Scale factor for the smallest-three encoding: the three smallest elements of
+// a unit quaternion are in the range [-1/√2, 1/√2], which is mapped to the
+// range of an int16.
+const (
+	smallestThreeScale = 46340    // about √2 * 32767
+	smallestThreeRecip = 23726566 // 2^40 / smallestThreeScale
+)
+
+// Compress encodes this unit quaternion in the smallest-three format: the
+// element with the largest magnitude is dropped and its index (0 for W, 1-3 for
+// X-Z) is returned, while the other three elements are returned as int16
+// values. Together they fit in 7 bytes. The maximum error of each element after
+// decompression is about 2^-15.
+func (q QuatQ24) Compress() (c [3]int16, index uint8) {
+	elements := [4]int32{q.W.N, q.V.X.N, q.V.Y.N, q.V.Z.N}
+	for i := uint8(1); i < 4; i++ {
+		if abs32(elements[i]) > abs32(elements[index]) {
+			index = i
+		}
+	}
+	// q and -q represent the same rotation, so make sure the dropped element
+	// is positive. That way, the sign doesn't need to be stored.
+	negate := elements[index] < 0
+	j := 0
+	for i, n := range elements {
+		if uint8(i) == index {
+			continue
+		}
+		if negate {
+			n = -n
+		}
+		v := (int64(n)*smallestThreeScale + 1<<23) >> 24
+		if v > 32767 {
+			v = 32767
+		} else if v < -32767 {
+			v = -32767
+		}
+		c[j] = int16(v)
+		j++
+	}
+	return
+}
+
+// QuatDecompress decodes a quaternion in the smallest-three format, see
+// QuatQ24.Compress. Only the lowest two bits of the index are used, so
+// corrupted data doesn't cause a panic.
+func QuatDecompress(c [3]int16, index uint8) QuatQ24 {
+	index &= 3
+	var elements [4]int32
+	var sum uint64
+	j := 0
+	for i := range elements {
+		if uint8(i) == index {
+			continue
+		}
+		n := int32((int64(c[j])*smallestThreeRecip + 1<<15) >> 16)
+		elements[i] = n
+		sum += uint64(int64(n) * int64(n))
+		j++
+	}
+	// The dropped element follows from the fact that this is a unit
+	// quaternion: it is sqrt(1 - a² - b² - c²).
+	if sum < 1<<48 {
+		elements[index] = int32(sqrt64(1<<48 - sum))
+	}
+	return QuatQ24{Q24{elements[0]}, Vec3Q24{Q24{elements[1]}, Q24{elements[2]}, Q24{elements[3]}}}
+}
+
+// Compress encodes this unit vector using the octahedral encoding: the vector
+// is projected on an octahedron, which is then unfolded into a square. The
+// result is two int16 values (4 bytes). The vector doesn't need to be
+// normalized, but its direction is all that is preserved. The angular error
+// after decompression is below 0.00007 radians (0.004°).
+//
+// Useful link:
+// https://knarkowicz.wordpress.com/2014/04/16/octahedron-normal-vector-encoding/
+func (v Vec3Q24) Compress() [2]int16 {
+	// Project on the octahedron |x| + |y| + |z| = 1.
+	l1 := int64(abs32(v.X.N)) + int64(abs32(v.Y.N)) + int64(abs32(v.Z.N))
+	if l1 == 0 {
+		return [2]int16{}
+	}
+	x := (int64(v.X.N) << 24) / l1
+	y := (int64(v.Y.N) << 24) / l1
+	if v.Z.N < 0 {
+		// Fold the lower half of the octahedron over the upper half.
+		x, y = octFold(x, y)
+	}
+	return [2]int16{octQuantize(x), octQuantize(y)}
+}
+
+// Vec3Decompress decodes a unit vector in the octahedral encoding, see
+// Vec3Q24.Compress. The returned vector is normalized.
+func Vec3Decompress(c [2]int16) Vec3Q24 {
+	x := octDequantize(c[0])
+	y := octDequantize(c[1])
+	z := 1<<24 - abs64(x) - abs64(y)
+	if z < 0 {
+		x, y = octFold(x, y)
+	}
+	return Vec3Q24{Q24{int32(x)}, Q24{int32(y)}, Q24{int32(z)}}.Normalize()
+}
+
+// octFold maps a point in one half of the unfolded octahedron to the other
+// half. Both x and y are in Q24 format.
+func octFold(x, y int64) (int64, int64) {
+	fx := 1<<24 - abs64(y)
+	fy := 1<<24 - abs64(x)
+	if x < 0 {
+		fx = -fx
+	}
+	if y < 0 {
+		fy = -fy
+	}
+	return fx, fy
+}
+
+// octQuantize converts a Q24 number in the range [-1, 1] to an int16, rounding
+// to the nearest value.
+func octQuantize(n int64) int16 {
+	if n < 0 {
+		return int16(-((-n*32767 + 1<<23) >> 24))
+	}
+	return int16((n*32767 + 1<<23) >> 24)
+}
+
+// octDequantize is the inverse of octQuantize. The value -32768, which
+// octQuantize never returns, is decoded as slightly below -1.
+func octDequantize(n int16) int64 {
+	if n < 0 {
+		return -((-int64(n)<<24 + 32767/2) / 32767)
+	}
+	return (int64(n)<<24 + 32767/2) / 32767
+}
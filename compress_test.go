@@ -0,0 +1,67 @@
+package fixpoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuatCompress(t *testing.T) {
+	for _, q := range []QuatQ24{
+		QuatIdent(),
+		QuatIdent().neg(),
+		QuatFromAxisAngle(Vec3Q24FromFloat(1, 2, 3), Q24FromFloat(0.7)),
+		QuatFromAxisAngle(Vec3Q24FromFloat(-1, 0.2, 0), Q24FromFloat(2.5)),
+		QuatFromAxisAngle(Vec3Q24FromFloat(0, 0, 1), Q24FromFloat(-3)),
+		QuatFromEuler(Q24FromFloat(0.1), Q24FromFloat(-1.2), Q24FromFloat(3)),
+	} {
+		c, index := q.Compress()
+		q2 := QuatDecompress(c, index)
+		if q.Dot(q2).N < 0 {
+			// The sign may be flipped, which is the same rotation.
+			q2 = q2.neg()
+		}
+		const delta = 1.0 / (1 << 15)
+		assert.InDelta(t, q.W.Float(), q2.W.Float(), delta, "W of %v", q)
+		assert.InDelta(t, q.V.X.Float(), q2.V.X.Float(), delta, "X of %v", q)
+		assert.InDelta(t, q.V.Y.Float(), q2.V.Y.Float(), delta, "Y of %v", q)
+		assert.InDelta(t, q.V.Z.Float(), q2.V.Z.Float(), delta, "Z of %v", q)
+	}
+
+	// Invalid indices, for example from a corrupted message, only use the
+	// lowest two bits.
+	c, index := QuatFromAxisAngle(Vec3Q24FromFloat(1, 2, 3), Q24FromFloat(0.7)).Compress()
+	assert.Equal(t, QuatDecompress(c, index), QuatDecompress(c, index|4))
+	assert.Equal(t, QuatDecompress(c, index), QuatDecompress(c, index|0xfc))
+}
+
+func TestVec3Compress(t *testing.T) {
+	for _, v := range []Vec3Q24{
+		Vec3Q24FromFloat(0, 0, 1),
+		Vec3Q24FromFloat(0, 0, -1),
+		Vec3Q24FromFloat(1, 0, 0),
+		Vec3Q24FromFloat(0, -1, 0),
+		Vec3Q24FromFloat(1, 2, 3),
+		Vec3Q24FromFloat(-1, 2, -3),
+		Vec3Q24FromFloat(0.3, -0.3, -0.01),
+		Vec3Q24FromFloat(-5, -4, -0.5),
+		Vec3Q24FromFloat(0.001, 0.002, -0.0001),
+	} {
+		v2 := Vec3Decompress(v.Compress())
+		assert.InDelta(t, 1, v2.Len().Float(), 0.000001, "length of %v", v)
+		// Angle between the original and the decompressed vector.
+		angle := Atan2(v.Normalize().Cross(v2).Len(), v.Normalize().Dot(v2))
+		assert.True(t, angle.Float() < 0.00007, "angle too big for %v: %f", v, angle.Float())
+	}
+	assert.Equal(t, [2]int16{}, Vec3Q24{}.Compress())
+
+	// The extreme values decode with the right sign.
+	assert.Equal(t, int64(-1<<24), octDequantize(-32767))
+	assert.True(t, octDequantize(-32768) < -1<<24)
+	v := Vec3Decompress([2]int16{-32768, 0})
+	assert.InDelta(t, -1, v.X.Float(), 0.0001)
+	assert.InDelta(t, 0, v.Y.Float(), 0.0001)
+	assert.InDelta(t, 0, v.Z.Float(), 0.0001)
+	v = Vec3Decompress([2]int16{0, -32768})
+	assert.InDelta(t, -1, v.Y.Float(), 0.0001)
+}
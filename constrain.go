@@ -0,0 +1,69 @@
+package fixpoint
+
+// Constraints on rotations, for joints with limited freedom such as a hinge
+// or a wrist that can only turn so far. They use the swing-twist
+// decomposition: any rotation is a twist around a given axis followed by a
+// swing around an axis perpendicular to it.
+
+// SwingTwist decomposes this unit quaternion into a twist around the given
+// axis and the remaining swing, so that q = swing·twist: the twist is applied
+// first. The axis does not need to be normalized. When the rotation is a
+// half turn around an axis perpendicular to the given axis, the twist is
+// undefined and the identity is returned as twist.
+func (q QuatQ24) SwingTwist(axis Vec3Q24) (swing, twist QuatQ24) {
+	twist = q.twist(axis.Normalize())
+	return q.Mul(twist.Conjugate()).Normalize(), twist
+}
+
+// twist returns the twist part of this quaternion around the normalized axis,
+// with a non-negative W.
+func (q QuatQ24) twist(axis Vec3Q24) QuatQ24 {
+	// The twist is the projection of the vector part onto the axis.
+	twist := QuatQ24{q.W, axis.Mul(q.V.Dot(axis))}
+	if twist.W.N < 0 {
+		twist = twist.neg()
+	}
+	if twist.len2Q48() == 0 {
+		return QuatIdent()
+	}
+	return twist.Normalize()
+}
+
+// ConstrainToAxis returns the part of this unit quaternion that rotates
+// around the given axis (the twist), dropping all other rotation. This
+// constrains an orientation to a hinge joint. The axis does not need to be
+// normalized.
+func (q QuatQ24) ConstrainToAxis(axis Vec3Q24) QuatQ24 {
+	return q.twist(axis.Normalize())
+}
+
+// ConstrainTwistRange limits the rotation around the given axis (the twist)
+// of this unit quaternion to the range [min, max] in radians, and keeps the
+// rest of the rotation (the swing) as it is. The range must be within
+// [-π, π], with positive angles rotating counterclockwise around the axis.
+// A twist outside the range is moved to the nearest end of the range, going
+// around the circle. The axis does not need to be normalized.
+func (q QuatQ24) ConstrainTwistRange(axis Vec3Q24, min, max Q24) QuatQ24 {
+	axis = axis.Normalize()
+	twist := q.twist(axis)
+	angle := Q24{Atan2(twist.V.Dot(axis), twist.W).N << 1}
+	if angle.N >= min.N && angle.N <= max.N {
+		return q
+	}
+	limit := min
+	if angleDistance(angle, max) < angleDistance(angle, min) {
+		limit = max
+	}
+	swing := q.Mul(twist.Conjugate())
+	return swing.Mul(QuatFromAxisAngle(axis, limit)).Normalize()
+}
+
+// angleDistance returns the distance between two angles in the range [-π, π]
+// around the circle, in Q24 format.
+func angleDistance(a, b Q24) int64 {
+	d := abs64(int64(a.N) - int64(b.N))
+	if d > int64(Pi.N) {
+		d = int64(TwoPi.N) - d
+	}
+	return d
+}
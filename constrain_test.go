@@ -0,0 +1,69 @@
+package fixpoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// assertQuatNear checks that two quaternions represent about the same
+// rotation.
+func assertQuatNear(t *testing.T, expected, actual QuatQ24, msgAndArgs ...interface{}) {
+	t.Helper()
+	if expected.Dot(actual).N < 0 {
+		actual = actual.neg()
+	}
+	const delta = 1e-5
+	assert.InDelta(t, expected.W.Float64(), actual.W.Float64(), delta, msgAndArgs...)
+	assert.InDelta(t, expected.V.X.Float64(), actual.V.X.Float64(), delta, msgAndArgs...)
+	assert.InDelta(t, expected.V.Y.Float64(), actual.V.Y.Float64(), delta, msgAndArgs...)
+	assert.InDelta(t, expected.V.Z.Float64(), actual.V.Z.Float64(), delta, msgAndArgs...)
+}
+
+func TestSwingTwist(t *testing.T) {
+	z := Vec3Q24FromFloat(0, 0, 2) // doesn't need to be normalized
+	twist := QuatFromAxisAngle(Vec3Q24FromFloat(0, 0, 1), Q24FromFloat(0.8))
+	swing := QuatFromAxisAngle(Vec3Q24FromFloat(1, 1, 0), Q24FromFloat(-0.5))
+	q := swing.Mul(twist)
+
+	s, tw := q.SwingTwist(z)
+	assertQuatNear(t, swing, s)
+	assertQuatNear(t, twist, tw)
+	assertQuatNear(t, q, s.Mul(tw))
+
+	// A hinge keeps only the twist.
+	assertQuatNear(t, twist, q.ConstrainToAxis(z))
+	assertQuatNear(t, QuatIdent(), swing.ConstrainToAxis(z))
+
+	// A half turn perpendicular to the axis has no defined twist.
+	flip := QuatFromAxisAngle(Vec3Q24FromFloat(1, 0, 0), Pi)
+	s, tw = flip.SwingTwist(z)
+	assert.Equal(t, QuatIdent(), tw)
+	assertQuatNear(t, flip, s)
+}
+
+func TestConstrainTwistRange(t *testing.T) {
+	z := Vec3Q24FromFloat(0, 0, 1)
+	swing := QuatFromAxisAngle(Vec3Q24FromFloat(0, 1, 0), Q24FromFloat(0.3))
+	min, max := Q24FromFloat(-0.5), Q24FromFloat(1)
+	for _, tc := range []struct {
+		twist, expected float64
+	}{
+		{0, 0},
+		{0.9, 0.9},
+		{-0.5, -0.5},
+		{1.5, 1},
+		{-1, -0.5},
+		{3, 1}, // closer to 1 than to -0.5 around the circle
+		{-2.5, -0.5},
+		{-2, -0.5},
+	} {
+		q := swing.Mul(QuatFromAxisAngle(z, Q24FromFloat64(tc.twist)))
+		expected := swing.Mul(QuatFromAxisAngle(z, Q24FromFloat64(tc.expected)))
+		assertQuatNear(t, expected, q.ConstrainTwistRange(z, min, max), "twist=%v", tc.twist)
+	}
+
+	// A twist close to π can be nearest to a limit close to -π.
+	q := QuatFromAxisAngle(z, Q24FromFloat(3.0))
+	assertQuatNear(t, QuatFromAxisAngle(z, Q24FromFloat(-2.5)), q.ConstrainTwistRange(z, Q24FromFloat(-2.5), Q24FromFloat(-1)))
+}
@@ -0,0 +1,42 @@
+// Package control implements building blocks for control loops and actuators,
+// using fixed point arithmetic.
+package control
+
+import (
+	"github.com/aykevl/fixpoint"
+)
+
+// FractionAccumulator converts a fractional rate into a whole number of steps
+// per tick, without any long-term error: the fractional part that couldn't be
+// output in one tick is carried over to the next. This is the same principle
+// as Bresenham's line algorithm and first-order delta-sigma modulation. It can
+// be used for stepper motor pulse generation or to dither the duty cycle of a
+// PWM output.
+//
+// The zero value is ready to use, with a rate of zero.
+type FractionAccumulator struct {
+	// Rate is the number of steps per tick. It may be negative and larger than
+	// one.
+	Rate fixpoint.Q24
+
+	residual int32 // fraction in the range [0, 1) in Q24 format
+}
+
+// Tick advances the accumulator by one tick and returns the number of steps
+// that should be output for this tick.
+func (a *FractionAccumulator) Tick() int32 {
+	sum := int64(a.residual) + int64(a.Rate.N)
+	a.residual = int32(sum & (1<<24 - 1))
+	return int32(sum >> 24)
+}
+
+// Residual returns the fractional step that has been accumulated but not yet
+// output, in the range [0, 1).
+func (a *FractionAccumulator) Residual() fixpoint.Q24 {
+	return fixpoint.Q24{N: a.residual}
+}
+
+// Reset clears the accumulated fraction.
+func (a *FractionAccumulator) Reset() {
+	a.residual = 0
+}
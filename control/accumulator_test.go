@@ -0,0 +1,39 @@
+package control
+
+import (
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFractionAccumulator(t *testing.T) {
+	var a FractionAccumulator
+	a.Rate = fixpoint.Q24FromFloat(0.25)
+	var steps []int32
+	for i := 0; i < 8; i++ {
+		steps = append(steps, a.Tick())
+	}
+	assert.Equal(t, []int32{0, 0, 0, 1, 0, 0, 0, 1}, steps)
+
+	// No long-term error, for all kinds of rates.
+	for _, rate := range []fixpoint.Q24{
+		fixpoint.Q24FromFloat(0.3),
+		fixpoint.Q24FromFloat(-0.7),
+		fixpoint.Q24FromFloat(2.71),
+		{N: 1},
+		fixpoint.Q24FromFloat(-5.5),
+	} {
+		a := FractionAccumulator{Rate: rate}
+		total := int64(0)
+		const ticks = 10000
+		for i := 0; i < ticks; i++ {
+			total += int64(a.Tick())
+		}
+		// total + residual must be exactly ticks * rate.
+		assert.Equal(t, int64(rate.N)*ticks, total<<24+int64(a.Residual().N), "rate %f", rate.Float())
+	}
+
+	a.Reset()
+	assert.Equal(t, fixpoint.Q24{}, a.Residual())
+}
@@ -0,0 +1,70 @@
+package control
+
+import (
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/fixmath"
+)
+
+// BackEMF estimates the speed of a brushed DC motor from its back-EMF, the
+// voltage the motor generates while it turns, which is proportional to its
+// speed. This allows a speed display or a rough speed control loop without an
+// encoder. The back-EMF can be sampled directly on the motor terminals while
+// the PWM output is off (after the current has decayed), or calculated from
+// the terminal voltage and current while it is driven.
+//
+// Voltages are in volts, currents in amperes and speeds in RPM.
+//
+// The zero value always estimates zero: KV must be set.
+type BackEMF struct {
+	// KV is the speed constant of the motor in RPM per volt. For motors that
+	// specify the back-EMF constant instead, see KVFromKE.
+	KV fixpoint.Q16
+
+	// Resistance is the winding resistance of the motor in ohms, used to
+	// subtract the voltage drop over the windings while current flows.
+	Resistance fixpoint.Q24
+
+	// Alpha is the smoothing factor of the low-pass filter on the speed in
+	// the range (0, 1]. The back-EMF is noisy because of the commutator, so
+	// a value like 0.1 is typical. Zero disables the filter, like 1.
+	Alpha fixpoint.Q24
+
+	initialized bool
+	rpm         int64 // Q40
+}
+
+// Update updates the estimate with the voltage over the motor terminals and
+// the current through the motor, and returns the estimated speed. Pass a zero
+// current when sampling the back-EMF while the motor isn't driven. The speed
+// is negative when the motor turns backwards.
+func (e *BackEMF) Update(voltage, current fixpoint.Q24) fixpoint.Q16 {
+	emf := int64(voltage.N) - (int64(current.N)*int64(e.Resistance.N)+1<<23)>>24
+	rpm := emf * int64(e.KV.N)
+	if !e.initialized || e.Alpha.N <= 0 || e.Alpha.N >= 1<<24 {
+		e.rpm = rpm
+		e.initialized = true
+	} else {
+		e.rpm += fixmath.MulQ24(rpm-e.rpm, int64(e.Alpha.N))
+	}
+	return e.RPM()
+}
+
+// RPM returns the current speed estimate, saturated to the range of a Q16.
+func (e *BackEMF) RPM() fixpoint.Q16 {
+	return fixpoint.Q16{N: int32(fixmath.Clamp((e.rpm+1<<23)>>24, -1<<31, 1<<31-1))}
+}
+
+// Reset restarts the estimate at the next update.
+func (e *BackEMF) Reset() {
+	e.rpm = 0
+	e.initialized = false
+}
+
+// KVFromKE returns the speed constant in RPM per volt of a motor with a
+// back-EMF constant of ke volts per 1000 RPM, the unit most datasheets use.
+// The back-EMF constant must be positive.
+func KVFromKE(ke fixpoint.Q24) fixpoint.Q16 {
+	// 1000/ke in Q16 is 1000<<40/ke.N, rounded to the nearest value.
+	n := (int64(1000)<<40 + int64(ke.N)/2) / int64(ke.N)
+	return fixpoint.Q16{N: int32(fixmath.Clamp(n, -1<<31, 1<<31-1))}
+}
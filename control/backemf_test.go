@@ -0,0 +1,50 @@
+package control
+
+import (
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackEMF(t *testing.T) {
+	// A 12V motor with a back-EMF constant of 0.5V/krpm and a winding
+	// resistance of 2Ω.
+	e := BackEMF{KV: KVFromKE(fixpoint.Q24FromFloat(0.5)), Resistance: fixpoint.Q24FromInt32(2)}
+	assert.Equal(t, fixpoint.Q16FromInt32(2000), e.KV)
+	assert.InDelta(t, 1250, KVFromKE(fixpoint.Q24FromFloat(0.8)).Float(), 0.001)
+	assert.Equal(t, fixpoint.Q16{}, e.RPM())
+
+	// Sampled while the motor isn't driven.
+	assert.Equal(t, fixpoint.Q16FromInt32(16000), e.Update(fixpoint.Q24FromInt32(8), fixpoint.Q24{}))
+
+	// Driven at 12V with 1.5A: the back-EMF is 9V.
+	assert.Equal(t, fixpoint.Q16FromInt32(18000), e.Update(fixpoint.Q24FromInt32(12), fixpoint.Q24FromFloat(1.5)))
+
+	// Turning backwards.
+	assert.Equal(t, fixpoint.Q16FromInt32(-8000), e.Update(fixpoint.Q24FromInt32(-4), fixpoint.Q24{}))
+
+	// Saturates instead of wrapping around.
+	e.KV = fixpoint.Q16FromInt32(30000)
+	assert.Equal(t, fixpoint.Q16{N: 1<<31 - 1}, e.Update(fixpoint.Q24FromInt32(100), fixpoint.Q24{}))
+}
+
+func TestBackEMFFilter(t *testing.T) {
+	e := BackEMF{KV: fixpoint.Q16FromInt32(1000), Alpha: fixpoint.Q24FromFloat(0.25)}
+
+	// The first update starts the filter, after that it converges
+	// exponentially while commutator noise averages out.
+	assert.Equal(t, fixpoint.Q16FromInt32(2000), e.Update(fixpoint.Q24FromInt32(2), fixpoint.Q24{}))
+	assert.Equal(t, fixpoint.Q16FromInt32(2250), e.Update(fixpoint.Q24FromInt32(3), fixpoint.Q24{}))
+	for i := 0; i < 200; i++ {
+		noise := fixpoint.Q24FromFloat(0.2)
+		if i%2 == 0 {
+			noise = noise.Neg()
+		}
+		e.Update(fixpoint.Q24FromInt32(3).Add(noise), fixpoint.Q24{})
+	}
+	assert.InDelta(t, 3000, e.RPM().Float(), 30)
+
+	e.Reset()
+	assert.Equal(t, fixpoint.Q16FromInt32(1000), e.Update(fixpoint.Q24FromInt32(1), fixpoint.Q24{}))
+}
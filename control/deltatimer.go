@@ -0,0 +1,67 @@
+package control
+
+import (
+	"github.com/aykevl/fixpoint"
+)
+
+// DeltaTimer converts a monotonically increasing tick counter, such as a
+// microsecond or millisecond timer, into the time elapsed between calls in
+// seconds. This is typically used as the dt input of filters and control
+// loops. Wraparound of the counter is handled transparently, as long as calls
+// are less than one counter period apart.
+//
+// The zero value is not usable: at least TicksPerSecond must be set.
+type DeltaTimer struct {
+	// TicksPerSecond is the frequency of the counter, for example 1000000 for
+	// a microsecond counter.
+	TicksPerSecond uint32
+
+	// Bits is the width of the counter in bits, for hardware timers that wrap
+	// around earlier than 32 bits. Zero means 32 bits.
+	Bits uint
+
+	last    uint32
+	started bool
+}
+
+// Update returns the time in seconds since the previous call to Update, given
+// the current value of the counter. The first call (and the first call after
+// Reset) returns zero. The result is rounded to the nearest representable
+// value and saturates at the largest Q24 value (almost 128 seconds).
+func (t *DeltaTimer) Update(now uint32) fixpoint.Q24 {
+	if !t.started {
+		t.last = now
+		t.started = true
+		return fixpoint.Q24{}
+	}
+	elapsed := t.Ticks(t.last, now)
+	t.last = now
+	return t.Seconds(elapsed)
+}
+
+// Ticks returns the number of ticks from start to end, taking wraparound of
+// the counter into account.
+func (t *DeltaTimer) Ticks(start, end uint32) uint32 {
+	elapsed := end - start
+	if t.Bits != 0 && t.Bits < 32 {
+		elapsed &= 1<<t.Bits - 1
+	}
+	return elapsed
+}
+
+// Seconds converts a number of ticks to seconds, rounded to the nearest
+// representable value. The result saturates at the largest Q24 value.
+func (t *DeltaTimer) Seconds(ticks uint32) fixpoint.Q24 {
+	tps := uint64(t.TicksPerSecond)
+	seconds := (uint64(ticks)<<24 + tps/2) / tps
+	if seconds > 1<<31-1 {
+		return fixpoint.Q24{N: 1<<31 - 1}
+	}
+	return fixpoint.Q24{N: int32(seconds)}
+}
+
+// Reset forgets the previous counter value, so that the next call to Update
+// returns zero.
+func (t *DeltaTimer) Reset() {
+	t.started = false
+}
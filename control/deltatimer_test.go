@@ -0,0 +1,34 @@
+package control
+
+import (
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeltaTimer(t *testing.T) {
+	timer := DeltaTimer{TicksPerSecond: 1000000}
+	assert.Equal(t, fixpoint.Q24{}, timer.Update(5000))
+	assert.Equal(t, fixpoint.Q24FromFloat(0.5), timer.Update(505000))
+	assert.InDelta(t, 0.001, timer.Update(506000).Float(), 1.0/(1<<24))
+
+	// Wraparound of a 32-bit counter.
+	timer.Reset()
+	timer.Update(0xffffff00)
+	assert.Equal(t, timer.Seconds(0x200), timer.Update(0x100))
+
+	// Wraparound of a 16-bit millisecond counter.
+	timer = DeltaTimer{TicksPerSecond: 1000, Bits: 16}
+	timer.Update(65000)
+	assert.Equal(t, fixpoint.Q24FromInt32(2), timer.Update(1464))
+
+	// Saturation.
+	assert.Equal(t, fixpoint.Q24{N: 1<<31 - 1}, timer.Seconds(200000))
+	assert.Equal(t, fixpoint.Q24{}, timer.Seconds(0))
+
+	// Rounding: 1/3 second.
+	timer = DeltaTimer{TicksPerSecond: 3}
+	assert.Equal(t, fixpoint.Q24{N: 5592405}, timer.Seconds(1))
+	assert.Equal(t, fixpoint.Q24{N: 11184811}, timer.Seconds(2))
+}
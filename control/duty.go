@@ -0,0 +1,46 @@
+package control
+
+import (
+	"github.com/aykevl/fixpoint"
+)
+
+// DutyQuantizer converts a duty cycle to a timer compare value of limited
+// resolution. The quantization error of each period is carried over to the
+// next (error-feedback dithering), so that the average duty cycle over many
+// periods is much more accurate than the timer resolution allows. This gives
+// smoother dimming of LEDs and smoother control of motors driven by timers
+// with only 8 or 10 bits of resolution.
+//
+// The zero value is not usable: Bits must be set.
+type DutyQuantizer struct {
+	// Bits is the resolution of the timer in bits, at most 31. The compare
+	// values are in the range [0, 2^Bits-1], where 2^Bits-1 means fully on.
+	Bits uint
+
+	err int64 // quantization error in Q24
+}
+
+// Quantize returns the compare value for the next period given the desired
+// duty cycle. Duty cycles outside of the range [0, 1] are clamped.
+func (d *DutyQuantizer) Quantize(duty fixpoint.Q24) uint32 {
+	max := int64(1)<<d.Bits - 1
+	if duty.N < 0 {
+		duty.N = 0
+	} else if duty.N > 1<<24 {
+		duty.N = 1 << 24
+	}
+	exact := int64(duty.N)*max + d.err
+	value := (exact + 1<<23) >> 24
+	if value < 0 {
+		value = 0
+	} else if value > max {
+		value = max
+	}
+	d.err = exact - value<<24
+	return uint32(value)
+}
+
+// Reset clears the accumulated quantization error.
+func (d *DutyQuantizer) Reset() {
+	d.err = 0
+}
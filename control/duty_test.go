@@ -0,0 +1,37 @@
+package control
+
+import (
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDutyQuantizer(t *testing.T) {
+	d := DutyQuantizer{Bits: 8}
+	assert.Equal(t, uint32(0), d.Quantize(fixpoint.Q24{}))
+	assert.Equal(t, uint32(255), d.Quantize(fixpoint.Q24FromInt32(1)))
+	assert.Equal(t, uint32(255), d.Quantize(fixpoint.Q24FromInt32(2)))
+	assert.Equal(t, uint32(0), d.Quantize(fixpoint.Q24FromInt32(-1)))
+
+	// A duty cycle between two steps alternates between them, with the right
+	// average.
+	d.Reset()
+	duty := fixpoint.Q24FromFloat(100.25 / 255)
+	sum := uint32(0)
+	const periods = 1000
+	for i := 0; i < periods; i++ {
+		value := d.Quantize(duty)
+		assert.True(t, value == 100 || value == 101, "value %d", value)
+		sum += value
+	}
+	assert.InDelta(t, 100.25, float64(sum)/periods, 0.01)
+
+	// Very small duty cycles still produce some output.
+	d = DutyQuantizer{Bits: 4}
+	sum = 0
+	for i := 0; i < 1500; i++ {
+		sum += d.Quantize(fixpoint.Q24FromFloat(0.001))
+	}
+	assert.InDelta(t, 0.001*15*1500, sum, 1)
+}
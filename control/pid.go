@@ -0,0 +1,89 @@
+package control
+
+import (
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/fixmath"
+)
+
+// PID is a proportional-integral-derivative controller.
+//
+// The integral term accumulates Ki*error*dt (rather than just error*dt), so
+// that Ki can be changed while the controller is running without a jump in
+// the output. It is kept with 16 extra bits of precision, so that small errors
+// still accumulate. The derivative term is calculated on the measurement
+// instead of the error, to avoid a spike in the output when the setpoint
+// changes. All intermediate calculations use 64-bit integers, so the terms
+// don't overflow.
+//
+// The zero value is a controller with all gains set to zero.
+type PID struct {
+	// Kp, Ki and Kd are the proportional, integral and derivative gains. The
+	// integral and derivative gains are per second.
+	Kp, Ki, Kd fixpoint.Q24
+
+	// OutputMin and OutputMax limit the output of the controller. If both are
+	// zero, the output is only limited by the Q24 range. While the output is
+	// limited, the integral term stops growing in that direction
+	// (anti-windup).
+	OutputMin, OutputMax fixpoint.Q24
+
+	// IntegralLimit is the maximum absolute value of the integral term. Zero
+	// means no limit.
+	IntegralLimit fixpoint.Q24
+
+	integral        int64 // Q40
+	prevMeasurement int32
+	started         bool
+}
+
+// Update returns the new controller output given the setpoint, the current
+// measurement and the time in seconds since the previous update.
+func (c *PID) Update(setpoint, measurement, dt fixpoint.Q24) fixpoint.Q24 {
+	e := int64(setpoint.N) - int64(measurement.N)
+	p := (int64(c.Kp.N)*e + 1<<23) >> 24
+
+	// Integral term, in Q40.
+	ke := (int64(c.Ki.N)*fixmath.Clamp(e, -1<<31, 1<<31-1) + 1<<7) >> 8
+	integral := c.integral + fixmath.MulQ24(ke, int64(dt.N))
+	if c.IntegralLimit.N > 0 {
+		limit := int64(c.IntegralLimit.N) << 16
+		integral = fixmath.Clamp(integral, -limit, limit)
+	}
+
+	// Derivative term, on the measurement.
+	var d int64
+	if c.started && dt.N > 0 {
+		diff := int64(measurement.N) - int64(c.prevMeasurement)
+		rate := fixmath.Clamp((diff<<24)/int64(dt.N), -1<<31, 1<<31-1)
+		d = -((int64(c.Kd.N)*rate + 1<<23) >> 24)
+	}
+	c.prevMeasurement = measurement.N
+	c.started = true
+
+	min, max := int64(-1<<31), int64(1<<31-1)
+	if c.OutputMin.N != 0 || c.OutputMax.N != 0 {
+		min, max = int64(c.OutputMin.N), int64(c.OutputMax.N)
+	}
+	output := p + (integral+1<<15)>>16 + d
+
+	// Anti-windup: don't let the integral term grow further while the output
+	// is saturated in the same direction.
+	if (output > max && integral > c.integral) || (output < min && integral < c.integral) {
+		integral = c.integral
+		output = p + (integral+1<<15)>>16 + d
+	}
+	c.integral = integral
+	return fixpoint.Q24{N: int32(fixmath.Clamp(output, min, max))}
+}
+
+// Integral returns the current value of the integral term.
+func (c *PID) Integral() fixpoint.Q24 {
+	return fixpoint.Q24{N: int32(fixmath.Clamp((c.integral+1<<15)>>16, -1<<31, 1<<31-1))}
+}
+
+// Reset clears the integral term and the previous measurement, for example
+// when the controller is enabled again after being disabled.
+func (c *PID) Reset() {
+	c.integral = 0
+	c.started = false
+}
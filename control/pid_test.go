@@ -0,0 +1,73 @@
+package control
+
+import (
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPID(t *testing.T) {
+	dt := fixpoint.Q24FromFloat(0.01)
+
+	// Proportional only.
+	c := PID{Kp: fixpoint.Q24FromInt32(2)}
+	assert.Equal(t, fixpoint.Q24FromInt32(3), c.Update(fixpoint.Q24FromFloat(2), fixpoint.Q24FromFloat(0.5), dt))
+
+	// Integral: a constant error of 0.5 with Ki=1 for one second.
+	c = PID{Ki: fixpoint.Q24FromInt32(1)}
+	var out fixpoint.Q24
+	for i := 0; i < 100; i++ {
+		out = c.Update(fixpoint.Q24FromFloat(0.5), fixpoint.Q24{}, dt)
+	}
+	assert.InDelta(t, 0.5, out.Float(), 1e-6)
+	assert.Equal(t, out, c.Integral())
+
+	// Small errors still accumulate.
+	c = PID{Ki: fixpoint.Q24FromFloat(0.001)}
+	for i := 0; i < 10000; i++ {
+		out = c.Update(fixpoint.Q24{N: 100}, fixpoint.Q24{}, dt)
+	}
+	assert.InDelta(t, 100*0.001*100, float64(out.N), 1)
+
+	// Derivative on measurement: no kick on the first update or when the
+	// setpoint changes.
+	c = PID{Kd: fixpoint.Q24FromFloat(0.5)}
+	assert.Equal(t, fixpoint.Q24{}, c.Update(fixpoint.Q24FromInt32(1), fixpoint.Q24{}, dt))
+	assert.Equal(t, fixpoint.Q24{}, c.Update(fixpoint.Q24FromInt32(5), fixpoint.Q24{}, dt))
+	// Measurement rising at 1/s.
+	assert.Equal(t, fixpoint.Q24FromFloat(-0.5), c.Update(fixpoint.Q24FromInt32(5), dt, dt))
+
+	// Output saturation and anti-windup.
+	c = PID{
+		Kp:        fixpoint.Q24FromInt32(1),
+		Ki:        fixpoint.Q24FromInt32(1),
+		OutputMin: fixpoint.Q24FromInt32(-1),
+		OutputMax: fixpoint.Q24FromInt32(1),
+	}
+	for i := 0; i < 1000; i++ {
+		out = c.Update(fixpoint.Q24FromInt32(10), fixpoint.Q24{}, dt)
+	}
+	assert.Equal(t, fixpoint.Q24FromInt32(1), out)
+	assert.Equal(t, fixpoint.Q24{}, c.Integral())
+	// Without windup, the output responds immediately when the error changes
+	// sign.
+	out = c.Update(fixpoint.Q24{}, fixpoint.Q24FromFloat(0.5), dt)
+	assert.InDelta(t, -0.505, out.Float(), 1e-6)
+
+	// Integral limit.
+	c = PID{Ki: fixpoint.Q24FromInt32(1), IntegralLimit: fixpoint.Q24FromFloat(0.25)}
+	for i := 0; i < 1000; i++ {
+		out = c.Update(fixpoint.Q24FromInt32(-1), fixpoint.Q24{}, dt)
+	}
+	assert.Equal(t, fixpoint.Q24FromFloat(-0.25), out)
+
+	c.Reset()
+	assert.Equal(t, fixpoint.Q24{}, c.Integral())
+
+	// Large values don't overflow.
+	c = PID{Kp: fixpoint.Q24FromInt32(100), Ki: fixpoint.Q24FromInt32(100), Kd: fixpoint.Q24FromInt32(100)}
+	c.Update(fixpoint.Q24FromInt32(100), fixpoint.Q24FromInt32(-100), fixpoint.Q24{N: 1})
+	out = c.Update(fixpoint.Q24FromInt32(100), fixpoint.Q24FromInt32(-100), fixpoint.Q24{N: 1})
+	assert.Equal(t, fixpoint.Q24{N: 1<<31 - 1}, out)
+}
@@ -0,0 +1,158 @@
+package control
+
+import (
+	"errors"
+
+	"github.com/aykevl/fixpoint/internal/le"
+)
+
+// Controller state
+//
+// All building blocks with internal state have a State method that returns it
+// (but not their configuration, such as gains) as a byte slice, and a Restore
+// method that sets it again, so that a control loop continues without a
+// transient after a deep sleep or a reset.
+
+var errInvalidState = errors.New("control: invalid state")
+
+// State returns the internal state of the controller.
+func (c *PID) State() []byte {
+	return c.AppendState(make([]byte, 0, 13))
+}
+
+// AppendState appends the state returned by State to dst and returns the
+// extended slice.
+func (c *PID) AppendState(dst []byte) []byte {
+	buf := le.AppendInt64(dst, c.integral)
+	buf = le.AppendInt32(buf, c.prevMeasurement)
+	return le.AppendBool(buf, c.started)
+}
+
+// Restore sets the internal state of the controller to a state returned by
+// State.
+func (c *PID) Restore(state []byte) error {
+	if len(state) != 13 {
+		return errInvalidState
+	}
+	c.integral = le.Int64(state)
+	c.prevMeasurement = le.Int32(state[8:])
+	c.started = state[12] != 0
+	return nil
+}
+
+// State returns the internal state of the accumulator.
+func (a *FractionAccumulator) State() []byte {
+	return a.AppendState(make([]byte, 0, 4))
+}
+
+// AppendState appends the state returned by State to dst and returns the
+// extended slice.
+func (a *FractionAccumulator) AppendState(dst []byte) []byte {
+	return le.AppendInt32(dst, a.residual)
+}
+
+// Restore sets the internal state of the accumulator to a state returned by
+// State.
+func (a *FractionAccumulator) Restore(state []byte) error {
+	if len(state) != 4 {
+		return errInvalidState
+	}
+	residual := le.Int32(state)
+	if residual < 0 || residual >= 1<<24 {
+		return errInvalidState
+	}
+	a.residual = residual
+	return nil
+}
+
+// State returns the internal state of the quantizer.
+func (d *DutyQuantizer) State() []byte {
+	return d.AppendState(make([]byte, 0, 8))
+}
+
+// AppendState appends the state returned by State to dst and returns the
+// extended slice.
+func (d *DutyQuantizer) AppendState(dst []byte) []byte {
+	return le.AppendInt64(dst, d.err)
+}
+
+// Restore sets the internal state of the quantizer to a state returned by
+// State.
+func (d *DutyQuantizer) Restore(state []byte) error {
+	if len(state) != 8 {
+		return errInvalidState
+	}
+	d.err = le.Int64(state)
+	return nil
+}
+
+// State returns the internal state of the rate limiter. Note that it contains
+// the tick count of the last update, so it should only be restored when the
+// tick counter continues to count (for example, a real-time clock).
+func (b *TokenBucket) State() []byte {
+	return b.AppendState(make([]byte, 0, 9))
+}
+
+// AppendState appends the state returned by State to dst and returns the
+// extended slice.
+func (b *TokenBucket) AppendState(dst []byte) []byte {
+	buf := le.AppendInt32(dst, b.tokens)
+	buf = le.AppendInt32(buf, int32(b.last))
+	return le.AppendBool(buf, b.started)
+}
+
+// Restore sets the internal state of the rate limiter to a state returned by
+// State.
+func (b *TokenBucket) Restore(state []byte) error {
+	if len(state) != 9 {
+		return errInvalidState
+	}
+	b.tokens = le.Int32(state)
+	b.last = uint32(le.Int32(state[4:]))
+	b.started = state[8] != 0
+	return nil
+}
+
+// State returns the internal state of the timer. Like for TokenBucket, this
+// is only useful when the counter continues to count.
+func (t *DeltaTimer) State() []byte {
+	return t.AppendState(make([]byte, 0, 5))
+}
+
+// AppendState appends the state returned by State to dst and returns the
+// extended slice.
+func (t *DeltaTimer) AppendState(dst []byte) []byte {
+	return le.AppendBool(le.AppendInt32(dst, int32(t.last)), t.started)
+}
+
+// Restore sets the internal state of the timer to a state returned by State.
+func (t *DeltaTimer) Restore(state []byte) error {
+	if len(state) != 5 {
+		return errInvalidState
+	}
+	t.last = uint32(le.Int32(state))
+	t.started = state[4] != 0
+	return nil
+}
+
+// State returns the internal state of the estimator.
+func (e *BackEMF) State() []byte {
+	return e.AppendState(make([]byte, 0, 9))
+}
+
+// AppendState appends the state returned by State to dst and returns the
+// extended slice.
+func (e *BackEMF) AppendState(dst []byte) []byte {
+	return le.AppendBool(le.AppendInt64(dst, e.rpm), e.initialized)
+}
+
+// Restore sets the internal state of the estimator to a state returned by
+// State.
+func (e *BackEMF) Restore(state []byte) error {
+	if len(state) != 9 {
+		return errInvalidState
+	}
+	e.rpm = le.Int64(state)
+	e.initialized = state[8] != 0
+	return nil
+}
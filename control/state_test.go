@@ -0,0 +1,83 @@
+package control
+
+import (
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/statetest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPIDState(t *testing.T) {
+	dt := fixpoint.Q24FromFloat(0.01)
+	pid := func() *PID {
+		return &PID{Kp: fixpoint.Q24FromFloat(2), Ki: fixpoint.Q24FromFloat(0.5), Kd: fixpoint.Q24FromFloat(0.1)}
+	}
+	c := pid()
+	for i := 0; i < 10; i++ {
+		c.Update(fixpoint.Q24FromInt32(1), fixpoint.Q24FromFloat(float32(i)*0.05), dt)
+	}
+	restored := pid()
+	assert.NoError(t, restored.Restore(c.State()))
+	assert.Equal(t, c.Integral(), restored.Integral())
+	for i := 10; i < 20; i++ {
+		m := fixpoint.Q24FromFloat(float32(i) * 0.05)
+		assert.Equal(t, c.Update(fixpoint.Q24FromInt32(1), m, dt), restored.Update(fixpoint.Q24FromInt32(1), m, dt))
+	}
+	assert.Error(t, restored.Restore(nil))
+}
+
+func TestState(t *testing.T) {
+	a := FractionAccumulator{Rate: fixpoint.Q24FromFloat(0.3)}
+	a.Tick()
+	a2 := FractionAccumulator{Rate: a.Rate}
+	assert.NoError(t, a2.Restore(a.State()))
+	assert.Equal(t, a.Residual(), a2.Residual())
+	assert.Error(t, a2.Restore([]byte{0, 0, 0, 1}))
+	assert.Error(t, a2.Restore(nil))
+
+	d := DutyQuantizer{Bits: 4}
+	d.Quantize(fixpoint.Q24FromFloat(0.33))
+	d2 := DutyQuantizer{Bits: 4}
+	assert.NoError(t, d2.Restore(d.State()))
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, d.Quantize(fixpoint.Q24FromFloat(0.33)), d2.Quantize(fixpoint.Q24FromFloat(0.33)))
+	}
+	assert.Error(t, d2.Restore(nil))
+
+	b := TokenBucket{Rate: fixpoint.Q24FromFloat(0.1), Burst: fixpoint.Q24FromInt32(2)}
+	b.Allow(100, fixpoint.Q24FromInt32(2))
+	b2 := TokenBucket{Rate: b.Rate, Burst: b.Burst}
+	assert.NoError(t, b2.Restore(b.State()))
+	assert.Equal(t, b.Allow(105, fixpoint.Q24FromInt32(1)), b2.Allow(105, fixpoint.Q24FromInt32(1)))
+	assert.Equal(t, b.Allow(110, fixpoint.Q24FromInt32(1)), b2.Allow(110, fixpoint.Q24FromInt32(1)))
+	assert.Error(t, b2.Restore(nil))
+
+	timer := DeltaTimer{TicksPerSecond: 1000}
+	timer.Update(5000)
+	timer2 := DeltaTimer{TicksPerSecond: 1000}
+	assert.NoError(t, timer2.Restore(timer.State()))
+	assert.Equal(t, fixpoint.Q24FromFloat(0.25), timer2.Update(5250))
+	assert.Error(t, timer2.Restore(nil))
+
+	e := BackEMF{KV: fixpoint.Q16FromInt32(500), Alpha: fixpoint.Q24FromFloat(0.5)}
+	e.Update(fixpoint.Q24FromInt32(6), fixpoint.Q24{})
+	e.Update(fixpoint.Q24FromInt32(5), fixpoint.Q24{})
+	e2 := BackEMF{KV: e.KV, Alpha: e.Alpha}
+	assert.NoError(t, e2.Restore(e.State()))
+	assert.Equal(t, e.Update(fixpoint.Q24FromInt32(4), fixpoint.Q24{}), e2.Update(fixpoint.Q24FromInt32(4), fixpoint.Q24{}))
+	assert.Error(t, e2.Restore(nil))
+}
+
+func TestAppendState(t *testing.T) {
+	for _, s := range []statetest.Appender{
+		&PID{},
+		&FractionAccumulator{},
+		&DutyQuantizer{},
+		&TokenBucket{},
+		&DeltaTimer{},
+		&BackEMF{},
+	} {
+		statetest.CheckAppend(t, s)
+	}
+}
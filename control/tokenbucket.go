@@ -0,0 +1,75 @@
+package control
+
+import (
+	"github.com/aykevl/fixpoint"
+)
+
+// TokenBucket is a rate limiter that refills at a fractional rate of tokens
+// per tick, up to a maximum burst size. It can be used for pacing telemetry
+// transmissions or limiting how often actuator commands are sent.
+//
+// The bucket starts full on the first call to Allow. Tick counters may wrap
+// around, as long as calls are less than 2^32 ticks apart.
+type TokenBucket struct {
+	// Rate is the number of tokens added per tick. Negative rates are treated
+	// as zero.
+	Rate fixpoint.Q24
+
+	// Burst is the maximum number of tokens the bucket can hold.
+	Burst fixpoint.Q24
+
+	tokens  int32  // number of tokens in Q24 format
+	last    uint32 // tick count of the last update
+	started bool
+}
+
+// Allow returns whether an action with the given cost can be done at the given
+// tick count, and if so, takes the tokens from the bucket. If there are not
+// enough tokens available, none are taken.
+func (b *TokenBucket) Allow(now uint32, cost fixpoint.Q24) bool {
+	b.update(now)
+	if cost.N > b.tokens {
+		return false
+	}
+	b.tokens -= cost.N
+	return true
+}
+
+// Tokens returns the number of tokens available at the given tick count.
+func (b *TokenBucket) Tokens(now uint32) fixpoint.Q24 {
+	b.update(now)
+	return fixpoint.Q24{N: b.tokens}
+}
+
+// Reset empties the bucket. It will be filled again (as if it was new) on the
+// next call to Allow or Tokens.
+func (b *TokenBucket) Reset() {
+	b.tokens = 0
+	b.started = false
+}
+
+// update refills the bucket for the ticks elapsed since the last update.
+func (b *TokenBucket) update(now uint32) {
+	if !b.started {
+		b.tokens = b.Burst.N
+		b.last = now
+		b.started = true
+		return
+	}
+	elapsed := now - b.last // unsigned subtraction handles wraparound
+	b.last = now
+	if b.Rate.N <= 0 || b.tokens >= b.Burst.N {
+		if b.tokens > b.Burst.N {
+			b.tokens = b.Burst.N
+		}
+		return
+	}
+	// Both factors are non-negative and fit in 32 bits, so the product can't
+	// overflow.
+	added := int64(b.Rate.N) * int64(elapsed)
+	if added >= int64(b.Burst.N)-int64(b.tokens) {
+		b.tokens = b.Burst.N
+	} else {
+		b.tokens += int32(added)
+	}
+}
@@ -0,0 +1,53 @@
+package control
+
+import (
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucket(t *testing.T) {
+	one := fixpoint.Q24FromInt32(1)
+	b := TokenBucket{
+		Rate:  fixpoint.Q24FromFloat(0.25),
+		Burst: fixpoint.Q24FromInt32(2),
+	}
+
+	// Starts full.
+	assert.True(t, b.Allow(100, one))
+	assert.True(t, b.Allow(100, one))
+	assert.False(t, b.Allow(100, one))
+
+	// Fractional refill.
+	assert.False(t, b.Allow(103, one))
+	assert.Equal(t, fixpoint.Q24FromFloat(0.75), b.Tokens(103))
+	assert.True(t, b.Allow(104, one))
+	assert.Equal(t, fixpoint.Q24{}, b.Tokens(104))
+
+	// Doesn't fill beyond the burst size.
+	assert.Equal(t, b.Burst, b.Tokens(1000))
+
+	// Average rate over a long time.
+	allowed := 0
+	for now := uint32(1000); now < 1000+4000; now++ {
+		if b.Allow(now, one) {
+			allowed++
+		}
+	}
+	// Two tokens from the full bucket plus 3999 ticks at a quarter token each.
+	assert.Equal(t, 2+999, allowed)
+
+	// Wraparound of the tick counter.
+	b = TokenBucket{Rate: one, Burst: fixpoint.Q24FromInt32(10)}
+	assert.True(t, b.Allow(0xfffffffe, fixpoint.Q24FromInt32(10)))
+	assert.Equal(t, fixpoint.Q24FromInt32(4), b.Tokens(2))
+
+	// A very long time with a high rate doesn't overflow.
+	b.Rate = fixpoint.Q24FromInt32(100)
+	assert.True(t, b.Allow(2, fixpoint.Q24FromInt32(4)))
+	assert.Equal(t, b.Burst, b.Tokens(0xfffffff0))
+
+	b.Reset()
+	assert.Equal(t, b.Burst, b.Tokens(5))
+}
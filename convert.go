@@ -0,0 +1,104 @@
+package fixpoint
+
+// Conversions between the different fixed point formats.
+
+// Q24 converts this number to a Q24. The result overflows if this number is
+// outside of the range of a Q24 (about ±128).
+func (q Q16) Q24() Q24 {
+	return Q24{q.N << 8}
+}
+
+// Q16 converts this number to a Q16, rounding to the nearest value. This is
+// always possible, as MaxQ24 rounds up to 128 which still fits in a Q16.
+func (q Q24) Q16() Q16 {
+	return Q16{int32((int64(q.N) + 1<<7) >> 8)}
+}
+
+// Q24 converts this number to a Q24, which is always exact.
+func (q Q15) Q24() Q24 {
+	return Q24{int32(q.N) << 9}
+}
+
+// Q15 converts this number to a Q15, rounding to the nearest value. The result
+// saturates to the range [-1, 1).
+func (q Q24) Q15() Q15 {
+	n := (int64(q.N) + 1<<8) >> 9
+	if n > 1<<15-1 {
+		return Q15{1<<15 - 1}
+	}
+	if n < -1<<15 {
+		return Q15{-1 << 15}
+	}
+	return Q15{int16(n)}
+}
+
+// Q24 converts this number to a Q24, rounding to the nearest value.
+func (q Q31) Q24() Q24 {
+	return Q24{int32((int64(q.N) + 1<<6) >> 7)}
+}
+
+// Q31 converts this number to a Q31. The result saturates to the range
+// [-1, 1).
+func (q Q24) Q31() Q31 {
+	return Q31{int32(clamp64(int64(q.N)<<7, -1<<31, 1<<31-1))}
+}
+
+// Q31 converts this number to a Q31, which is always exact.
+func (q Q15) Q31() Q31 {
+	return Q31{int32(q.N) << 16}
+}
+
+// Q15 converts this number to a Q15, rounding to the nearest value. The result
+// saturates at the largest Q15 value.
+func (q Q31) Q15() Q15 {
+	n := (int64(q.N) + 1<<15) >> 16
+	if n > 1<<15-1 {
+		n = 1<<15 - 1
+	}
+	return Q15{int16(n)}
+}
+
+// Vec3Q24 converts this vector to a Vec3Q24. See Q16.Q24.
+func (v Vec3Q16) Vec3Q24() Vec3Q24 {
+	return Vec3Q24{v.X.Q24(), v.Y.Q24(), v.Z.Q24()}
+}
+
+// Vec3Q16 converts this vector to a Vec3Q16. See Q24.Q16.
+func (v Vec3Q24) Vec3Q16() Vec3Q16 {
+	return Vec3Q16{v.X.Q16(), v.Y.Q16(), v.Z.Q16()}
+}
+
+// QuatQ24 converts this quaternion to a QuatQ24. See Q16.Q24.
+func (q QuatQ16) QuatQ24() QuatQ24 {
+	return QuatQ24{q.W.Q24(), q.V.Vec3Q24()}
+}
+
+// QuatQ16 converts this quaternion to a QuatQ16. See Q24.Q16.
+func (q QuatQ24) QuatQ16() QuatQ16 {
+	return QuatQ16{q.W.Q16(), q.V.Vec3Q16()}
+}
+
+// RotateQ16 returns the Q16 vector from the argument rotated by the rotation
+// this quaternion represents. This allows rotating vectors that are larger than
+// the range of a Q24, such as positions, with the precision of a Q24
+// quaternion.
+func (q1 QuatQ24) RotateQ16(v Vec3Q16) Vec3Q16 {
+	// Multiplying a Q16 with a Q24 using the Q24 multiplication results in a
+	// Q16.
+	mul := func(v Vec3Q16, c Q24) Vec3Q16 {
+		return Vec3Q16{Q16(Q24(v.X).Mul(c)), Q16(Q24(v.Y).Mul(c)), Q16(Q24(v.Z).Mul(c))}
+	}
+	cross := Vec3Q16{
+		Q16(Q24(v.Z).Mul(q1.V.Y).Sub(Q24(v.Y).Mul(q1.V.Z))),
+		Q16(Q24(v.X).Mul(q1.V.Z).Sub(Q24(v.Z).Mul(q1.V.X))),
+		Q16(Q24(v.Y).Mul(q1.V.X).Sub(Q24(v.X).Mul(q1.V.Y))),
+	}
+	cross2 := Vec3Q16{
+		Q16(Q24(cross.Z).Mul(q1.V.Y).Sub(Q24(cross.Y).Mul(q1.V.Z))),
+		Q16(Q24(cross.X).Mul(q1.V.Z).Sub(Q24(cross.Z).Mul(q1.V.X))),
+		Q16(Q24(cross.Y).Mul(q1.V.X).Sub(Q24(cross.X).Mul(q1.V.Y))),
+	}
+	// v + 2q_w * (q_v x v) + 2 (q_v x (q_v x v))
+	two := Q24FromInt32(2)
+	return v.Add(mul(cross, two.Mul(q1.W))).Add(mul(cross2, two))
+}
@@ -0,0 +1,67 @@
+package fixpoint
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertQ16(t *testing.T) {
+	assert.Equal(t, Q24FromFloat(1.5), Q16FromFloat(1.5).Q24())
+	assert.Equal(t, Q16FromFloat(-100.25), Q24FromFloat(-100.25).Q16())
+	// Rounding.
+	assert.Equal(t, Q16{1}, Q24{128}.Q16())
+	assert.Equal(t, Q16{0}, Q24{127}.Q16())
+	// Rounding up near the top of the range must not overflow.
+	for n := int32(0); n < 128; n++ {
+		q := Q24{MaxQ24.N - n}
+		assert.Equal(t, Q16FromInt32(128), q.Q16(), "%d", q.N)
+	}
+	assert.Equal(t, Q16{1<<23 - 1}, Q24{MaxQ24.N - 128}.Q16())
+	assert.Equal(t, Q16FromInt32(-128), MinQ24.Q16())
+
+	v := Vec3Q24FromFloat(1, -2, 3.5)
+	assert.Equal(t, v, v.Vec3Q16().Vec3Q24())
+	q := QuatQ24{Q24FromFloat(0.5), Vec3Q24FromFloat(0.5, -0.5, 0.5)}
+	assert.Equal(t, q, q.QuatQ16().QuatQ24())
+}
+
+func TestConvertAudio(t *testing.T) {
+	half := Q15FromFloat(0.5)
+	assert.Equal(t, Q24FromFloat(0.5), half.Q24())
+	assert.Equal(t, half, Q24FromFloat(0.5).Q15())
+	assert.Equal(t, Q31FromFloat(-0.25), Q24FromFloat(-0.25).Q31())
+	assert.Equal(t, Q24FromFloat(-0.25), Q31FromFloat(-0.25).Q24())
+	assert.Equal(t, Q31FromFloat(0.5), half.Q31())
+	assert.Equal(t, half, Q31FromFloat(0.5).Q15())
+
+	// Rounding.
+	assert.Equal(t, Q15{1}, Q24{256}.Q15())
+	assert.Equal(t, Q15{0}, Q24{255}.Q15())
+	assert.Equal(t, Q24{1}, Q31{64}.Q24())
+	assert.Equal(t, Q15{1}, Q31{1 << 15}.Q15())
+
+	// Saturation.
+	assert.Equal(t, Q15{1<<15 - 1}, Q24FromInt32(1).Q15())
+	assert.Equal(t, Q15{-1 << 15}, Q24FromInt32(-2).Q15())
+	assert.Equal(t, Q31{1<<31 - 1}, Q24FromInt32(1).Q31())
+	assert.Equal(t, Q31{-1 << 31}, Q24FromInt32(-1).Q31())
+	assert.Equal(t, Q15{1<<15 - 1}, Q31{1<<31 - 1}.Q15())
+}
+
+func TestRotateQ16(t *testing.T) {
+	q := QuatFromAxisAngle(Vec3Q24FromFloat(1, 2, 3), Q24FromFloat(0.7))
+	qf := mgl32.QuatRotate(0.7, mgl32.Vec3{1, 2, 3}.Normalize())
+
+	// Positions larger than a Q24 can hold.
+	v := qf.Rotate(mgl32.Vec3{100, -20, 3000})
+	rotated := q.RotateQ16(Vec3Q16FromFloat(100, -20, 3000))
+	assert.InDelta(t, v.X(), rotated.X.Float(), 0.01)
+	assert.InDelta(t, v.Y(), rotated.Y.Float(), 0.01)
+	assert.InDelta(t, v.Z(), rotated.Z.Float(), 0.01)
+
+	// Should be the same as Rotate for small vectors.
+	small := Vec3Q24FromFloat(1, 2, 3)
+	assert.Equal(t, q.Rotate(small).Vec3Q16(), q.RotateQ16(small.Vec3Q16()))
+}
@@ -0,0 +1,79 @@
+package fixpoint
+
+// Tolerances for comparing calculated values. The resolution of a Q24 is the
+// same over its whole range, but the error of a calculation is not: it grows
+// with the magnitude of the values involved (see MulErrorBound), so an
+// epsilon that is right for unit vectors is far too strict for values around
+// 100, and one that is right around 100 hides real errors around 1. The
+// functions below scale the tolerance with the magnitude instead, like a
+// relative epsilon for floating point numbers.
+
+// EpsilonFor returns a tolerance of the given number of ULPs for values with
+// the magnitude of x, where an ULP is counted relative to 1: it is ulps·2^-24
+// for values up to 1 and ulps·|x|·2^-24 above that, rounded to the nearest
+// ULP. The smaller values still get the absolute floor of ulps ULPs, as the
+// resolution doesn't get any finer near zero. It is a good choice for
+// Convergence.Epsilon when the size of the solution is roughly known. The
+// result saturates at MaxQ24, and a negative number of ULPs is treated as
+// zero.
+func EpsilonFor(x Q24, ulps int) Q24 {
+	if ulps <= 0 {
+		return Q24{}
+	}
+	n := abs64(int64(x.N))
+	if n < 1<<24 {
+		n = 1 << 24
+	}
+	if int64(ulps) > maxN {
+		return MaxQ24
+	}
+	eps := (int64(ulps)*n + 1<<23) >> 24
+	if eps > maxN {
+		return MaxQ24
+	}
+	return Q24{int32(eps)}
+}
+
+// ApproxEqual returns whether this number and the argument differ by at most
+// the tolerance EpsilonFor returns for the larger magnitude of the two.
+func (q1 Q24) ApproxEqual(q2 Q24, ulps int) bool {
+	return diff64(q1, q2) <= int64(EpsilonFor(maxAbs(q1, q2), ulps).N)
+}
+
+// ApproxEqualRel returns whether this number and the argument differ by at
+// most rel times the larger magnitude of the two, or by at most abs. The
+// absolute tolerance is needed to compare values close to zero, where any
+// relative tolerance gets smaller than the resolution. For example, a rel of
+// 2^-16 accepts about 5 significant digits.
+func (q1 Q24) ApproxEqualRel(q2, rel, abs Q24) bool {
+	d := diff64(q1, q2)
+	if d <= int64(abs.N) {
+		return true
+	}
+	return d<<24 <= int64(rel.N)*abs64(int64(maxAbs(q1, q2).N))
+}
+
+// ApproxEqual returns whether each element of this vector and the argument
+// differ by at most the tolerance EpsilonFor returns for the largest element
+// of either vector. Using the largest element for all of them matches how
+// errors spread in operations like rotations, where the error of a small
+// element depends on the size of the others.
+func (v1 Vec3Q24) ApproxEqual(v2 Vec3Q24, ulps int) bool {
+	m := maxAbs(maxAbs(maxAbs(v1.X, v1.Y), maxAbs(v1.Z, v2.X)), maxAbs(v2.Y, v2.Z))
+	eps := int64(EpsilonFor(m, ulps).N)
+	return diff64(v1.X, v2.X) <= eps && diff64(v1.Y, v2.Y) <= eps && diff64(v1.Z, v2.Z) <= eps
+}
+
+// diff64 returns the absolute difference between two numbers, which doesn't
+// overflow.
+func diff64(q1, q2 Q24) int64 {
+	return abs64(int64(q1.N) - int64(q2.N))
+}
+
+// maxAbs returns the number with the largest magnitude.
+func maxAbs(q1, q2 Q24) Q24 {
+	if abs64(int64(q2.N)) > abs64(int64(q1.N)) {
+		return q2
+	}
+	return q1
+}
@@ -0,0 +1,74 @@
+package fixpoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpsilonFor(t *testing.T) {
+	assert.Equal(t, Q24{4}, EpsilonFor(Q24{}, 4))
+	assert.Equal(t, Q24{4}, EpsilonFor(Q24FromFloat(-0.5), 4))
+	assert.Equal(t, Q24{4}, EpsilonFor(Q24FromInt32(1), 4))
+	assert.Equal(t, Q24{400}, EpsilonFor(Q24FromInt32(100), 4))
+	assert.Equal(t, Q24{400}, EpsilonFor(Q24FromInt32(-100), 4))
+	assert.Equal(t, Q24{5}, EpsilonFor(Q24FromFloat(1.5), 3)) // 4.5, rounded
+	assert.Equal(t, Q24{512}, EpsilonFor(MinQ24, 4))
+	assert.Equal(t, Q24{}, EpsilonFor(Q24FromInt32(3), 0))
+	assert.Equal(t, Q24{}, EpsilonFor(Q24FromInt32(3), -1))
+	assert.Equal(t, MaxQ24, EpsilonFor(Q24FromInt32(100), 1<<30))
+	assert.Equal(t, MaxQ24, EpsilonFor(Q24FromInt32(1), maxN))
+}
+
+func TestApproxEqual(t *testing.T) {
+	one := Q24FromInt32(1)
+	assert.True(t, one.ApproxEqual(one, 0))
+	assert.True(t, one.ApproxEqual(Q24{one.N + 2}, 2))
+	assert.False(t, one.ApproxEqual(Q24{one.N + 3}, 2))
+
+	// The same difference is acceptable for larger values.
+	big := Q24FromInt32(64)
+	assert.True(t, big.ApproxEqual(Q24{big.N + 128}, 2))
+	assert.True(t, Q24{big.N + 128}.ApproxEqual(big, 2))
+	assert.False(t, big.ApproxEqual(Q24{big.N + 129}, 2))
+
+	// It doesn't overflow for values far apart.
+	assert.False(t, MinQ24.ApproxEqual(MaxQ24, 1<<20))
+
+	// The result of a calculation is within its error bound, which grows
+	// with the magnitude of the inputs.
+	for _, x := range []float64{0.01, 0.7, 3, 50} {
+		a, b := Q24FromFloat64(x), Q24FromFloat64(1.3)
+		expected := Q24FromFloat64(x * 1.3)
+		if !a.MulRound(b).ApproxEqual(expected, 1) {
+			t.Errorf("%v·1.3: %v not approximately equal to %v", x, a.MulRound(b), expected)
+		}
+	}
+}
+
+func TestApproxEqualRel(t *testing.T) {
+	rel := Q24{One >> 10} // about 0.1%
+	assert.True(t, Q24FromInt32(100).ApproxEqualRel(Q24FromFloat(100.09), rel, Q24{}))
+	assert.False(t, Q24FromInt32(100).ApproxEqualRel(Q24FromFloat(100.11), rel, Q24{}))
+	assert.True(t, Q24FromFloat(-0.5).ApproxEqualRel(Q24FromFloat(-0.5004), rel, Q24{}))
+	assert.False(t, Q24FromFloat(-0.5).ApproxEqualRel(Q24FromFloat(-0.5006), rel, Q24{}))
+
+	// Near zero only the absolute tolerance helps.
+	assert.False(t, Q24{}.ApproxEqualRel(Q24{10}, rel, Q24{}))
+	assert.True(t, Q24{}.ApproxEqualRel(Q24{10}, rel, Q24{10}))
+	assert.True(t, Q24{-5}.ApproxEqualRel(Q24{5}, rel, Q24{10}))
+	assert.False(t, MinQ24.ApproxEqualRel(MaxQ24, rel, Q24{10}))
+}
+
+func TestVec3ApproxEqual(t *testing.T) {
+	v := Vec3Q24FromFloat(50, 0.001, -1)
+	assert.True(t, v.ApproxEqual(v, 0))
+
+	// The tolerance of the small elements follows the largest one.
+	w := v.Add(Vec3Q24{Q24{}, Q24{100}, Q24{-100}})
+	assert.True(t, v.ApproxEqual(w, 2))
+	assert.True(t, w.ApproxEqual(v, 2))
+	assert.False(t, v.ApproxEqual(w, 1))
+	small := Vec3Q24FromFloat(0.5, 0.001, -1)
+	assert.False(t, small.ApproxEqual(small.Add(Vec3Q24{Q24{}, Q24{100}, Q24{-100}}), 2))
+}
@@ -0,0 +1,44 @@
+package fixpoint
+
+// Worst-case rounding errors of the Q24 operations, as the largest absolute
+// difference with the exact result in units in the last place (ULP, which is
+// 2^-24 for Q24). They assume exact inputs and results that don't overflow.
+// Combine them with MulErrorBound to estimate the error of a calculation, for
+// example to decide whether Q24 is precise enough or whether an algorithm
+// needs more fractional bits.
+const (
+	MulErrorULP      = 1   // Mul rounds down
+	MulRoundErrorULP = 0.5 // MulRound and Vec3Q24.Mul
+	DivErrorULP      = 1   // Div rounds towards zero
+	DivRoundErrorULP = 0.5 // DivRound and Vec3Q24.Div
+	DivFastErrorULP  = 1.5 // DivFast and Recip, for results below 64
+	SqrtErrorULP     = 0.5 // Sqrt and Vec3Q24.Len
+	SinCosErrorULP   = 2   // Sin, Cos and SinCos in the range [-4π, 4π]
+	Atan2ErrorULP    = 2   // Atan2, Atan, Asin and Acos
+	Exp2ErrorULP     = 1.5 // Exp2
+	DotErrorULP      = 1.5 // Vec3Q24.Dot: three rounded products
+	CrossErrorULP    = 1   // Vec3Q24.Cross, per element
+	QuatMulErrorULP  = 2   // QuatQ24.Mul, per element
+
+	// NormalizeErrorULP is the error per element of Vec3Q24.Normalize and
+	// QuatQ24.Normalize, for inputs that are not too small (with a length of
+	// at least 2^-8).
+	NormalizeErrorULP = 0.6
+
+	// RotateErrorULP is the rounding error per element of QuatQ24.Rotate
+	// for a unit quaternion, independent of the length of the vector. Note
+	// that a quaternion is rarely exactly normalized: its length differs from
+	// 1 by up to about 2^-23 after Normalize, which scales the result by the
+	// same amount.
+	RotateErrorULP = 5
+)
+
+// MulErrorBound returns the error bound in ULP of a.MulRound(b), where a and b
+// already have an error of at most errA and errB ULP. This is the propagated
+// input error |a|·errB + |b|·errA + errA·errB·2^-24 plus the rounding error of
+// MulRound itself.
+func MulErrorBound(a, b Q24, errA, errB float64) float64 {
+	absA := float64(abs64(int64(a.N))) / (1 << 24)
+	absB := float64(abs64(int64(b.N))) / (1 << 24)
+	return absA*errB + absB*errA + errA*errB/(1<<24) + MulRoundErrorULP
+}
@@ -0,0 +1,101 @@
+package fixpoint
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestErrorBounds checks the documented error bounds against random inputs.
+func TestErrorBounds(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	random := func(max float64) Q24 {
+		return Q24FromFloat64((r.Float64()*2 - 1) * max)
+	}
+	randomVec := func(max float64) Vec3Q24 {
+		return Vec3Q24{random(max), random(max), random(max)}
+	}
+	randomQuat := func() QuatQ24 {
+		return QuatQ24{random(1), randomVec(1)}.Normalize()
+	}
+	check := func(name string, bound float64, expected float64, actual Q24) {
+		if err := math.Abs(expected*(1<<24) - float64(actual.N)); err > bound {
+			t.Errorf("%s: error of %.3f ULP is above the bound of %v ULP", name, err, bound)
+		}
+	}
+
+	for i := 0; i < 10000; i++ {
+		a, b := random(8), random(8)
+		check("Mul", MulErrorULP, a.Float64()*b.Float64(), a.Mul(b))
+		check("MulRound", MulRoundErrorULP, a.Float64()*b.Float64(), a.MulRound(b))
+		if math.Abs(b.Float64()) > 0.1 {
+			check("Div", DivErrorULP, a.Float64()/b.Float64(), a.Div(b))
+			check("DivRound", DivRoundErrorULP, a.Float64()/b.Float64(), a.DivRound(b))
+			check("DivFast", DivFastErrorULP, a.Float64()/b.Float64(), a.DivFast(b))
+		}
+		abs := a.Abs()
+		check("Sqrt", SqrtErrorULP, math.Sqrt(abs.Float64()), abs.Sqrt())
+
+		angle := random(4 * math.Pi)
+		sin, cos := SinCos(angle)
+		check("Sin", SinCosErrorULP, math.Sin(angle.Float64()), sin)
+		check("Cos", SinCosErrorULP, math.Cos(angle.Float64()), cos)
+		check("Atan2", Atan2ErrorULP, math.Atan2(a.Float64(), b.Float64()), Atan2(a, b))
+		check("Exp2", Exp2ErrorULP, math.Exp2(a.Float64()-2), Exp2(a.Sub(Q24FromInt32(2))))
+
+		v1, v2 := randomVec(4), randomVec(4)
+		x1, y1, z1 := v1.X.Float64(), v1.Y.Float64(), v1.Z.Float64()
+		x2, y2, z2 := v2.X.Float64(), v2.Y.Float64(), v2.Z.Float64()
+		check("Dot", DotErrorULP, x1*x2+y1*y2+z1*z2, v1.Dot(v2))
+		cross := v1.Cross(v2)
+		check("Cross.X", CrossErrorULP, y1*z2-z1*y2, cross.X)
+		check("Cross.Y", CrossErrorULP, z1*x2-x1*z2, cross.Y)
+		check("Cross.Z", CrossErrorULP, x1*y2-y1*x2, cross.Z)
+
+		n := v1.Normalize()
+		l := math.Sqrt(x1*x1 + y1*y1 + z1*z1)
+		check("Normalize.X", NormalizeErrorULP, x1/l, n.X)
+		check("Normalize.Y", NormalizeErrorULP, y1/l, n.Y)
+		check("Normalize.Z", NormalizeErrorULP, z1/l, n.Z)
+
+		q1, q2 := randomQuat(), QuatQ24{random(2), randomVec(2)}
+		w1, w2 := q1.W.Float64(), q2.W.Float64()
+		x1, y1, z1 = q1.V.X.Float64(), q1.V.Y.Float64(), q1.V.Z.Float64()
+		x2, y2, z2 = q2.V.X.Float64(), q2.V.Y.Float64(), q2.V.Z.Float64()
+		q := q1.Mul(q2)
+		check("QuatMul.W", QuatMulErrorULP, w1*w2-x1*x2-y1*y2-z1*z2, q.W)
+		check("QuatMul.X", QuatMulErrorULP, w1*x2+x1*w2+y1*z2-z1*y2, q.V.X)
+		check("QuatMul.Y", QuatMulErrorULP, w1*y2-x1*z2+y1*w2+z1*x2, q.V.Y)
+		check("QuatMul.Z", QuatMulErrorULP, w1*z2+x1*y2-y1*x2+z1*w2, q.V.Z)
+
+		// Rotate evaluates v + 2w(u×v) + 2u×(u×v), where u is the vector part
+		// of the quaternion. This is only a rotation for unit quaternions, so
+		// compare against the same formula instead of a rotation matrix to
+		// leave out the error of Normalize.
+		v := randomVec(20)
+		vx, vy, vz := v.X.Float64(), v.Y.Float64(), v.Z.Float64()
+		cx, cy, cz := y1*vz-z1*vy, z1*vx-x1*vz, x1*vy-y1*vx
+		rotated := q1.Rotate(v)
+		check("Rotate.X", RotateErrorULP, vx+2*w1*cx+2*(y1*cz-z1*cy), rotated.X)
+		check("Rotate.Y", RotateErrorULP, vy+2*w1*cy+2*(z1*cx-x1*cz), rotated.Y)
+		check("Rotate.Z", RotateErrorULP, vz+2*w1*cz+2*(x1*cy-y1*cx), rotated.Z)
+	}
+}
+
+func TestMulErrorBound(t *testing.T) {
+	assert.Equal(t, 0.5, MulErrorBound(Q24FromInt32(3), Q24FromInt32(-5), 0, 0))
+	assert.InDelta(t, 3*2+2*1+0.5, MulErrorBound(Q24FromInt32(-3), Q24FromInt32(2), 1, 2), 1e-6)
+
+	// The bound holds for the inputs with the largest error.
+	a, b := Q24FromFloat(1.75), Q24FromFloat(-2.5)
+	bound := MulErrorBound(a, b, 3, 2)
+	for _, da := range []int32{-3, 3} {
+		for _, db := range []int32{-2, 2} {
+			actual := Q24{a.N + da}.MulRound(Q24{b.N + db})
+			err := math.Abs(a.Float64()*b.Float64()*(1<<24) - float64(actual.N))
+			assert.True(t, err <= bound, "error %v above bound %v", err, bound)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package fixpoint
+
+// Conversions between quaternions and Euler angles. The angles follow the
+// aerospace convention (Tait-Bryan angles, intrinsic Z-Y'-X''): yaw around the
+// Z axis, then pitch around the new Y axis, then roll around the new X axis.
+// All angles are in radians.
+//
+// Useful link:
+// https://en.wikipedia.org/wiki/Conversion_between_quaternions_and_Euler_angles
+
+// QuatFromEuler returns the quaternion for the given roll, pitch and yaw angles.
+func QuatFromEuler(roll, pitch, yaw Q24) QuatQ24 {
+	sr, cr := SinCos(Q24{roll.N >> 1})
+	sp, cp := SinCos(Q24{pitch.N >> 1})
+	sy, cy := SinCos(Q24{yaw.N >> 1})
+	return QuatQ24{
+		cr.Mul(cp).Mul(cy).Add(sr.Mul(sp).Mul(sy)),
+		Vec3Q24{
+			sr.Mul(cp).Mul(cy).Sub(cr.Mul(sp).Mul(sy)),
+			cr.Mul(sp).Mul(cy).Add(sr.Mul(cp).Mul(sy)),
+			cr.Mul(cp).Mul(sy).Sub(sr.Mul(sp).Mul(cy)),
+		},
+	}
+}
+
+// Euler returns the roll, pitch and yaw angles of the rotation this unit
+// quaternion represents. Roll and yaw are in the range [-π, π] and pitch is in
+// the range [-π/2, π/2]. Near a pitch of ±π/2 (gimbal lock), roll and yaw
+// become imprecise.
+func (q QuatQ24) Euler() (roll, pitch, yaw Q24) {
+	w, x, y, z := q.W, q.V.X, q.V.Y, q.V.Z
+	one := Q24FromInt32(1)
+	two := Q24FromInt32(2)
+	roll = Atan2(two.Mul(w.Mul(x).Add(y.Mul(z))), one.Sub(two.Mul(x.Mul(x).Add(y.Mul(y)))))
+	pitch = Asin(two.Mul(w.Mul(y).Sub(z.Mul(x))))
+	yaw = Atan2(two.Mul(w.Mul(z).Add(x.Mul(y))), one.Sub(two.Mul(y.Mul(y).Add(z.Mul(z)))))
+	return
+}
+
+// EulerDegrees is like Euler, but returns the angles in degrees multiplied by
+// the given scale. For example, with a scale of 100 the angles are returned in
+// hundredths of a degree.
+func (q QuatQ24) EulerDegrees(scale int32) (roll, pitch, yaw int32) {
+	r, p, y := q.Euler()
+	return r.DegreesScaled(scale), p.DegreesScaled(scale), y.DegreesScaled(scale)
+}
+
+// DegreesScaled interprets this number as an angle in radians and returns it
+// in degrees, multiplied by the given scale and rounded to the nearest integer.
+// The result is an integer because angles in degrees may not fit in a Q24.
+func (q Q24) DegreesScaled(scale int32) int32 {
+	n := int64(q.N) * int64(scale) * 180
+	if n < 0 {
+		return int32((n - piN/2) / piN)
+	}
+	return int32((n + piN/2) / piN)
+}
@@ -0,0 +1,44 @@
+package fixpoint
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEuler(t *testing.T) {
+	for _, angles := range [][3]float32{
+		{0, 0, 0},
+		{0.1, 0.2, 0.3},
+		{-1, 0.5, 3},
+		{3, -1.2, -2},
+		{0.5, 1.5, 0.5},
+	} {
+		roll, pitch, yaw := angles[0], angles[1], angles[2]
+		q := QuatFromEuler(Q24FromFloat(roll), Q24FromFloat(pitch), Q24FromFloat(yaw))
+		qf := mgl32.AnglesToQuat(yaw, pitch, roll, mgl32.ZYX)
+		assertQuat(t, qf, q, "QuatFromEuler(%f, %f, %f)", roll, pitch, yaw)
+
+		roll2, pitch2, yaw2 := q.Euler()
+		assert.InDelta(t, roll, roll2.Float(), 0.0001, "roll")
+		assert.InDelta(t, pitch, pitch2.Float(), 0.0001, "pitch")
+		assert.InDelta(t, yaw, yaw2.Float(), 0.0001, "yaw")
+	}
+}
+
+func TestEulerDegrees(t *testing.T) {
+	q := QuatFromEuler(Q24FromFloat(math.Pi/2), Q24FromFloat(-math.Pi/4), Q24FromFloat(math.Pi))
+	roll, pitch, yaw := q.EulerDegrees(100)
+	assert.Equal(t, int32(9000), roll)
+	assert.Equal(t, int32(-4500), pitch)
+	if yaw < 0 {
+		// -180° and 180° are the same angle.
+		yaw = -yaw
+	}
+	assert.Equal(t, int32(18000), yaw)
+
+	assert.Equal(t, int32(57), Q24FromInt32(1).DegreesScaled(1))
+	assert.Equal(t, int32(-57296), Q24FromInt32(-1).DegreesScaled(1000))
+}
@@ -0,0 +1,34 @@
+package fixpoint
+
+// ln2Q31 is ln(2) in Q31 format.
+const ln2Q31 = 1488522236
+
+// expCoeffsQ31 contains the Taylor coefficients 1/k! of e^x in Q31 format.
+var expCoeffsQ31 = [...]int64{2147483648, 2147483648, 1073741824, 357913941, 89478485, 17895697, 2982616, 426088, 53261}
+
+// Exp2 returns 2^x. It differs from the correctly rounded result by at most one
+// least significant bit, and saturates to MaxQ24 for x >= 7.
+func Exp2(x Q24) Q24 {
+	// Split x into an integer part i and a fraction f in the range
+	// [-0.5, 0.5), so that 2^x = 2^i * e^(f*ln2) where the exponent is small
+	// enough for a short Taylor series.
+	i := (int64(x.N) + 1<<23) >> 24
+	if i >= 8 {
+		return MaxQ24
+	}
+	if i < -30 {
+		return Q24{}
+	}
+	f := int64(x.N) - i<<24
+	t := (f*ln2Q31 + 1<<23) >> 24 // Q31
+	p := expCoeffsQ31[len(expCoeffsQ31)-1]
+	for k := len(expCoeffsQ31) - 2; k >= 0; k-- {
+		p = expCoeffsQ31[k] + (t*p+1<<30)>>31
+	}
+	// p is 2^f in Q31 format, convert to Q24 and multiply by 2^i.
+	shift := 7 - i
+	if shift <= 0 {
+		return saturate(p << uint(-shift))
+	}
+	return Q24{int32((p + 1<<uint(shift-1)) >> uint(shift))}
+}
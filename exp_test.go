@@ -0,0 +1,29 @@
+package fixpoint
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExp2(t *testing.T) {
+	assert.Equal(t, Q24FromInt32(1), Exp2(Q24{}))
+	assert.Equal(t, Q24FromInt32(2), Exp2(Q24FromInt32(1)))
+	assert.Equal(t, Q24FromFloat(0.125), Exp2(Q24FromInt32(-3)))
+	assert.Equal(t, Q24FromInt32(64), Exp2(Q24FromInt32(6)))
+	assert.Equal(t, MaxQ24, Exp2(Q24FromInt32(7)))
+	assert.Equal(t, MaxQ24, Exp2(MaxQ24))
+	assert.Equal(t, Q24{}, Exp2(Q24FromInt32(-30)))
+	assert.Equal(t, Q24{}, Exp2(MinQ24))
+	assert.Equal(t, Q24{1}, Exp2(Q24FromInt32(-24)))
+
+	for x := -26.0; x < 7; x += 0.00377 {
+		q := Q24FromFloat64(x)
+		expected := math.Exp2(q.Float64()) * (1 << 24)
+		actual := float64(Exp2(q).N)
+		if math.Abs(expected-actual) > 1.5 {
+			t.Errorf("Exp2(%f): expected %f, got %f", x, expected, actual)
+		}
+	}
+}
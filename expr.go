@@ -0,0 +1,311 @@
+package fixpoint
+
+// EvalQ24 evaluates an arithmetic expression like "0.5 * pi / 180" and
+// returns the result as a Q24. It is meant for human-readable configuration
+// that is parsed on the device itself, so it does not use any floating point
+// arithmetic.
+//
+// Expressions consist of decimal numbers, the operators + - * / with the usual
+// precedence, parentheses, the constants pi and e, and the functions sqrt,
+// sin, cos and exp2. Intermediate values have 32 fractional bits and may be up
+// to 2^24 in magnitude, so that the result is rounded to the nearest Q24 in
+// most cases. The trigonometric and exponential functions take and return a
+// Q24 however.
+//
+// If the expression is invalid, ErrSyntax is returned. If the result or an
+// intermediate value is out of range, or on division by zero, ErrRange is
+// returned.
+func EvalQ24(expr string) (Q24, error) {
+	p := exprParser{s: expr}
+	n, err := p.expr()
+	if err != nil {
+		return Q24{}, err
+	}
+	if p.peek() != 0 {
+		return Q24{}, ErrSyntax
+	}
+	q, ok := checked((n + 1<<7) >> 8)
+	if !ok {
+		return Q24{}, ErrRange
+	}
+	return q, nil
+}
+
+// Intermediate values of EvalQ24 are stored as an int64 with 32 fractional
+// bits and a magnitude below 2^24, which leaves enough headroom to multiply
+// and divide them without overflow.
+const (
+	exprFrac = 32
+	exprMax  = 1<<(24+exprFrac) - 1
+
+	piQ32 = 13493037705 // π
+	eQ32  = 11674931555 // e
+)
+
+// exprParser is a recursive descent parser for EvalQ24.
+type exprParser struct {
+	s   string
+	pos int
+}
+
+// expr parses a sum: term {('+' | '-') term}.
+func (p *exprParser) expr() (int64, error) {
+	n, err := p.term()
+	for err == nil {
+		op := p.peek()
+		if op != '+' && op != '-' {
+			break
+		}
+		p.pos++
+		var n2 int64
+		n2, err = p.term()
+		if op == '+' {
+			n += n2
+		} else {
+			n -= n2
+		}
+		if err == nil && (n > exprMax || n < -exprMax) {
+			err = ErrRange
+		}
+	}
+	return n, err
+}
+
+// term parses a product: unary {('*' | '/') unary}.
+func (p *exprParser) term() (int64, error) {
+	n, err := p.unary()
+	for err == nil {
+		op := p.peek()
+		if op != '*' && op != '/' {
+			break
+		}
+		p.pos++
+		var n2 int64
+		n2, err = p.unary()
+		if err != nil {
+			break
+		}
+		if op == '*' {
+			n, err = exprMul(n, n2)
+		} else {
+			n, err = exprDiv(n, n2)
+		}
+	}
+	return n, err
+}
+
+// unary parses an optionally negated operand: {'+' | '-'} primary.
+func (p *exprParser) unary() (int64, error) {
+	switch p.peek() {
+	case '-':
+		p.pos++
+		n, err := p.unary()
+		return -n, err
+	case '+':
+		p.pos++
+		return p.unary()
+	}
+	return p.primary()
+}
+
+// primary parses a number, a constant, a function call or a parenthesized
+// expression.
+func (p *exprParser) primary() (int64, error) {
+	c := p.peek()
+	start := p.pos
+	switch {
+	case c == '(':
+		p.pos++
+		n, err := p.expr()
+		if err != nil {
+			return 0, err
+		}
+		if p.peek() != ')' {
+			return 0, ErrSyntax
+		}
+		p.pos++
+		return n, nil
+	case c >= '0' && c <= '9' || c == '.':
+		for p.pos < len(p.s) && (isDigit(p.s[p.pos]) || p.s[p.pos] == '.') {
+			p.pos++
+		}
+		return parseExprNumber(p.s[start:p.pos])
+	case c >= 'a' && c <= 'z':
+		for p.pos < len(p.s) && (p.s[p.pos] >= 'a' && p.s[p.pos] <= 'z' || isDigit(p.s[p.pos])) {
+			p.pos++
+		}
+		return p.call(p.s[start:p.pos])
+	}
+	return 0, ErrSyntax
+}
+
+// call returns the value of a constant, or parses the argument of a function
+// and returns the result.
+func (p *exprParser) call(name string) (int64, error) {
+	switch name {
+	case "pi":
+		return piQ32, nil
+	case "e":
+		return eQ32, nil
+	case "sqrt", "sin", "cos", "exp2":
+	default:
+		return 0, ErrSyntax
+	}
+	if p.peek() != '(' {
+		return 0, ErrSyntax
+	}
+	n, err := p.primary()
+	if err != nil {
+		return 0, err
+	}
+	if name == "sqrt" {
+		return exprSqrt(n)
+	}
+	arg, ok := checked((n + 1<<7) >> 8)
+	if !ok {
+		return 0, ErrRange
+	}
+	var result Q24
+	switch name {
+	case "sin":
+		result = Sin(arg)
+	case "cos":
+		result = Cos(arg)
+	case "exp2":
+		if arg.N >= 7<<24 {
+			return 0, ErrRange
+		}
+		result = Exp2(arg)
+	}
+	return int64(result.N) << 8, nil
+}
+
+// peek skips whitespace and returns the next character, or 0 at the end of
+// the expression.
+func (p *exprParser) peek() byte {
+	for p.pos < len(p.s) && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t') {
+		p.pos++
+	}
+	if p.pos == len(p.s) {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// parseExprNumber parses an unsigned decimal number to an intermediate value,
+// like ParseQ24 does.
+func parseExprNumber(s string) (int64, error) {
+	i := 0
+	var intPart int64
+	for ; i < len(s) && isDigit(s[i]); i++ {
+		intPart = intPart*10 + int64(s[i]-'0')
+		if intPart > exprMax>>exprFrac {
+			return 0, ErrRange
+		}
+	}
+	var frac []byte
+	if i < len(s) && s[i] == '.' {
+		frac = []byte(s[i+1:])
+		i++
+		for ; i < len(s) && isDigit(s[i]); i++ {
+		}
+	}
+	if i != len(s) || len(s) == 1 && frac != nil {
+		return 0, ErrSyntax
+	}
+
+	// See ParseQ24.
+	var carry uint64
+	for j := len(frac) - 1; j >= 0; j-- {
+		x := uint64(frac[j]-'0')<<exprFrac + carry
+		frac[j] = '0' + byte(x%10)
+		carry = x / 10
+	}
+	n := intPart<<exprFrac + int64(carry)
+	if len(frac) > 0 && frac[0] >= '5' {
+		n++
+	}
+	if n > exprMax {
+		return 0, ErrRange
+	}
+	return n, nil
+}
+
+// exprMul returns the product of two intermediate values, rounded to the
+// nearest value.
+func exprMul(a, b int64) (int64, error) {
+	// Split both numbers into an integer and a fractional part, and multiply
+	// the parts separately to avoid overflow.
+	ah, al := a>>exprFrac, a&(1<<exprFrac-1)
+	bh, bl := b>>exprFrac, b&(1<<exprFrac-1)
+	hh := ah * bh
+	if hh > exprMax>>exprFrac+1 || hh < -exprMax>>exprFrac-1 {
+		return 0, ErrRange
+	}
+	n := hh<<exprFrac + ah*bl + al*bh + int64((uint64(al)*uint64(bl)+1<<(exprFrac-1))>>exprFrac)
+	if n > exprMax || n < -exprMax {
+		return 0, ErrRange
+	}
+	return n, nil
+}
+
+// exprDiv returns the quotient of two intermediate values, rounded to the
+// nearest value (halfway cases away from zero).
+func exprDiv(a, b int64) (int64, error) {
+	if b == 0 {
+		return 0, ErrRange
+	}
+	neg := (a < 0) != (b < 0)
+	a, b = abs64(a), abs64(b)
+	q, r := a/b, a%b
+	if q > exprMax>>exprFrac {
+		return 0, ErrRange
+	}
+	// Long division for the fractional bits. The remainder stays below b, so
+	// this doesn't overflow.
+	for i := 0; i < exprFrac; i++ {
+		q <<= 1
+		r <<= 1
+		if r >= b {
+			r -= b
+			q++
+		}
+	}
+	if r >= b-r {
+		q++
+	}
+	if q > exprMax {
+		return 0, ErrRange
+	}
+	if neg {
+		q = -q
+	}
+	return q, nil
+}
+
+// exprSqrt returns the square root of an intermediate value.
+func exprSqrt(n int64) (int64, error) {
+	if n < 0 {
+		return 0, ErrRange
+	}
+	// sqrt(n) in intermediate format is sqrt(n << 32) = sqrt(n << 2k) >> (k -
+	// 16), for a k that keeps n << 2k below 2^62.
+	k := uint(0)
+	for n != 0 && n < 1<<60 {
+		n <<= 2
+		k++
+	}
+	root := int64(sqrt64(uint64(n)))
+	if k >= exprFrac/2 {
+		s := k - exprFrac/2
+		if s == 0 {
+			return root, nil
+		}
+		return (root + 1<<(s-1)) >> s, nil
+	}
+	return root << (exprFrac/2 - k), nil
+}
@@ -0,0 +1,76 @@
+package fixpoint
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvalQ24(t *testing.T) {
+	for _, tc := range []struct {
+		expr     string
+		expected Q24
+	}{
+		{"1", Q24FromInt32(1)},
+		{" 12.375 ", Q24FromFloat(12.375)},
+		{"1 + 2 * 3", Q24FromInt32(7)},
+		{"(1 + 2) * 3", Q24FromInt32(9)},
+		{"10 - 4 - 3", Q24FromInt32(3)},
+		{"-2 * -3", Q24FromInt32(6)},
+		{"--1", Q24FromInt32(1)},
+		{"+.5", Q24FromFloat(0.5)},
+		{"1/3", Q24{5592405}},
+		{"2/3", Q24{11184811}},
+		{"-2/3", Q24{-11184811}},
+		{"0.5 * pi / 180", Q24FromFloat64(0.5 * math.Pi / 180)},
+		{"1 / 3 * 3", Q24FromInt32(1)},
+		{"1000000 / 3600 / 100", Q24FromFloat64(1000000.0 / 3600 / 100)},
+		{"(180 - 60) / 1000", Q24FromFloat64(0.12)},
+		{"pi/2", HalfPi},
+		{"2*pi", TwoPi},
+		{"e", Q24FromFloat64(2.718281828459045)},
+		{"sqrt(2)/2", Q24FromInt32(2).Sqrt().DivRound(Q24FromInt32(2))},
+		{"sqrt(0.25) + sqrt(0)", Q24FromFloat(0.5)},
+		{"sqrt(10000)", Q24FromInt32(100)},
+		{"sin(pi/2)", Q24FromInt32(1)},
+		{"cos(0)*2", Q24FromInt32(2)},
+		{"exp2(-3)", Q24FromFloat(0.125)},
+		{"127.99999994", MaxQ24},
+		{"120 + 7.99999994", MaxQ24},
+	} {
+		q, err := EvalQ24(tc.expr)
+		if err != nil || q != tc.expected {
+			t.Errorf("EvalQ24(%#v): expected %v, got %v (error: %v)", tc.expr, tc.expected, q, err)
+		}
+	}
+
+	for _, tc := range []struct {
+		expr string
+		err  error
+	}{
+		{"", ErrSyntax},
+		{"1 +", ErrSyntax},
+		{"(1 + 2", ErrSyntax},
+		{"1 2", ErrSyntax},
+		{"1..2", ErrSyntax},
+		{"tau", ErrSyntax},
+		{"sqrt 2", ErrSyntax},
+		{"foo(2)", ErrSyntax},
+		{"2 % 3", ErrSyntax},
+		{"128", ErrRange},
+		{"100 + 100", ErrRange},
+		{"-100 - 100", ErrRange},
+		{"20 * 20", ErrRange},
+		{"1 / 0", ErrRange},
+		{"1 / 0.0000001", ErrRange},
+		{"sqrt(-1)", ErrRange},
+		{"exp2(7)", ErrRange},
+		{"20000000", ErrRange},
+		{"5000 * 5000 / 10000000", ErrRange},
+		{"sin(200)", ErrRange},
+	} {
+		_, err := EvalQ24(tc.expr)
+		assert.Equal(t, tc.err, err, "EvalQ24(%#v)", tc.expr)
+	}
+}
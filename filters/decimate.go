@@ -0,0 +1,126 @@
+package filters
+
+import (
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/fixmath"
+)
+
+// Block is a filter with a single input and output, like the filters in this
+// package.
+type Block interface {
+	Update(x fixpoint.Q24) fixpoint.Q24
+}
+
+// RateDivider divides the rate of a periodic task, for example to run a
+// magnetometer update at 50Hz inside a 1kHz gyroscope loop. Unlike Decimator,
+// it doesn't run anything itself, so it can be used for any kind of task.
+//
+// The zero value runs the task on every call to Tick.
+type RateDivider struct {
+	// Factor is the ratio between the fast and the slow rate: the slow task
+	// runs once every Factor calls to Tick. A value of 0 or 1 runs it on
+	// every call.
+	Factor int
+
+	phase int // position of the current sample in the slow period
+	next  int // position of the next sample
+}
+
+// Tick advances by one fast sample, and returns whether the slow task should
+// run on this sample. It returns true on the first call.
+func (r *RateDivider) Tick() bool {
+	r.phase = r.next
+	r.next++
+	if r.next >= r.Factor {
+		r.next = 0
+	}
+	return r.phase == 0
+}
+
+// Frac returns the position of the current sample in the slow period, in the
+// range [0, 1): it is zero when the slow task ran on the last call to Tick
+// and increases by 1/Factor on every call after that. It can be used to
+// interpolate the output of the slow task.
+func (r *RateDivider) Frac() fixpoint.Q24 {
+	if r.Factor <= 1 {
+		return fixpoint.Q24{}
+	}
+	return fixpoint.Q24{N: int32((int64(r.phase) << 24) / int64(r.Factor))}
+}
+
+// Reset restarts the slow period, so that the slow task runs on the next call
+// to Tick.
+func (r *RateDivider) Reset() {
+	r.phase, r.next = 0, 0
+}
+
+// Decimator runs a block at a lower rate than its input, and holds or
+// interpolates the output of the block in between. This allows a slow (and
+// expensive) filter to be used in a fast loop.
+//
+// The zero value is not usable: Block must be set.
+type Decimator struct {
+	// Block is the filter that runs at the lower rate.
+	Block Block
+
+	// Factor is the ratio between the input rate and the rate of Block.
+	Factor int
+
+	// Average passes the average of the input samples since the previous
+	// run to Block, instead of only the last one. This is a simple
+	// anti-aliasing filter.
+	Average bool
+
+	// Interpolate interpolates linearly between the two most recent outputs
+	// of Block, instead of holding the most recent one. The output is
+	// smoother, but is delayed by another Factor samples.
+	Interpolate bool
+
+	divider RateDivider
+	sum     int64
+	count   int64
+	started bool
+	prev    fixpoint.Q24
+	last    fixpoint.Q24
+}
+
+// Update adds a new sample and returns the output at the input rate.
+func (d *Decimator) Update(x fixpoint.Q24) fixpoint.Q24 {
+	d.divider.Factor = d.Factor
+	d.sum += int64(x.N)
+	d.count++
+	if d.divider.Tick() {
+		in := x
+		if d.Average {
+			// Average with rounding to the nearest value.
+			sum := d.sum
+			if sum < 0 {
+				sum -= d.count / 2
+			} else {
+				sum += d.count / 2
+			}
+			in = fixpoint.Q24{N: int32(sum / d.count)}
+		}
+		d.sum, d.count = 0, 0
+		d.prev = d.last
+		d.last = d.Block.Update(in)
+		if !d.started {
+			d.prev = d.last
+			d.started = true
+		}
+	}
+	if !d.Interpolate {
+		return d.last
+	}
+	diff := int64(d.last.N) - int64(d.prev.N)
+	return fixpoint.Q24{N: fixmath.Saturate(int64(d.prev.N) + (diff*int64(d.divider.Frac().N)+1<<23)>>24)}
+}
+
+// Reset restarts the decimator, so that Block runs on the next call to
+// Update. It doesn't reset Block itself.
+func (d *Decimator) Reset() {
+	d.divider.Reset()
+	d.sum, d.count = 0, 0
+	d.started = false
+	d.prev, d.last = fixpoint.Q24{}, fixpoint.Q24{}
+}
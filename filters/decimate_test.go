@@ -0,0 +1,97 @@
+package filters
+
+import (
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+// The filters in this package can be used as a Block, including a Decimator
+// itself.
+var (
+	_ Block = (*LowPass)(nil)
+	_ Block = (*Biquad)(nil)
+	_ Block = (*FIR)(nil)
+	_ Block = (*MovingAverage)(nil)
+	_ Block = (*SavitzkyGolay)(nil)
+	_ Block = (*Decimator)(nil)
+)
+
+// countingBlock is a Block that records its inputs and returns them
+// multiplied by two.
+type countingBlock struct {
+	inputs []fixpoint.Q24
+}
+
+func (b *countingBlock) Update(x fixpoint.Q24) fixpoint.Q24 {
+	b.inputs = append(b.inputs, x)
+	return x.Add(x)
+}
+
+func TestRateDivider(t *testing.T) {
+	r := RateDivider{Factor: 4}
+	var ticks []bool
+	var fracs []float32
+	for i := 0; i < 9; i++ {
+		ticks = append(ticks, r.Tick())
+		fracs = append(fracs, r.Frac().Float())
+	}
+	assert.Equal(t, []bool{true, false, false, false, true, false, false, false, true}, ticks)
+	assert.Equal(t, []float32{0, 0.25, 0.5, 0.75, 0, 0.25, 0.5, 0.75, 0}, fracs)
+
+	r.Tick()
+	r.Reset()
+	assert.True(t, r.Tick())
+
+	// The zero value ticks every time.
+	var every RateDivider
+	for i := 0; i < 3; i++ {
+		assert.True(t, every.Tick())
+		assert.Equal(t, fixpoint.Q24{}, every.Frac())
+	}
+}
+
+func TestDecimator(t *testing.T) {
+	input := func(i int) fixpoint.Q24 { return fixpoint.Q24FromInt32(int32(i)) }
+
+	// Hold the output.
+	b := &countingBlock{}
+	d := Decimator{Block: b, Factor: 3}
+	var output []fixpoint.Q24
+	for i := 0; i < 7; i++ {
+		output = append(output, d.Update(input(i)))
+	}
+	assert.Equal(t, []fixpoint.Q24{input(0), input(3), input(6)}, b.inputs)
+	assert.Equal(t, []fixpoint.Q24{
+		input(0), input(0), input(0),
+		input(6), input(6), input(6),
+		input(12),
+	}, output)
+
+	// Average the input.
+	b = &countingBlock{}
+	d = Decimator{Block: b, Factor: 3, Average: true}
+	for i := 0; i < 7; i++ {
+		d.Update(input(i))
+	}
+	assert.Equal(t, []fixpoint.Q24{input(0), input(2), input(5)}, b.inputs)
+
+	// Interpolate the output, which is delayed by one period.
+	b = &countingBlock{}
+	d = Decimator{Block: b, Factor: 4, Interpolate: true}
+	output = nil
+	for i := 0; i < 10; i++ {
+		output = append(output, d.Update(input(i)))
+	}
+	assert.Equal(t, []fixpoint.Q24{
+		input(0), input(0), input(0), input(0),
+		input(0), input(2), input(4), input(6),
+		input(8), input(10),
+	}, output)
+
+	// Reset runs the block on the next update.
+	d.Reset()
+	assert.Equal(t, input(40), d.Update(input(20)))
+	assert.Equal(t, input(20), b.inputs[len(b.inputs)-1])
+}
@@ -0,0 +1,20 @@
+// Package filters implements digital filters for smoothing sensor data and
+// other signals, operating on Q24 samples.
+//
+// Filter coefficients are calculated from a normalized frequency: the cutoff
+// frequency divided by the sample rate. It must be in the range (0, 0.5), where
+// 0.5 is the Nyquist frequency. NormalizedFrequency calculates it in fixed
+// point for sample rates of up to 32kHz.
+package filters
+
+import (
+	"github.com/aykevl/fixpoint"
+)
+
+// NormalizedFrequency returns cutoff/sampleRate, rounded to the nearest Q24
+// value. Both frequencies are in Hz.
+func NormalizedFrequency(cutoff, sampleRate fixpoint.Q16) fixpoint.Q24 {
+	num := int64(cutoff.N) << 24
+	den := int64(sampleRate.N)
+	return fixpoint.Q24{N: int32((num + den/2) / den)}
+}
@@ -0,0 +1,108 @@
+package filters
+
+import (
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/fixmath"
+)
+
+// FIR is a finite impulse response filter. The output is the sum of the most
+// recent samples, each multiplied by the corresponding tap.
+//
+// The zero value outputs only zeroes: Taps must be set. The sample history is
+// allocated on the first call to Update.
+type FIR struct {
+	// Taps contains the filter coefficients, where Taps[0] is applied to the
+	// most recent sample. It must not be resized after the first call to
+	// Update.
+	Taps []fixpoint.Q24
+
+	history []int32
+	index   int // index of the most recent sample in history
+}
+
+// Update filters a new sample and returns the filtered value. The sum is
+// calculated with a 64-bit accumulator and rounded once, and the output
+// saturates to the Q24 range.
+func (f *FIR) Update(x fixpoint.Q24) fixpoint.Q24 {
+	if len(f.Taps) == 0 {
+		return fixpoint.Q24{}
+	}
+	if f.history == nil {
+		f.history = make([]int32, len(f.Taps))
+	}
+	f.index--
+	if f.index < 0 {
+		f.index = len(f.history) - 1
+	}
+	f.history[f.index] = x.N
+
+	var acc int64
+	j := f.index
+	for _, tap := range f.Taps {
+		acc += int64(tap.N) * int64(f.history[j])
+		j++
+		if j == len(f.history) {
+			j = 0
+		}
+	}
+	return fixpoint.Q24{N: fixmath.Saturate((acc + 1<<23) >> 24)}
+}
+
+// Reset clears the sample history.
+func (f *FIR) Reset() {
+	for i := range f.history {
+		f.history[i] = 0
+	}
+}
+
+// MovingAverage is a simple moving average over the last Length samples. It is
+// a FIR filter with equal taps, but it only takes constant time per sample.
+//
+// The zero value passes the input through unchanged, like a Length of 1. The
+// sample history is allocated on the first call to Update.
+type MovingAverage struct {
+	// Length is the number of samples to average. Zero or less means no
+	// averaging. It must not be changed after the first call to Update.
+	Length int
+
+	history []int32
+	index   int
+	count   int
+	sum     int64
+}
+
+// Update adds a new sample and returns the average of the last Length samples.
+// Until Length samples have been added, the average of the samples so far is
+// returned. The result is rounded to the nearest value.
+func (f *MovingAverage) Update(x fixpoint.Q24) fixpoint.Q24 {
+	if f.Length <= 0 {
+		return x
+	}
+	if f.history == nil {
+		f.history = make([]int32, f.Length)
+	}
+	if f.count == len(f.history) {
+		f.sum -= int64(f.history[f.index])
+	} else {
+		f.count++
+	}
+	f.history[f.index] = x.N
+	f.sum += int64(x.N)
+	f.index++
+	if f.index == len(f.history) {
+		f.index = 0
+	}
+
+	n := int64(f.count)
+	if f.sum < 0 {
+		return fixpoint.Q24{N: int32((f.sum - n/2) / n)}
+	}
+	return fixpoint.Q24{N: int32((f.sum + n/2) / n)}
+}
+
+// Reset clears the sample history.
+func (f *MovingAverage) Reset() {
+	f.index = 0
+	f.count = 0
+	f.sum = 0
+}
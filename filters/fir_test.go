@@ -0,0 +1,61 @@
+package filters
+
+import (
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFIR(t *testing.T) {
+	f := FIR{Taps: []fixpoint.Q24{
+		fixpoint.Q24FromFloat(0.5),
+		fixpoint.Q24FromFloat(0.25),
+		fixpoint.Q24FromFloat(0.25),
+	}}
+	var output []fixpoint.Q24
+	for _, x := range []int32{4, 0, 0, 0, 8} {
+		output = append(output, f.Update(fixpoint.Q24FromInt32(x)))
+	}
+	assert.Equal(t, []fixpoint.Q24{
+		fixpoint.Q24FromInt32(2),
+		fixpoint.Q24FromInt32(1),
+		fixpoint.Q24FromInt32(1),
+		fixpoint.Q24FromInt32(0),
+		fixpoint.Q24FromInt32(4),
+	}, output)
+
+	f.Reset()
+	assert.Equal(t, fixpoint.Q24FromInt32(1), f.Update(fixpoint.Q24FromInt32(2)))
+
+	// Saturation.
+	f = FIR{Taps: []fixpoint.Q24{fixpoint.Q24FromInt32(100)}}
+	assert.Equal(t, fixpoint.Q24{N: 1<<31 - 1}, f.Update(fixpoint.Q24FromInt32(2)))
+
+	var empty FIR
+	assert.Equal(t, fixpoint.Q24{}, empty.Update(fixpoint.Q24FromInt32(1)))
+}
+
+func TestMovingAverage(t *testing.T) {
+	f := MovingAverage{Length: 4}
+	var output []float32
+	for _, x := range []float32{1, 2, 3, 4, 5, -10} {
+		output = append(output, f.Update(fixpoint.Q24FromFloat(x)).Float())
+	}
+	assert.Equal(t, []float32{1, 1.5, 2, 2.5, 3.5, 0.5}, output)
+
+	f.Reset()
+	assert.Equal(t, fixpoint.Q24FromInt32(-3), f.Update(fixpoint.Q24FromInt32(-3)))
+	assert.Equal(t, fixpoint.Q24{N: -1}, (&MovingAverage{Length: 2}).Update(fixpoint.Q24{N: -1}))
+	f = MovingAverage{Length: 2}
+	f.Update(fixpoint.Q24{N: -1})
+	assert.Equal(t, fixpoint.Q24{N: -1}, f.Update(fixpoint.Q24{N: 0})) // -0.5 rounds away from zero
+
+	// The zero value passes the input through.
+	var zero MovingAverage
+	assert.Equal(t, fixpoint.Q24FromInt32(2), zero.Update(fixpoint.Q24FromInt32(2)))
+	assert.Equal(t, fixpoint.Q24FromInt32(-5), zero.Update(fixpoint.Q24FromInt32(-5)))
+	assert.NoError(t, zero.Restore(zero.State()))
+	negative := MovingAverage{Length: -1}
+	assert.Equal(t, fixpoint.Q24FromInt32(3), negative.Update(fixpoint.Q24FromInt32(3)))
+}
@@ -0,0 +1,181 @@
+package filters
+
+import (
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/fixmath"
+)
+
+// LowPass is a first-order low-pass filter, also known as an exponential
+// moving average: y += alpha * (x - y). The state is kept with 24 extra bits
+// of precision, so that the output does converge to the input even for very
+// small values of Alpha.
+//
+// The zero value isn't useful: with an Alpha of zero, the output stays at the
+// first sample forever. Set Alpha, for example with LowPassAlpha.
+type LowPass struct {
+	// Alpha is the smoothing factor in the range (0, 1]. A value of 1 passes
+	// the input unfiltered.
+	Alpha fixpoint.Q24
+
+	y       int64 // Q48
+	started bool
+}
+
+// LowPassAlpha returns the smoothing factor of a first-order low-pass filter
+// with the given normalized cutoff frequency. It is calculated as in an RC
+// circuit: alpha = 2πf / (2πf + 1).
+func LowPassAlpha(freq fixpoint.Q24) fixpoint.Q24 {
+	w := int64(fixpoint.TwoPi.MulRound(freq).N)
+	return fixpoint.Q24{N: int32((w<<24 + (w+1<<24)/2) / (w + 1<<24))}
+}
+
+// Update filters a new sample and returns the filtered value. The first sample
+// initializes the filter state, to avoid a slow start from zero.
+func (f *LowPass) Update(x fixpoint.Q24) fixpoint.Q24 {
+	if !f.started {
+		f.Reset(x)
+	} else {
+		f.y += fixmath.MulQ24(int64(x.N)<<24-f.y, int64(f.Alpha.N))
+	}
+	return f.Value()
+}
+
+// Value returns the current output of the filter.
+func (f *LowPass) Value() fixpoint.Q24 {
+	return fixpoint.Q24{N: int32((f.y + 1<<23) >> 24)}
+}
+
+// Reset sets the filter state to the given value.
+func (f *LowPass) Reset(value fixpoint.Q24) {
+	f.y = int64(value.N) << 24
+	f.started = true
+}
+
+// BiquadCoeffs are the coefficients of a second-order IIR filter (biquad),
+// normalized so that a0 is 1. They are stored in Q2.30 format to keep enough
+// precision for low cutoff frequencies.
+type BiquadCoeffs struct {
+	B0, B1, B2 int32
+	A1, A2     int32
+}
+
+// Butterworth is the quality factor 1/√2 of a second-order Butterworth filter,
+// which has a maximally flat passband.
+var Butterworth = fixpoint.Q24{N: 11863283}
+
+// BiquadLowPass returns the coefficients of a second-order low-pass filter with
+// the given normalized cutoff frequency and quality factor, using the formulas
+// of the Audio EQ Cookbook by Robert Bristow-Johnson.
+func BiquadLowPass(freq, q fixpoint.Q24) BiquadCoeffs {
+	// 1 - cos(w0), calculated as 2*sin²(w0/2) for precision at low
+	// frequencies. The rest of the calculation is done in Q30.
+	oneMinusCos, cos, alpha := biquadParams(freq, q)
+	a0 := 1<<30 + alpha
+	b0 := div30(oneMinusCos/2, a0)
+	a1 := div30(-2*cos, a0)
+	// Adjust A2 by a rounding error so that the DC gain (b0+b1+b2)/(1+a1+a2)
+	// is exactly one. Otherwise the quantization of the coefficients causes
+	// a noticeable gain error at low cutoff frequencies.
+	return BiquadCoeffs{
+		B0: b0,
+		B1: 2 * b0,
+		B2: b0,
+		A1: a1,
+		A2: 4*b0 - 1<<30 - a1,
+	}
+}
+
+// BiquadHighPass returns the coefficients of a second-order high-pass filter
+// with the given normalized cutoff frequency and quality factor, using the
+// formulas of the Audio EQ Cookbook by Robert Bristow-Johnson.
+func BiquadHighPass(freq, q fixpoint.Q24) BiquadCoeffs {
+	oneMinusCos, cos, alpha := biquadParams(freq, q)
+	onePlusCos := 2<<30 - oneMinusCos
+	a0 := 1<<30 + alpha
+	b0 := div30(onePlusCos/2, a0)
+	// B1 is exactly -2*B0, so that the DC gain is exactly zero.
+	return BiquadCoeffs{
+		B0: b0,
+		B1: -2 * b0,
+		B2: b0,
+		A1: div30(-2*cos, a0),
+		A2: div30(1<<30-alpha, a0),
+	}
+}
+
+// BiquadBandPass returns the coefficients of a second-order band-pass filter
+// with the given normalized center frequency and quality factor, and a gain of
+// one at the center frequency. The bandwidth is freq/q. It uses the formulas of
+// the Audio EQ Cookbook by Robert Bristow-Johnson.
+func BiquadBandPass(freq, q fixpoint.Q24) BiquadCoeffs {
+	_, cos, alpha := biquadParams(freq, q)
+	a0 := 1<<30 + alpha
+	b0 := div30(alpha, a0)
+	// B2 is exactly -B0, so that the DC gain is exactly zero.
+	return BiquadCoeffs{
+		B0: b0,
+		B1: 0,
+		B2: -b0,
+		A1: div30(-2*cos, a0),
+		A2: div30(1<<30-alpha, a0),
+	}
+}
+
+// biquadParams returns 1-cos(w0), cos(w0) and sin(w0)/(2q) in Q30 format,
+// where w0 = 2π*freq.
+func biquadParams(freq, q fixpoint.Q24) (oneMinusCos, cos, alpha int64) {
+	w0 := fixpoint.TwoPi.MulRound(freq)
+	sinHalf := int64(fixpoint.Sin(w0.Mul(fixpoint.Q24{N: 1 << 23})).N) << 6
+	oneMinusCos = (2 * sinHalf * sinHalf) >> 30
+	cos = 1<<30 - oneMinusCos
+	sin := int64(fixpoint.Sin(w0).N) << 6
+	alpha = (sin << 23) / int64(q.N) // sin / (2q), both Q30
+	return
+}
+
+// div30 returns a/b for Q30 numbers, rounded to the nearest value.
+func div30(a, b int64) int32 {
+	num := a << 30
+	if num < 0 {
+		num -= b / 2
+	} else {
+		num += b / 2
+	}
+	return int32(num / b)
+}
+
+// Biquad is a second-order IIR filter in direct form I. The rounding error of
+// each step is fed back into the next, which avoids limit cycles and keeps the
+// noise low even with low cutoff frequencies.
+//
+// The zero value outputs only zeroes: Coeffs must be set, for example with
+// BiquadLowPass.
+type Biquad struct {
+	Coeffs BiquadCoeffs
+
+	x1, x2 int32 // previous inputs
+	y1, y2 int32 // previous outputs
+	err    int64 // rounding error of the previous output
+}
+
+// Update filters a new sample and returns the filtered value. The output
+// saturates to the Q24 range.
+func (f *Biquad) Update(x fixpoint.Q24) fixpoint.Q24 {
+	c := &f.Coeffs
+	acc := int64(c.B0)*int64(x.N) + int64(c.B1)*int64(f.x1) + int64(c.B2)*int64(f.x2) -
+		int64(c.A1)*int64(f.y1) - int64(c.A2)*int64(f.y2) + f.err
+	y := fixmath.Saturate(acc >> 30)
+	f.err = acc - int64(y)<<30
+	f.x2, f.x1 = f.x1, x.N
+	f.y2, f.y1 = f.y1, y
+	return fixpoint.Q24{N: y}
+}
+
+// Reset sets the filter state as if the given value had been the input for a
+// long time. This assumes the filter has unity gain at DC (like a low-pass
+// filter), use zero for other filters.
+func (f *Biquad) Reset(value fixpoint.Q24) {
+	f.x1, f.x2 = value.N, value.N
+	f.y1, f.y2 = value.N, value.N
+	f.err = 0
+}
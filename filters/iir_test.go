@@ -0,0 +1,142 @@
+package filters
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizedFrequency(t *testing.T) {
+	assert.Equal(t, fixpoint.Q24FromFloat(0.01), NormalizedFrequency(fixpoint.Q16FromInt32(10), fixpoint.Q16FromInt32(1000)))
+	assert.InDelta(t, 0.5/25000, NormalizedFrequency(fixpoint.Q16FromFloat(0.5), fixpoint.Q16FromInt32(25000)).Float(), 1e-7)
+}
+
+func TestLowPass(t *testing.T) {
+	alpha := LowPassAlpha(fixpoint.Q24FromFloat(0.01))
+	w := 2 * math.Pi * 0.01
+	assert.InDelta(t, w/(w+1), alpha.Float(), 1e-7)
+
+	// The first sample initializes the filter.
+	f := LowPass{Alpha: alpha}
+	assert.Equal(t, fixpoint.Q24FromInt32(1), f.Update(fixpoint.Q24FromInt32(1)))
+
+	// Without an Alpha, the output stays at the first sample.
+	var zero LowPass
+	assert.Equal(t, fixpoint.Q24FromInt32(2), zero.Update(fixpoint.Q24FromInt32(2)))
+	assert.Equal(t, fixpoint.Q24FromInt32(2), zero.Update(fixpoint.Q24FromInt32(7)))
+
+	// Compare the step response against float64.
+	y := 1.0
+	a := float64(alpha.N) / (1 << 24)
+	for i := 0; i < 500; i++ {
+		y += a * (3 - y)
+		assert.InDelta(t, y, f.Update(fixpoint.Q24FromInt32(3)).Float(), 1e-6)
+	}
+
+	// Even with a tiny alpha, the output reaches the input.
+	f = LowPass{Alpha: fixpoint.Q24{N: 100}}
+	f.Reset(fixpoint.Q24{})
+	for i := 0; i < 10000000; i++ {
+		f.Update(fixpoint.Q24{N: 1000})
+	}
+	assert.Equal(t, fixpoint.Q24{N: 1000}, f.Value())
+}
+
+// biquadFloat filters the input with the given coefficients in float64.
+func biquadFloat(c BiquadCoeffs, input []float64) []float64 {
+	b0, b1, b2 := float64(c.B0)/(1<<30), float64(c.B1)/(1<<30), float64(c.B2)/(1<<30)
+	a1, a2 := float64(c.A1)/(1<<30), float64(c.A2)/(1<<30)
+	var x1, x2, y1, y2 float64
+	output := make([]float64, len(input))
+	for i, x := range input {
+		y := b0*x + b1*x1 + b2*x2 - a1*y1 - a2*y2
+		x2, x1 = x1, x
+		y2, y1 = y1, y
+		output[i] = y
+	}
+	return output
+}
+
+func TestBiquad(t *testing.T) {
+	// Compare the coefficients against the cookbook formulas in float64.
+	for _, freq := range []float64{0.001, 0.01, 0.1, 0.3} {
+		w0 := 2 * math.Pi * freq
+		alpha := math.Sin(w0) / (2 * math.Sqrt2 / 2)
+		cos := math.Cos(w0)
+		a0 := 1 + alpha
+		lp := BiquadLowPass(fixpoint.Q24FromFloat(float32(freq)), Butterworth)
+		hp := BiquadHighPass(fixpoint.Q24FromFloat(float32(freq)), Butterworth)
+		bp := BiquadBandPass(fixpoint.Q24FromFloat(float32(freq)), Butterworth)
+		for _, tc := range []struct {
+			got  int32
+			want float64
+		}{
+			{lp.B0, (1 - cos) / 2 / a0},
+			{lp.B1, (1 - cos) / a0},
+			{lp.B2, (1 - cos) / 2 / a0},
+			{lp.A1, -2 * cos / a0},
+			{lp.A2, (1 - alpha) / a0},
+			{hp.B0, (1 + cos) / 2 / a0},
+			{hp.B1, -(1 + cos) / a0},
+			{bp.B0, alpha / a0},
+			{bp.B2, -alpha / a0},
+			{bp.A2, (1 - alpha) / a0},
+		} {
+			got := float64(tc.got) / (1 << 30)
+			assert.InDelta(t, tc.want, got, math.Max(math.Abs(tc.want)*1e-4, 1e-6), "freq=%f", freq)
+		}
+	}
+
+	// Filter random noise and compare against float64.
+	c := BiquadLowPass(fixpoint.Q24FromFloat(0.02), Butterworth)
+	f := Biquad{Coeffs: c}
+	r := rand.New(rand.NewSource(1))
+	var input []float64
+	var output []float32
+	for i := 0; i < 2000; i++ {
+		x := fixpoint.Q24FromFloat(float32(r.Float64()*2 - 1))
+		input = append(input, float64(x.N)/(1<<24))
+		output = append(output, f.Update(x).Float())
+	}
+	for i, want := range biquadFloat(c, input) {
+		assert.InDelta(t, want, output[i], 1e-5, "sample %d", i)
+	}
+
+	// DC gain is 1 for a low-pass filter, even at a very low cutoff.
+	f = Biquad{Coeffs: BiquadLowPass(fixpoint.Q24FromFloat(0.0005), Butterworth)}
+	var y fixpoint.Q24
+	for i := 0; i < 20000; i++ {
+		y = f.Update(fixpoint.Q24FromInt32(1))
+	}
+	assert.InDelta(t, 1, y.Float(), 1e-6)
+
+	// After reset, a constant input stays constant.
+	f.Reset(fixpoint.Q24FromInt32(-2))
+	assert.InDelta(t, -2, f.Update(fixpoint.Q24FromInt32(-2)).Float(), 1e-6)
+
+	// DC gain is 0 for a high-pass filter.
+	f = Biquad{Coeffs: BiquadHighPass(fixpoint.Q24FromFloat(0.01), Butterworth)}
+	for i := 0; i < 5000; i++ {
+		y = f.Update(fixpoint.Q24FromInt32(1))
+	}
+	assert.InDelta(t, 0, y.Float(), 1e-6)
+
+	// A band-pass filter passes its center frequency with a gain of one, but
+	// not DC.
+	f = Biquad{Coeffs: BiquadBandPass(fixpoint.Q24FromFloat(0.05), fixpoint.Q24FromInt32(2))}
+	var peak float32
+	for i := 0; i < 2000; i++ {
+		y = f.Update(fixpoint.Sin(fixpoint.Q24FromFloat(float32(2 * math.Pi * 0.05 * float64(i%20)))))
+		if i > 1000 && y.Float() > peak {
+			peak = y.Float()
+		}
+	}
+	assert.InDelta(t, 1, peak, 0.01)
+	for i := 0; i < 5000; i++ {
+		y = f.Update(fixpoint.Q24FromInt32(1))
+	}
+	assert.InDelta(t, 0, y.Float(), 1e-6)
+}
@@ -0,0 +1,106 @@
+package filters
+
+import (
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/fixmath"
+)
+
+// SGCoeffs is a set of Savitzky-Golay coefficients: the weights of a least
+// squares polynomial fit over a window of samples, evaluated at the center of
+// the window. The weights are integers so that they are exact, and the result
+// is divided by Norm.
+type SGCoeffs struct {
+	// Weights contains the weights in time order: Weights[0] is applied to
+	// the oldest sample in the window.
+	Weights []int32
+
+	// Norm is the value to divide the weighted sum by.
+	Norm int32
+}
+
+// Common Savitzky-Golay coefficients, with a quadratic (or cubic, which has
+// the same coefficients) fit for smoothing and the second derivative, and a
+// quadratic fit for the first derivative. A longer window reduces more noise,
+// but also smooths out more of the signal and adds more delay.
+var (
+	SGSmooth5 = SGCoeffs{[]int32{-3, 12, 17, 12, -3}, 35}
+	SGSmooth7 = SGCoeffs{[]int32{-2, 3, 6, 7, 6, 3, -2}, 21}
+	SGSmooth9 = SGCoeffs{[]int32{-21, 14, 39, 54, 59, 54, 39, 14, -21}, 231}
+
+	SGDerivative5 = SGCoeffs{[]int32{-2, -1, 0, 1, 2}, 10}
+	SGDerivative7 = SGCoeffs{[]int32{-3, -2, -1, 0, 1, 2, 3}, 28}
+	SGDerivative9 = SGCoeffs{[]int32{-4, -3, -2, -1, 0, 1, 2, 3, 4}, 60}
+
+	SGSecondDerivative5 = SGCoeffs{[]int32{2, -1, -2, -1, 2}, 7}
+	SGSecondDerivative7 = SGCoeffs{[]int32{5, 0, -3, -4, -3, 0, 5}, 42}
+)
+
+// SavitzkyGolay is a streaming Savitzky-Golay filter, which fits a polynomial
+// to a window of samples to smooth them or to estimate their derivative. It
+// estimates the rate of change of a noisy signal much better than the
+// difference between two samples. The output belongs to the center of the
+// window, so it is delayed by (len(Weights)-1)/2 samples.
+//
+// The zero value outputs only zeroes: Coeffs must be set. The sample history
+// is allocated on the first call to Update.
+type SavitzkyGolay struct {
+	// Coeffs contains the filter coefficients. The number of weights must not
+	// be changed after the first call to Update.
+	Coeffs SGCoeffs
+
+	// Scale is an integer factor for the output. A derivative is calculated
+	// per sample, so set it to the sample rate to get the first derivative
+	// per second, or to the square of the sample rate for the second
+	// derivative. Zero means 1.
+	Scale int32
+
+	history []int32
+	index   int // index of the most recent sample in history
+}
+
+// Update filters a new sample and returns the filtered value. The weighted sum
+// is calculated with a 64-bit accumulator and rounded once, and the output
+// saturates to the Q24 range.
+func (f *SavitzkyGolay) Update(x fixpoint.Q24) fixpoint.Q24 {
+	weights := f.Coeffs.Weights
+	if len(weights) == 0 || f.Coeffs.Norm == 0 {
+		return fixpoint.Q24{}
+	}
+	if f.history == nil {
+		f.history = make([]int32, len(weights))
+	}
+	f.index--
+	if f.index < 0 {
+		f.index = len(f.history) - 1
+	}
+	f.history[f.index] = x.N
+
+	var acc int64
+	j := f.index
+	for i := len(weights) - 1; i >= 0; i-- {
+		acc += int64(weights[i]) * int64(f.history[j])
+		j++
+		if j == len(f.history) {
+			j = 0
+		}
+	}
+	if f.Scale != 0 {
+		acc *= int64(f.Scale)
+	}
+
+	// Divide by the norm, rounding to the nearest value.
+	norm := int64(f.Coeffs.Norm)
+	if acc < 0 {
+		acc -= norm / 2
+	} else {
+		acc += norm / 2
+	}
+	return fixpoint.Q24{N: fixmath.Saturate(acc / norm)}
+}
+
+// Reset clears the sample history.
+func (f *SavitzkyGolay) Reset() {
+	for i := range f.history {
+		f.history[i] = 0
+	}
+}
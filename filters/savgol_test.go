@@ -0,0 +1,73 @@
+package filters
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSavitzkyGolay(t *testing.T) {
+	// The polynomial fit is exact for cubic polynomials (quadratic for the
+	// first derivative), once the window is filled.
+	cubic := func(k float64) float64 { return 0.001*k*k*k - 0.02*k*k + 0.3*k - 1 }
+	quadratic := func(k float64) float64 { return 0.01*k*k - 0.3*k + 2 }
+	for _, tc := range []struct {
+		coeffs SGCoeffs
+		input  func(k float64) float64
+		output func(k float64) float64
+	}{
+		{SGSmooth5, cubic, cubic},
+		{SGSmooth7, cubic, cubic},
+		{SGSmooth9, cubic, cubic},
+		{SGDerivative5, quadratic, func(k float64) float64 { return 0.02*k - 0.3 }},
+		{SGDerivative7, quadratic, func(k float64) float64 { return 0.02*k - 0.3 }},
+		{SGDerivative9, quadratic, func(k float64) float64 { return 0.02*k - 0.3 }},
+		{SGSecondDerivative5, cubic, func(k float64) float64 { return 0.006*k - 0.04 }},
+		{SGSecondDerivative7, cubic, func(k float64) float64 { return 0.006*k - 0.04 }},
+	} {
+		f := SavitzkyGolay{Coeffs: tc.coeffs}
+		n := len(tc.coeffs.Weights)
+		delay := float64(n-1) / 2
+		for k := 0; k < 30; k++ {
+			y := f.Update(fixpoint.Q24FromFloat64(tc.input(float64(k))))
+			if k >= n-1 {
+				assert.InDelta(t, tc.output(float64(k)-delay), y.Float64(), 1e-6, "%v, k=%d", tc.coeffs.Weights, k)
+			}
+		}
+	}
+
+	// The derivative of a noisy ramp of 0.5 per second, sampled at 50Hz, is
+	// much closer to the real rate than the difference between two samples.
+	var r fixpoint.Rand
+	r.Seed(1)
+	f := SavitzkyGolay{Coeffs: SGDerivative9, Scale: 50}
+	var prev fixpoint.Q24
+	var errSG, errDiff float64
+	for k := 0; k < 200; k++ {
+		noise := r.Q24Range(fixpoint.Q24FromFloat(-0.002), fixpoint.Q24FromFloat(0.002))
+		x := fixpoint.Q24FromFloat64(0.01 * float64(k)).Add(noise)
+		rate := f.Update(x)
+		diff := x.Sub(prev).Mul(fixpoint.Q24FromInt32(50))
+		prev = x
+		if k >= 10 {
+			errSG = math.Max(errSG, math.Abs(rate.Float64()-0.5))
+			errDiff = math.Max(errDiff, math.Abs(diff.Float64()-0.5))
+		}
+	}
+	if errSG > 0.05 || errSG*3 > errDiff {
+		t.Errorf("derivative not smooth enough: error %f, finite difference error %f", errSG, errDiff)
+	}
+
+	// Reset clears the history, only the newest sample is weighted.
+	f.Reset()
+	assert.Equal(t, fixpoint.Q24FromFloat64(0.25*4*50/60), f.Update(fixpoint.Q24FromFloat(0.25)))
+
+	// Saturation.
+	f = SavitzkyGolay{Coeffs: SGDerivative5, Scale: 1000}
+	assert.Equal(t, fixpoint.Q24{N: 1<<31 - 1}, f.Update(fixpoint.Q24FromInt32(100)))
+
+	var empty SavitzkyGolay
+	assert.Equal(t, fixpoint.Q24{}, empty.Update(fixpoint.Q24FromInt32(1)))
+}
@@ -0,0 +1,214 @@
+package filters
+
+import (
+	"errors"
+
+	"github.com/aykevl/fixpoint/internal/le"
+)
+
+// Filter state
+//
+// All filters have a State method that returns their internal state (but not
+// their configuration, such as coefficients) as a byte slice, and a Restore
+// method that sets it again.
+
+var errInvalidState = errors.New("filters: invalid state")
+
+// State returns the internal state of the filter.
+func (f *LowPass) State() []byte {
+	return f.AppendState(make([]byte, 0, 9))
+}
+
+// AppendState appends the state returned by State to dst and returns the
+// extended slice.
+func (f *LowPass) AppendState(dst []byte) []byte {
+	buf := le.AppendInt64(dst, f.y)
+	return le.AppendBool(buf, f.started)
+}
+
+// Restore sets the internal state of the filter to a state returned by State.
+func (f *LowPass) Restore(state []byte) error {
+	if len(state) != 9 {
+		return errInvalidState
+	}
+	f.y = le.Int64(state)
+	f.started = state[8] != 0
+	return nil
+}
+
+// State returns the internal state of the filter.
+func (f *Biquad) State() []byte {
+	return f.AppendState(make([]byte, 0, 24))
+}
+
+// AppendState appends the state returned by State to dst and returns the
+// extended slice.
+func (f *Biquad) AppendState(dst []byte) []byte {
+	buf := dst
+	for _, n := range [...]int32{f.x1, f.x2, f.y1, f.y2} {
+		buf = le.AppendInt32(buf, n)
+	}
+	return le.AppendInt64(buf, f.err)
+}
+
+// Restore sets the internal state of the filter to a state returned by State.
+func (f *Biquad) Restore(state []byte) error {
+	if len(state) != 24 {
+		return errInvalidState
+	}
+	f.x1, f.x2 = le.Int32(state[0:]), le.Int32(state[4:])
+	f.y1, f.y2 = le.Int32(state[8:]), le.Int32(state[12:])
+	f.err = le.Int64(state[16:])
+	return nil
+}
+
+// State returns the internal state of the filter.
+func (f *SVF) State() []byte {
+	return f.AppendState(make([]byte, 0, 16))
+}
+
+// AppendState appends the state returned by State to dst and returns the
+// extended slice.
+func (f *SVF) AppendState(dst []byte) []byte {
+	return le.AppendInt64(le.AppendInt64(dst, f.low), f.band)
+}
+
+// Restore sets the internal state of the filter to a state returned by State.
+func (f *SVF) Restore(state []byte) error {
+	if len(state) != 16 {
+		return errInvalidState
+	}
+	f.low, f.band = le.Int64(state), le.Int64(state[8:])
+	return nil
+}
+
+// State returns the internal state of the filter, which includes the sample
+// history.
+func (f *FIR) State() []byte {
+	return f.AppendState(make([]byte, 0, 4+4*len(f.history)))
+}
+
+// AppendState appends the state returned by State to dst and returns the
+// extended slice.
+func (f *FIR) AppendState(dst []byte) []byte {
+	buf := le.AppendInt32(dst, int32(f.index))
+	for _, n := range f.history {
+		buf = le.AppendInt32(buf, n)
+	}
+	return buf
+}
+
+// Restore sets the internal state of the filter to a state returned by State.
+// The number of taps must be the same as when the state was saved.
+func (f *FIR) Restore(state []byte) error {
+	if len(state) == 4 && le.Int32(state) == 0 {
+		// Saved before the first call to Update.
+		f.history = nil
+		f.index = 0
+		return nil
+	}
+	if len(state) != 4+4*len(f.Taps) {
+		return errInvalidState
+	}
+	index := int(le.Int32(state))
+	if index < 0 || index >= len(f.Taps) {
+		return errInvalidState
+	}
+	if len(f.history) != len(f.Taps) {
+		f.history = make([]int32, len(f.Taps))
+	}
+	f.index = index
+	for i := range f.history {
+		f.history[i] = le.Int32(state[4+4*i:])
+	}
+	return nil
+}
+
+// State returns the internal state of the filter, which includes the sample
+// history.
+func (f *SavitzkyGolay) State() []byte {
+	return f.AppendState(make([]byte, 0, 4+4*len(f.history)))
+}
+
+// AppendState appends the state returned by State to dst and returns the
+// extended slice.
+func (f *SavitzkyGolay) AppendState(dst []byte) []byte {
+	buf := le.AppendInt32(dst, int32(f.index))
+	for _, n := range f.history {
+		buf = le.AppendInt32(buf, n)
+	}
+	return buf
+}
+
+// Restore sets the internal state of the filter to a state returned by State.
+// The number of weights must be the same as when the state was saved.
+func (f *SavitzkyGolay) Restore(state []byte) error {
+	n := len(f.Coeffs.Weights)
+	if len(state) == 4 && le.Int32(state) == 0 {
+		// Saved before the first call to Update.
+		f.history = nil
+		f.index = 0
+		return nil
+	}
+	if len(state) != 4+4*n {
+		return errInvalidState
+	}
+	index := int(le.Int32(state))
+	if index < 0 || index >= n {
+		return errInvalidState
+	}
+	if len(f.history) != n {
+		f.history = make([]int32, n)
+	}
+	f.index = index
+	for i := range f.history {
+		f.history[i] = le.Int32(state[4+4*i:])
+	}
+	return nil
+}
+
+// State returns the internal state of the filter, which includes the sample
+// history.
+func (f *MovingAverage) State() []byte {
+	return f.AppendState(make([]byte, 0, 16+4*len(f.history)))
+}
+
+// AppendState appends the state returned by State to dst and returns the
+// extended slice.
+func (f *MovingAverage) AppendState(dst []byte) []byte {
+	buf := dst
+	buf = le.AppendInt32(buf, int32(f.index))
+	buf = le.AppendInt32(buf, int32(f.count))
+	buf = le.AppendInt64(buf, f.sum)
+	for _, n := range f.history {
+		buf = le.AppendInt32(buf, n)
+	}
+	return buf
+}
+
+// Restore sets the internal state of the filter to a state returned by State.
+// Length must be the same as when the state was saved.
+func (f *MovingAverage) Restore(state []byte) error {
+	if len(state) == 16 {
+		// Saved before the first call to Update.
+		f.history = nil
+		f.Reset()
+		return nil
+	}
+	if len(state) != 16+4*f.Length {
+		return errInvalidState
+	}
+	index, count := int(le.Int32(state)), int(le.Int32(state[4:]))
+	if index < 0 || index >= f.Length || count < 0 || count > f.Length {
+		return errInvalidState
+	}
+	if len(f.history) != f.Length {
+		f.history = make([]int32, f.Length)
+	}
+	f.index, f.count = index, count
+	f.sum = le.Int64(state[8:])
+	for i := range f.history {
+		f.history[i] = le.Int32(state[16+4*i:])
+	}
+	return nil
+}
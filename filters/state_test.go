@@ -0,0 +1,102 @@
+package filters
+
+import (
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/statetest"
+	"github.com/stretchr/testify/assert"
+)
+
+// filter is implemented by all filters in this package that take one sample
+// at a time.
+type filter interface {
+	Update(fixpoint.Q24) fixpoint.Q24
+	State() []byte
+	Restore([]byte) error
+}
+
+func TestState(t *testing.T) {
+	taps := []fixpoint.Q24{fixpoint.Q24FromFloat(0.5), fixpoint.Q24FromFloat(0.25), fixpoint.Q24FromFloat(0.25)}
+	newFilters := func() []filter {
+		return []filter{
+			&LowPass{Alpha: fixpoint.Q24FromFloat(0.1)},
+			&Biquad{Coeffs: BiquadLowPass(fixpoint.Q24FromFloat(0.05), fixpoint.Q24FromFloat(0.7071))},
+			&svfFilter{},
+			&FIR{Taps: taps},
+			&MovingAverage{Length: 5},
+			&SavitzkyGolay{Coeffs: SGDerivative7, Scale: 100},
+		}
+	}
+	input := func(i int) fixpoint.Q24 {
+		return fixpoint.Q24{N: int32(i*i*7919%100000) << 8}
+	}
+
+	filters := newFilters()
+	for i, f := range filters {
+		// A state saved before the first update can be restored too.
+		initial := f.State()
+		for j := 0; j < 20; j++ {
+			f.Update(input(j))
+		}
+		state := f.State()
+
+		// A new filter restored from the state continues exactly where the
+		// old one left off.
+		restored := newFilters()[i]
+		if !assert.NoError(t, restored.Restore(state)) {
+			continue
+		}
+		assert.Equal(t, state, restored.State())
+		for j := 20; j < 40; j++ {
+			assert.Equal(t, f.Update(input(j)), restored.Update(input(j)), "filter %T", f)
+		}
+
+		fresh := newFilters()[i]
+		assert.NoError(t, restored.Restore(initial))
+		assert.Equal(t, fresh.Update(input(1)), restored.Update(input(1)), "filter %T", f)
+
+		// Once the history has been allocated, updates don't allocate.
+		if allocs := testing.AllocsPerRun(10, func() { f.Update(input(1)) }); allocs != 0 {
+			t.Errorf("%T: Update allocates %v times", f, allocs)
+		}
+
+		assert.Error(t, f.Restore(state[:len(state)-1]))
+	}
+
+	// The history size must match.
+	fir := &FIR{Taps: taps}
+	fir.Update(fixpoint.Q24{})
+	assert.Error(t, (&FIR{Taps: taps[:2]}).Restore(fir.State()))
+	avg := &MovingAverage{Length: 5}
+	avg.Update(fixpoint.Q24{})
+	assert.Error(t, (&MovingAverage{Length: 4}).Restore(avg.State()))
+}
+
+// svfFilter is a state-variable filter as a low-pass filter, for TestState.
+type svfFilter struct {
+	SVF
+}
+
+func (f *svfFilter) Update(x fixpoint.Q24) fixpoint.Q24 {
+	return f.SVF.Update(x, SVFCutoff(fixpoint.Q24FromFloat(0.05)), SVFDamping(fixpoint.Q24FromFloat(2))).Low
+}
+
+func TestAppendState(t *testing.T) {
+	fir := &FIR{Taps: []fixpoint.Q24{fixpoint.Q24FromFloat(0.5), fixpoint.Q24FromFloat(0.5)}}
+	fir.Update(fixpoint.Q24FromInt32(1))
+	avg := &MovingAverage{Length: 5}
+	avg.Update(fixpoint.Q24FromInt32(1))
+	sg := &SavitzkyGolay{Coeffs: SGDerivative7, Scale: 100}
+	sg.Update(fixpoint.Q24FromInt32(1))
+	for _, s := range []statetest.Appender{
+		&LowPass{},
+		&Biquad{},
+		&SVF{},
+		fir,
+		avg,
+		sg,
+	} {
+		statetest.CheckAppend(t, s)
+	}
+}
@@ -0,0 +1,75 @@
+package filters
+
+import (
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/fixmath"
+)
+
+// SVF is a Chamberlin state-variable filter. It calculates low-pass,
+// high-pass, band-pass and notch outputs at the same time, and its cutoff
+// frequency and resonance can be changed on every sample without clicks or
+// instability, which makes it well suited for synthesizers.
+//
+// The filter is stable as long as the cutoff coefficient is below
+// 2 - damping, which in practice limits the cutoff frequency to about a sixth
+// of the sample rate. The state saturates instead of wrapping around, so even
+// an unstable filter only distorts.
+//
+// The zero value is a filter with zero state, ready to use.
+type SVF struct {
+	low, band int64 // Q48
+}
+
+// SVFOutput contains all outputs of a state-variable filter for one sample.
+type SVFOutput struct {
+	Low   fixpoint.Q24
+	High  fixpoint.Q24
+	Band  fixpoint.Q24
+	Notch fixpoint.Q24
+}
+
+// SVFCutoff returns the cutoff coefficient of a state-variable filter for a
+// normalized cutoff frequency: 2·sin(π·freq).
+func SVFCutoff(freq fixpoint.Q24) fixpoint.Q24 {
+	return fixpoint.Sin(fixpoint.Pi.MulRound(freq)).Mul(fixpoint.Q24FromInt32(2))
+}
+
+// SVFDamping returns the damping coefficient for the given resonance (Q
+// factor): 1/q. A Q of 0.707 (no resonance peak) gives a damping of 1.414, and
+// higher values of Q give a stronger resonance.
+func SVFDamping(q fixpoint.Q24) fixpoint.Q24 {
+	return fixpoint.Q24FromInt32(1).DivRound(q)
+}
+
+// Update filters a new sample with the given cutoff coefficient (see
+// SVFCutoff) and damping (see SVFDamping), and returns all outputs. The
+// outputs saturate to the Q24 range.
+func (f *SVF) Update(x, cutoff, damping fixpoint.Q24) SVFOutput {
+	f.low = clampState(f.low + fixmath.MulQ24(f.band, int64(cutoff.N)))
+	high := clampState(int64(x.N)<<24 - f.low - fixmath.MulQ24(f.band, int64(damping.N)))
+	f.band = clampState(f.band + fixmath.MulQ24(high, int64(cutoff.N)))
+	return SVFOutput{
+		Low:   fixpoint.Q24{N: fixmath.Saturate((f.low + 1<<23) >> 24)},
+		High:  fixpoint.Q24{N: fixmath.Saturate((high + 1<<23) >> 24)},
+		Band:  fixpoint.Q24{N: fixmath.Saturate((f.band + 1<<23) >> 24)},
+		Notch: fixpoint.Q24{N: fixmath.Saturate((high + f.low + 1<<23) >> 24)},
+	}
+}
+
+// Reset clears the filter state.
+func (f *SVF) Reset() {
+	f.low, f.band = 0, 0
+}
+
+// clampState clamps a Q48 state variable to the Q24 range.
+func clampState(n int64) int64 {
+	const max = (1<<31 - 1) << 24
+	const min = -1 << 31 << 24
+	if n > max {
+		return max
+	}
+	if n < min {
+		return min
+	}
+	return n
+}
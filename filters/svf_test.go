@@ -0,0 +1,77 @@
+package filters
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSVFCoefficients(t *testing.T) {
+	assert.InDelta(t, 2*math.Sin(math.Pi*0.05), SVFCutoff(fixpoint.Q24FromFloat(0.05)).Float64(), 1e-6)
+	assert.InDelta(t, math.Sqrt2, SVFDamping(fixpoint.Q24FromFloat(math.Sqrt2/2)).Float64(), 1e-6)
+}
+
+// svfGain returns the amplitude of the low-pass, high-pass and band-pass
+// outputs of a sine wave with the given normalized frequency.
+func svfGain(freq float64, cutoff, damping fixpoint.Q24) (low, high, band float64) {
+	var f SVF
+	for i := 0; i < 4000; i++ {
+		x := fixpoint.Q24FromFloat64(math.Sin(2 * math.Pi * freq * float64(i)))
+		out := f.Update(x, cutoff, damping)
+		if i >= 2000 {
+			low = math.Max(low, out.Low.Float64())
+			high = math.Max(high, out.High.Float64())
+			band = math.Max(band, out.Band.Float64())
+		}
+	}
+	return
+}
+
+func TestSVF(t *testing.T) {
+	cutoff := SVFCutoff(fixpoint.Q24FromFloat(0.02))
+	damping := SVFDamping(fixpoint.Q24FromFloat(math.Sqrt2 / 2))
+
+	// DC passes through the low-pass output only.
+	var f SVF
+	var out SVFOutput
+	for i := 0; i < 2000; i++ {
+		out = f.Update(fixpoint.Q24FromInt32(1), cutoff, damping)
+	}
+	assert.Equal(t, fixpoint.Q24FromInt32(1), out.Low)
+	assert.Equal(t, fixpoint.Q24{}, out.High)
+	assert.Equal(t, fixpoint.Q24{}, out.Band)
+	assert.Equal(t, out.Low, out.Notch)
+
+	// Frequencies well below and above the cutoff.
+	low, high, _ := svfGain(0.002, cutoff, damping)
+	assert.InDelta(t, 1, low, 0.01)
+	assert.InDelta(t, 0, high, 0.02)
+	low, high, _ = svfGain(0.15, cutoff, damping)
+	assert.InDelta(t, 0, low, 0.03)
+	assert.InDelta(t, 1, high, 0.1)
+
+	// With a high Q, there is a resonance peak at the cutoff frequency.
+	_, _, band := svfGain(0.02, cutoff, SVFDamping(fixpoint.Q24FromInt32(8)))
+	assert.InDelta(t, 8, band, 0.5)
+
+	f.Reset()
+	assert.Equal(t, SVF{}, f)
+}
+
+func TestSVFModulation(t *testing.T) {
+	// Sweep the cutoff and resonance on every sample. The output must stay
+	// bounded.
+	var f SVF
+	for i := 0; i < 20000; i++ {
+		x := fixpoint.Q24FromFloat64(math.Sin(0.05 * float64(i)))
+		freq := 0.01 + 0.14*(1+math.Sin(0.001*float64(i)))/2
+		q := 0.5 + 10*(1+math.Cos(0.0007*float64(i)))/2
+		out := f.Update(x, SVFCutoff(fixpoint.Q24FromFloat64(freq)), SVFDamping(fixpoint.Q24FromFloat64(q)))
+		if out.Low.Float64() > 30 || out.Low.Float64() < -30 {
+			t.Fatalf("sample %d: output out of range: %v", i, out.Low.Float64())
+		}
+	}
+
+}
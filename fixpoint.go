@@ -3,9 +3,22 @@
 // package.
 package fixpoint
 
+import (
+	"math"
+)
+
 // Useful link:
 // https://spin.atomicobject.com/2012/03/15/simple-fixed-point-math/
 
+// Fixed point formats other than Q24 are generated from a template. Q16 has a
+// lot more range than Q24 at the cost of less precision, which makes it more
+// suitable for things like positions and velocities.
+//go:generate go run ./cmd/fixgen -name Q16 -frac 16 -o q16.go
+
+// Q15 and Q31 are the usual formats for audio samples in the range [-1, 1).
+//go:generate go run ./cmd/fixgen -name Q15 -frac 15 -bits 16 -scalar -o q15.go
+//go:generate go run ./cmd/fixgen -name Q31 -frac 31 -scalar -o q31.go
+
 // Q24 is a Q7.24 fixed point integer type that has 24 bits of precision to the
 // right of the fixed point. It is designed to be used as a more efficient
 // replacement for unit vectors with some extra room to avoid overflow.
@@ -13,23 +26,81 @@ type Q24 struct {
 	N int32
 }
 
+// The smallest and largest numbers that can be represented as a Q24.
+var (
+	MinQ24 = Q24{minN} // -128
+	MaxQ24 = Q24{maxN} // 128 - 2^-24
+)
+
 // Q24FromFloat converts a float32 to the same number in fixed point format.
 // Inverse of .Float().
 func Q24FromFloat(x float32) Q24 {
 	return Q24{int32(x * (1 << 24))}
 }
 
+// Q24FromFloat64 converts a float64 to the nearest number in fixed point
+// format. The result is undefined for values outside the range of Q24, use
+// Q24FromFloat64Checked when the input may be out of range. Inverse of
+// .Float64().
+func Q24FromFloat64(x float64) Q24 {
+	return Q24{int32(math.Floor(x*(1<<24) + 0.5))}
+}
+
+// Q24FromFloatChecked converts a float32 to the nearest number in fixed point
+// format, and reports whether it is within the range of Q24 (that is, between
+// MinQ24 and MaxQ24). It returns false for NaN.
+func Q24FromFloatChecked(x float32) (Q24, bool) {
+	return Q24FromFloat64Checked(float64(x))
+}
+
+// Q24FromFloat64Checked converts a float64 to the nearest number in fixed
+// point format, and reports whether it is within the range of Q24 (that is,
+// between MinQ24 and MaxQ24). It returns false for NaN.
+func Q24FromFloat64Checked(x float64) (Q24, bool) {
+	n := math.Floor(x*(1<<24) + 0.5)
+	if !(n >= minN && n <= maxN) {
+		return Q24{}, false
+	}
+	return Q24{int32(n)}, true
+}
+
 // Q24FromInt32 returns a fixed point integer with all decimals set to zero.
 func Q24FromInt32(x int32) Q24 {
 	return Q24{x << 24}
 }
 
+// One is the underlying integer of the Q24 number 1, as an untyped constant.
+// Literals like Q24{One / 4} or Q24{-3 * One} are calculated by the compiler,
+// so they are a single immediate load at runtime.
+const One = 1 << 24
+
+// Q24FromConstFloat converts a float64 to the nearest number in fixed point
+// format, with ties rounded away from zero. It is meant for constant
+// arguments, like Q24FromConstFloat(0.7071): the function is small enough to
+// be inlined, after which the compiler (including TinyGo) folds the whole
+// conversion into a constant, so no floating point code is needed at runtime.
+// Use Q24FromFloat64 for values that are only known at runtime. The fixgen
+// command can check a package for conversions that could use this function
+// instead.
+func Q24FromConstFloat(x float64) Q24 {
+	if x < 0 {
+		return Q24{int32(x*(1<<24) - 0.5)}
+	}
+	return Q24{int32(x*(1<<24) + 0.5)}
+}
+
 // Float returns the floating point version of this fixed point number. Inverse
 // of Q24FromFloat.
 func (q Q24) Float() float32 {
 	return float32(q.N) / (1 << 24)
 }
 
+// Float64 returns the floating point version of this fixed point number, which
+// is always exact. Inverse of Q24FromFloat64.
+func (q Q24) Float64() float64 {
+	return float64(q.N) / (1 << 24)
+}
+
 // Int32Scaled returns the underlying fixed point number multiplied by scale.
 func (q Q24) Int32Scaled(scale int32) int32 {
 	return q.N / (1 << 24 / scale)
@@ -50,16 +121,46 @@ func (q1 Q24) Neg() Q24 {
 	return Q24{-q1.N}
 }
 
-// Mul returns this number multiplied by the argument.
+// Mul returns this number multiplied by the argument. The result is rounded
+// down (towards negative infinity).
 func (q1 Q24) Mul(q2 Q24) Q24 {
 	return Q24{int32((int64(q1.N) * int64(q2.N)) >> 24)}
 }
 
-// Div returns this number divided by the argument.
+// MulRound returns this number multiplied by the argument, rounded to the
+// nearest representable value. It is slightly slower than Mul, but doesn't
+// introduce a bias in iterative algorithms.
+func (q1 Q24) MulRound(q2 Q24) Q24 {
+	return Q24{int32((int64(q1.N)*int64(q2.N) + 1<<23) >> 24)}
+}
+
+// Div returns this number divided by the argument. The result is rounded
+// towards zero.
 func (q1 Q24) Div(q2 Q24) Q24 {
 	return Q24{int32((int64(q1.N) << 24) / int64(q2.N))}
 }
 
+// DivRound returns this number divided by the argument, rounded to the nearest
+// representable value.
+func (q1 Q24) DivRound(q2 Q24) Q24 {
+	return Q24{int32(divRound64(q1, q2))}
+}
+
+// divRound64 returns the quotient of two Q24 numbers as a Q24 in an int64,
+// rounded like DivRound. The divisor must not be zero.
+func divRound64(q1, q2 Q24) int64 {
+	num := int64(q1.N) << 24
+	den := int64(q2.N)
+	// Move the dividend half the divisor away from zero so that the truncating
+	// division rounds to the nearest value.
+	if num < 0 {
+		num -= abs64(den) / 2
+	} else {
+		num += abs64(den) / 2
+	}
+	return num / den
+}
+
 // Vec3Q24 is a 3-dimensional vector with Q24 fixed point elements.
 type Vec3Q24 struct {
 	X Q24
@@ -78,22 +179,76 @@ func (v1 Vec3Q24) Add(v2 Vec3Q24) Vec3Q24 {
 	return Vec3Q24{v1.X.Add(v2.X), v1.Y.Add(v2.Y), v1.Z.Add(v2.Z)}
 }
 
-// Mul returns this vector multiplied by the argument.
+// Sub returns the argument subtracted from this vector.
+func (v1 Vec3Q24) Sub(v2 Vec3Q24) Vec3Q24 {
+	// Copied from go-gl/mathgl and modified.
+	return Vec3Q24{v1.X.Sub(v2.X), v1.Y.Sub(v2.Y), v1.Z.Sub(v2.Z)}
+}
+
+// Neg returns the inverse of this vector.
+func (v Vec3Q24) Neg() Vec3Q24 {
+	return Vec3Q24{v.X.Neg(), v.Y.Neg(), v.Z.Neg()}
+}
+
+// Mul returns this vector multiplied by the argument. Like all vector and
+// quaternion operations, it rounds to the nearest representable value.
 func (v1 Vec3Q24) Mul(c Q24) Vec3Q24 {
 	// Copied from go-gl/mathgl and modified.
-	return Vec3Q24{v1.X.Mul(c), v1.Y.Mul(c), v1.Z.Mul(c)}
+	return Vec3Q24{v1.X.MulRound(c), v1.Y.MulRound(c), v1.Z.MulRound(c)}
+}
+
+// Div returns this vector divided by the argument, rounded to the nearest
+// representable value.
+func (v1 Vec3Q24) Div(c Q24) Vec3Q24 {
+	return Vec3Q24{v1.X.DivRound(c), v1.Y.DivRound(c), v1.Z.DivRound(c)}
+}
+
+// MulComponents returns the component-wise product of this vector and the
+// argument, for example to scale each axis by a different factor.
+func (v1 Vec3Q24) MulComponents(v2 Vec3Q24) Vec3Q24 {
+	return Vec3Q24{v1.X.MulRound(v2.X), v1.Y.MulRound(v2.Y), v1.Z.MulRound(v2.Z)}
 }
 
 // Dot returns the dot product between this vector and the argument.
 func (v1 Vec3Q24) Dot(v2 Vec3Q24) Q24 {
-	// Copied from go-gl/mathgl and modified.
-	return v1.X.Mul(v2.X).Add(v1.Y.Mul(v2.Y)).Add(v1.Z.Mul(v2.Z))
+	// Copied from go-gl/mathgl and modified, see mul_generic.go.
+	return Q24{dot3(v1.X.N, v1.Y.N, v1.Z.N, v2.X.N, v2.Y.N, v2.Z.N)}
 }
 
 // Cross returns the cross product between this vector and the argument.
 func (v1 Vec3Q24) Cross(v2 Vec3Q24) Vec3Q24 {
-	// Copied from go-gl/mathgl and modified.
-	return Vec3Q24{v1.Y.Mul(v2.Z).Sub(v1.Z.Mul(v2.Y)), v1.Z.Mul(v2.X).Sub(v1.X.Mul(v2.Z)), v1.X.Mul(v2.Y).Sub(v1.Y.Mul(v2.X))}
+	// Copied from go-gl/mathgl and modified, see mul_generic.go.
+	x, y, z := cross3(v1.X.N, v1.Y.N, v1.Z.N, v2.X.N, v2.Y.N, v2.Z.N)
+	return Vec3Q24{Q24{x}, Q24{y}, Q24{z}}
+}
+
+// Len2 returns the squared length of this vector. Note that it overflows for
+// vectors longer than about 11.3, use Len for those.
+func (v Vec3Q24) Len2() Q24 {
+	return v.Dot(v)
+}
+
+// Len returns the length of this vector.
+func (v Vec3Q24) Len() Q24 {
+	return Q24{int32(sqrt64(v.len2Q48()))}
+}
+
+// Normalize returns this vector scaled to unit length. The zero vector is
+// returned unmodified.
+func (v Vec3Q24) Normalize() Vec3Q24 {
+	len2 := v.len2Q48()
+	if len2 == 0 {
+		return v
+	}
+	r, shift := recipSqrtQ48(len2)
+	return Vec3Q24{mulRecip(v.X, r, shift), mulRecip(v.Y, r, shift), mulRecip(v.Z, r, shift)}
+}
+
+// len2Q48 returns the squared length of this vector in Q48 format, which does
+// not lose precision and doesn't overflow.
+func (v Vec3Q24) len2Q48() uint64 {
+	x, y, z := int64(v.X.N), int64(v.Y.N), int64(v.Z.N)
+	return uint64(x*x) + uint64(y*y) + uint64(z*z)
 }
 
 // QuatQ24 is a quaternion with Q24 fixed point elements.
@@ -124,8 +279,12 @@ func (q QuatQ24) Z() Q24 {
 
 // Mul returns this quaternion multiplied by the argument.
 func (q1 QuatQ24) Mul(q2 QuatQ24) QuatQ24 {
-	// Copied from go-gl/mathgl and modified.
-	return QuatQ24{q1.W.Mul(q2.W).Sub(q1.V.Dot(q2.V)), q1.V.Cross(q2.V).Add(q2.V.Mul(q1.W)).Add(q1.V.Mul(q2.W))}
+	// Copied from go-gl/mathgl and modified: the result is
+	//   W: q1.W*q2.W - q1.V·q2.V
+	//   V: q1.V×q2.V + q2.V*q1.W + q1.V*q2.W
+	// See mul_generic.go.
+	w, x, y, z := quatMul(q1.W.N, q1.V.X.N, q1.V.Y.N, q1.V.Z.N, q2.W.N, q2.V.X.N, q2.V.Y.N, q2.V.Z.N)
+	return QuatQ24{Q24{w}, Vec3Q24{Q24{x}, Q24{y}, Q24{z}}}
 }
 
 // Rotate returns the vector from the argument rotated by the rotation this
@@ -1,6 +1,7 @@
 package fixpoint
 
 import (
+	"math"
 	"testing"
 
 	"github.com/go-gl/mathgl/mgl32"
@@ -52,3 +53,99 @@ func TestRotation(t *testing.T) {
 		}
 	}
 }
+
+func TestQ24FromConstFloat(t *testing.T) {
+	assert.Equal(t, Q24FromInt32(1), Q24{One})
+	assert.Equal(t, Q24FromFloat(-0.75), Q24{-3 * One / 4})
+	for _, x := range []float64{0, 0.1, -0.1, 0.5, -2.75, 1.0 / 3, 127.9} {
+		assert.Equal(t, Q24FromFloat64(x), Q24FromConstFloat(x), "x=%v", x)
+	}
+
+	// Ties are rounded away from zero, unlike Q24FromFloat64.
+	assert.Equal(t, Q24{2}, Q24FromConstFloat(1.5/(1<<24)))
+	assert.Equal(t, Q24{-2}, Q24FromConstFloat(-1.5/(1<<24)))
+	assert.Equal(t, Q24{-1}, Q24FromFloat64(-1.5/(1<<24)))
+
+	assert.Equal(t, Q16FromInt32(3), Q16FromConstFloat(3))
+	assert.Equal(t, Q16{OneQ16 / 4}, Q16FromConstFloat(0.25))
+	assert.Equal(t, Q15{-1 << 14}, Q15FromConstFloat(-0.5))
+	assert.Equal(t, Q31{1 << 29}, Q31FromConstFloat(0.25))
+}
+
+func TestQ24Round(t *testing.T) {
+	third := Q24FromInt32(1).DivRound(Q24FromInt32(3))
+	assert.Equal(t, Q24{5592405}, third)                                      // 5592405.33
+	assert.Equal(t, Q24{11184811}, Q24FromInt32(2).DivRound(Q24FromInt32(3))) // 11184810.67
+	assert.Equal(t, Q24{-11184811}, Q24FromInt32(-2).DivRound(Q24FromInt32(3)))
+	assert.Equal(t, Q24{-11184811}, Q24FromInt32(2).DivRound(Q24FromInt32(-3)))
+	assert.Equal(t, Q24{11184811}, Q24FromInt32(-2).DivRound(Q24FromInt32(-3)))
+	assert.Equal(t, Q24{11184810}, Q24FromInt32(2).Div(Q24FromInt32(3)))
+
+	// 0.75 ULP should round up, 0.25 ULP should round down.
+	half := Q24FromFloat(0.5)
+	assert.Equal(t, Q24{2}, Q24{3}.MulRound(half)) // 1.5 ULP
+	assert.Equal(t, Q24{1}, Q24{3}.Mul(half))
+	assert.Equal(t, Q24{1}, Q24{1}.MulRound(Q24FromFloat(0.75)))
+	assert.Equal(t, Q24{0}, Q24{1}.MulRound(Q24FromFloat(0.25)))
+	assert.Equal(t, Q24{-1}, Q24{-1}.MulRound(Q24FromFloat(0.75)))
+	assert.Equal(t, Q24{0}, Q24{-1}.MulRound(Q24FromFloat(0.25)))
+}
+
+func TestRotationDrift(t *testing.T) {
+	// Integrate a small rotation many times. With truncation, the quaternion
+	// would slowly shrink. With rounding, it should stay very close to unit
+	// length.
+	inc := QuatFromAxisAngle(Vec3Q24FromFloat(1, 2, 3), Q24FromFloat(0.001))
+	rotation := QuatIdent()
+	for i := 0; i < 5000; i++ {
+		rotation = rotation.Mul(inc)
+	}
+	assert.InDelta(t, 1, rotation.Len().Float(), 0.0001)
+}
+
+func TestVec3(t *testing.T) {
+	v1 := Vec3Q24FromFloat(1, -2, 3.5)
+	v2 := Vec3Q24FromFloat(0.5, 4, -1)
+	assert.Equal(t, Vec3Q24FromFloat(0.5, -6, 4.5), v1.Sub(v2))
+	assert.Equal(t, Vec3Q24FromFloat(-1, 2, -3.5), v1.Neg())
+	assert.Equal(t, Vec3Q24FromFloat(0.5, -8, -3.5), v1.MulComponents(v2))
+	assert.Equal(t, Vec3Q24FromFloat(0.25, -0.5, 0.875), v1.Div(Q24FromInt32(4)))
+	assert.Equal(t, Q24FromFloat(17.25), v1.Len2())
+	third := Vec3Q24FromFloat(1, 1, -1).Div(Q24FromInt32(3))
+	assert.Equal(t, Vec3Q24{Q24{5592405}, Q24{5592405}, Q24{-5592405}}, third)
+}
+
+func TestQ24FromFloat64(t *testing.T) {
+	// Rounds to the nearest value instead of truncating.
+	assert.Equal(t, Q24{1}, Q24FromFloat64(0.9/(1<<24)))
+	assert.Equal(t, Q24{-1}, Q24FromFloat64(-0.9/(1<<24)))
+	assert.Equal(t, Q24{0}, Q24FromFloat64(0.4/(1<<24)))
+	assert.Equal(t, Q24{5592405}, Q24FromFloat64(1.0/3))
+	assert.Equal(t, Q24{11184811}, Q24FromFloat64(2.0/3))
+	assert.Equal(t, MaxQ24.Float64(), Q24FromFloat64(MaxQ24.Float64()).Float64())
+	assert.Equal(t, MinQ24, Q24FromFloat64(-128))
+
+	for _, tc := range []struct {
+		x  float64
+		q  Q24
+		ok bool
+	}{
+		{1.5, Q24FromFloat(1.5), true},
+		{-128, MinQ24, true},
+		{127.99999997, MaxQ24, true},
+		{128, Q24{}, false},
+		{-128.00000003, Q24{}, false},
+		{1e10, Q24{}, false},
+		{math.Inf(-1), Q24{}, false},
+		{math.NaN(), Q24{}, false},
+	} {
+		q, ok := Q24FromFloat64Checked(tc.x)
+		assert.Equal(t, tc.ok, ok, "%v", tc.x)
+		assert.Equal(t, tc.q, q, "%v", tc.x)
+	}
+	q, ok := Q24FromFloatChecked(-3.25)
+	assert.True(t, ok)
+	assert.Equal(t, Q24FromFloat(-3.25), q)
+	_, ok = Q24FromFloatChecked(200)
+	assert.False(t, ok)
+}
@@ -0,0 +1,119 @@
+// Package frame provides vector types that are tagged with the coordinate
+// frame they're expressed in. Sensor fusion code constantly moves vectors
+// between the body frame of a device (the frame its sensors measure in) and
+// the world frame, and using a vector in the wrong frame is an easy mistake
+// that still compiles with plain fixpoint.Vec3Q24 values. With these types,
+// the only way to go from one frame to the other is through an Attitude.
+//
+// The conventions are the same as in the ahrs package: an attitude rotates
+// vectors from the body frame to the world frame. All types are defined as
+// their fixpoint counterparts, so they convert with a type conversion:
+//
+//	att := frame.Attitude(filter.Orientation())
+//	up := att.RotateInv(frame.Vec3World{Z: fixpoint.Q24FromInt32(1)})
+package frame
+
+import (
+	"github.com/aykevl/fixpoint"
+)
+
+// Vec3Body is a vector in the body frame.
+type Vec3Body fixpoint.Vec3Q24
+
+// Vec3World is a vector in the world frame.
+type Vec3World fixpoint.Vec3Q24
+
+// Attitude is the orientation of a body, as a unit quaternion that rotates
+// vectors from the body frame to the world frame.
+type Attitude fixpoint.QuatQ24
+
+// Rotate returns the vector in the body frame rotated to the world frame.
+func (a Attitude) Rotate(v Vec3Body) Vec3World {
+	return Vec3World(fixpoint.QuatQ24(a).Rotate(fixpoint.Vec3Q24(v)))
+}
+
+// RotateInv returns the vector in the world frame rotated to the body frame.
+func (a Attitude) RotateInv(v Vec3World) Vec3Body {
+	return Vec3Body(fixpoint.QuatQ24(a).RotateInv(fixpoint.Vec3Q24(v)))
+}
+
+// Mul returns this attitude followed by a rotation in the body frame, for
+// example the rotation measured by a gyroscope during a time step.
+func (a Attitude) Mul(q fixpoint.QuatQ24) Attitude {
+	return Attitude(fixpoint.QuatQ24(a).Mul(q))
+}
+
+// Quat returns this attitude as a plain quaternion.
+func (a Attitude) Quat() fixpoint.QuatQ24 {
+	return fixpoint.QuatQ24(a)
+}
+
+// Vec3Q24 returns this vector as a plain vector.
+func (v Vec3Body) Vec3Q24() fixpoint.Vec3Q24 {
+	return fixpoint.Vec3Q24(v)
+}
+
+// Add returns this vector added to the argument.
+func (v Vec3Body) Add(v2 Vec3Body) Vec3Body {
+	return Vec3Body(fixpoint.Vec3Q24(v).Add(fixpoint.Vec3Q24(v2)))
+}
+
+// Sub returns the argument subtracted from this vector.
+func (v Vec3Body) Sub(v2 Vec3Body) Vec3Body {
+	return Vec3Body(fixpoint.Vec3Q24(v).Sub(fixpoint.Vec3Q24(v2)))
+}
+
+// Mul returns this vector multiplied by the argument.
+func (v Vec3Body) Mul(c fixpoint.Q24) Vec3Body {
+	return Vec3Body(fixpoint.Vec3Q24(v).Mul(c))
+}
+
+// Dot returns the dot product between this vector and the argument.
+func (v Vec3Body) Dot(v2 Vec3Body) fixpoint.Q24 {
+	return fixpoint.Vec3Q24(v).Dot(fixpoint.Vec3Q24(v2))
+}
+
+// Cross returns the cross product between this vector and the argument.
+func (v Vec3Body) Cross(v2 Vec3Body) Vec3Body {
+	return Vec3Body(fixpoint.Vec3Q24(v).Cross(fixpoint.Vec3Q24(v2)))
+}
+
+// Normalize returns this vector scaled to unit length.
+func (v Vec3Body) Normalize() Vec3Body {
+	return Vec3Body(fixpoint.Vec3Q24(v).Normalize())
+}
+
+// Vec3Q24 returns this vector as a plain vector.
+func (v Vec3World) Vec3Q24() fixpoint.Vec3Q24 {
+	return fixpoint.Vec3Q24(v)
+}
+
+// Add returns this vector added to the argument.
+func (v Vec3World) Add(v2 Vec3World) Vec3World {
+	return Vec3World(fixpoint.Vec3Q24(v).Add(fixpoint.Vec3Q24(v2)))
+}
+
+// Sub returns the argument subtracted from this vector.
+func (v Vec3World) Sub(v2 Vec3World) Vec3World {
+	return Vec3World(fixpoint.Vec3Q24(v).Sub(fixpoint.Vec3Q24(v2)))
+}
+
+// Mul returns this vector multiplied by the argument.
+func (v Vec3World) Mul(c fixpoint.Q24) Vec3World {
+	return Vec3World(fixpoint.Vec3Q24(v).Mul(c))
+}
+
+// Dot returns the dot product between this vector and the argument.
+func (v Vec3World) Dot(v2 Vec3World) fixpoint.Q24 {
+	return fixpoint.Vec3Q24(v).Dot(fixpoint.Vec3Q24(v2))
+}
+
+// Cross returns the cross product between this vector and the argument.
+func (v Vec3World) Cross(v2 Vec3World) Vec3World {
+	return Vec3World(fixpoint.Vec3Q24(v).Cross(fixpoint.Vec3Q24(v2)))
+}
+
+// Normalize returns this vector scaled to unit length.
+func (v Vec3World) Normalize() Vec3World {
+	return Vec3World(fixpoint.Vec3Q24(v).Normalize())
+}
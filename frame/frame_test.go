@@ -0,0 +1,53 @@
+package frame
+
+import (
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttitude(t *testing.T) {
+	// Rotated 90° around the Z axis: the body X axis points along the world
+	// Y axis.
+	q := fixpoint.QuatFromAxisAngle(fixpoint.Vec3Q24FromFloat(0, 0, 1), fixpoint.HalfPi)
+	att := Attitude(q)
+	assert.Equal(t, q, att.Quat())
+
+	x := Vec3Body{X: fixpoint.Q24FromInt32(1)}
+	w := att.Rotate(x)
+	assert.InDelta(t, 0, w.X.Float(), 1e-6)
+	assert.InDelta(t, 1, w.Y.Float(), 1e-6)
+	assert.InDelta(t, 0, w.Z.Float(), 1e-6)
+	b := att.RotateInv(w)
+	assert.InDelta(t, 1, b.X.Float(), 1e-6)
+	assert.InDelta(t, 0, b.Y.Float(), 1e-6)
+
+	// Another 90° around the body X axis, which is now the world Y axis.
+	att = att.Mul(fixpoint.QuatFromAxisAngle(fixpoint.Vec3Q24FromFloat(1, 0, 0), fixpoint.HalfPi))
+	z := att.Rotate(Vec3Body{Z: fixpoint.Q24FromInt32(1)})
+	assert.InDelta(t, 1, z.X.Float(), 1e-6)
+	assert.InDelta(t, 0, z.Y.Float(), 1e-6)
+	assert.InDelta(t, 0, z.Z.Float(), 1e-6)
+}
+
+func TestVectors(t *testing.T) {
+	b1 := Vec3Body(fixpoint.Vec3Q24FromFloat(1, 2, 3))
+	b2 := Vec3Body(fixpoint.Vec3Q24FromFloat(-1, 0.5, 2))
+	v1, v2 := b1.Vec3Q24(), b2.Vec3Q24()
+	assert.Equal(t, Vec3Body(v1.Add(v2)), b1.Add(b2))
+	assert.Equal(t, Vec3Body(v1.Sub(v2)), b1.Sub(b2))
+	assert.Equal(t, Vec3Body(v1.Mul(fixpoint.Q24FromFloat(0.5))), b1.Mul(fixpoint.Q24FromFloat(0.5)))
+	assert.Equal(t, v1.Dot(v2), b1.Dot(b2))
+	assert.Equal(t, Vec3Body(v1.Cross(v2)), b1.Cross(b2))
+	assert.Equal(t, Vec3Body(v1.Normalize()), b1.Normalize())
+
+	w1, w2 := Vec3World(v1), Vec3World(v2)
+	assert.Equal(t, v1, w1.Vec3Q24())
+	assert.Equal(t, Vec3World(v1.Add(v2)), w1.Add(w2))
+	assert.Equal(t, Vec3World(v1.Sub(v2)), w1.Sub(w2))
+	assert.Equal(t, Vec3World(v1.Mul(fixpoint.Q24FromFloat(0.5))), w1.Mul(fixpoint.Q24FromFloat(0.5)))
+	assert.Equal(t, v1.Dot(v2), w1.Dot(w2))
+	assert.Equal(t, Vec3World(v1.Cross(v2)), w1.Cross(w2))
+	assert.Equal(t, Vec3World(v1.Normalize()), w1.Normalize())
+}
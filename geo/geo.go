@@ -0,0 +1,43 @@
+// Package geo implements calculations on geographic coordinates, such as the
+// ones returned by a GPS receiver, using fixed point arithmetic.
+package geo
+
+import (
+	"github.com/aykevl/fixpoint"
+)
+
+// Point is a position on the earth, with latitude and longitude in radians.
+// Latitude is positive to the north and longitude is positive to the east.
+type Point struct {
+	Lat fixpoint.Q24
+	Lon fixpoint.Q24
+}
+
+// PointFromE7 returns a point from a latitude and longitude in degrees
+// multiplied by 10^7, which is the format used by many GPS receivers and
+// protocols.
+func PointFromE7(lat, lon int32) Point {
+	return Point{e7ToRadians(lat), e7ToRadians(lon)}
+}
+
+// E7 returns the latitude and longitude of this point in degrees multiplied by
+// 10^7. Inverse of PointFromE7.
+func (p Point) E7() (lat, lon int32) {
+	return radiansToE7(p.Lat), radiansToE7(p.Lon)
+}
+
+func e7ToRadians(deg int32) fixpoint.Q24 {
+	return fixpoint.Q24{N: int32(divRound(int64(deg)*int64(fixpoint.Pi.N), 180e7))}
+}
+
+func radiansToE7(rad fixpoint.Q24) int32 {
+	return int32(divRound(int64(rad.N)*180e7, int64(fixpoint.Pi.N)))
+}
+
+// divRound returns n/d rounded to the nearest integer, for positive d.
+func divRound(n, d int64) int64 {
+	if n < 0 {
+		return (n - d/2) / d
+	}
+	return (n + d/2) / d
+}
@@ -0,0 +1,25 @@
+package geo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPointE7(t *testing.T) {
+	for _, tc := range [][2]int32{
+		{0, 0},
+		{523700000, 48900000},
+		{-338700000, 1512100000},
+		{90e7, -180e7},
+	} {
+		p := PointFromE7(tc[0], tc[1])
+		assert.InDelta(t, float64(tc[0])/1e7*math.Pi/180, float64(p.Lat.N)/(1<<24), 1e-7)
+		assert.InDelta(t, float64(tc[1])/1e7*math.Pi/180, float64(p.Lon.N)/(1<<24), 1e-7)
+		lat, lon := p.E7()
+		// One Q24 unit is about 34 units of 10^-7 degree.
+		assert.InDelta(t, tc[0], lat, 20)
+		assert.InDelta(t, tc[1], lon, 20)
+	}
+}
@@ -0,0 +1,89 @@
+package geo
+
+import (
+	"github.com/aykevl/fixpoint"
+)
+
+// Great-circle calculations on a spherical earth. Distances are returned as
+// angles (in radians) to avoid overflow, use Meters to convert them to a
+// distance on the surface of the earth.
+//
+// Useful link:
+// https://www.movable-type.co.uk/scripts/latlong.html
+
+// EarthRadius is the mean radius of the earth in meters.
+const EarthRadius = 6371009
+
+// Meters converts an angular distance in radians to meters on the surface of
+// the earth, rounded to the nearest meter.
+func Meters(angle fixpoint.Q24) int32 {
+	return int32((int64(angle.N)*EarthRadius + 1<<23) >> 24)
+}
+
+// Distance returns the great-circle distance between two points as an angle in
+// radians.
+func Distance(p1, p2 Point) fixpoint.Q24 {
+	// Haversine formula. The square root of the haversine is calculated
+	// directly as the length of a vector, to avoid losing precision for small
+	// distances by squaring small numbers.
+	//   a = sin²(Δφ/2) + cos φ1 ⋅ cos φ2 ⋅ sin²(Δλ/2)
+	//   δ = 2 ⋅ atan2(√a, √(1−a))
+	s1 := fixpoint.Sin(fixpoint.Q24{N: (p2.Lat.N - p1.Lat.N) / 2})
+	s2 := fixpoint.Sin(fixpoint.Q24{N: (p2.Lon.N - p1.Lon.N) / 2})
+	c := fixpoint.Cos(p1.Lat).Mul(fixpoint.Cos(p2.Lat)).Sqrt()
+	sqrtA := fixpoint.Vec2Q24{X: s1, Y: s2.Mul(c)}.Len()
+	sqrt1MinusA := fixpoint.Q24FromInt32(1).Sub(sqrtA.Mul(sqrtA)).Sqrt()
+	return fixpoint.Q24{N: fixpoint.Atan2(sqrtA, sqrt1MinusA).N * 2}
+}
+
+// Bearing returns the initial bearing (forward azimuth) when going from p1 to
+// p2 along a great circle, in radians clockwise from the north in the range
+// [-π, π].
+func Bearing(p1, p2 Point) fixpoint.Q24 {
+	sinLat1, cosLat1 := fixpoint.SinCos(p1.Lat)
+	sinLat2, cosLat2 := fixpoint.SinCos(p2.Lat)
+	sinDLon, cosDLon := fixpoint.SinCos(p2.Lon.Sub(p1.Lon))
+	y := sinDLon.Mul(cosLat2)
+	x := cosLat1.Mul(sinLat2).Sub(sinLat1.Mul(cosLat2).Mul(cosDLon))
+	return fixpoint.Atan2(y, x)
+}
+
+// Intermediate returns the point at fraction t (in the range [0, 1]) along the
+// great circle from p1 to p2. The result is undefined for antipodal points.
+func Intermediate(p1, p2 Point, t fixpoint.Q24) Point {
+	dist := Distance(p1, p2)
+	if dist.N == 0 {
+		return p1
+	}
+	// Rotate the unit vector of p1 towards p2 in the plane of the great
+	// circle. This is better conditioned for short distances than the usual
+	// formula, which divides by sin δ.
+	n1 := p1.unitVector()
+	n2 := p2.unitVector()
+	cosDist := fixpoint.Cos(dist)
+	w := n2.Add(n1.Mul(cosDist.Neg())).Normalize()
+	sin, cos := fixpoint.SinCos(dist.Mul(t))
+	n := n1.Mul(cos).Add(w.Mul(sin))
+	return Point{
+		Lat: fixpoint.Atan2(n.Z, fixpoint.Vec2Q24{X: n.X, Y: n.Y}.Len()),
+		Lon: fixpoint.Atan2(n.Y, n.X),
+	}
+}
+
+// CrossTrackDistance returns the distance of the point p from the great circle
+// path going from start to end, as an angle in radians. The distance is
+// positive when the point is to the right of the path and negative when it is
+// to the left.
+func CrossTrackDistance(start, end, p Point) fixpoint.Q24 {
+	dist := Distance(start, p)
+	angle := Bearing(start, p).Sub(Bearing(start, end))
+	return fixpoint.Asin(fixpoint.Sin(dist).Mul(fixpoint.Sin(angle)))
+}
+
+// unitVector returns the point as a unit vector, with the Z axis through the
+// north pole and the X axis through longitude 0.
+func (p Point) unitVector() fixpoint.Vec3Q24 {
+	sinLat, cosLat := fixpoint.SinCos(p.Lat)
+	sinLon, cosLon := fixpoint.SinCos(p.Lon)
+	return fixpoint.Vec3Q24{X: cosLat.Mul(cosLon), Y: cosLat.Mul(sinLon), Z: sinLat}
+}
@@ -0,0 +1,84 @@
+package geo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func pointFromDegrees(lat, lon float64) Point {
+	return PointFromE7(int32(math.Round(lat*1e7)), int32(math.Round(lon*1e7)))
+}
+
+func (p Point) degrees() (lat, lon float64) {
+	return float64(p.Lat.N) / (1 << 24) * 180 / math.Pi, float64(p.Lon.N) / (1 << 24) * 180 / math.Pi
+}
+
+func TestDistance(t *testing.T) {
+	for _, tc := range []struct {
+		lat1, lon1, lat2, lon2 float64
+		meters                 int32
+	}{
+		{52.37, 4.89, 52.37, 4.89, 0},
+		{52.37, 4.89, 51.92, 4.48, 57328},        // Amsterdam - Rotterdam
+		{52.37, 4.89, 40.71, -74.01, 5862694},    // Amsterdam - New York
+		{-33.87, 151.21, 35.68, 139.69, 7825756}, // Sydney - Tokyo
+		{0, 179.9, 0, -179.9, 22239},             // across the date line
+		{52.37, 4.89, 52.3701, 4.89, 11},         // short distance
+	} {
+		d := Distance(pointFromDegrees(tc.lat1, tc.lon1), pointFromDegrees(tc.lat2, tc.lon2))
+		// Allow an error of 1m plus 0.001%.
+		assert.InDelta(t, tc.meters, Meters(d), 1+float64(tc.meters)*0.00001, "distance (%f, %f) - (%f, %f)", tc.lat1, tc.lon1, tc.lat2, tc.lon2)
+	}
+}
+
+func TestBearing(t *testing.T) {
+	amsterdam := pointFromDegrees(52.37, 4.89)
+	newYork := pointFromDegrees(40.71, -74.01)
+	assert.InDelta(t, -69.1939, float64(Bearing(amsterdam, newYork).Float())*180/math.Pi, 0.01)
+	assert.InDelta(t, 0, Bearing(pointFromDegrees(10, 10), pointFromDegrees(20, 10)).Float(), 0.0001)
+	assert.InDelta(t, math.Pi/2, Bearing(pointFromDegrees(0, 10), pointFromDegrees(0, 20)).Float(), 0.0001)
+}
+
+func TestIntermediate(t *testing.T) {
+	amsterdam := pointFromDegrees(52.37, 4.89)
+	newYork := pointFromDegrees(40.71, -74.01)
+
+	// Endpoints.
+	lat, lon := Intermediate(amsterdam, newYork, fixpoint.Q24{}).degrees()
+	assert.InDelta(t, 52.37, lat, 0.0001)
+	assert.InDelta(t, 4.89, lon, 0.0001)
+	lat, lon = Intermediate(amsterdam, newYork, fixpoint.Q24FromInt32(1)).degrees()
+	assert.InDelta(t, 40.71, lat, 0.0001)
+	assert.InDelta(t, -74.01, lon, 0.0001)
+
+	// Midpoint: the great circle goes further north than both endpoints.
+	mid := Intermediate(amsterdam, newYork, fixpoint.Q24FromFloat(0.5))
+	lat, lon = mid.degrees()
+	assert.InDelta(t, 53.6983, lat, 0.0001)
+	assert.InDelta(t, -39.6267, lon, 0.0001)
+	assert.InDelta(t, Meters(Distance(amsterdam, mid)), Meters(Distance(mid, newYork)), 2)
+
+	// Short distances should remain precise.
+	p1 := pointFromDegrees(52.37, 4.89)
+	p2 := pointFromDegrees(52.38, 4.90)
+	lat, lon = Intermediate(p1, p2, fixpoint.Q24FromFloat(0.25)).degrees()
+	assert.InDelta(t, 52.3725, lat, 0.00001)
+	assert.InDelta(t, 4.8925, lon, 0.00001)
+}
+
+func TestCrossTrackDistance(t *testing.T) {
+	// Path along the equator going east: points to the north are on the left.
+	start := pointFromDegrees(0, 0)
+	end := pointFromDegrees(0, 10)
+	assert.InDelta(t, -111195, Meters(CrossTrackDistance(start, end, pointFromDegrees(1, 5))), 10)
+	assert.InDelta(t, 111195, Meters(CrossTrackDistance(start, end, pointFromDegrees(-1, 5))), 10)
+	assert.InDelta(t, 0, Meters(CrossTrackDistance(start, end, pointFromDegrees(0, 3))), 1)
+
+	// Small deviations.
+	start = pointFromDegrees(52.37, 4.89)
+	end = pointFromDegrees(52.37, 4.99)
+	assert.InDelta(t, -10, Meters(CrossTrackDistance(start, end, pointFromDegrees(52.3701, 4.95))), 2)
+}
@@ -0,0 +1,85 @@
+package geo
+
+import (
+	"time"
+
+	"github.com/aykevl/fixpoint"
+)
+
+// The sun position is calculated using the low precision algorithm from the
+// Astronomical Almanac, which is accurate to about 0.01° between the years 1950
+// and 2050. That is more than enough for solar trackers.
+//
+// Useful links:
+// https://en.wikipedia.org/wiki/Position_of_the_Sun
+// https://aa.usno.navy.mil/faq/sun_approx
+
+// Angles that increase linearly with time (the mean longitude and anomaly of
+// the sun and the sidereal time) are calculated as fractions of a full turn
+// with 48 bits of precision, which wrap around naturally. Each is stored as
+// the value at J2000.0 (2000-01-01 12:00 UTC), the fractional part of the
+// increase per day and the increase per second.
+type linearAngle struct {
+	base, perDay, perSecond int64
+}
+
+var (
+	sunMeanLongitude = linearAngle{219284644356307, 770652997111, 8919595} // 280.460° + 0.9856474°/day
+	sunMeanAnomaly   = linearAngle{279542181870576, 770616170801, 8919169} // 357.528° + 0.9856003°/day
+	siderealTime     = linearAngle{219285127773942, 770652970751, 3266731825}
+)
+
+// Unix time of J2000.0.
+const j2000 = 946728000
+
+// at returns the angle in radians (in the range [-π, π)) at the given number
+// of days and seconds since J2000.0.
+func (a linearAngle) at(days, seconds int64) fixpoint.Q24 {
+	turns := (a.base + days*a.perDay + seconds*a.perSecond) & (1<<48 - 1)
+	// Take the upper 32 bits as a signed fraction of a turn, in the range
+	// [-0.5, 0.5).
+	frac := int64(int32(turns >> 16))
+	return fixpoint.Q24{N: int32((frac * int64(fixpoint.TwoPi.N)) >> 32)}
+}
+
+// SunPosition returns the position of the sun in the sky as seen from the
+// given point at the given time. The azimuth is measured clockwise from the
+// north, in the range [0, 2π). The elevation is the angle above the horizon, in
+// the range [-π/2, π/2]. Atmospheric refraction is not taken into account.
+func SunPosition(p Point, t time.Time) (azimuth, elevation fixpoint.Q24) {
+	seconds := t.Unix() - j2000
+	days := seconds / 86400
+	seconds %= 86400
+	if seconds < 0 {
+		days--
+		seconds += 86400
+	}
+
+	// Ecliptic longitude of the sun.
+	meanLongitude := sunMeanLongitude.at(days, seconds)
+	meanAnomaly := sunMeanAnomaly.at(days, seconds)
+	longitude := meanLongitude.
+		Add(fixpoint.Sin(meanAnomaly).Mul(fixpoint.Q24{N: 560746})).                     // 1.915°
+		Add(fixpoint.Sin(fixpoint.Q24{N: meanAnomaly.N * 2}).Mul(fixpoint.Q24{N: 5856})) // 0.020°
+
+	// Obliquity of the ecliptic: 23.439° - 0.0000004°/day.
+	obliquity := fixpoint.Q24{N: 6863353 - int32(divRound(days*117127, 1000000))}
+
+	// Convert to equatorial coordinates: right ascension and declination.
+	sinLon, cosLon := fixpoint.SinCos(longitude)
+	sinObl, cosObl := fixpoint.SinCos(obliquity)
+	rightAscension := fixpoint.Atan2(cosObl.Mul(sinLon), cosLon)
+	declination := fixpoint.Asin(sinObl.Mul(sinLon))
+
+	// Convert to horizontal coordinates using the local hour angle.
+	hourAngle := siderealTime.at(days, seconds).Add(p.Lon).Sub(rightAscension)
+	sinLat, cosLat := fixpoint.SinCos(p.Lat)
+	sinDec, cosDec := fixpoint.SinCos(declination)
+	sinHA, cosHA := fixpoint.SinCos(hourAngle)
+	elevation = fixpoint.Asin(sinLat.Mul(sinDec).Add(cosLat.Mul(cosDec).Mul(cosHA)))
+	azimuth = fixpoint.Atan2(sinHA.Neg().Mul(cosDec), cosLat.Mul(sinDec).Sub(sinLat.Mul(cosDec).Mul(cosHA)))
+	if azimuth.N < 0 {
+		azimuth = azimuth.Add(fixpoint.TwoPi)
+	}
+	return
+}
@@ -0,0 +1,60 @@
+package geo
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sunPositionFloat is a floating point implementation of the same algorithm,
+// to compare against. It returns azimuth and elevation in degrees.
+func sunPositionFloat(lat, lon float64, t time.Time) (azimuth, elevation float64) {
+	rad := math.Pi / 180
+	d := float64(t.Unix()-j2000) / 86400
+	L := 280.460 + 0.9856474*d
+	g := (357.528 + 0.9856003*d) * rad
+	lambda := (L + 1.915*math.Sin(g) + 0.020*math.Sin(2*g)) * rad
+	epsilon := (23.439 - 0.0000004*d) * rad
+	ra := math.Atan2(math.Cos(epsilon)*math.Sin(lambda), math.Cos(lambda))
+	dec := math.Asin(math.Sin(epsilon) * math.Sin(lambda))
+	gmst := (18.697374558 + 24.06570982441908*d) * 15 * rad
+	h := gmst + lon*rad - ra
+	lat *= rad
+	elevation = math.Asin(math.Sin(lat)*math.Sin(dec) + math.Cos(lat)*math.Cos(dec)*math.Cos(h))
+	azimuth = math.Atan2(-math.Sin(h)*math.Cos(dec), math.Cos(lat)*math.Sin(dec)-math.Sin(lat)*math.Cos(dec)*math.Cos(h))
+	azimuth = math.Mod(azimuth/rad+360, 360)
+	return azimuth, elevation / rad
+}
+
+func TestSunPosition(t *testing.T) {
+	for _, tc := range []struct {
+		lat, lon float64
+		time     string
+	}{
+		{52.37, 4.89, "2020-06-21T11:40:00Z"},
+		{52.37, 4.89, "2020-12-21T08:00:00Z"},
+		{-33.87, 151.21, "2019-03-05T23:15:00Z"},
+		{0, 0, "2000-01-01T12:00:00Z"},
+		{64.13, -21.9, "1995-09-30T18:30:00Z"},
+		{35.68, 139.69, "2038-02-01T03:00:00Z"},
+	} {
+		tm, err := time.Parse(time.RFC3339, tc.time)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p := PointFromE7(int32(tc.lat*1e7), int32(tc.lon*1e7))
+		azimuth, elevation := SunPosition(p, tm)
+		wantAzimuth, wantElevation := sunPositionFloat(tc.lat, tc.lon, tm)
+		assert.InDelta(t, wantAzimuth, float64(azimuth.Float())*180/math.Pi, 0.001, "azimuth at %s", tc.time)
+		assert.InDelta(t, wantElevation, float64(elevation.Float())*180/math.Pi, 0.001, "elevation at %s", tc.time)
+	}
+
+	// Around solar noon at the summer solstice, the sun should be at 90° -
+	// latitude + 23.44° and in the south.
+	tm := time.Date(2020, 6, 21, 11, 40, 0, 0, time.UTC)
+	azimuth, elevation := SunPosition(PointFromE7(52.37e7, 4.89e7), tm)
+	assert.InDelta(t, 90-52.37+23.44, float64(elevation.Float())*180/math.Pi, 0.1)
+	assert.InDelta(t, 180, float64(azimuth.Float())*180/math.Pi, 2)
+}
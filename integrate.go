@@ -0,0 +1,72 @@
+package fixpoint
+
+// Numerical integration. The function values are summed in an int64 and the
+// result is rounded only once at the end, so the result only depends on the
+// values f returns: it is the same on a desktop system and on a
+// microcontroller, which makes these functions suitable to calculate lookup
+// tables and calibration integrals on either side.
+
+// Integrate returns the integral of f from a to b using Simpson's rule with n
+// intervals. An odd n is rounded up to the next even number. The result is
+// exact for polynomials up to the third degree (apart from rounding the
+// function values) and saturates when it doesn't fit in a Q24. If b is smaller
+// than a, the result is negated like usual. The number of intervals must be
+// below 2^20.
+func Integrate(f func(Q24) Q24, a, b Q24, n int) Q24 {
+	if n < 2 {
+		n = 2
+	}
+	n += n & 1
+	return integrate(f, a, b, n, func(i int) int64 {
+		// Weights 1, 4, 2, 4, ..., 2, 4, 1.
+		if i == 0 || i == n {
+			return 1
+		}
+		return 2 + 2*int64(i&1)
+	}, 3)
+}
+
+// IntegrateTrapezoid returns the integral of f from a to b using the
+// trapezoidal rule with n intervals. It is exact for linear functions, and
+// unlike Integrate doesn't need an even number of intervals. The number of
+// intervals must be below 2^20.
+func IntegrateTrapezoid(f func(Q24) Q24, a, b Q24, n int) Q24 {
+	if n < 1 {
+		n = 1
+	}
+	return integrate(f, a, b, n, func(i int) int64 {
+		// Weights 1, 2, 2, ..., 2, 1.
+		if i == 0 || i == n {
+			return 1
+		}
+		return 2
+	}, 2)
+}
+
+// integrate calculates the sum of weight(i)*f(x_i) for n+1 evenly spaced
+// points from a to b, and multiplies it by (b-a)/(n*div).
+func integrate(f func(Q24) Q24, a, b Q24, n int, weight func(int) int64, div int32) Q24 {
+	// Sample from the lower end so that the points are rounded the same way
+	// for reversed intervals.
+	lo, hi, neg := a, b, false
+	if b.N < a.N {
+		lo, hi, neg = b, a, true
+	}
+	width := int64(hi.N) - int64(lo.N)
+	var sum int64
+	for i := 0; i <= n; i++ {
+		x := Q24{int32(int64(lo.N) + (width*int64(i)+int64(n)/2)/int64(n))}
+		sum += weight(i) * int64(f(x).N)
+	}
+
+	// The sum fits in a Q32 with 8 bits to spare for n below 2^20, and the
+	// mean function value fits in the range of a Q24. Multiplying by the
+	// width is done with a 128-bit intermediate, so it doesn't overflow
+	// either.
+	mean := Q32{sum << 8}.Div(Q32FromInt32(int32(n) * div))
+	result := mean.Mul(Q32{width << 8})
+	if neg {
+		result = result.Neg()
+	}
+	return result.Q24()
+}
@@ -0,0 +1,52 @@
+package fixpoint
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntegrate(t *testing.T) {
+	// Simpson's rule is exact for cubic polynomials: the integral of
+	// x^3 - 2x from 0 to 2 is 4 - 4 = 0, and from -1 to 3 it is 20 - 8 = 12.
+	cubic := func(x Q24) Q24 {
+		return x.MulRound(x).MulRound(x).Sub(x.Add(x))
+	}
+	assert.InDelta(t, 0, Integrate(cubic, Q24{}, Q24FromInt32(2), 8).Float64(), 1e-6)
+	assert.InDelta(t, 12, Integrate(cubic, Q24FromInt32(-1), Q24FromInt32(3), 8).Float64(), 1e-6)
+	assert.InDelta(t, 12, Integrate(cubic, Q24FromInt32(-1), Q24FromInt32(3), 7).Float64(), 1e-6)
+
+	// Reversed bounds negate the result.
+	assert.Equal(t, Integrate(cubic, Q24FromInt32(-1), Q24FromInt32(3), 10).Neg(),
+		Integrate(cubic, Q24FromInt32(3), Q24FromInt32(-1), 10))
+	assert.Equal(t, Q24{}, Integrate(cubic, Q24FromInt32(1), Q24FromInt32(1), 4))
+
+	// The integral of sin from 0 to pi is 2.
+	assert.InDelta(t, 2, Integrate(Sin, Q24{}, Pi, 64).Float64(), 1e-6)
+	assert.InDelta(t, 2, IntegrateTrapezoid(Sin, Q24{}, Pi, 64).Float64(), 2*math.Pow(math.Pi/64, 2)/12*math.Pi)
+
+	// Large results saturate.
+	constant := func(Q24) Q24 { return Q24FromInt32(100) }
+	assert.Equal(t, MaxQ24, Integrate(constant, MinQ24, MaxQ24, 2))
+	assert.Equal(t, MinQ24, IntegrateTrapezoid(constant, MaxQ24, MinQ24, 1))
+}
+
+func TestIntegrateTrapezoid(t *testing.T) {
+	// The trapezoidal rule is exact for linear functions.
+	linear := func(x Q24) Q24 {
+		return x.Mul(Q24FromInt32(3)).Add(Q24FromInt32(1))
+	}
+	assert.Equal(t, Q24FromInt32(8), IntegrateTrapezoid(linear, Q24{}, Q24FromInt32(2), 3))
+	assert.Equal(t, Q24FromInt32(8), IntegrateTrapezoid(linear, Q24{}, Q24FromInt32(2), 0))
+
+	// A square root integrated over [0, 1] is 2/3. Neither rule is exact,
+	// but they get closer with more intervals.
+	sqrt := func(x Q24) Q24 { return x.Sqrt() }
+	coarse := math.Abs(IntegrateTrapezoid(sqrt, Q24{}, Q24FromInt32(1), 4).Float64() - 2.0/3)
+	fine := math.Abs(IntegrateTrapezoid(sqrt, Q24{}, Q24FromInt32(1), 256).Float64() - 2.0/3)
+	if fine >= coarse || fine > 1e-4 {
+		t.Errorf("expected the error to shrink with more intervals: %g, %g", coarse, fine)
+	}
+	assert.InDelta(t, 2.0/3, Integrate(sqrt, Q24{}, Q24FromInt32(1), 256).Float64(), 1e-4)
+}
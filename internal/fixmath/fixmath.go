@@ -0,0 +1,104 @@
+// Package fixmath contains the small integer helpers that the subpackages
+// share: saturation, multiplication by a Q24 coefficient without overflow,
+// integer square roots and angle wrapping. The fixpoint package itself has its
+// own copies, as it can't import this package.
+package fixmath
+
+import "github.com/aykevl/fixpoint"
+
+// Abs returns the absolute value of n.
+func Abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// Clamp clamps n to the range [min, max].
+func Clamp(n, min, max int64) int64 {
+	if n < min {
+		return min
+	}
+	if n > max {
+		return max
+	}
+	return n
+}
+
+// Saturate clamps n to the int32 range.
+func Saturate(n int64) int32 {
+	if n > 1<<31-1 {
+		return 1<<31 - 1
+	}
+	if n < -1<<31 {
+		return -1 << 31
+	}
+	return int32(n)
+}
+
+// MulQ24 returns n*c>>24 rounded to the nearest value, for a coefficient c in
+// Q24 format. Unlike a plain multiplication, the intermediate result can't
+// overflow as long as the result itself fits in an int64.
+func MulQ24(n, c int64) int64 {
+	hi, lo := n>>24, n&(1<<24-1)
+	return hi*c + (lo*c+1<<23)>>24
+}
+
+// Sqrt returns the square root of n, rounded down.
+func Sqrt(n uint64) uint64 {
+	result, _ := sqrt(n)
+	return result
+}
+
+// SqrtRound returns the square root of n, rounded to the nearest integer.
+func SqrtRound(n uint64) uint64 {
+	result, rem := sqrt(n)
+	// The remainder is the input minus result². Round up when the input is
+	// above (result + 0.5)² = result² + result + 0.25.
+	if rem > result {
+		result++
+	}
+	return result
+}
+
+// sqrt returns the square root of n rounded down, and the remainder. It uses
+// the bit-by-bit method, like fixpoint.Sqrt, see:
+// https://en.wikipedia.org/wiki/Methods_of_computing_square_roots#Binary_numeral_system_(base_2)
+func sqrt(n uint64) (result, rem uint64) {
+	bit := uint64(1) << 62
+	for bit > n {
+		bit >>= 2
+	}
+	for bit != 0 {
+		if n >= result+bit {
+			n -= result + bit
+			result = result>>1 + bit
+		} else {
+			result >>= 1
+		}
+		bit >>= 2
+	}
+	return result, n
+}
+
+// WrapAngle returns the angle n in Q24 format wrapped to the range [-π, π).
+func WrapAngle(n int64) fixpoint.Q24 {
+	return fixpoint.Q24{N: int32(wrap(n, int64(fixpoint.Pi.N), int64(fixpoint.TwoPi.N)))}
+}
+
+// WrapAngleQ48 returns the angle n in Q48 format wrapped to the range [-π, π).
+func WrapAngleQ48(n int64) int64 {
+	pi := int64(fixpoint.Pi.N) << 24
+	return wrap(n, pi, 2*pi)
+}
+
+// wrap wraps n to the range [-pi, pi), for the given values of π and 2π.
+func wrap(n, pi, twoPi int64) int64 {
+	n %= twoPi
+	if n >= pi {
+		n -= twoPi
+	} else if n < -pi {
+		n += twoPi
+	}
+	return n
+}
@@ -0,0 +1,59 @@
+package fixmath
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaturate(t *testing.T) {
+	assert.Equal(t, int32(5), Saturate(5))
+	assert.Equal(t, int32(math.MaxInt32), Saturate(1<<40))
+	assert.Equal(t, int32(math.MinInt32), Saturate(-1<<40))
+	assert.Equal(t, int64(3), Clamp(7, -3, 3))
+	assert.Equal(t, int64(-3), Clamp(-7, -3, 3))
+	assert.Equal(t, int64(7), Abs(-7))
+}
+
+func TestMulQ24(t *testing.T) {
+	half := int64(1 << 23)
+	assert.Equal(t, int64(3), MulQ24(4, 1<<23+1<<22))
+	assert.Equal(t, int64(1)<<60, MulQ24(1<<61, half))
+	assert.Equal(t, -int64(1)<<60, MulQ24(-1<<61, half))
+	assert.Equal(t, int64(1), MulQ24(1, half)) // 0.5 rounds up
+}
+
+func TestSqrt(t *testing.T) {
+	for _, n := range []uint64{0, 1, 2, 3, 4, 15, 16, 17, 1<<32 - 1, 1 << 62, 1<<64 - 1<<33} {
+		floor := uint64(math.Sqrt(float64(n)))
+		for floor*floor > n {
+			floor--
+		}
+		for (floor+1)*(floor+1) <= n {
+			floor++
+		}
+		assert.Equal(t, floor, Sqrt(n), "%d", n)
+		round := floor
+		if n-floor*floor > floor {
+			round++
+		}
+		assert.Equal(t, round, SqrtRound(n), "%d", n)
+	}
+	assert.Equal(t, uint64(1<<32-1), Sqrt(math.MaxUint64))
+	assert.Equal(t, uint64(1<<32), SqrtRound(math.MaxUint64))
+	assert.Equal(t, uint64(2), SqrtRound(6))
+	assert.Equal(t, uint64(3), SqrtRound(7))
+}
+
+func TestWrapAngle(t *testing.T) {
+	for _, f := range []float64{0, 1, -1, 3, -3, 4, -4, 10, -10, 100} {
+		n := int64(f * (1 << 24))
+		want := math.Remainder(f, 2*math.Pi)
+		assert.InDelta(t, want, WrapAngle(n).Float64(), 1e-6, "%f", f)
+		assert.InDelta(t, want, float64(WrapAngleQ48(n<<24))/(1<<48), 1e-6, "%f", f)
+	}
+	assert.Equal(t, fixpoint.Q24{N: fixpoint.Pi.N - fixpoint.TwoPi.N}, WrapAngle(int64(fixpoint.Pi.N)))
+	assert.Equal(t, -int64(fixpoint.Pi.N)<<24, WrapAngleQ48(int64(fixpoint.Pi.N)<<24))
+}
@@ -0,0 +1,56 @@
+// Package le encodes and decodes little-endian integers: those of the State
+// and Restore methods of the ahrs, audio, control and filters packages, the
+// calibration blobs of the calib package and the tracks of the interp package.
+//
+// The encoding/binary package has similar functions, but only for unsigned
+// integers, and its append functions need Go 1.19. The decoding functions
+// expect enough data: callers check the length of the whole input first.
+package le
+
+// AppendUint16 appends n to buf in 2 bytes.
+func AppendUint16(buf []byte, n uint16) []byte {
+	return append(buf, byte(n), byte(n>>8))
+}
+
+// AppendUint32 appends n to buf in 4 bytes.
+func AppendUint32(buf []byte, n uint32) []byte {
+	return append(buf, byte(n), byte(n>>8), byte(n>>16), byte(n>>24))
+}
+
+// AppendInt32 appends n to buf in 4 bytes.
+func AppendInt32(buf []byte, n int32) []byte {
+	return AppendUint32(buf, uint32(n))
+}
+
+// AppendInt64 appends n to buf in 8 bytes.
+func AppendInt64(buf []byte, n int64) []byte {
+	return AppendInt32(AppendInt32(buf, int32(n)), int32(n>>32))
+}
+
+// AppendBool appends b to buf as a single byte that is 1 for true.
+func AppendBool(buf []byte, b bool) []byte {
+	if b {
+		return append(buf, 1)
+	}
+	return append(buf, 0)
+}
+
+// Uint16 returns the uint16 in the first 2 bytes of data.
+func Uint16(data []byte) uint16 {
+	return uint16(data[0]) | uint16(data[1])<<8
+}
+
+// Uint32 returns the uint32 in the first 4 bytes of data.
+func Uint32(data []byte) uint32 {
+	return uint32(data[0]) | uint32(data[1])<<8 | uint32(data[2])<<16 | uint32(data[3])<<24
+}
+
+// Int32 returns the int32 in the first 4 bytes of data.
+func Int32(data []byte) int32 {
+	return int32(Uint32(data))
+}
+
+// Int64 returns the int64 in the first 8 bytes of data.
+func Int64(data []byte) int64 {
+	return int64(uint32(Int32(data))) | int64(Int32(data[4:]))<<32
+}
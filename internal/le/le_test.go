@@ -0,0 +1,31 @@
+package le
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLittleEndian(t *testing.T) {
+	for _, n := range []int64{0, 1, -1, 0x12345678, -0x123456789abcdef, 1<<63 - 1, -1 << 63} {
+		buf := AppendInt64([]byte{0xff}, n)
+		assert.Equal(t, 9, len(buf))
+		assert.Equal(t, uint64(n), binary.LittleEndian.Uint64(buf[1:]))
+		assert.Equal(t, n, Int64(buf[1:]))
+
+		buf = AppendInt32(nil, int32(n))
+		assert.Equal(t, uint32(n), binary.LittleEndian.Uint32(buf))
+		assert.Equal(t, int32(n), Int32(buf))
+
+		buf = AppendUint32(nil, uint32(n))
+		assert.Equal(t, uint32(n), binary.LittleEndian.Uint32(buf))
+		assert.Equal(t, uint32(n), Uint32(buf))
+
+		buf = AppendUint16(nil, uint16(n))
+		assert.Equal(t, 2, len(buf))
+		assert.Equal(t, uint16(n), binary.LittleEndian.Uint16(buf))
+		assert.Equal(t, uint16(n), Uint16(buf))
+	}
+	assert.Equal(t, []byte{1, 0}, AppendBool(AppendBool(nil, true), false))
+}
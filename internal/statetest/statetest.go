@@ -0,0 +1,27 @@
+// Package statetest checks the State and AppendState methods of the ahrs,
+// audio, control and filters packages in their tests.
+package statetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Appender is implemented by all types with a State method.
+type Appender interface {
+	State() []byte
+	AppendState([]byte) []byte
+}
+
+// CheckAppend checks that AppendState appends the same state as State
+// returns, without allocating when dst is large enough.
+func CheckAppend(t *testing.T, s Appender) {
+	t.Helper()
+	prefix := []byte{1, 2, 3}
+	assert.Equal(t, append(prefix, s.State()...), s.AppendState(prefix), "%T", s)
+	buf := make([]byte, 0, 256)
+	if allocs := testing.AllocsPerRun(10, func() { s.AppendState(buf) }); allocs != 0 {
+		t.Errorf("%T: AppendState allocates %v times", s, allocs)
+	}
+}
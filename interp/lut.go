@@ -0,0 +1,74 @@
+package interp
+
+import (
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/fixmath"
+)
+
+// LUT2D is a two-dimensional lookup table on a regular grid, with bilinear
+// interpolation between the grid points. It is useful for control tables (such
+// as a fuel map indexed by speed and load) or for correction surfaces that are
+// too expensive to calculate directly.
+//
+// The zero value is an empty table, which always returns zero.
+type LUT2D struct {
+	// Width and Height are the number of grid points along the X and Y axis.
+	Width  int
+	Height int
+
+	// Values contains the values at the grid points, row by row: the value at
+	// grid point (i, j) is Values[j*Width+i].
+	Values []fixpoint.Q24
+
+	// Origin is the position of the first grid point, and Spacing is the
+	// distance between two grid points along each axis. Both elements of
+	// Spacing must be positive.
+	Origin  fixpoint.Vec2Q24
+	Spacing fixpoint.Vec2Q24
+
+	// Edge determines the value outside of the grid. With Loop, the table
+	// wraps around: like with a Timeline, the last row and column coincide
+	// with the first, so they should contain the same values.
+	Edge Extrapolation
+}
+
+// Lookup returns the interpolated value at the given position.
+func (t *LUT2D) Lookup(x, y fixpoint.Q24) fixpoint.Q24 {
+	if t.Width <= 0 || t.Height <= 0 || len(t.Values) < t.Width*t.Height {
+		return fixpoint.Q24{}
+	}
+	i0, i1, fx := t.axis(x, t.Origin.X, t.Spacing.X, t.Width)
+	j0, j1, fy := t.axis(y, t.Origin.Y, t.Spacing.Y, t.Height)
+	row0, row1 := t.Values[j0*t.Width:], t.Values[j1*t.Width:]
+	return lerp(lerp(row0[i0], row0[i1], fx), lerp(row1[i0], row1[i1], fx), fy)
+}
+
+// axis returns the two grid indices along one axis around the given position,
+// and the position between them where 0 is at i0 and 1 is at i1.
+func (t *LUT2D) axis(pos, origin, spacing fixpoint.Q24, n int) (i0, i1 int, frac fixpoint.Q24) {
+	if n == 1 {
+		return 0, 0, fixpoint.Q24{}
+	}
+	p := ((int64(pos.N) - int64(origin.N)) << 24) / int64(spacing.N)
+	span := int64(n-1) << 24
+	switch t.Edge {
+	case Clamp:
+		if p < 0 {
+			p = 0
+		} else if p > span {
+			p = span
+		}
+	case Loop:
+		p %= span
+		if p < 0 {
+			p += span
+		}
+	}
+	i := p >> 24
+	if i < 0 {
+		i = 0
+	} else if i > int64(n-2) {
+		i = int64(n - 2)
+	}
+	return int(i), int(i) + 1, fixpoint.Q24{N: fixmath.Saturate(p - i<<24)}
+}
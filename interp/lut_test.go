@@ -0,0 +1,56 @@
+package interp
+
+import (
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLUT2D(t *testing.T) {
+	// Bilinear interpolation is exact for f(x, y) = 1 + 2x + 3y + xy.
+	f := func(x, y float32) fixpoint.Q24 {
+		return fixpoint.Q24FromFloat(1 + 2*x + 3*y + x*y)
+	}
+	lut := LUT2D{
+		Width:   3,
+		Height:  2,
+		Origin:  fixpoint.Vec2Q24FromFloat(1, -1),
+		Spacing: fixpoint.Vec2Q24FromFloat(0.5, 2),
+	}
+	for j := 0; j < lut.Height; j++ {
+		for i := 0; i < lut.Width; i++ {
+			lut.Values = append(lut.Values, f(1+0.5*float32(i), -1+2*float32(j)))
+		}
+	}
+	for _, p := range [][2]float32{{1, -1}, {2, 1}, {1.25, 0}, {1.75, 0.5}, {1.1, -0.9}} {
+		assert.InDelta(t, f(p[0], p[1]).N, lut.Lookup(fixpoint.Q24FromFloat(p[0]), fixpoint.Q24FromFloat(p[1])).N, 2, "%v", p)
+	}
+
+	// Clamped to the edges of the grid.
+	assert.Equal(t, f(1, -1), lut.Lookup(fixpoint.Q24FromFloat(-5), fixpoint.Q24FromFloat(-5)))
+	assert.Equal(t, f(2, 1), lut.Lookup(fixpoint.Q24FromFloat(5), fixpoint.Q24FromFloat(5)))
+	assert.InDelta(t, f(1.5, 1).N, lut.Lookup(fixpoint.Q24FromFloat(1.5), fixpoint.Q24FromFloat(3)).N, 1)
+
+	// Extrapolated from the nearest cell.
+	lut.Edge = Linear
+	assert.InDelta(t, f(3, 2).N, lut.Lookup(fixpoint.Q24FromFloat(3), fixpoint.Q24FromFloat(2)).N, 4)
+	assert.InDelta(t, f(0, -2).N, lut.Lookup(fixpoint.Q24FromFloat(0), fixpoint.Q24FromFloat(-2)).N, 4)
+
+	// Wrapped around: one period is 1 along X and 2 along Y.
+	lut.Edge = Loop
+	assert.Equal(t, lut.Lookup(fixpoint.Q24FromFloat(1.25), fixpoint.Q24FromFloat(0)),
+		lut.Lookup(fixpoint.Q24FromFloat(3.25), fixpoint.Q24FromFloat(-4)))
+	assert.Equal(t, lut.Lookup(fixpoint.Q24FromFloat(1.75), fixpoint.Q24FromFloat(0.5)),
+		lut.Lookup(fixpoint.Q24FromFloat(-0.25), fixpoint.Q24FromFloat(4.5)))
+
+	// Degenerate tables.
+	assert.Equal(t, fixpoint.Q24{}, (&LUT2D{}).Lookup(fixpoint.Q24{}, fixpoint.Q24{}))
+	row := LUT2D{
+		Width:   2,
+		Height:  1,
+		Values:  []fixpoint.Q24{fixpoint.Q24FromInt32(1), fixpoint.Q24FromInt32(3)},
+		Spacing: fixpoint.Vec2Q24FromFloat(1, 1),
+	}
+	assert.Equal(t, fixpoint.Q24FromInt32(2), row.Lookup(fixpoint.Q24FromFloat(0.5), fixpoint.Q24FromInt32(7)))
+}
@@ -0,0 +1,171 @@
+package interp
+
+import (
+	"github.com/aykevl/fixpoint"
+)
+
+// Resampling
+//
+// Sensors often have different output data rates, or timestamps that jitter.
+// Before their readings can be fused, they need to be resampled to a common
+// fixed rate. The samplers can do this for recorded data, and the resamplers
+// below do it for a stream of readings on the device.
+
+// Resample appends n samples to dst, at the times start, start+period,
+// start+2*period and so on, and returns the extended slice.
+func (s *Sampler) Resample(dst []fixpoint.Q24, start, period int32, n int) []fixpoint.Q24 {
+	for i := 0; i < n; i++ {
+		dst = append(dst, s.Sample(start+int32(i)*period))
+	}
+	return dst
+}
+
+// Resample appends n samples to dst, at the times start, start+period,
+// start+2*period and so on, and returns the extended slice.
+func (s *Vec3Sampler) Resample(dst []fixpoint.Vec3Q24, start, period int32, n int) []fixpoint.Vec3Q24 {
+	for i := 0; i < n; i++ {
+		dst = append(dst, s.Sample(start+int32(i)*period))
+	}
+	return dst
+}
+
+// Resample appends n samples to dst, at the times start, start+period,
+// start+2*period and so on, and returns the extended slice.
+func (s *QuatSampler) Resample(dst []fixpoint.QuatQ24, start, period int32, n int) []fixpoint.QuatQ24 {
+	for i := 0; i < n; i++ {
+		dst = append(dst, s.Sample(start+int32(i)*period))
+	}
+	return dst
+}
+
+// resampleClock keeps track of the times of the two most recent input samples
+// and of the next output sample of a resampler. Times are only compared by
+// their difference, so timestamps may wrap around, like a microsecond counter
+// does after about 36 minutes.
+type resampleClock struct {
+	t0, t1  int32 // times of the previous and the most recent input
+	next    int32 // time of the next output
+	started bool
+}
+
+// add adds an input sample at time t, and returns false if it must be ignored
+// because it isn't newer than the previous one.
+func (c *resampleClock) add(t, period int32) bool {
+	if !c.started {
+		// Align the output to a multiple of the period, so that all
+		// resamplers with the same period produce samples at the same times.
+		c.next = t / period * period
+		if c.next < t {
+			c.next += period
+		}
+		c.t0, c.t1 = t, t
+		c.started = true
+		return true
+	}
+	if t-c.t1 <= 0 {
+		return false
+	}
+	c.t0, c.t1 = c.t1, t
+	return true
+}
+
+// frac returns the position of the next output between the two most recent
+// inputs, and advances to the output after it. It returns false when the
+// next output is after the most recent input.
+func (c *resampleClock) frac(period int32) (fixpoint.Q24, bool) {
+	if c.next-c.t1 > 0 {
+		return fixpoint.Q24{}, false
+	}
+	var f fixpoint.Q24
+	if c.t1 != c.t0 {
+		f.N = int32((int64(c.next-c.t0) << 24) / int64(c.t1-c.t0))
+	}
+	c.next += period
+	return f, true
+}
+
+// Vec3Resampler converts a stream of timestamped Vec3Q24 readings to a fixed
+// rate, with linear interpolation. The output samples are at multiples of
+// Period, so the output of resamplers for different sensors with the same
+// Period is aligned.
+//
+// The zero value is not usable: Period must be set.
+type Vec3Resampler struct {
+	// Period is the time between output samples, in the same unit as the
+	// timestamps of the input. The timestamps may wrap around, but the output
+	// samples are only at multiples of Period after a wrap if Period is a
+	// power of two.
+	Period int32
+
+	clock  resampleClock
+	v0, v1 fixpoint.Vec3Q24
+}
+
+// Add adds a reading at time t, and appends the output samples up to time t
+// to dst. Readings must be added in order: a reading that isn't newer than
+// the previous one is ignored.
+func (r *Vec3Resampler) Add(dst []fixpoint.Vec3Q24, t int32, v fixpoint.Vec3Q24) []fixpoint.Vec3Q24 {
+	if !r.clock.add(t, r.Period) {
+		return dst
+	}
+	r.v0, r.v1 = r.v1, v
+	if r.clock.t0 == r.clock.t1 {
+		r.v0 = v
+	}
+	for {
+		f, ok := r.clock.frac(r.Period)
+		if !ok {
+			return dst
+		}
+		dst = append(dst, fixpoint.Vec3Q24{
+			X: lerp(r.v0.X, r.v1.X, f),
+			Y: lerp(r.v0.Y, r.v1.Y, f),
+			Z: lerp(r.v0.Z, r.v1.Z, f),
+		})
+	}
+}
+
+// NextTime returns the time of the next output sample.
+func (r *Vec3Resampler) NextTime() int32 {
+	return r.clock.next
+}
+
+// QuatResampler converts a stream of timestamped orientations to a fixed
+// rate, with spherical linear interpolation. Like with a Vec3Resampler, the
+// output samples are at multiples of Period.
+//
+// The zero value is not usable: Period must be set.
+type QuatResampler struct {
+	// Period is the time between output samples, in the same unit as the
+	// timestamps of the input. Like with a Vec3Resampler, the timestamps may
+	// wrap around.
+	Period int32
+
+	clock  resampleClock
+	q0, q1 fixpoint.QuatQ24
+}
+
+// Add adds a unit quaternion at time t, and appends the output samples up to
+// time t to dst. Orientations must be added in order: an orientation that
+// isn't newer than the previous one is ignored.
+func (r *QuatResampler) Add(dst []fixpoint.QuatQ24, t int32, q fixpoint.QuatQ24) []fixpoint.QuatQ24 {
+	if !r.clock.add(t, r.Period) {
+		return dst
+	}
+	r.q0, r.q1 = r.q1, q
+	if r.clock.t0 == r.clock.t1 {
+		r.q0 = q
+	}
+	for {
+		f, ok := r.clock.frac(r.Period)
+		if !ok {
+			return dst
+		}
+		dst = append(dst, fixpoint.QuatSlerp(r.q0, r.q1, f))
+	}
+}
+
+// NextTime returns the time of the next output sample.
+func (r *QuatResampler) NextTime() int32 {
+	return r.clock.next
+}
@@ -0,0 +1,110 @@
+package interp
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSamplerResample(t *testing.T) {
+	s := Vec3Sampler{
+		Timeline: Timeline{Times: []int32{0, 1000}},
+		Values: []fixpoint.Vec3Q24{
+			fixpoint.Vec3Q24FromFloat(0, 1, 2),
+			fixpoint.Vec3Q24FromFloat(4, -1, 2),
+		},
+	}
+	assert.Equal(t, []fixpoint.Vec3Q24{
+		fixpoint.Vec3Q24FromFloat(0, 1, 2),
+		fixpoint.Vec3Q24FromFloat(2, 0, 2),
+		fixpoint.Vec3Q24FromFloat(4, -1, 2),
+		fixpoint.Vec3Q24FromFloat(4, -1, 2),
+	}, s.Resample(nil, 0, 500, 4))
+
+	scalar := Sampler{
+		Timeline: s.Timeline,
+		Values:   []fixpoint.Q24{fixpoint.Q24FromInt32(1), fixpoint.Q24FromInt32(3)},
+	}
+	assert.Equal(t, []fixpoint.Q24{fixpoint.Q24FromFloat(1.5), fixpoint.Q24FromFloat(2.5)}, scalar.Resample(nil, 250, 500, 2))
+
+	axis := fixpoint.Vec3Q24FromFloat(0, 0, 1)
+	q := QuatSampler{
+		Timeline: s.Timeline,
+		Values:   []fixpoint.QuatQ24{fixpoint.QuatIdent(), fixpoint.QuatFromAxisAngle(axis, fixpoint.Q24FromInt32(1))},
+	}
+	for i, o := range q.Resample(nil, 0, 100, 11) {
+		_, angle := o.ToAxisAngle()
+		assert.InDelta(t, float32(i)/10, angle.Float(), 0.0001, "sample %d", i)
+	}
+}
+
+func TestVec3Resampler(t *testing.T) {
+	// A sensor with a jittery 95Hz output, resampled to 100Hz (times in
+	// microseconds). The signal is linear, so the interpolation is exact.
+	value := func(t int32) fixpoint.Vec3Q24 {
+		x := fixpoint.Q24{N: t * 8}
+		return fixpoint.Vec3Q24{X: x, Y: x.Neg(), Z: fixpoint.Q24FromInt32(1)}
+	}
+	r := Vec3Resampler{Period: 10000}
+	var output []fixpoint.Vec3Q24
+	next := int32(10000)
+	for i := 0; i < 100; i++ {
+		ts := 3000 + int32(i)*10526 + int32(i%3)*500
+		if i == 0 {
+			assert.Equal(t, 0, len(r.Add(nil, ts, value(ts))))
+			assert.Equal(t, next, r.NextTime())
+			continue
+		}
+		output = r.Add(output, ts, value(ts))
+		assert.True(t, r.NextTime() > ts)
+	}
+	assert.Equal(t, 104, len(output))
+	for i, v := range output {
+		ts := next + int32(i)*10000
+		assert.InDelta(t, value(ts).X.N, v.X.N, 1, "t=%d", ts)
+		assert.InDelta(t, value(ts).Y.N, v.Y.N, 1, "t=%d", ts)
+		assert.Equal(t, value(ts).Z, v.Z)
+	}
+
+	// A first reading at a multiple of the period is output as-is, and
+	// readings that aren't newer are ignored.
+	r = Vec3Resampler{Period: 100}
+	assert.Equal(t, []fixpoint.Vec3Q24{value(200)}, r.Add(nil, 200, value(200)))
+	assert.Equal(t, 0, len(r.Add(nil, 200, value(0))))
+	assert.Equal(t, 0, len(r.Add(nil, 150, value(0))))
+	assert.Equal(t, []fixpoint.Vec3Q24{value(300), value(400)}, r.Add(nil, 450, value(450)))
+
+	// Timestamps of a microsecond counter wrap around after about 36
+	// minutes, which doesn't interrupt the output.
+	r = Vec3Resampler{Period: 1024}
+	start := int32(math.MaxInt32 - 5000)
+	output = r.Add(output[:0], start, value(0))
+	for i := int32(1); i <= 10; i++ {
+		output = r.Add(output, start+i*1000, value(i*1000))
+	}
+	assert.Equal(t, 9, len(output))
+	first := r.NextTime() - 9*1024 - start
+	assert.Equal(t, int32(905), first) // the first multiple of 1024 after start
+	for i, v := range output {
+		ts := first + int32(i)*1024
+		assert.InDelta(t, value(ts).X.N, v.X.N, 8, "t=%d", ts)
+	}
+	assert.True(t, r.NextTime() < 0)
+}
+
+func TestQuatResampler(t *testing.T) {
+	axis := fixpoint.Vec3Q24FromFloat(1, 0, 0)
+	r := QuatResampler{Period: 20}
+	var output []fixpoint.QuatQ24
+	for _, ts := range []int32{5, 33, 61, 90, 118} {
+		output = r.Add(output, ts, fixpoint.QuatFromAxisAngle(axis, fixpoint.Q24{N: ts << 16}))
+	}
+	assert.Equal(t, 5, len(output))
+	for i, q := range output {
+		_, angle := q.ToAxisAngle()
+		assert.InDelta(t, float64(20*(i+1))/(1<<8), angle.Float(), 0.0001, "sample %d", i)
+	}
+	assert.Equal(t, int32(120), r.NextTime())
+}
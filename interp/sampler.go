@@ -0,0 +1,175 @@
+// Package interp implements interpolation of fixed point values, for example to
+// replay sensor data or to play back actuator trajectories.
+package interp
+
+import (
+	"sort"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/fixmath"
+)
+
+// Extrapolation determines what a sampler returns for query times outside of
+// the range of its keyframes.
+type Extrapolation uint8
+
+const (
+	// Clamp holds the value of the first or last keyframe.
+	Clamp Extrapolation = iota
+
+	// Linear continues the first or last segment in a straight line.
+	Linear
+
+	// Loop repeats the keyframes. The last keyframe coincides with the first
+	// keyframe of the next repetition, so for a smooth loop the first and last
+	// value should be the same.
+	Loop
+)
+
+// Timeline is a sorted list of keyframe times, shared by all sampler types.
+// Times are in arbitrary integer units, for example milliseconds.
+//
+// A timeline remembers the last segment that was used, so that sampling at
+// increasing (or slowly changing) times doesn't need to search the whole
+// timeline. This makes sampling O(1) in the common case of playback in a
+// periodic task.
+type Timeline struct {
+	// Times contains the keyframe times in increasing order. Two keyframes
+	// may have the same time, to create a discontinuity.
+	Times []int32
+
+	// Extrapolate determines the value outside of the keyframe times.
+	Extrapolate Extrapolation
+
+	hint int // index of the last used segment
+}
+
+// Segment returns the index i of the keyframe segment [i, i+1] for the given
+// time, and the position within that segment where 0 is at keyframe i and 1 is
+// at keyframe i+1. The position may be outside of the range [0, 1] with
+// Linear extrapolation. There must be at least two keyframes.
+func (tl *Timeline) Segment(t int32) (i int, frac fixpoint.Q24) {
+	times := tl.Times
+	first, last := times[0], times[len(times)-1]
+	if tl.Extrapolate == Loop && last > first {
+		span := int64(last) - int64(first)
+		offset := (int64(t) - int64(first)) % span
+		if offset < 0 {
+			offset += span
+		}
+		t = int32(int64(first) + offset)
+	}
+
+	// Find the segment, trying the previous and next segment first.
+	i = tl.hint
+	if i >= len(times)-1 || !(times[i] <= t && t < times[i+1]) {
+		if i+2 < len(times) && times[i+1] <= t && t < times[i+2] {
+			i++
+		} else {
+			i = sort.Search(len(times), func(j int) bool {
+				return times[j] > t
+			}) - 1
+		}
+	}
+	if i < 0 {
+		i = 0
+	} else if i > len(times)-2 {
+		i = len(times) - 2
+	}
+	tl.hint = i
+
+	t0, t1 := int64(times[i]), int64(times[i+1])
+	if t1 == t0 {
+		if int64(t) < t0 {
+			return i, fixpoint.Q24{}
+		}
+		return i, fixpoint.Q24FromInt32(1)
+	}
+	f := ((int64(t) - t0) << 24) / (t1 - t0)
+	if tl.Extrapolate != Linear {
+		// Clamp to the first or last keyframe.
+		if f < 0 {
+			f = 0
+		} else if f > 1<<24 {
+			f = 1 << 24
+		}
+	}
+	return i, fixpoint.Q24{N: fixmath.Saturate(f)}
+}
+
+// Sampler interpolates linearly between Q24 keyframes.
+type Sampler struct {
+	Timeline
+
+	// Values contains the keyframe values, one for each time in Times.
+	Values []fixpoint.Q24
+}
+
+// Sample returns the interpolated value at the given time. It returns zero if
+// there are no keyframes.
+func (s *Sampler) Sample(t int32) fixpoint.Q24 {
+	switch len(s.Values) {
+	case 0:
+		return fixpoint.Q24{}
+	case 1:
+		return s.Values[0]
+	}
+	i, frac := s.Segment(t)
+	return lerp(s.Values[i], s.Values[i+1], frac)
+}
+
+// Vec3Sampler interpolates linearly between Vec3Q24 keyframes.
+type Vec3Sampler struct {
+	Timeline
+
+	// Values contains the keyframe values, one for each time in Times.
+	Values []fixpoint.Vec3Q24
+}
+
+// Sample returns the interpolated vector at the given time. It returns the
+// zero vector if there are no keyframes.
+func (s *Vec3Sampler) Sample(t int32) fixpoint.Vec3Q24 {
+	switch len(s.Values) {
+	case 0:
+		return fixpoint.Vec3Q24{}
+	case 1:
+		return s.Values[0]
+	}
+	i, frac := s.Segment(t)
+	v0, v1 := s.Values[i], s.Values[i+1]
+	return fixpoint.Vec3Q24{
+		X: lerp(v0.X, v1.X, frac),
+		Y: lerp(v0.Y, v1.Y, frac),
+		Z: lerp(v0.Z, v1.Z, frac),
+	}
+}
+
+// QuatSampler interpolates between QuatQ24 keyframes with spherical linear
+// interpolation, so that the rotation speed is constant within a segment.
+type QuatSampler struct {
+	Timeline
+
+	// Values contains the keyframe orientations as unit quaternions, one for
+	// each time in Times.
+	Values []fixpoint.QuatQ24
+}
+
+// Sample returns the interpolated orientation at the given time. It returns
+// the identity quaternion if there are no keyframes.
+func (s *QuatSampler) Sample(t int32) fixpoint.QuatQ24 {
+	switch len(s.Values) {
+	case 0:
+		return fixpoint.QuatIdent()
+	case 1:
+		return s.Values[0]
+	}
+	i, frac := s.Segment(t)
+	return fixpoint.QuatSlerp(s.Values[i], s.Values[i+1], frac)
+}
+
+// lerp returns a + (b-a)*t, rounded to the nearest value and saturated to the
+// Q24 range.
+func lerp(a, b, t fixpoint.Q24) fixpoint.Q24 {
+	diff := int64(b.N) - int64(a.N)
+	return fixpoint.Q24{N: fixmath.Saturate(int64(a.N) + (diff*int64(t.N)+1<<23)>>24)}
+}
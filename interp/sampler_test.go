@@ -0,0 +1,79 @@
+package interp
+
+import (
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSampler(t *testing.T) {
+	s := Sampler{
+		Timeline: Timeline{Times: []int32{0, 100, 100, 300}},
+		Values: []fixpoint.Q24{
+			fixpoint.Q24FromInt32(1),
+			fixpoint.Q24FromInt32(2),
+			fixpoint.Q24FromInt32(-2),
+			fixpoint.Q24FromInt32(0),
+		},
+	}
+	for _, tc := range []struct {
+		t int32
+		v float32
+	}{
+		{-50, 1},
+		{0, 1},
+		{25, 1.25},
+		{99, 1.99},
+		{100, -2}, // discontinuity
+		{200, -1},
+		{300, 0},
+		{1000, 0},
+		{150, -1.5}, // going back in time
+	} {
+		assert.Equal(t, fixpoint.Q24FromFloat(tc.v).Text(6), s.Sample(tc.t).Text(6), "t=%d", tc.t)
+	}
+
+	s.Extrapolate = Linear
+	assert.Equal(t, fixpoint.Q24FromFloat(0.5), s.Sample(-50))
+	assert.Equal(t, fixpoint.Q24FromFloat(1), s.Sample(400))
+	assert.Equal(t, fixpoint.Q24{N: 1<<31 - 1}, s.Sample(1<<31-1)) // saturated
+
+	s.Extrapolate = Loop
+	assert.Equal(t, fixpoint.Q24FromFloat(1.25), s.Sample(325))
+	assert.Equal(t, fixpoint.Q24FromFloat(-1), s.Sample(-100))
+
+	// Degenerate cases.
+	assert.Equal(t, fixpoint.Q24{}, (&Sampler{}).Sample(5))
+	one := Sampler{Timeline: Timeline{Times: []int32{10}}, Values: []fixpoint.Q24{fixpoint.Q24FromInt32(3)}}
+	assert.Equal(t, fixpoint.Q24FromInt32(3), one.Sample(-5))
+}
+
+func TestVec3Sampler(t *testing.T) {
+	s := Vec3Sampler{
+		Timeline: Timeline{Times: []int32{0, 1000}},
+		Values: []fixpoint.Vec3Q24{
+			fixpoint.Vec3Q24FromFloat(0, 1, 2),
+			fixpoint.Vec3Q24FromFloat(4, -1, 2),
+		},
+	}
+	assert.Equal(t, fixpoint.Vec3Q24FromFloat(1, 0.5, 2), s.Sample(250))
+	assert.Equal(t, fixpoint.Vec3Q24FromFloat(4, -1, 2), s.Sample(2000))
+}
+
+func TestQuatSampler(t *testing.T) {
+	axis := fixpoint.Vec3Q24FromFloat(0, 0, 1)
+	s := QuatSampler{
+		Timeline: Timeline{Times: []int32{0, 1000, 2000}},
+		Values: []fixpoint.QuatQ24{
+			fixpoint.QuatIdent(),
+			fixpoint.QuatFromAxisAngle(axis, fixpoint.Q24FromFloat(1)),
+			fixpoint.QuatFromAxisAngle(axis, fixpoint.Q24FromFloat(2)),
+		},
+	}
+	for ms := int32(0); ms <= 2000; ms += 100 {
+		_, angle := s.Sample(ms).ToAxisAngle()
+		assert.InDelta(t, float32(ms)/1000, angle.Float(), 0.0001, "t=%d", ms)
+	}
+	assert.Equal(t, fixpoint.QuatIdent(), (&QuatSampler{}).Sample(0))
+}
@@ -0,0 +1,109 @@
+package interp
+
+import (
+	"sort"
+
+	"github.com/aykevl/fixpoint"
+)
+
+// Spline is a natural cubic spline through a table of points. Unlike
+// piecewise linear interpolation its slope is continuous, so it doesn't
+// introduce kinks, for example when it is used to linearize a sensor.
+//
+// The second derivatives at the points are calculated once with Init, after
+// which evaluating the spline only takes a handful of multiplications. The
+// calculations are done with Q32 numbers, so that the result is within about
+// one ULP of the exact spline.
+type Spline struct {
+	// X contains the X coordinates of the points, in strictly increasing
+	// order.
+	X []fixpoint.Q24
+
+	// Y contains the Y coordinates of the points, one for each element of X.
+	Y []fixpoint.Q24
+
+	m []fixpoint.Q32 // second derivatives at the points
+	c []fixpoint.Q32 // scratch buffer for Init
+}
+
+// Init calculates the second derivatives of the spline at the points. It is
+// called automatically on the first call to Eval, but must be called again
+// after X or Y is modified. It only allocates when there are more points than
+// in any previous call.
+func (s *Spline) Init() {
+	n := len(s.X)
+	if cap(s.m) < n {
+		s.m = make([]fixpoint.Q32, n)
+		s.c = make([]fixpoint.Q32, n)
+	}
+	s.m = s.m[:n]
+	for i := range s.m {
+		s.m[i] = fixpoint.Q32{}
+	}
+	if n < 3 {
+		return
+	}
+
+	// Solve the tridiagonal system
+	//   h[i-1]*m[i-1] + 2*(h[i-1]+h[i])*m[i] + h[i]*m[i+1] = 6*(d[i]-d[i-1])
+	// where h[i] is the width of segment i and d[i] its slope, with the
+	// Thomas algorithm. The natural spline has m[0] = m[n-1] = 0.
+	c := s.c[:n]
+	c[0] = fixpoint.Q32{}
+	h0 := s.width(0)
+	d0 := s.slope(0, h0)
+	for i := 1; i < n-1; i++ {
+		h1 := s.width(i)
+		d1 := s.slope(i, h1)
+		b := h0.Add(h1).Add(h0.Add(h1)).Sub(h0.Mul(c[i-1]))
+		c[i] = h1.Div(b)
+		r := d1.Sub(d0).Mul(fixpoint.Q32FromInt32(6))
+		s.m[i] = r.Sub(h0.Mul(s.m[i-1])).Div(b)
+		h0, d0 = h1, d1
+	}
+	for i := n - 2; i > 0; i-- {
+		s.m[i] = s.m[i].Sub(c[i].Mul(s.m[i+1]))
+	}
+}
+
+// width returns the width of segment i.
+func (s *Spline) width(i int) fixpoint.Q32 {
+	return fixpoint.Q32FromQ24(s.X[i+1]).Sub(fixpoint.Q32FromQ24(s.X[i]))
+}
+
+// slope returns the slope of the straight line through the ends of segment i.
+func (s *Spline) slope(i int, width fixpoint.Q32) fixpoint.Q32 {
+	return fixpoint.Q32FromQ24(s.Y[i+1]).Sub(fixpoint.Q32FromQ24(s.Y[i])).Div(width)
+}
+
+// Eval returns the value of the spline at x. Outside of the range of X, it
+// returns the value of the first or last point. It returns zero if there are
+// no points.
+func (s *Spline) Eval(x fixpoint.Q24) fixpoint.Q24 {
+	n := len(s.X)
+	switch {
+	case n == 0:
+		return fixpoint.Q24{}
+	case x.N <= s.X[0].N:
+		return s.Y[0]
+	case x.N >= s.X[n-1].N:
+		return s.Y[n-1]
+	}
+	if len(s.m) != n {
+		s.Init()
+	}
+	i := sort.Search(n, func(j int) bool {
+		return s.X[j].N > x.N
+	}) - 1
+
+	// y = a*y[i] + b*y[i+1] + ((a^3-a)*m[i] + (b^3-b)*m[i+1]) * h^2/6
+	h := s.width(i)
+	one := fixpoint.Q32FromInt32(1)
+	b := fixpoint.Q32FromQ24(x).Sub(fixpoint.Q32FromQ24(s.X[i])).Div(h)
+	a := one.Sub(b)
+	y := a.Mul(fixpoint.Q32FromQ24(s.Y[i])).Add(b.Mul(fixpoint.Q32FromQ24(s.Y[i+1])))
+	ca := a.Mul(a).Mul(a).Sub(a).Mul(s.m[i])
+	cb := b.Mul(b).Mul(b).Sub(b).Mul(s.m[i+1])
+	y = y.Add(ca.Add(cb).Mul(h.Mul(h)).Div(fixpoint.Q32FromInt32(6)))
+	return y.Q24()
+}
@@ -0,0 +1,81 @@
+package interp
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+// naturalSpline is a floating point reference implementation of Spline.
+func naturalSpline(xs, ys []float64, x float64) float64 {
+	n := len(xs)
+	m := make([]float64, n)
+	c := make([]float64, n)
+	for i := 1; i < n-1; i++ {
+		h0, h1 := xs[i]-xs[i-1], xs[i+1]-xs[i]
+		b := 2*(h0+h1) - h0*c[i-1]
+		c[i] = h1 / b
+		r := 6 * ((ys[i+1]-ys[i])/h1 - (ys[i]-ys[i-1])/h0)
+		m[i] = (r - h0*m[i-1]) / b
+	}
+	for i := n - 2; i > 0; i-- {
+		m[i] -= c[i] * m[i+1]
+	}
+	i := sort.SearchFloat64s(xs, x) - 1
+	if i < 0 {
+		i = 0
+	}
+	h := xs[i+1] - xs[i]
+	b := (x - xs[i]) / h
+	a := 1 - b
+	return a*ys[i] + b*ys[i+1] + ((a*a*a-a)*m[i]+(b*b*b-b)*m[i+1])*h*h/6
+}
+
+func TestSpline(t *testing.T) {
+	// A nonlinear sensor response with unevenly spaced calibration points.
+	xs := []float64{0, 0.5, 1.25, 2, 3.5, 4}
+	ys := []float64{0, 0.3, 1.1, 2.5, 3, 6.25}
+	var s Spline
+	for i := range xs {
+		s.X = append(s.X, fixpoint.Q24FromFloat64(xs[i]))
+		s.Y = append(s.Y, fixpoint.Q24FromFloat64(ys[i]))
+	}
+	for f := -0.5; f <= 4.5; f += 0.01 {
+		x := fixpoint.Q24FromFloat64(f).Float64()
+		want := naturalSpline(xs, ys, math.Max(0, math.Min(4, x)))
+		if x > 4 {
+			want = ys[len(ys)-1]
+		}
+		assert.InDelta(t, want, s.Eval(fixpoint.Q24FromFloat64(x)).Float64(), 1.0/(1<<24), "x=%v", x)
+	}
+	for i := range xs {
+		assert.Equal(t, s.Y[i], s.Eval(s.X[i]), "x=%v", xs[i])
+	}
+
+	// Changing the points requires a new call to Init.
+	s.Y[2] = fixpoint.Q24FromFloat(0.6)
+	s.Init()
+	ys[2] = 0.6
+	assert.InDelta(t, naturalSpline(xs, ys, 1), s.Eval(fixpoint.Q24FromInt32(1)).Float64(), 2.0/(1<<24))
+
+	// Calling Init again with the same number of points doesn't allocate.
+	if allocs := testing.AllocsPerRun(10, s.Init); allocs != 0 {
+		t.Errorf("expected Init to reuse its buffers, got %v allocations", allocs)
+	}
+
+	// A spline through points on a straight line is that line.
+	line := Spline{
+		X: []fixpoint.Q24{fixpoint.Q24FromInt32(-2), fixpoint.Q24FromInt32(1), fixpoint.Q24FromInt32(2)},
+		Y: []fixpoint.Q24{fixpoint.Q24FromInt32(5), fixpoint.Q24FromInt32(-1), fixpoint.Q24FromInt32(-3)},
+	}
+	assert.Equal(t, fixpoint.Q24FromInt32(1), line.Eval(fixpoint.Q24{}))
+	assert.Equal(t, fixpoint.Q24FromFloat(-2), line.Eval(fixpoint.Q24FromFloat(1.5)))
+
+	// Degenerate splines.
+	assert.Equal(t, fixpoint.Q24{}, (&Spline{}).Eval(fixpoint.Q24{}))
+	one := Spline{X: []fixpoint.Q24{{}}, Y: []fixpoint.Q24{fixpoint.Q24FromInt32(3)}}
+	assert.Equal(t, fixpoint.Q24FromInt32(3), one.Eval(fixpoint.Q24FromInt32(1)))
+}
@@ -0,0 +1,125 @@
+package interp
+
+import (
+	"errors"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/le"
+)
+
+// QuatKey is an orientation keyframe compressed in the smallest-three format
+// (see fixpoint.QuatQ24.Compress), which takes 7 bytes instead of 16.
+type QuatKey struct {
+	C     [3]int16
+	Index uint8
+}
+
+// QuatKeyFromQuat compresses the unit quaternion q.
+func QuatKeyFromQuat(q fixpoint.QuatQ24) QuatKey {
+	c, index := q.Compress()
+	return QuatKey{C: c, Index: index}
+}
+
+// Quat returns the decompressed orientation.
+func (k QuatKey) Quat() fixpoint.QuatQ24 {
+	return fixpoint.QuatDecompress(k.C, k.Index)
+}
+
+// QuatTrack is a recorded motion: a list of compressed orientation keyframes
+// that is played back with spherical linear interpolation, like a
+// QuatSampler. It is meant to store long motions in little memory, for
+// example in flash to be replayed on servos or a gimbal. Each keyframe takes
+// 11 bytes in the binary encoding (including its time), and the error of the
+// compression is about 2^-15 per element.
+type QuatTrack struct {
+	Timeline
+
+	// Keys contains the compressed keyframes, one for each time in Times.
+	Keys []QuatKey
+}
+
+// Append adds a keyframe at the end of the track. The time must not be before
+// the time of the last keyframe.
+func (tr *QuatTrack) Append(t int32, q fixpoint.QuatQ24) {
+	tr.Times = append(tr.Times, t)
+	tr.Keys = append(tr.Keys, QuatKeyFromQuat(q))
+}
+
+// Sample returns the interpolated orientation at the given time. It returns
+// the identity quaternion if there are no keyframes.
+func (tr *QuatTrack) Sample(t int32) fixpoint.QuatQ24 {
+	switch len(tr.Keys) {
+	case 0:
+		return fixpoint.QuatIdent()
+	case 1:
+		return tr.Keys[0].Quat()
+	}
+	i, frac := tr.Segment(t)
+	return fixpoint.QuatSlerp(tr.Keys[i].Quat(), tr.Keys[i+1].Quat(), frac)
+}
+
+// Binary layout of a QuatTrack, with all integers in little-endian byte
+// order:
+//
+//	magic:       "QT" (2 bytes)
+//	extrapolate: uint8
+//	count:       uint32, the number of keyframes
+//	keyframes:   for each keyframe its time as an int32, the three elements
+//	             of the QuatKey as int16 and the dropped index as uint8
+
+var errTrackFormat = errors.New("interp: invalid track data")
+
+const (
+	trackHeaderSize = 7
+	trackKeySize    = 11
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler. It returns an error if
+// the number of times and keys differ.
+func (tr *QuatTrack) MarshalBinary() ([]byte, error) {
+	if len(tr.Times) != len(tr.Keys) {
+		return nil, errTrackFormat
+	}
+	buf := make([]byte, 0, trackHeaderSize+trackKeySize*len(tr.Keys))
+	buf = append(buf, 'Q', 'T', byte(tr.Extrapolate))
+	buf = le.AppendUint32(buf, uint32(len(tr.Keys)))
+	for i, k := range tr.Keys {
+		buf = le.AppendInt32(buf, tr.Times[i])
+		for _, c := range k.C {
+			buf = le.AppendUint16(buf, uint16(c))
+		}
+		buf = append(buf, k.Index)
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It returns an error
+// if the data is not a valid track, for example when the keyframe times are
+// not in increasing order.
+func (tr *QuatTrack) UnmarshalBinary(data []byte) error {
+	if len(data) < trackHeaderSize || data[0] != 'Q' || data[1] != 'T' || data[2] > byte(Loop) {
+		return errTrackFormat
+	}
+	count := uint64(le.Uint32(data[3:]))
+	if uint64(len(data)) != trackHeaderSize+trackKeySize*count {
+		return errTrackFormat
+	}
+	times := make([]int32, count)
+	keys := make([]QuatKey, count)
+	for i := range keys {
+		b := data[trackHeaderSize+trackKeySize*i:]
+		times[i] = le.Int32(b)
+		if i > 0 && times[i] < times[i-1] {
+			return errTrackFormat
+		}
+		for j := range keys[i].C {
+			keys[i].C[j] = int16(le.Uint16(b[4+2*j:]))
+		}
+		keys[i].Index = b[10]
+		if keys[i].Index > 3 {
+			return errTrackFormat
+		}
+	}
+	*tr = QuatTrack{Timeline: Timeline{Times: times, Extrapolate: Extrapolation(data[2])}, Keys: keys}
+	return nil
+}
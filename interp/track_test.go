@@ -0,0 +1,76 @@
+package interp
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuatTrack(t *testing.T) {
+	var tr QuatTrack
+	assert.Equal(t, fixpoint.QuatIdent(), tr.Sample(0))
+
+	// A rotation around Z in steps of 40°, every 100ms, and the same
+	// keyframes uncompressed for reference.
+	axis := fixpoint.Vec3Q24FromFloat(0, 0, 1)
+	ref := QuatSampler{Timeline: Timeline{Extrapolate: Loop}}
+	tr.Extrapolate = Loop
+	for i := 0; i <= 9; i++ {
+		q := fixpoint.QuatFromAxisAngle(axis, fixpoint.Q24FromFloat64(float64(i)*40*math.Pi/180))
+		tr.Append(int32(i*100), q)
+		ref.Times = append(ref.Times, int32(i*100))
+		ref.Values = append(ref.Values, q)
+	}
+	assert.Equal(t, ref.Values[0], tr.Sample(0))
+	for _, now := range []int32{0, 30, 150, 420, 899, 900, 1050, -20} {
+		got, expected := tr.Sample(now), ref.Sample(now)
+		if got.Dot(expected).N < 0 {
+			// The compression may flip the sign, which is the same rotation.
+			got = fixpoint.QuatQ24{W: got.W.Neg(), V: got.V.Neg()}
+		}
+		for i, diff := range [...]int32{got.W.N - expected.W.N, got.V.X.N - expected.V.X.N, got.V.Y.N - expected.V.Y.N, got.V.Z.N - expected.V.Z.N} {
+			if diff > 1<<10 || diff < -1<<10 {
+				t.Errorf("t=%d: element %d differs by %d", now, i, diff)
+			}
+		}
+	}
+
+	// A single keyframe.
+	single := QuatTrack{Timeline: Timeline{Times: []int32{5}}, Keys: tr.Keys[2:3]}
+	assert.Equal(t, tr.Keys[2].Quat(), single.Sample(1000))
+}
+
+func TestQuatTrackBinary(t *testing.T) {
+	tr := QuatTrack{Timeline: Timeline{Extrapolate: Linear}}
+	tr.Append(-10, fixpoint.QuatIdent())
+	tr.Append(250, fixpoint.QuatFromAxisAngle(fixpoint.Vec3Q24FromFloat(1, 0, 0), fixpoint.Q24FromFloat(-2)))
+	tr.Append(250, fixpoint.QuatFromAxisAngle(fixpoint.Vec3Q24FromFloat(0, 0.6, 0.8), fixpoint.Q24FromFloat(1)))
+	data, err := tr.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Len(t, data, 7+3*11)
+
+	var decoded QuatTrack
+	assert.NoError(t, decoded.UnmarshalBinary(data))
+	assert.Equal(t, tr.Times, decoded.Times)
+	assert.Equal(t, tr.Keys, decoded.Keys)
+	assert.Equal(t, Linear, decoded.Extrapolate)
+
+	// Invalid data is rejected.
+	for i, invalid := range [][]byte{
+		nil,
+		data[:len(data)-1],
+		append([]byte("QX"), data[2:]...),
+		append([]byte("QT\x05"), data[3:]...),
+		append(append([]byte{}, data[:len(data)-1]...), 4),
+		append(append(append([]byte{}, data[:7]...), data[7+11:7+22]...), data[7:7+11]...),
+	} {
+		if err := decoded.UnmarshalBinary(invalid); err == nil {
+			t.Errorf("case %d: expected an error", i)
+		}
+	}
+	tr.Times = tr.Times[:2]
+	_, err = tr.MarshalBinary()
+	assert.Error(t, err)
+}
@@ -0,0 +1,36 @@
+package fixpoint
+
+// Convergence controls when an iterative algorithm stops, such as SqrtIter,
+// SinCosIter and Atan2Iter. They normally run a fixed number of iterations
+// that gives the most precise result, but fewer iterations may be enough for
+// some uses and take fewer cycles.
+//
+// The zero value runs the algorithm to full precision.
+type Convergence struct {
+	// MaxIterations is the maximum number of iterations. Zero (or a number
+	// above the default of the algorithm) means the default.
+	MaxIterations int
+
+	// Epsilon stops the algorithm early once the magnitude of the residual is
+	// below it. Zero means it never stops early. EpsilonFor returns a
+	// suitable value when the magnitude of the solution is roughly known.
+	Epsilon Q24
+}
+
+// limit returns the number of iterations to run, given the default of an
+// algorithm.
+func (c Convergence) limit(max int) int {
+	if c.MaxIterations <= 0 || c.MaxIterations > max {
+		return max
+	}
+	return c.MaxIterations
+}
+
+// done returns whether the residual (with the given number of fractional
+// bits) is below Epsilon.
+func (c Convergence) done(residual int64, frac uint) bool {
+	if residual < 0 {
+		residual = -residual
+	}
+	return residual < int64(c.Epsilon.N)<<(frac-24)
+}
@@ -0,0 +1,122 @@
+package fixpoint
+
+// Low-discrepancy sequences. These are deterministic sequences of numbers in
+// the range [0, 1) that cover the range much more evenly than random numbers,
+// so fewer samples are needed for the same accuracy in Monte Carlo
+// integration, and they can be used to spread out sensor readings or dither
+// sample times. All of them can be evaluated at any index directly, without
+// keeping state.
+//
+// Useful link:
+// https://extremelearning.com.au/unreasonable-effectiveness-of-quasirandom-sequences/
+
+// Halton returns element n of the Halton sequence in the given base, which is
+// the radical inverse of n: the digits of n in that base mirrored around the
+// decimal point. The base should be a prime number, and must be in the range
+// [2, 127]. The result is rounded down.
+func Halton(n, base uint32) Q24 {
+	var num, den uint64 = 0, 1
+	for n != 0 {
+		num = num*uint64(base) + uint64(n%base)
+		den *= uint64(base)
+		n /= base
+	}
+	return Q24{int32((num << 24) / den)}
+}
+
+// Halton2 returns element n of the two-dimensional Halton sequence, which uses
+// the bases 2 and 3.
+func Halton2(n uint32) Vec2Q24 {
+	return Vec2Q24{Halton(n, 2), Halton(n, 3)}
+}
+
+// Halton3 returns element n of the three-dimensional Halton sequence, which
+// uses the bases 2, 3 and 5.
+func Halton3(n uint32) Vec3Q24 {
+	return Vec3Q24{Halton(n, 2), Halton(n, 3), Halton(n, 5)}
+}
+
+// Sobol returns element n of the one-dimensional Sobol sequence, which is the
+// same as the Halton sequence in base 2 but a lot faster to calculate.
+func Sobol(n uint32) Q24 {
+	x, _, _ := sobol(n)
+	return Q24{int32(x >> 8)}
+}
+
+// Sobol2 returns element n of the two-dimensional Sobol sequence. Every block
+// of 2^k elements starting at a multiple of 2^k is perfectly stratified: each
+// of the 2^k boxes with sides that are a power of two contains exactly one
+// element.
+func Sobol2(n uint32) Vec2Q24 {
+	x, y, _ := sobol(n)
+	return Vec2Q24{Q24{int32(x >> 8)}, Q24{int32(y >> 8)}}
+}
+
+// Sobol3 returns element n of the three-dimensional Sobol sequence.
+func Sobol3(n uint32) Vec3Q24 {
+	x, y, z := sobol(n)
+	return Vec3Q24{Q24{int32(x >> 8)}, Q24{int32(y >> 8)}, Q24{int32(z >> 8)}}
+}
+
+// sobol returns the first three dimensions of element n of the Sobol sequence
+// as 32-bit fractions. The direction numbers are calculated on the fly, for
+// the primitive polynomials x+1 (with m = 1) and x²+x+1 (with m = 1, 3) from
+// the tables by Joe and Kuo.
+func sobol(n uint32) (x, y, z uint32) {
+	vx := uint32(1 << 31)
+	vy := uint32(1 << 31)
+	vz, vzPrev := uint32(1<<31), uint32(0)
+	for k := 0; n != 0; k++ {
+		if n&1 != 0 {
+			x ^= vx
+			y ^= vy
+			z ^= vz
+		}
+		n >>= 1
+		vx >>= 1
+		vy ^= vy >> 1
+		if k == 0 {
+			vz, vzPrev = 3<<30, vz
+		} else {
+			vz, vzPrev = vz^vzPrev^vzPrev>>2, vz
+		}
+	}
+	return
+}
+
+// The constants of the R sequences: 1/φ^i in 0.32 format, where φ is the
+// generalized golden ratio for the number of dimensions.
+const (
+	r1Alpha = 2654435769 // 1/φ, the golden ratio
+
+	r2Alpha1 = 3242174889 // 1/φ₂, the plastic number
+	r2Alpha2 = 2447445414 // 1/φ₂²
+
+	r3Alpha1 = 3518319155 // 1/φ₃
+	r3Alpha2 = 2882110345 // 1/φ₃²
+	r3Alpha3 = 2360945575 // 1/φ₃³
+)
+
+// R1 returns element n of the one-dimensional R sequence by Martin Roberts,
+// which is the fractional part of 0.5 + n/φ (the golden ratio). It is the
+// fastest low-discrepancy sequence to calculate. The result is rounded down.
+func R1(n uint32) Q24 {
+	return rseq(n, r1Alpha)
+}
+
+// R2 returns element n of the two-dimensional R sequence. Unlike the Halton
+// and Sobol sequences, it has no visible patterns at any number of elements.
+func R2(n uint32) Vec2Q24 {
+	return Vec2Q24{rseq(n, r2Alpha1), rseq(n, r2Alpha2)}
+}
+
+// R3 returns element n of the three-dimensional R sequence.
+func R3(n uint32) Vec3Q24 {
+	return Vec3Q24{rseq(n, r3Alpha1), rseq(n, r3Alpha2), rseq(n, r3Alpha3)}
+}
+
+// rseq returns the fractional part of 0.5 + n*alpha for a constant alpha in
+// 0.32 format, rounded down to a Q24.
+func rseq(n uint32, alpha uint64) Q24 {
+	return Q24{int32((uint32(uint64(n)*alpha>>8) + 1<<23) & (1<<24 - 1))}
+}
@@ -0,0 +1,92 @@
+package fixpoint
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHalton(t *testing.T) {
+	assert.Equal(t, Q24{}, Halton(0, 2))
+	assert.Equal(t, Q24FromFloat(0.5), Halton(1, 2))
+	assert.Equal(t, Q24FromFloat(0.25), Halton(2, 2))
+	assert.Equal(t, Q24FromFloat(0.75), Halton(3, 2))
+	assert.Equal(t, Q24{1 << 24 / 3}, Halton(1, 3))
+	assert.Equal(t, Q24{7 << 24 / 9}, Halton(5, 3)) // 12 → 0.21 in base 3
+	assert.Equal(t, Vec2Q24{Q24FromFloat(0.75), Q24{1 << 24 / 9}}, Halton2(3))
+	assert.Equal(t, Vec3Q24{Q24FromFloat(0.75), Q24{1 << 24 / 9}, Q24{3 << 24 / 5}}, Halton3(3))
+
+	// Large indices don't overflow.
+	for _, base := range []uint32{2, 3, 5, 7, 127} {
+		q := Halton(1<<32-1, base)
+		if q.N < 0 || q.N >= 1<<24 {
+			t.Errorf("Halton(2^32-1, %d) out of range: %v", base, q)
+		}
+	}
+	assert.Equal(t, Q24{1<<24 - 1}, Halton(1<<32-1, 2))
+}
+
+func TestSobol(t *testing.T) {
+	for n := uint32(0); n < 1000; n++ {
+		assert.Equal(t, Halton(n, 2), Sobol(n))
+	}
+	assert.Equal(t, Vec3Q24{Q24FromFloat(0.5), Q24FromFloat(0.5), Q24FromFloat(0.5)}, Sobol3(1))
+	assert.Equal(t, Vec3Q24{Q24FromFloat(0.25), Q24FromFloat(0.75), Q24FromFloat(0.75)}, Sobol3(2))
+	assert.Equal(t, Vec3Q24{Q24FromFloat(0.75), Q24FromFloat(0.25), Q24FromFloat(0.25)}, Sobol3(3))
+
+	// Every block of 16 points has exactly one point in each box of 1×16,
+	// 2×8, 4×4, 8×2 and 16×1.
+	for start := uint32(0); start < 64; start += 16 {
+		for k := uint(0); k <= 4; k++ {
+			seen := map[[2]int32]bool{}
+			for n := start; n < start+16; n++ {
+				p := Sobol2(n)
+				assert.Equal(t, p.X, Sobol(n))
+				box := [2]int32{p.X.N >> (24 - k), p.Y.N >> (20 + k)}
+				if seen[box] {
+					t.Errorf("Sobol2: two points in box %v (k=%d, start=%d)", box, k, start)
+				}
+				seen[box] = true
+			}
+		}
+	}
+
+	// Each dimension on its own is stratified too.
+	seen := map[int32]bool{}
+	for n := uint32(0); n < 32; n++ {
+		assert.Equal(t, Sobol2(n), Vec2Q24{Sobol3(n).X, Sobol3(n).Y})
+		seen[Sobol3(n).Z.N>>19] = true
+	}
+	assert.Len(t, seen, 32)
+}
+
+func TestR(t *testing.T) {
+	phi := (1 + math.Sqrt(5)) / 2
+	for n := uint32(0); n < 100; n++ {
+		_, frac := math.Modf(0.5 + float64(n)/phi)
+		assert.InDelta(t, frac, R1(n).Float64(), 1e-7)
+	}
+
+	// The plastic number is the real root of x³ = x + 1.
+	g := 1.32471795724474602596
+	for n := uint32(0); n < 100; n++ {
+		_, x := math.Modf(0.5 + float64(n)/g)
+		_, y := math.Modf(0.5 + float64(n)/(g*g))
+		p := R2(n)
+		assert.InDelta(t, x, p.X.Float64(), 1e-7)
+		assert.InDelta(t, y, p.Y.Float64(), 1e-7)
+	}
+
+	// The points are evenly spread: the estimate of the volume of a sphere is
+	// better than the typical error of 0.008 with random points.
+	inside := 0
+	const n = 4096
+	for i := uint32(0); i < n; i++ {
+		p := R3(i).Sub(Vec3Q24{Q24FromFloat(0.5), Q24FromFloat(0.5), Q24FromFloat(0.5)})
+		if p.Len2().N < 1<<22 {
+			inside++
+		}
+	}
+	assert.InDelta(t, 4.0/3*math.Pi/8, float64(inside)/n, 0.004)
+}
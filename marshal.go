@@ -0,0 +1,251 @@
+package fixpoint
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// Binary layout
+//
+// All types are encoded as a sequence of the underlying int32 values in
+// little-endian byte order, so the layout does not depend on the host:
+//
+//     Q24:     N (4 bytes)
+//     Vec3Q24: X, Y, Z (12 bytes)
+//     QuatQ24: W, X, Y, Z (16 bytes)
+//
+// JSON layout
+//
+// A Q24 is encoded as a JSON number with the shortest decimal representation
+// that decodes to exactly the same value (see Q24.String). A Vec3Q24 is
+// encoded as an array [X, Y, Z] and a QuatQ24 as an array [W, X, Y, Z]. When
+// decoding, numbers with an exponent (like 1e-3) are also accepted.
+
+var errBinaryLength = errors.New("fixpoint: invalid binary data length")
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (q Q24) MarshalBinary() ([]byte, error) {
+	return appendInt32LE(make([]byte, 0, 4), q.N), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (q *Q24) UnmarshalBinary(data []byte) error {
+	if len(data) != 4 {
+		return errBinaryLength
+	}
+	q.N = int32LE(data)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (q Q24) MarshalJSON() ([]byte, error) {
+	return q.AppendText(nil, -1), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A JSON null leaves the value
+// unmodified.
+func (q *Q24) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	s, err := expandExponent(s)
+	if err != nil {
+		return err
+	}
+	v, err := ParseQ24(s)
+	if err != nil {
+		return err
+	}
+	*q = v
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (v Vec3Q24) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, 12)
+	buf = appendInt32LE(buf, v.X.N)
+	buf = appendInt32LE(buf, v.Y.N)
+	buf = appendInt32LE(buf, v.Z.N)
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (v *Vec3Q24) UnmarshalBinary(data []byte) error {
+	if len(data) != 12 {
+		return errBinaryLength
+	}
+	v.X.N = int32LE(data[0:])
+	v.Y.N = int32LE(data[4:])
+	v.Z.N = int32LE(data[8:])
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (v Vec3Q24) MarshalJSON() ([]byte, error) {
+	return appendJSONArray(nil, v.X, v.Y, v.Z), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A JSON null leaves the value
+// unmodified.
+func (v *Vec3Q24) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var elements []Q24
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+	if len(elements) != 3 {
+		return errors.New("fixpoint: expected 3 elements in Vec3Q24")
+	}
+	*v = Vec3Q24{elements[0], elements[1], elements[2]}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (q QuatQ24) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 0, 16)
+	buf = appendInt32LE(buf, q.W.N)
+	buf = appendInt32LE(buf, q.V.X.N)
+	buf = appendInt32LE(buf, q.V.Y.N)
+	buf = appendInt32LE(buf, q.V.Z.N)
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (q *QuatQ24) UnmarshalBinary(data []byte) error {
+	if len(data) != 16 {
+		return errBinaryLength
+	}
+	q.W.N = int32LE(data[0:])
+	return q.V.UnmarshalBinary(data[4:])
+}
+
+// MarshalJSON implements json.Marshaler.
+func (q QuatQ24) MarshalJSON() ([]byte, error) {
+	return appendJSONArray(nil, q.W, q.V.X, q.V.Y, q.V.Z), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A JSON null leaves the value
+// unmodified.
+func (q *QuatQ24) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var elements []Q24
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+	if len(elements) != 4 {
+		return errors.New("fixpoint: expected 4 elements in QuatQ24")
+	}
+	*q = QuatQ24{elements[0], Vec3Q24{elements[1], elements[2], elements[3]}}
+	return nil
+}
+
+func appendInt32LE(buf []byte, n int32) []byte {
+	return append(buf, byte(n), byte(n>>8), byte(n>>16), byte(n>>24))
+}
+
+func int32LE(data []byte) int32 {
+	return int32(uint32(data[0]) | uint32(data[1])<<8 | uint32(data[2])<<16 | uint32(data[3])<<24)
+}
+
+func appendJSONArray(buf []byte, elements ...Q24) []byte {
+	buf = append(buf, '[')
+	for i, e := range elements {
+		if i != 0 {
+			buf = append(buf, ',')
+		}
+		buf = e.AppendText(buf, -1)
+	}
+	return append(buf, ']')
+}
+
+// expandExponent rewrites a number with an exponent, like "-1.5e-3", to plain
+// decimal notation ("-0.0015") that ParseQ24 understands. Numbers without an
+// exponent are returned unmodified.
+func expandExponent(s string) (string, error) {
+	e := -1
+	for i := 0; i < len(s); i++ {
+		if s[i] == 'e' || s[i] == 'E' {
+			e = i
+			break
+		}
+	}
+	if e < 0 {
+		return s, nil
+	}
+	mantissa, expStr := s[:e], s[e+1:]
+
+	// Parse the exponent. Very large exponents are clamped: the result is then
+	// either zero or out of range anyway.
+	expNeg := false
+	if len(expStr) > 0 && (expStr[0] == '+' || expStr[0] == '-') {
+		expNeg = expStr[0] == '-'
+		expStr = expStr[1:]
+	}
+	if len(expStr) == 0 {
+		return "", ErrSyntax
+	}
+	exp := 0
+	for i := 0; i < len(expStr); i++ {
+		c := expStr[i]
+		if c < '0' || c > '9' {
+			return "", ErrSyntax
+		}
+		if exp < 1000 {
+			exp = exp*10 + int(c-'0')
+		}
+	}
+	if expNeg {
+		exp = -exp
+	}
+
+	// Split the mantissa in sign, digits and the position of the decimal
+	// point.
+	sign := ""
+	if len(mantissa) > 0 && (mantissa[0] == '+' || mantissa[0] == '-') {
+		sign = mantissa[:1]
+		mantissa = mantissa[1:]
+	}
+	point := len(mantissa)
+	digits := make([]byte, 0, len(mantissa))
+	for i := 0; i < len(mantissa); i++ {
+		c := mantissa[i]
+		switch {
+		case c >= '0' && c <= '9':
+			digits = append(digits, c)
+		case c == '.' && point == len(mantissa):
+			point = i
+		default:
+			return "", ErrSyntax
+		}
+	}
+	if len(digits) == 0 {
+		return "", ErrSyntax
+	}
+
+	// Move the decimal point.
+	point += exp
+	buf := []byte(sign)
+	switch {
+	case point <= 0:
+		buf = append(buf, '0', '.')
+		for i := point; i < 0; i++ {
+			buf = append(buf, '0')
+		}
+		buf = append(buf, digits...)
+	case point >= len(digits):
+		buf = append(buf, digits...)
+		for i := len(digits); i < point; i++ {
+			buf = append(buf, '0')
+		}
+	default:
+		buf = append(buf, digits[:point]...)
+		buf = append(buf, '.')
+		buf = append(buf, digits[point:]...)
+	}
+	return string(buf), nil
+}
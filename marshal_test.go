@@ -0,0 +1,90 @@
+package fixpoint
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalBinary(t *testing.T) {
+	q := Q24{0x01020304}
+	data, err := q.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{4, 3, 2, 1}, data)
+	var q2 Q24
+	assert.NoError(t, q2.UnmarshalBinary(data))
+	assert.Equal(t, q, q2)
+	assert.Error(t, q2.UnmarshalBinary(data[:3]))
+
+	v := Vec3Q24{Q24{1}, Q24{-1}, Q24{0x7f000000}}
+	data, err = v.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0, 0, 0, 0x7f}, data)
+	var v2 Vec3Q24
+	assert.NoError(t, v2.UnmarshalBinary(data))
+	assert.Equal(t, v, v2)
+
+	quat := QuatFromAxisAngle(Vec3Q24FromFloat(1, 2, 3), Q24FromFloat(0.5))
+	data, err = quat.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Len(t, data, 16)
+	var quat2 QuatQ24
+	assert.NoError(t, quat2.UnmarshalBinary(data))
+	assert.Equal(t, quat, quat2)
+	assert.Error(t, quat2.UnmarshalBinary(data[:12]))
+}
+
+func TestMarshalJSON(t *testing.T) {
+	type calibration struct {
+		Gain   Q24
+		Offset Vec3Q24
+		Mount  QuatQ24
+	}
+	c := calibration{
+		Gain:   Q24FromFloat(1.5),
+		Offset: Vec3Q24FromFloat(-0.25, 0, 100),
+		Mount:  QuatIdent(),
+	}
+	data, err := json.Marshal(c)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"Gain":1.5,"Offset":[-0.25,0,100],"Mount":[1,0,0,0]}`, string(data))
+	var c2 calibration
+	assert.NoError(t, json.Unmarshal(data, &c2))
+	assert.Equal(t, c, c2)
+
+	// Exact round trip for values that aren't exactly representable in
+	// decimal.
+	third := Q24FromInt32(1).DivRound(Q24FromInt32(3))
+	data, err = json.Marshal(third)
+	assert.NoError(t, err)
+	var third2 Q24
+	assert.NoError(t, json.Unmarshal(data, &third2))
+	assert.Equal(t, third, third2)
+
+	// Numbers as written by other encoders.
+	for _, tc := range []struct {
+		s string
+		q Q24
+	}{
+		{"6e-08", Q24{1}},
+		{"-1.5E1", Q24FromInt32(-15)},
+		{"1.25e+1", Q24FromFloat(12.5)},
+		{"0.5e0", Q24FromFloat(0.5)},
+		{"12.5e-1", Q24FromFloat(1.25)},
+		{"1e-1000", Q24{}},
+	} {
+		var q Q24
+		assert.NoError(t, json.Unmarshal([]byte(tc.s), &q), tc.s)
+		assert.Equal(t, tc.q, q, tc.s)
+	}
+
+	var q Q24
+	assert.Error(t, json.Unmarshal([]byte(`1e1000`), &q))
+	assert.Error(t, json.Unmarshal([]byte(`"1"`), &q))
+	var v Vec3Q24
+	assert.Error(t, json.Unmarshal([]byte(`[1,2]`), &v))
+	var quat QuatQ24
+	assert.Error(t, json.Unmarshal([]byte(`[1,2,3]`), &quat))
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &quat))
+}
@@ -0,0 +1,197 @@
+package fixpoint
+
+// Mat3Q24 is a 3x3 matrix with Q24 fixed point elements. Like in go-gl/mathgl,
+// the elements are stored in column-major order.
+type Mat3Q24 [9]Q24
+
+// Mat4Q24 is a 4x4 matrix with Q24 fixed point elements. Like in go-gl/mathgl,
+// the elements are stored in column-major order.
+type Mat4Q24 [16]Q24
+
+// Vec4Q24 is a 4-dimensional vector with Q24 fixed point elements. It is
+// mostly useful as a homogeneous coordinate together with Mat4Q24.
+type Vec4Q24 struct {
+	X Q24
+	Y Q24
+	Z Q24
+	W Q24
+}
+
+// Vec3 returns the X, Y and Z elements of this vector.
+func (v Vec4Q24) Vec3() Vec3Q24 {
+	return Vec3Q24{v.X, v.Y, v.Z}
+}
+
+// Mat3Ident returns the 3x3 identity matrix.
+func Mat3Ident() Mat3Q24 {
+	one := Q24FromInt32(1)
+	return Mat3Q24{0: one, 4: one, 8: one}
+}
+
+// Mat4Ident returns the 4x4 identity matrix.
+func Mat4Ident() Mat4Q24 {
+	one := Q24FromInt32(1)
+	return Mat4Q24{0: one, 5: one, 10: one, 15: one}
+}
+
+// Mat3Scale returns a matrix that scales each axis by the given factor.
+func Mat3Scale(scale Vec3Q24) Mat3Q24 {
+	return Mat3Q24{0: scale.X, 4: scale.Y, 8: scale.Z}
+}
+
+// Mat3Rotate returns a matrix that rotates by the given angle (in radians)
+// around the given axis. The axis does not need to be normalized.
+func Mat3Rotate(axis Vec3Q24, angle Q24) Mat3Q24 {
+	return QuatFromAxisAngle(axis, angle).Mat3()
+}
+
+// Mat4Translate returns a homogeneous transformation matrix that translates
+// by the given vector.
+func Mat4Translate(v Vec3Q24) Mat4Q24 {
+	m := Mat4Ident()
+	m[12], m[13], m[14] = v.X, v.Y, v.Z
+	return m
+}
+
+// Mat4Scale returns a homogeneous transformation matrix that scales each axis
+// by the given factor.
+func Mat4Scale(scale Vec3Q24) Mat4Q24 {
+	return Mat3Scale(scale).Mat4()
+}
+
+// Mat4Rotate returns a homogeneous transformation matrix that rotates by the
+// given angle (in radians) around the given axis. The axis does not need to be
+// normalized.
+func Mat4Rotate(axis Vec3Q24, angle Q24) Mat4Q24 {
+	return Mat3Rotate(axis, angle).Mat4()
+}
+
+// Mat3 returns the rotation matrix of this unit quaternion.
+func (q QuatQ24) Mat3() Mat3Q24 {
+	// Copied from go-gl/mathgl and modified.
+	w, x, y, z := q.W, q.V.X, q.V.Y, q.V.Z
+	one := Q24FromInt32(1)
+	two := Q24FromInt32(2)
+	xx, yy, zz := two.Mul(x.MulRound(x)), two.Mul(y.MulRound(y)), two.Mul(z.MulRound(z))
+	xy, xz, yz := two.Mul(x.MulRound(y)), two.Mul(x.MulRound(z)), two.Mul(y.MulRound(z))
+	wx, wy, wz := two.Mul(w.MulRound(x)), two.Mul(w.MulRound(y)), two.Mul(w.MulRound(z))
+	return Mat3Q24{
+		one.Sub(yy).Sub(zz), xy.Add(wz), xz.Sub(wy),
+		xy.Sub(wz), one.Sub(xx).Sub(zz), yz.Add(wx),
+		xz.Add(wy), yz.Sub(wx), one.Sub(xx).Sub(yy),
+	}
+}
+
+// Mat4 returns the homogeneous rotation matrix of this unit quaternion.
+func (q QuatQ24) Mat4() Mat4Q24 {
+	return q.Mat3().Mat4()
+}
+
+// Mat4 returns this matrix as the upper left part of a homogeneous 4x4
+// matrix.
+func (m Mat3Q24) Mat4() Mat4Q24 {
+	return Mat4Q24{
+		m[0], m[1], m[2], Q24{},
+		m[3], m[4], m[5], Q24{},
+		m[6], m[7], m[8], Q24{},
+		Q24{}, Q24{}, Q24{}, Q24FromInt32(1),
+	}
+}
+
+// Mat3 returns the upper left 3x3 part of this matrix.
+func (m Mat4Q24) Mat3() Mat3Q24 {
+	return Mat3Q24{
+		m[0], m[1], m[2],
+		m[4], m[5], m[6],
+		m[8], m[9], m[10],
+	}
+}
+
+// At returns the element at the given row and column.
+func (m Mat3Q24) At(row, col int) Q24 {
+	return m[col*3+row]
+}
+
+// At returns the element at the given row and column.
+func (m Mat4Q24) At(row, col int) Q24 {
+	return m[col*4+row]
+}
+
+// Transpose returns the transpose of this matrix.
+func (m Mat3Q24) Transpose() Mat3Q24 {
+	return Mat3Q24{
+		m[0], m[3], m[6],
+		m[1], m[4], m[7],
+		m[2], m[5], m[8],
+	}
+}
+
+// Transpose returns the transpose of this matrix.
+func (m Mat4Q24) Transpose() Mat4Q24 {
+	return Mat4Q24{
+		m[0], m[4], m[8], m[12],
+		m[1], m[5], m[9], m[13],
+		m[2], m[6], m[10], m[14],
+		m[3], m[7], m[11], m[15],
+	}
+}
+
+// Mul returns this matrix multiplied by the argument. Each element is
+// calculated with a 64-bit intermediate and rounded only once.
+func (m1 Mat3Q24) Mul(m2 Mat3Q24) Mat3Q24 {
+	var result Mat3Q24
+	for col := 0; col < 3; col++ {
+		for row := 0; row < 3; row++ {
+			var sum int64
+			for i := 0; i < 3; i++ {
+				sum += int64(m1[i*3+row].N) * int64(m2[col*3+i].N)
+			}
+			result[col*3+row] = Q24{int32((sum + 1<<23) >> 24)}
+		}
+	}
+	return result
+}
+
+// Mul returns this matrix multiplied by the argument. Each element is
+// calculated with a 64-bit intermediate and rounded only once.
+func (m1 Mat4Q24) Mul(m2 Mat4Q24) Mat4Q24 {
+	var result Mat4Q24
+	for col := 0; col < 4; col++ {
+		for row := 0; row < 4; row++ {
+			var sum int64
+			for i := 0; i < 4; i++ {
+				sum += int64(m1[i*4+row].N) * int64(m2[col*4+i].N)
+			}
+			result[col*4+row] = Q24{int32((sum + 1<<23) >> 24)}
+		}
+	}
+	return result
+}
+
+// MulVec returns the vector from the argument multiplied by this matrix.
+func (m Mat3Q24) MulVec(v Vec3Q24) Vec3Q24 {
+	x, y, z := int64(v.X.N), int64(v.Y.N), int64(v.Z.N)
+	return Vec3Q24{
+		Q24{int32((int64(m[0].N)*x + int64(m[3].N)*y + int64(m[6].N)*z + 1<<23) >> 24)},
+		Q24{int32((int64(m[1].N)*x + int64(m[4].N)*y + int64(m[7].N)*z + 1<<23) >> 24)},
+		Q24{int32((int64(m[2].N)*x + int64(m[5].N)*y + int64(m[8].N)*z + 1<<23) >> 24)},
+	}
+}
+
+// MulVec returns the vector from the argument multiplied by this matrix.
+func (m Mat4Q24) MulVec(v Vec4Q24) Vec4Q24 {
+	x, y, z, w := int64(v.X.N), int64(v.Y.N), int64(v.Z.N), int64(v.W.N)
+	return Vec4Q24{
+		Q24{int32((int64(m[0].N)*x + int64(m[4].N)*y + int64(m[8].N)*z + int64(m[12].N)*w + 1<<23) >> 24)},
+		Q24{int32((int64(m[1].N)*x + int64(m[5].N)*y + int64(m[9].N)*z + int64(m[13].N)*w + 1<<23) >> 24)},
+		Q24{int32((int64(m[2].N)*x + int64(m[6].N)*y + int64(m[10].N)*z + int64(m[14].N)*w + 1<<23) >> 24)},
+		Q24{int32((int64(m[3].N)*x + int64(m[7].N)*y + int64(m[11].N)*z + int64(m[15].N)*w + 1<<23) >> 24)},
+	}
+}
+
+// MulPoint transforms the point from the argument by this homogeneous
+// transformation matrix. The point is treated as having a W of 1, and the
+// resulting W is ignored (no perspective division is done).
+func (m Mat4Q24) MulPoint(v Vec3Q24) Vec3Q24 {
+	return m.MulVec(Vec4Q24{v.X, v.Y, v.Z, Q24FromInt32(1)}).Vec3()
+}
@@ -0,0 +1,80 @@
+package fixpoint
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/stretchr/testify/assert"
+)
+
+func assertMat3(t *testing.T, expected mgl32.Mat3, actual Mat3Q24, msgAndArgs ...interface{}) {
+	t.Helper()
+	for i := range expected {
+		assert.InDelta(t, expected[i], actual[i].Float(), 0.00001, msgAndArgs...)
+	}
+}
+
+func assertMat4(t *testing.T, expected mgl32.Mat4, actual Mat4Q24, msgAndArgs ...interface{}) {
+	t.Helper()
+	for i := range expected {
+		assert.InDelta(t, expected[i], actual[i].Float(), 0.00001, msgAndArgs...)
+	}
+}
+
+func TestMat3(t *testing.T) {
+	assertMat3(t, mgl32.Ident3(), Mat3Ident())
+	q := QuatFromAxisAngle(Vec3Q24FromFloat(1, 2, 3), Q24FromFloat(0.7))
+	qf := mgl32.QuatRotate(0.7, mgl32.Vec3{1, 2, 3}.Normalize())
+	m := q.Mat3()
+	mf := qf.Mat4().Mat3()
+	assertMat3(t, mf, m)
+	assertMat3(t, mgl32.Rotate3DX(0.5), Mat3Rotate(Vec3Q24FromFloat(1, 0, 0), Q24FromFloat(0.5)))
+	assertMat3(t, mf.Transpose(), m.Transpose())
+	assert.Equal(t, m.At(0, 1), m[3])
+	assert.Equal(t, m.Transpose().At(1, 0), m.At(0, 1))
+
+	m2 := Mat3Rotate(Vec3Q24FromFloat(0, 1, 0), Q24FromFloat(-1.2))
+	m2f := mgl32.Rotate3DY(-1.2)
+	assertMat3(t, mf.Mul3(m2f), m.Mul(m2))
+
+	// Rotating a vector with the matrix is the same as with the quaternion.
+	v := Vec3Q24FromFloat(1, -2, 3)
+	rotated := m.MulVec(v)
+	rotatedq := q.Rotate(v)
+	assert.InDelta(t, rotatedq.X.Float(), rotated.X.Float(), 0.00001)
+	assert.InDelta(t, rotatedq.Y.Float(), rotated.Y.Float(), 0.00001)
+	assert.InDelta(t, rotatedq.Z.Float(), rotated.Z.Float(), 0.00001)
+
+	s := Mat3Scale(Vec3Q24FromFloat(2, 3, 4))
+	assert.Equal(t, Vec3Q24FromFloat(2, 6, 12), s.MulVec(Vec3Q24FromFloat(1, 2, 3)))
+}
+
+func TestMat4(t *testing.T) {
+	assertMat4(t, mgl32.Ident4(), Mat4Ident())
+	assertMat4(t, mgl32.Translate3D(1, -2, 3), Mat4Translate(Vec3Q24FromFloat(1, -2, 3)))
+	assertMat4(t, mgl32.Scale3D(1, -2, 3), Mat4Scale(Vec3Q24FromFloat(1, -2, 3)))
+	assertMat4(t, mgl32.HomogRotate3D(0.7, mgl32.Vec3{1, 2, 3}.Normalize()), Mat4Rotate(Vec3Q24FromFloat(1, 2, 3), Q24FromFloat(0.7)))
+	q := QuatFromAxisAngle(Vec3Q24FromFloat(1, 2, 3), Q24FromFloat(0.7))
+	assertMat4(t, mgl32.QuatRotate(0.7, mgl32.Vec3{1, 2, 3}.Normalize()).Mat4(), q.Mat4())
+	assert.Equal(t, q.Mat3(), q.Mat4().Mat3())
+
+	// Combined transformation.
+	m := Mat4Translate(Vec3Q24FromFloat(1, -2, 3)).Mul(q.Mat4()).Mul(Mat4Scale(Vec3Q24FromFloat(0.5, 0.5, 0.5)))
+	mf := mgl32.Translate3D(1, -2, 3).Mul4(mgl32.QuatRotate(0.7, mgl32.Vec3{1, 2, 3}.Normalize()).Mat4()).Mul4(mgl32.Scale3D(0.5, 0.5, 0.5))
+	assertMat4(t, mf, m)
+	assertMat4(t, mf.Transpose(), m.Transpose())
+	assert.Equal(t, m[13], m.At(1, 3))
+
+	point := m.MulPoint(Vec3Q24FromFloat(4, 5, 6))
+	pointf := mgl32.TransformCoordinate(mgl32.Vec3{4, 5, 6}, mf)
+	assert.InDelta(t, pointf.X(), point.X.Float(), 0.00001)
+	assert.InDelta(t, pointf.Y(), point.Y.Float(), 0.00001)
+	assert.InDelta(t, pointf.Z(), point.Z.Float(), 0.00001)
+
+	v := m.MulVec(Vec4Q24{Q24FromInt32(4), Q24FromInt32(5), Q24FromInt32(6), Q24{}})
+	vf := mf.Mul4x1(mgl32.Vec4{4, 5, 6, 0})
+	assert.InDelta(t, vf.X(), v.X.Float(), 0.00001)
+	assert.InDelta(t, vf.Y(), v.Y.Float(), 0.00001)
+	assert.InDelta(t, vf.Z(), v.Z.Float(), 0.00001)
+	assert.Equal(t, Q24{}, v.W)
+}
@@ -0,0 +1,194 @@
+//go:build mgl32
+// +build mgl32
+
+package fixpoint
+
+// Conversions between the fixed point types of this package and the float
+// types of go-gl/mathgl, to make it easy to prototype an algorithm on a desktop
+// system and compare the results. They are only available with the mgl32 build
+// tag, so that regular builds don't depend on mathgl.
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Mgl32 returns this vector as a mgl32.Vec2.
+func (v Vec2Q24) Mgl32() mgl32.Vec2 {
+	return mgl32.Vec2{v.X.Float(), v.Y.Float()}
+}
+
+// Vec2Q24FromMgl32 returns the fixed point version of the given vector.
+func Vec2Q24FromMgl32(v mgl32.Vec2) Vec2Q24 {
+	return Vec2Q24FromFloat(v[0], v[1])
+}
+
+// Mgl32 returns this vector as a mgl32.Vec3.
+func (v Vec3Q24) Mgl32() mgl32.Vec3 {
+	return mgl32.Vec3{v.X.Float(), v.Y.Float(), v.Z.Float()}
+}
+
+// Vec3Q24FromMgl32 returns the fixed point version of the given vector.
+func Vec3Q24FromMgl32(v mgl32.Vec3) Vec3Q24 {
+	return Vec3Q24FromFloat(v[0], v[1], v[2])
+}
+
+// Mgl32 returns this quaternion as a mgl32.Quat.
+func (q QuatQ24) Mgl32() mgl32.Quat {
+	return mgl32.Quat{W: q.W.Float(), V: q.V.Mgl32()}
+}
+
+// QuatQ24FromMgl32 returns the fixed point version of the given quaternion.
+func QuatQ24FromMgl32(q mgl32.Quat) QuatQ24 {
+	return QuatQ24{Q24FromFloat(q.W), Vec3Q24FromMgl32(q.V)}
+}
+
+// Mgl32 returns this matrix as a mgl32.Mat3. Elements with a magnitude below
+// 1 are converted exactly, larger elements are rounded to the precision of a
+// float32.
+func (m Mat3Q24) Mgl32() mgl32.Mat3 {
+	var result mgl32.Mat3
+	for i, e := range m {
+		result[i] = e.Float()
+	}
+	return result
+}
+
+// Mat3Q24FromMgl32 returns the fixed point version of the given matrix. Each
+// element is rounded to the nearest value, so it differs at most half a ULP
+// from the float.
+func Mat3Q24FromMgl32(m mgl32.Mat3) Mat3Q24 {
+	var result Mat3Q24
+	for i, e := range m {
+		result[i] = Q24FromFloat64(float64(e))
+	}
+	return result
+}
+
+// MaxULPDiffMgl32 compares this matrix element by element against a float
+// reference, for example one calculated with mathgl while developing a fixed
+// point renderer. It returns the largest difference in units of the last
+// place of a Q24 (2^-24) and the index of that element. A difference of at
+// most 0.5 means that this matrix is the reference rounded to the nearest
+// values. NaN elements in the reference result in an infinite difference.
+func (m Mat3Q24) MaxULPDiffMgl32(ref mgl32.Mat3) (ulps float64, index int) {
+	for i, e := range ref {
+		if diff := ulpDiff(m[i], float64(e)); diff > ulps {
+			ulps, index = diff, i
+		}
+	}
+	return ulps, index
+}
+
+// Mgl32 returns this matrix as a mgl32.Mat4. Elements with a magnitude below
+// 1 are converted exactly, larger elements are rounded to the precision of a
+// float32.
+func (m Mat4Q24) Mgl32() mgl32.Mat4 {
+	var result mgl32.Mat4
+	for i, e := range m {
+		result[i] = e.Float()
+	}
+	return result
+}
+
+// Mat4Q24FromMgl32 returns the fixed point version of the given matrix. Each
+// element is rounded to the nearest value, so it differs at most half a ULP
+// from the float.
+func Mat4Q24FromMgl32(m mgl32.Mat4) Mat4Q24 {
+	var result Mat4Q24
+	for i, e := range m {
+		result[i] = Q24FromFloat64(float64(e))
+	}
+	return result
+}
+
+// MaxULPDiffMgl32 compares this matrix element by element against a float
+// reference, for example one calculated with mathgl while developing a fixed
+// point renderer. It returns the largest difference in units of the last
+// place of a Q24 (2^-24) and the index of that element. A difference of at
+// most 0.5 means that this matrix is the reference rounded to the nearest
+// values. NaN elements in the reference result in an infinite difference.
+func (m Mat4Q24) MaxULPDiffMgl32(ref mgl32.Mat4) (ulps float64, index int) {
+	for i, e := range ref {
+		if diff := ulpDiff(m[i], float64(e)); diff > ulps {
+			ulps, index = diff, i
+		}
+	}
+	return ulps, index
+}
+
+// Mgl64 returns this vector as a mgl64.Vec2.
+func (v Vec2Q24) Mgl64() mgl64.Vec2 {
+	return mgl64.Vec2{v.X.Float64(), v.Y.Float64()}
+}
+
+// Vec2Q24FromMgl64 returns the fixed point version of the given vector.
+func Vec2Q24FromMgl64(v mgl64.Vec2) Vec2Q24 {
+	return Vec2Q24{Q24FromFloat64(v[0]), Q24FromFloat64(v[1])}
+}
+
+// Mgl64 returns this vector as a mgl64.Vec3.
+func (v Vec3Q24) Mgl64() mgl64.Vec3 {
+	return mgl64.Vec3{v.X.Float64(), v.Y.Float64(), v.Z.Float64()}
+}
+
+// Vec3Q24FromMgl64 returns the fixed point version of the given vector.
+func Vec3Q24FromMgl64(v mgl64.Vec3) Vec3Q24 {
+	return Vec3Q24{Q24FromFloat64(v[0]), Q24FromFloat64(v[1]), Q24FromFloat64(v[2])}
+}
+
+// Mgl64 returns this quaternion as a mgl64.Quat.
+func (q QuatQ24) Mgl64() mgl64.Quat {
+	return mgl64.Quat{W: q.W.Float64(), V: q.V.Mgl64()}
+}
+
+// QuatQ24FromMgl64 returns the fixed point version of the given quaternion.
+func QuatQ24FromMgl64(q mgl64.Quat) QuatQ24 {
+	return QuatQ24{Q24FromFloat64(q.W), Vec3Q24FromMgl64(q.V)}
+}
+
+// Mgl64 returns this matrix as a mgl64.Mat3.
+func (m Mat3Q24) Mgl64() mgl64.Mat3 {
+	var result mgl64.Mat3
+	for i, e := range m {
+		result[i] = e.Float64()
+	}
+	return result
+}
+
+// Mat3Q24FromMgl64 returns the fixed point version of the given matrix.
+func Mat3Q24FromMgl64(m mgl64.Mat3) Mat3Q24 {
+	var result Mat3Q24
+	for i, e := range m {
+		result[i] = Q24FromFloat64(e)
+	}
+	return result
+}
+
+// Mgl64 returns this matrix as a mgl64.Mat4.
+func (m Mat4Q24) Mgl64() mgl64.Mat4 {
+	var result mgl64.Mat4
+	for i, e := range m {
+		result[i] = e.Float64()
+	}
+	return result
+}
+
+// Mat4Q24FromMgl64 returns the fixed point version of the given matrix.
+func Mat4Q24FromMgl64(m mgl64.Mat4) Mat4Q24 {
+	var result Mat4Q24
+	for i, e := range m {
+		result[i] = Q24FromFloat64(e)
+	}
+	return result
+}
+
+// ulpDiff returns the absolute difference between q and f in units of 2^-24.
+func ulpDiff(q Q24, f float64) float64 {
+	if math.IsNaN(f) {
+		return math.Inf(1)
+	}
+	return math.Abs(float64(q.N) - f*(1<<24))
+}
@@ -0,0 +1,76 @@
+//go:build mgl32
+// +build mgl32
+
+package fixpoint
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMgl32(t *testing.T) {
+	v2 := mgl32.Vec2{1.5, -0.25}
+	assert.Equal(t, v2, Vec2Q24FromMgl32(v2).Mgl32())
+	v3 := mgl32.Vec3{1.5, -0.25, 3}
+	assert.Equal(t, v3, Vec3Q24FromMgl32(v3).Mgl32())
+	q := mgl32.Quat{W: 0.5, V: mgl32.Vec3{0.5, -0.5, 0.5}}
+	assert.Equal(t, q, QuatQ24FromMgl32(q).Mgl32())
+	assert.Equal(t, QuatIdent(), QuatQ24FromMgl32(mgl32.QuatIdent()))
+	m3 := mgl32.Rotate3DZ(0.5)
+	assert.Equal(t, Mat3Rotate(Vec3Q24FromFloat(0, 0, 1), Q24FromFloat(0.5)).Mgl32().ApproxEqualThreshold(m3, 1e-6), true)
+	assert.Equal(t, Mat3Ident(), Mat3Q24FromMgl32(mgl32.Ident3()))
+	m4 := mgl32.Translate3D(1, 2, -3)
+	assert.Equal(t, m4, Mat4Q24FromMgl32(m4).Mgl32())
+}
+
+func TestMaxULPDiffMgl32(t *testing.T) {
+	// Converting rounds to the nearest value: within half a ULP of the
+	// float, which truncating doesn't guarantee.
+	m4 := mgl32.HomogRotate3D(0.7, mgl32.Vec3{0, 0.6, 0.8}).Mul4(mgl32.Translate3D(0.1, -0.2, 0.3))
+	fixed := Mat4Q24FromMgl32(m4)
+	ulps, _ := fixed.MaxULPDiffMgl32(m4)
+	if ulps > 0.5 {
+		t.Errorf("expected at most half a ULP of difference, got %g", ulps)
+	}
+	assert.Equal(t, Q24FromFloat(-0.1).Add(Q24{-1}), Mat4Q24FromMgl32(mgl32.Mat4{-0.1})[0])
+
+	// The element with the largest difference is reported.
+	fixed[6] = fixed[6].Add(Q24{3})
+	fixed[9] = fixed[9].Sub(Q24{5})
+	ulps, index := fixed.MaxULPDiffMgl32(m4)
+	assert.InDelta(t, 5, ulps, 0.5)
+	assert.Equal(t, 9, index)
+	ulps, index = Mat4Q24{}.MaxULPDiffMgl32(mgl32.Mat4{})
+	assert.Equal(t, 0.0, ulps)
+	assert.Equal(t, 0, index)
+
+	m3 := mgl32.Rotate3DX(-1.1)
+	ulps, _ = Mat3Q24FromMgl32(m3).MaxULPDiffMgl32(m3)
+	if ulps > 0.5 {
+		t.Errorf("expected at most half a ULP of difference, got %g", ulps)
+	}
+	m3[4] = float32(math.NaN())
+	ulps, index = Mat3Ident().MaxULPDiffMgl32(m3)
+	assert.True(t, math.IsInf(ulps, 1))
+	assert.Equal(t, 4, index)
+}
+
+func TestMgl64(t *testing.T) {
+	third := Q24FromInt32(1).DivRound(Q24FromInt32(3))
+	assert.Equal(t, third, Q24FromFloat64(third.Float64()))
+
+	v2 := mgl64.Vec2{1.5, -0.25}
+	assert.Equal(t, v2, Vec2Q24FromMgl64(v2).Mgl64())
+	v3 := mgl64.Vec3{1.5, -0.25, 3}
+	assert.Equal(t, v3, Vec3Q24FromMgl64(v3).Mgl64())
+	q := mgl64.Quat{W: 0.5, V: mgl64.Vec3{0.5, -0.5, 0.5}}
+	assert.Equal(t, q, QuatQ24FromMgl64(q).Mgl64())
+	m3 := mgl64.Scale2D(2, 3)
+	assert.Equal(t, m3, Mat3Q24FromMgl64(m3).Mgl64())
+	m4 := mgl64.Translate3D(1, 2, -3)
+	assert.Equal(t, m4, Mat4Q24FromMgl64(m4).Mgl64())
+}
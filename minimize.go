@@ -0,0 +1,43 @@
+package fixpoint
+
+// invPhiN is 1/φ = 0.618... (the inverse of the golden ratio) in Q24 format.
+const invPhiN = 10368890
+
+// Minimize returns the x between a and b where f is smallest, using a golden
+// section search. The function must be unimodal in the interval: it decreases
+// up to the minimum and increases after it, like the current ripple as a
+// function of a PWM phase. Each iteration evaluates f once and shrinks the
+// interval by a factor 0.618, until it is two ULP wide (up to 48 iterations).
+// It stops as specified by c, where the residual is the width of the
+// remaining interval. Noisy measurements may lead it to the wrong part of the
+// interval, so average them when possible.
+func Minimize(f func(Q24) Q24, a, b Q24, c Convergence) (x Q24, iterations int) {
+	lo, hi := int64(a.N), int64(b.N)
+	if hi < lo {
+		lo, hi = hi, lo
+	}
+	step := func() int64 {
+		return ((hi-lo)*invPhiN + 1<<23) >> 24
+	}
+	x1, x2 := hi-step(), lo+step()
+	f1, f2 := f(Q24{int32(x1)}), f(Q24{int32(x2)})
+	max := c.limit(48)
+	for iterations < max && hi-lo > 2 && !c.done(hi-lo, 24) {
+		iterations++
+		if f1.N <= f2.N {
+			// The minimum is in [lo, x2].
+			hi, x2, f2 = x2, x1, f1
+			x1 = hi - step()
+			f1 = f(Q24{int32(x1)})
+		} else {
+			// The minimum is in [x1, hi].
+			lo, x1, f1 = x1, x2, f2
+			x2 = lo + step()
+			f2 = f(Q24{int32(x2)})
+		}
+	}
+	if f1.N <= f2.N {
+		return Q24{int32(x1)}, iterations
+	}
+	return Q24{int32(x2)}, iterations
+}
@@ -0,0 +1,41 @@
+package fixpoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinimize(t *testing.T) {
+	// A parabola with its minimum at 1.3.
+	target := Q24FromFloat(1.3)
+	f := func(x Q24) Q24 {
+		d := x.Sub(target)
+		return d.Mul(d)
+	}
+	x, iterations := Minimize(f, Q24{}, Q24FromInt32(4), Convergence{})
+	// The parabola is flat near its minimum, so the function value is zero
+	// for points within 2^-12 of it.
+	assert.InDelta(t, target.Float64(), x.Float64(), 1.0/(1<<12))
+	assert.True(t, iterations <= 48, "iterations: %d", iterations)
+	x2, _ := Minimize(f, Q24FromInt32(4), Q24{}, Convergence{})
+	assert.Equal(t, x, x2)
+
+	// A minimum that isn't flat is found to within a few ULP.
+	v := func(x Q24) Q24 {
+		return x.Sub(target).Abs()
+	}
+	x, _ = Minimize(v, Q24FromInt32(-8), Q24FromInt32(8), Convergence{})
+	assert.InDelta(t, target.N, x.N, 2)
+
+	// The cosine has its minimum at π.
+	x, _ = Minimize(Cos, Q24FromInt32(2), Q24FromInt32(4), Convergence{})
+	assert.InDelta(t, Pi.Float64(), x.Float64(), 0.001)
+
+	// Stop early: the interval is 1/64 wide.
+	x, iterations = Minimize(v, Q24{}, Q24FromInt32(4), Convergence{Epsilon: Q24FromFloat(1.0 / 64)})
+	assert.InDelta(t, target.Float64(), x.Float64(), 1.0/64)
+	assert.True(t, iterations < 20, "iterations: %d", iterations)
+	_, iterations = Minimize(v, Q24{}, Q24FromInt32(4), Convergence{MaxIterations: 5})
+	assert.Equal(t, 5, iterations)
+}
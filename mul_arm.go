@@ -0,0 +1,23 @@
+//go:build arm && !tinygo && !purego
+// +build arm,!tinygo,!purego
+
+package fixpoint
+
+// The gc compiler implements a widening 32x32→64 bit signed multiplication on
+// arm with an unsigned MULLU and two correction multiplications, instead of a
+// single MULL. These kernels do several multiplications per call in assembly,
+// which more than makes up for the call overhead (assembly functions can't be
+// inlined). They must return exactly the same results as mul_generic.go.
+//
+// Q24.Mul and Q24.MulRound are deliberately left in Go. They do a single
+// multiplication, which the compiler inlines as eight instructions (MULLU, MUL
+// and MULA plus shifts). A call to an assembly function would replace the two
+// correction multiplications with a branch, a return and the stores and loads
+// of its stack arguments, which is no faster and prevents further
+// optimization of the caller.
+
+func dot3(x1, y1, z1, x2, y2, z2 int32) int32
+
+func cross3(x1, y1, z1, x2, y2, z2 int32) (x, y, z int32)
+
+func quatMul(w1, x1, y1, z1, w2, x2, y2, z2 int32) (w, x, y, z int32)
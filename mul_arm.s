@@ -0,0 +1,102 @@
+//go:build arm && !tinygo && !purego
+// +build arm,!tinygo,!purego
+
+#include "textflag.h"
+
+// MULROUND sets lo to (a*b + 1<<23) >> 24, like Q24.MulRound. It clobbers hi.
+#define MULROUND(a, b, hi, lo) \
+	MULL	a, b, (hi, lo); \
+	ADD.S	$0x800000, lo; \
+	ADC	$0, hi; \
+	MOVW	lo>>24, lo; \
+	ORR	hi<<8, lo
+
+// func dot3(x1, y1, z1, x2, y2, z2 int32) int32
+TEXT ·dot3(SB), NOSPLIT, $0-28
+	MOVW	x1+0(FP), R0
+	MOVW	y1+4(FP), R1
+	MOVW	z1+8(FP), R2
+	MOVW	x2+12(FP), R3
+	MOVW	y2+16(FP), R4
+	MOVW	z2+20(FP), R5
+	MULROUND(R0, R3, R7, R6)
+	MULROUND(R1, R4, R7, R8)
+	ADD	R8, R6
+	MULROUND(R2, R5, R7, R8)
+	ADD	R8, R6
+	MOVW	R6, ret+24(FP)
+	RET
+
+// func cross3(x1, y1, z1, x2, y2, z2 int32) (x, y, z int32)
+TEXT ·cross3(SB), NOSPLIT, $0-36
+	MOVW	x1+0(FP), R0
+	MOVW	y1+4(FP), R1
+	MOVW	z1+8(FP), R2
+	MOVW	x2+12(FP), R3
+	MOVW	y2+16(FP), R4
+	MOVW	z2+20(FP), R5
+	MULROUND(R1, R5, R7, R6)
+	MULROUND(R2, R4, R7, R8)
+	SUB	R8, R6
+	MOVW	R6, x+24(FP)
+	MULROUND(R2, R3, R7, R6)
+	MULROUND(R0, R5, R7, R8)
+	SUB	R8, R6
+	MOVW	R6, y+28(FP)
+	MULROUND(R0, R4, R7, R6)
+	MULROUND(R1, R3, R7, R8)
+	SUB	R8, R6
+	MOVW	R6, z+32(FP)
+	RET
+
+// func quatMul(w1, x1, y1, z1, w2, x2, y2, z2 int32) (w, x, y, z int32)
+TEXT ·quatMul(SB), NOSPLIT, $0-48
+	MOVW	w1+0(FP), R0
+	MOVW	x1+4(FP), R1
+	MOVW	y1+8(FP), R2
+	MOVW	z1+12(FP), R3
+	MOVW	w2+16(FP), R4
+	MOVW	x2+20(FP), R5
+	MOVW	y2+24(FP), R6
+	MOVW	z2+28(FP), R7
+
+	// w = w1*w2 - x1*x2 - y1*y2 - z1*z2
+	MULROUND(R0, R4, R9, R8)
+	MULROUND(R1, R5, R9, R12)
+	SUB	R12, R8
+	MULROUND(R2, R6, R9, R12)
+	SUB	R12, R8
+	MULROUND(R3, R7, R9, R12)
+	SUB	R12, R8
+	MOVW	R8, w+32(FP)
+
+	// x = y1*z2 - z1*y2 + x2*w1 + x1*w2
+	MULROUND(R2, R7, R9, R8)
+	MULROUND(R3, R6, R9, R12)
+	SUB	R12, R8
+	MULROUND(R5, R0, R9, R12)
+	ADD	R12, R8
+	MULROUND(R1, R4, R9, R12)
+	ADD	R12, R8
+	MOVW	R8, x+36(FP)
+
+	// y = z1*x2 - x1*z2 + y2*w1 + y1*w2
+	MULROUND(R3, R5, R9, R8)
+	MULROUND(R1, R7, R9, R12)
+	SUB	R12, R8
+	MULROUND(R6, R0, R9, R12)
+	ADD	R12, R8
+	MULROUND(R2, R4, R9, R12)
+	ADD	R12, R8
+	MOVW	R8, y+40(FP)
+
+	// z = x1*y2 - y1*x2 + z2*w1 + z1*w2
+	MULROUND(R1, R6, R9, R8)
+	MULROUND(R2, R5, R9, R12)
+	SUB	R12, R8
+	MULROUND(R7, R0, R9, R12)
+	ADD	R12, R8
+	MULROUND(R3, R4, R9, R12)
+	ADD	R12, R8
+	MOVW	R8, z+44(FP)
+	RET
@@ -0,0 +1,38 @@
+//go:build !arm || tinygo || purego
+// +build !arm tinygo purego
+
+package fixpoint
+
+// Portable implementations of the multiplication kernels. Compilers based on
+// LLVM, like TinyGo, recognize the widening multiplication and emit a single
+// instruction for it (such as SMULL on Cortex-M3 and up), so these are only
+// replaced by assembly for the gc compiler on arm. Build with the purego tag
+// to use them everywhere.
+
+// dot3 returns the dot product of two vectors, see Vec3Q24.Dot.
+func dot3(x1, y1, z1, x2, y2, z2 int32) int32 {
+	return mulRoundN(x1, x2) + mulRoundN(y1, y2) + mulRoundN(z1, z2)
+}
+
+// cross3 returns the cross product of two vectors, see Vec3Q24.Cross.
+func cross3(x1, y1, z1, x2, y2, z2 int32) (x, y, z int32) {
+	x = mulRoundN(y1, z2) - mulRoundN(z1, y2)
+	y = mulRoundN(z1, x2) - mulRoundN(x1, z2)
+	z = mulRoundN(x1, y2) - mulRoundN(y1, x2)
+	return
+}
+
+// quatMul returns the product of two quaternions, see QuatQ24.Mul.
+func quatMul(w1, x1, y1, z1, w2, x2, y2, z2 int32) (w, x, y, z int32) {
+	w = mulRoundN(w1, w2) - dot3(x1, y1, z1, x2, y2, z2)
+	x, y, z = cross3(x1, y1, z1, x2, y2, z2)
+	x += mulRoundN(x2, w1) + mulRoundN(x1, w2)
+	y += mulRoundN(y2, w1) + mulRoundN(y1, w2)
+	z += mulRoundN(z2, w1) + mulRoundN(z1, w2)
+	return
+}
+
+// mulRoundN returns a*b for two Q24 numbers, rounded like Q24.MulRound.
+func mulRoundN(a, b int32) int32 {
+	return int32((int64(a)*int64(b) + 1<<23) >> 24)
+}
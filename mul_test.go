@@ -0,0 +1,105 @@
+package fixpoint
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// mulRoundRef is the reference implementation of Q24.MulRound, to check the
+// (possibly assembly) multiplication kernels against.
+func mulRoundRef(a, b int32) int32 {
+	return int32((int64(a)*int64(b) + 1<<23) >> 24)
+}
+
+func TestMulKernels(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	n := func() int32 {
+		// Mix small and large numbers, including the extremes.
+		switch r.Intn(4) {
+		case 0:
+			return minN
+		case 1:
+			return maxN
+		default:
+			return int32(r.Uint32()) >> uint(r.Intn(32))
+		}
+	}
+	for i := 0; i < 10000; i++ {
+		w1, x1, y1, z1 := n(), n(), n(), n()
+		w2, x2, y2, z2 := n(), n(), n(), n()
+
+		dot := mulRoundRef(x1, x2) + mulRoundRef(y1, y2) + mulRoundRef(z1, z2)
+		if d := dot3(x1, y1, z1, x2, y2, z2); d != dot {
+			t.Errorf("dot3: expected %d, got %d", dot, d)
+		}
+
+		cx := mulRoundRef(y1, z2) - mulRoundRef(z1, y2)
+		cy := mulRoundRef(z1, x2) - mulRoundRef(x1, z2)
+		cz := mulRoundRef(x1, y2) - mulRoundRef(y1, x2)
+		if x, y, z := cross3(x1, y1, z1, x2, y2, z2); x != cx || y != cy || z != cz {
+			t.Errorf("cross3: expected %d %d %d, got %d %d %d", cx, cy, cz, x, y, z)
+		}
+
+		qw := mulRoundRef(w1, w2) - dot
+		qx := cx + mulRoundRef(x2, w1) + mulRoundRef(x1, w2)
+		qy := cy + mulRoundRef(y2, w1) + mulRoundRef(y1, w2)
+		qz := cz + mulRoundRef(z2, w1) + mulRoundRef(z1, w2)
+		if w, x, y, z := quatMul(w1, x1, y1, z1, w2, x2, y2, z2); w != qw || x != qx || y != qy || z != qz {
+			t.Errorf("quatMul: expected %d %d %d %d, got %d %d %d %d", qw, qx, qy, qz, w, x, y, z)
+		}
+	}
+}
+
+// Sinks for the benchmarks, so that the compiler can't remove the calls.
+var (
+	sinkQ24  Q24
+	sinkVec3 Vec3Q24
+	sinkQuat QuatQ24
+)
+
+// Run the benchmarks with and without the purego tag to compare the assembly
+// implementation against the portable one, for example:
+//
+//	GOARCH=arm go test -c && ./fixpoint.test -test.bench=.
+//	GOARCH=arm go test -c -tags purego && ./fixpoint.test -test.bench=.
+//
+// On a desktop system, the test binary can be run with qemu-arm instead, which
+// also runs TestMulKernels against the assembly. Note that qemu doesn't model
+// the timing of a real core, so the benchmark results are only indicative.
+
+func BenchmarkMul(b *testing.B) {
+	q1, q2 := Q24FromFloat(0.3), Q24FromFloat(-0.7)
+	for i := 0; i < b.N; i++ {
+		sinkQ24 = q1.Mul(q2)
+	}
+}
+
+func BenchmarkDot(b *testing.B) {
+	v1, v2 := Vec3Q24FromFloat(0.1, 0.2, 0.3), Vec3Q24FromFloat(-0.3, 0.5, 0.7)
+	for i := 0; i < b.N; i++ {
+		sinkQ24 = v1.Dot(v2)
+	}
+}
+
+func BenchmarkCross(b *testing.B) {
+	v1, v2 := Vec3Q24FromFloat(0.1, 0.2, 0.3), Vec3Q24FromFloat(-0.3, 0.5, 0.7)
+	for i := 0; i < b.N; i++ {
+		sinkVec3 = v1.Cross(v2)
+	}
+}
+
+func BenchmarkQuatMul(b *testing.B) {
+	q1 := QuatFromAxisAngle(Vec3Q24FromFloat(1, 0, 0), Q24FromFloat(0.5))
+	q2 := QuatFromAxisAngle(Vec3Q24FromFloat(0, 0.6, 0.8), Q24FromFloat(1.2))
+	for i := 0; i < b.N; i++ {
+		sinkQuat = q1.Mul(q2)
+	}
+}
+
+func BenchmarkQuatRotate(b *testing.B) {
+	q := QuatFromAxisAngle(Vec3Q24FromFloat(0, 0.6, 0.8), Q24FromFloat(1.2))
+	v := Vec3Q24FromFloat(0.1, 0.2, 0.3)
+	for i := 0; i < b.N; i++ {
+		sinkVec3 = q.Rotate(v)
+	}
+}
@@ -0,0 +1,51 @@
+package fixpoint
+
+// Conversions to small integer types, for example to produce register values
+// for a DAC or PWM peripheral. Unlike Int32Scaled, they round to the nearest
+// integer (halfway cases away from zero) and saturate to the range of the
+// output type instead of wrapping around.
+
+// Int8Scaled returns this number multiplied by scale, rounded to the nearest
+// integer and saturated to the range of an int8.
+func (q Q24) Int8Scaled(scale int32) int8 {
+	return int8(clamp64(q.scaledRound(scale), -1<<7, 1<<7-1))
+}
+
+// Int16Scaled returns this number multiplied by scale, rounded to the nearest
+// integer and saturated to the range of an int16.
+func (q Q24) Int16Scaled(scale int32) int16 {
+	return int16(clamp64(q.scaledRound(scale), -1<<15, 1<<15-1))
+}
+
+// Uint8Scaled returns this number multiplied by scale, rounded to the nearest
+// integer and saturated to the range of an uint8. Negative results become 0.
+func (q Q24) Uint8Scaled(scale int32) uint8 {
+	return uint8(clamp64(q.scaledRound(scale), 0, 1<<8-1))
+}
+
+// Uint16Scaled returns this number multiplied by scale, rounded to the nearest
+// integer and saturated to the range of an uint16. Negative results become 0.
+func (q Q24) Uint16Scaled(scale int32) uint16 {
+	return uint16(clamp64(q.scaledRound(scale), 0, 1<<16-1))
+}
+
+// scaledRound returns this number multiplied by scale, rounded to the nearest
+// integer with halfway cases rounded away from zero.
+func (q Q24) scaledRound(scale int32) int64 {
+	p := int64(q.N) * int64(scale)
+	if p < 0 {
+		return -((-p + 1<<23) >> 24)
+	}
+	return (p + 1<<23) >> 24
+}
+
+// clamp64 clamps n to the range [min, max].
+func clamp64(n, min, max int64) int64 {
+	if n < min {
+		return min
+	}
+	if n > max {
+		return max
+	}
+	return n
+}
@@ -0,0 +1,30 @@
+package fixpoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNarrow(t *testing.T) {
+	// Duty cycle to a PWM register with a top value of 1000.
+	assert.Equal(t, int16(500), Q24FromFloat(0.5).Int16Scaled(1000))
+	assert.Equal(t, int16(1000), Q24FromInt32(1).Int16Scaled(1000))
+	assert.Equal(t, uint16(333), Q24FromInt32(1).DivRound(Q24FromInt32(3)).Uint16Scaled(1000))
+	assert.Equal(t, uint16(667), Q24FromInt32(2).DivRound(Q24FromInt32(3)).Uint16Scaled(1000))
+
+	// Rounding of halfway cases is symmetric.
+	assert.Equal(t, int8(3), Q24FromFloat(2.5).Int8Scaled(1))
+	assert.Equal(t, int8(-3), Q24FromFloat(-2.5).Int8Scaled(1))
+	assert.Equal(t, int8(-2), Q24FromFloat(-2.4).Int8Scaled(1))
+
+	// Saturation.
+	assert.Equal(t, int8(127), Q24FromInt32(2).Int8Scaled(100))
+	assert.Equal(t, int8(-128), Q24FromInt32(-2).Int8Scaled(100))
+	assert.Equal(t, int16(32767), Q24FromInt32(100).Int16Scaled(1000))
+	assert.Equal(t, int16(-32768), Q24{minN}.Int16Scaled(1<<31-1))
+	assert.Equal(t, uint8(255), Q24FromInt32(1).Uint8Scaled(256))
+	assert.Equal(t, uint8(0), Q24FromFloat(-0.1).Uint8Scaled(255))
+	assert.Equal(t, uint16(65535), Q24{maxN}.Uint16Scaled(1<<31-1))
+	assert.Equal(t, uint16(0), Q24FromInt32(-1).Uint16Scaled(1000))
+}
@@ -0,0 +1,109 @@
+package nav
+
+import (
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/fixmath"
+)
+
+// Flock updates a flock of boids with the three rules of Craig Reynolds: each
+// boid steers away from neighbors that are too close (separation), towards
+// the average velocity of its neighbors (alignment) and towards the center of
+// its neighbors (cohesion). Neighbors are the boids within Radius.
+//
+// The boids are stored as a structure of arrays: one slice with the positions
+// and one with the velocities, so that the inner loop over all neighbors only
+// reads the positions it needs. An update takes O(n²) time for n boids, which
+// also makes it a good benchmark of the vector operations.
+//
+// The zero value doesn't move the boids: at least Radius, MaxSpeed and
+// MaxForce must be set, and one of the weights.
+type Flock struct {
+	// Radius is the distance within which other boids are neighbors.
+	Radius fixpoint.Q24
+
+	// SeparationRadius is the distance within which boids push each other
+	// away, with a force that increases linearly from zero at this distance.
+	// It is usually smaller than Radius.
+	SeparationRadius fixpoint.Q24
+
+	// Separation, Alignment and Cohesion are the weights of the three rules.
+	Separation fixpoint.Q24
+	Alignment  fixpoint.Q24
+	Cohesion   fixpoint.Q24
+
+	// MaxSpeed and MaxForce limit the speed of each boid and how quickly it
+	// can change its velocity, like for a Vehicle.
+	MaxSpeed fixpoint.Q24
+	MaxForce fixpoint.Q24
+
+	forces []fixpoint.Vec2Q24
+}
+
+// Update moves all boids by dt seconds. The slices must have the same length.
+// The forces are calculated for all boids before any boid moves, so the
+// result doesn't depend on the order of the boids.
+func (f *Flock) Update(positions, velocities []fixpoint.Vec2Q24, dt fixpoint.Q24) {
+	if cap(f.forces) < len(positions) {
+		f.forces = make([]fixpoint.Vec2Q24, len(positions))
+	}
+	f.forces = f.forces[:len(positions)]
+	for i := range positions {
+		f.forces[i] = f.Force(positions, velocities, i)
+	}
+	for i, force := range f.forces {
+		v := Vehicle{Position: positions[i], Velocity: velocities[i], MaxSpeed: f.MaxSpeed, MaxForce: f.MaxForce}
+		v.Update(force, dt)
+		positions[i], velocities[i] = v.Position, v.Velocity
+	}
+}
+
+// Force returns the steering force of boid i, limited to MaxForce.
+func (f *Flock) Force(positions, velocities []fixpoint.Vec2Q24, i int) fixpoint.Vec2Q24 {
+	p, v := positions[i], velocities[i]
+	radius := fixmath.Abs(int64(f.Radius.N))
+	radius2 := radius * radius
+	sepRadius := int64(f.SeparationRadius.N)
+	var count int64
+	var center, heading, separation [2]int64
+	for j, q := range positions {
+		if j == i {
+			continue
+		}
+		dx, dy := int64(p.X.N)-int64(q.X.N), int64(p.Y.N)-int64(q.Y.N)
+		if fixmath.Abs(dx) > radius || fixmath.Abs(dy) > radius {
+			// Too far away, and the squared distance could overflow.
+			continue
+		}
+		d2 := dx*dx + dy*dy
+		if d2 > radius2 {
+			continue
+		}
+		count++
+		center[0] += int64(q.X.N)
+		center[1] += int64(q.Y.N)
+		heading[0] += int64(velocities[j].X.N)
+		heading[1] += int64(velocities[j].Y.N)
+		if d := int64(fixmath.SqrtRound(uint64(d2))); d < sepRadius && d > 0 {
+			// Push away along the unit vector from q to p, with a strength
+			// of (sepRadius-d)/sepRadius.
+			strength := ((sepRadius - d) << 24) / sepRadius
+			separation[0] += (dx << 24 / d * strength) >> 24
+			separation[1] += (dy << 24 / d * strength) >> 24
+		}
+	}
+	if count == 0 {
+		return fixpoint.Vec2Q24{}
+	}
+	cohesion := fixpoint.Vec2Q24{
+		X: fixpoint.Q24{N: fixmath.Saturate(center[0]/count - int64(p.X.N))},
+		Y: fixpoint.Q24{N: fixmath.Saturate(center[1]/count - int64(p.Y.N))},
+	}
+	alignment := fixpoint.Vec2Q24{
+		X: fixpoint.Q24{N: fixmath.Saturate(heading[0]/count - int64(v.X.N))},
+		Y: fixpoint.Q24{N: fixmath.Saturate(heading[1]/count - int64(v.Y.N))},
+	}
+	force := fixpoint.Vec2Q24{X: fixpoint.Q24{N: fixmath.Saturate(separation[0])}, Y: fixpoint.Q24{N: fixmath.Saturate(separation[1])}}.Mul(f.Separation).
+		Add(alignment.Mul(f.Alignment)).
+		Add(cohesion.Mul(f.Cohesion))
+	return truncate(force, f.MaxForce)
+}
@@ -0,0 +1,131 @@
+package nav
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlock(t *testing.T) {
+	f := Flock{
+		Radius:           fixpoint.Q24FromInt32(2),
+		SeparationRadius: fixpoint.Q24FromInt32(1),
+		Separation:       fixpoint.Q24FromInt32(1),
+		MaxSpeed:         fixpoint.Q24FromInt32(1),
+		MaxForce:         fixpoint.Q24FromInt32(4),
+	}
+
+	// Two boids at half the separation radius push each other apart with
+	// half the weight.
+	positions := []fixpoint.Vec2Q24{fixpoint.Vec2Q24FromFloat(0, 0), fixpoint.Vec2Q24FromFloat(0.5, 0)}
+	velocities := make([]fixpoint.Vec2Q24, 2)
+	force := f.Force(positions, velocities, 0)
+	assert.InDelta(t, -0.5, force.X.Float(), 1e-6)
+	assert.InDelta(t, 0, force.Y.Float(), 1e-6)
+	assert.Equal(t, force.Neg(), f.Force(positions, velocities, 1))
+
+	// A boid without neighbors isn't steered.
+	positions[1] = fixpoint.Vec2Q24FromFloat(3, 0)
+	assert.Equal(t, fixpoint.Vec2Q24{}, f.Force(positions, velocities, 0))
+
+	// Boids far apart are no neighbors either, even though their squared
+	// distance doesn't fit in an int64.
+	far := f
+	far.Cohesion = fixpoint.Q24FromInt32(1)
+	positions[0], positions[1] = fixpoint.Vec2Q24FromFloat(-100, 0), fixpoint.Vec2Q24FromFloat(100, 0)
+	assert.Equal(t, fixpoint.Vec2Q24{}, far.Force(positions, velocities, 0))
+	positions[1] = fixpoint.Vec2Q24FromFloat(100, 100)
+	assert.Equal(t, fixpoint.Vec2Q24{}, far.Force(positions, velocities, 0))
+
+	// Cohesion steers towards the center of the neighbors, alignment towards
+	// their average velocity.
+	f.Separation = fixpoint.Q24{}
+	f.Cohesion = fixpoint.Q24FromInt32(1)
+	f.Alignment = fixpoint.Q24FromFloat(0.5)
+	positions = []fixpoint.Vec2Q24{fixpoint.Vec2Q24FromFloat(0, 0), fixpoint.Vec2Q24FromFloat(1, 0), fixpoint.Vec2Q24FromFloat(0, 1)}
+	velocities = []fixpoint.Vec2Q24{{}, fixpoint.Vec2Q24FromFloat(0, 1), fixpoint.Vec2Q24FromFloat(0, 1)}
+	force = f.Force(positions, velocities, 0)
+	assert.InDelta(t, 0.5, force.X.Float(), 1e-6)
+	assert.InDelta(t, 1, force.Y.Float(), 1e-6)
+
+	// The force is limited to MaxForce.
+	f.MaxForce = fixpoint.Q24FromFloat(0.5)
+	assert.InDelta(t, 0.5, f.Force(positions, velocities, 0).Len().Float(), 1e-5)
+
+	// A flock with random headings gets aligned, while the boids keep their
+	// distance.
+	f = Flock{
+		Radius:           fixpoint.Q24FromInt32(3),
+		SeparationRadius: fixpoint.Q24FromFloat(0.5),
+		Separation:       fixpoint.Q24FromInt32(4),
+		Alignment:        fixpoint.Q24FromInt32(1),
+		Cohesion:         fixpoint.Q24FromFloat(0.5),
+		MaxSpeed:         fixpoint.Q24FromInt32(1),
+		MaxForce:         fixpoint.Q24FromInt32(2),
+	}
+	positions, velocities = randomFlock(16)
+	before := meanHeading(velocities)
+	dt := fixpoint.Q24FromFloat(0.05)
+	for i := 0; i < 400; i++ {
+		f.Update(positions, velocities, dt)
+	}
+	after := meanHeading(velocities)
+	if allocs := testing.AllocsPerRun(10, func() { f.Update(positions, velocities, dt) }); allocs != 0 {
+		t.Errorf("expected Update to reuse its buffer, got %v allocations", allocs)
+	}
+	if after < 0.9 || after <= before {
+		t.Errorf("expected the flock to align: %.3f before, %.3f after", before, after)
+	}
+	for i := range positions {
+		for j := i + 1; j < len(positions); j++ {
+			if d := positions[i].Sub(positions[j]).Len().Float(); d < 0.1 {
+				t.Errorf("boids %d and %d are too close: %.3f", i, j, d)
+			}
+		}
+	}
+}
+
+// randomFlock returns n boids in a small area with random velocities.
+func randomFlock(n int) (positions, velocities []fixpoint.Vec2Q24) {
+	var r fixpoint.Rand
+	r.Seed(1)
+	one := fixpoint.Q24FromInt32(1)
+	for i := 0; i < n; i++ {
+		positions = append(positions, fixpoint.Vec2Q24{X: r.Q24Range(one.Neg(), one), Y: r.Q24Range(one.Neg(), one)})
+		velocities = append(velocities, fixpoint.Vec2Q24{X: r.Q24Range(one.Neg(), one), Y: r.Q24Range(one.Neg(), one)})
+	}
+	return
+}
+
+// meanHeading returns the length of the average heading of all velocities: 1
+// when all boids move in the same direction, close to 0 when they move in
+// random directions.
+func meanHeading(velocities []fixpoint.Vec2Q24) float64 {
+	var x, y float64
+	for _, v := range velocities {
+		h := v.Normalize()
+		x += h.X.Float64()
+		y += h.Y.Float64()
+	}
+	n := float64(len(velocities))
+	return math.Hypot(x/n, y/n)
+}
+
+func BenchmarkFlock(b *testing.B) {
+	f := Flock{
+		Radius:           fixpoint.Q24FromInt32(3),
+		SeparationRadius: fixpoint.Q24FromFloat(0.5),
+		Separation:       fixpoint.Q24FromInt32(4),
+		Alignment:        fixpoint.Q24FromInt32(1),
+		Cohesion:         fixpoint.Q24FromFloat(0.5),
+		MaxSpeed:         fixpoint.Q24FromInt32(1),
+		MaxForce:         fixpoint.Q24FromInt32(2),
+	}
+	positions, velocities := randomFlock(64)
+	dt := fixpoint.Q24FromFloat(0.05)
+	for i := 0; i < b.N; i++ {
+		f.Update(positions, velocities, dt)
+	}
+}
@@ -0,0 +1,169 @@
+package nav
+
+import (
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/fixmath"
+)
+
+// Cost is a path cost for pathfinding algorithms like A* and Dijkstra. It has
+// 24 fractional bits like a Q24, so edge costs and heuristics convert from a
+// Q24 exactly, but it is backed by an int64 so that the costs of very long
+// paths can be summed. All operations saturate at MaxCost, which also serves
+// as the cost of an unreachable node, so a sum never wraps around to a small
+// (attractive) cost.
+type Cost struct {
+	N int64
+}
+
+// MaxCost is the largest cost, used for unreachable nodes.
+var MaxCost = Cost{1<<63 - 1}
+
+// Edge costs of a grid where moving to a neighboring cell costs 1.
+var (
+	CostStraight = Cost{1 << 24}  // 1, to a horizontal or vertical neighbor
+	CostDiagonal = Cost{23726566} // √2, to a diagonal neighbor
+)
+
+// CostFromQ24 converts a Q24 to a cost. Negative numbers are treated as zero,
+// as negative edge costs are not allowed in A* or Dijkstra.
+func CostFromQ24(q fixpoint.Q24) Cost {
+	if q.N < 0 {
+		return Cost{}
+	}
+	return Cost{int64(q.N)}
+}
+
+// CostFromInt returns the cost n (without fraction), saturated to MaxCost.
+func CostFromInt(n int64) Cost {
+	if n < 0 {
+		return Cost{}
+	}
+	if n >= MaxCost.N>>24 {
+		return MaxCost
+	}
+	return Cost{n << 24}
+}
+
+// Add returns the sum of this cost and the argument, saturated to MaxCost.
+func (c Cost) Add(c2 Cost) Cost {
+	if c.N > MaxCost.N-c2.N {
+		return MaxCost
+	}
+	return Cost{c.N + c2.N}
+}
+
+// AddQ24 returns this cost plus the given edge cost, see CostFromQ24.
+func (c Cost) AddQ24(edge fixpoint.Q24) Cost {
+	return c.Add(CostFromQ24(edge))
+}
+
+// Mul returns this cost multiplied by a non-negative weight, rounded to the
+// nearest value and saturated to MaxCost. It can be used for the weight of
+// the heuristic in weighted A*, or for the cost of terrain types.
+func (c Cost) Mul(weight fixpoint.Q24) Cost {
+	if weight.N <= 0 {
+		return Cost{}
+	}
+	w := int64(weight.N)
+	hi, lo := c.N>>24, c.N&(1<<24-1)
+	if hi > MaxCost.N/w {
+		return MaxCost
+	}
+	return Cost{}.Add(Cost{hi * w}).Add(Cost{(lo*w + 1<<23) >> 24})
+}
+
+// Less returns whether this cost is smaller than the argument.
+func (c Cost) Less(c2 Cost) bool {
+	return c.N < c2.N
+}
+
+// Reachable returns whether this cost is below MaxCost.
+func (c Cost) Reachable() bool {
+	return c.N < MaxCost.N
+}
+
+// Q24 returns this cost as a Q24, saturated to MaxQ24.
+func (c Cost) Q24() fixpoint.Q24 {
+	if c.N > int64(fixpoint.MaxQ24.N) {
+		return fixpoint.MaxQ24
+	}
+	return fixpoint.Q24{N: int32(c.N)}
+}
+
+// Float64 returns the floating point version of this cost.
+func (c Cost) Float64() float64 {
+	return float64(c.N) / (1 << 24)
+}
+
+// Heuristics
+//
+// The heuristics estimate the cost from a node to the goal. They return the
+// exact cost of the shortest path when there are no obstacles, so they never
+// overestimate, as A* requires. Use the one that matches the moves of the
+// grid or graph: Manhattan for 4-connected grids, Octile for 8-connected grids
+// with the edge costs CostStraight and CostDiagonal, and Euclidean for any
+// movement. The cell variants take the difference in cell coordinates.
+
+// Manhattan returns the Manhattan distance |dx| + |dy| between two points.
+func Manhattan(a, b fixpoint.Vec2Q24) Cost {
+	dx, dy := delta(a, b)
+	return Cost{dx + dy}
+}
+
+// Octile returns the octile distance between two points: the length of the
+// shortest path that only moves horizontally, vertically and diagonally.
+func Octile(a, b fixpoint.Vec2Q24) Cost {
+	dx, dy := delta(a, b)
+	return octile(dx, dy)
+}
+
+// Euclidean returns the straight line distance between two points, rounded
+// to the nearest value.
+func Euclidean(a, b fixpoint.Vec2Q24) Cost {
+	dx, dy := delta(a, b)
+	// The squares fit in a uint64 as the differences are below 2^32, but
+	// their sum may not, so divide them by 4 when needed.
+	x2, y2 := uint64(dx)*uint64(dx), uint64(dy)*uint64(dy)
+	if x2+y2 < x2 {
+		return Cost{int64(fixmath.SqrtRound(x2>>2+y2>>2) << 1)}
+	}
+	return Cost{int64(fixmath.SqrtRound(x2 + y2))}
+}
+
+// ManhattanCells is like Manhattan, for a difference in cell coordinates.
+func ManhattanCells(dx, dy int32) Cost {
+	return CostFromInt(fixmath.Abs(int64(dx)) + fixmath.Abs(int64(dy)))
+}
+
+// OctileCells is like Octile, for a difference in cell coordinates.
+func OctileCells(dx, dy int32) Cost {
+	return octile(fixmath.Abs(int64(dx))<<24, fixmath.Abs(int64(dy))<<24)
+}
+
+// EuclideanCells is like Euclidean, for a difference in cell coordinates.
+func EuclideanCells(dx, dy int32) Cost {
+	// The distance in Q24 is √((dx²+dy²)·2^48). Shift the sum of squares
+	// left as far as possible by an even number of bits, and shift the
+	// square root by the rest.
+	sum := uint64(int64(dx)*int64(dx)) + uint64(int64(dy)*int64(dy))
+	shift := uint(48)
+	for shift > 0 && sum >= 1<<(64-shift) {
+		shift -= 2
+	}
+	return Cost{int64(fixmath.SqrtRound(sum<<shift) << ((48 - shift) / 2))}
+}
+
+// delta returns the absolute differences of the coordinates of two points,
+// in Q24 format.
+func delta(a, b fixpoint.Vec2Q24) (dx, dy int64) {
+	return fixmath.Abs(int64(b.X.N) - int64(a.X.N)), fixmath.Abs(int64(b.Y.N) - int64(a.Y.N))
+}
+
+// octile returns max(dx, dy) + (√2-1)·min(dx, dy) for non-negative dx and dy
+// in Q24 format, rounded to the nearest value.
+func octile(dx, dy int64) Cost {
+	if dx < dy {
+		dx, dy = dy, dx
+	}
+	return Cost{dx}.Add(Cost{dy}.Mul(fixpoint.Q24{N: 6949350}))
+}
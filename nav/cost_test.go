@@ -0,0 +1,64 @@
+package nav
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCost(t *testing.T) {
+	assert.Equal(t, CostStraight, CostFromInt(1))
+	assert.InDelta(t, math.Sqrt2, CostDiagonal.Float64(), 1e-7)
+	assert.Equal(t, Cost{}, CostFromQ24(fixpoint.Q24FromInt32(-3)))
+	assert.Equal(t, Cost{}, CostFromInt(-3))
+	assert.Equal(t, MaxCost, CostFromInt(1<<40))
+
+	// A long path doesn't overflow a Q24.
+	var c Cost
+	for i := 0; i < 1000; i++ {
+		c = c.Add(CostDiagonal).AddQ24(fixpoint.Q24FromFloat(0.5))
+	}
+	assert.InDelta(t, 1000*(math.Sqrt2+0.5), c.Float64(), 1e-3)
+	assert.Equal(t, fixpoint.MaxQ24, c.Q24())
+	assert.Equal(t, fixpoint.Q24FromFloat(0.5), Cost{}.AddQ24(fixpoint.Q24FromFloat(0.5)).Q24())
+
+	// Additions saturate at MaxCost.
+	assert.Equal(t, MaxCost, MaxCost.Add(CostStraight))
+	assert.Equal(t, MaxCost, Cost{MaxCost.N - 5}.Add(Cost{10}))
+	assert.False(t, MaxCost.Reachable())
+	assert.True(t, c.Reachable())
+	assert.True(t, c.Less(MaxCost))
+	assert.False(t, MaxCost.Less(c))
+
+	// Weights.
+	assert.Equal(t, CostFromInt(3), CostFromInt(2).Mul(fixpoint.Q24FromFloat(1.5)))
+	assert.Equal(t, Cost{}, CostFromInt(2).Mul(fixpoint.Q24FromInt32(-1)))
+	assert.Equal(t, MaxCost, Cost{MaxCost.N / 2}.Mul(fixpoint.Q24FromInt32(3)))
+	assert.Equal(t, MaxCost, MaxCost.Mul(fixpoint.Q24FromInt32(1)))
+}
+
+func TestHeuristics(t *testing.T) {
+	a := fixpoint.Vec2Q24FromFloat(1, 2)
+	b := fixpoint.Vec2Q24FromFloat(4, -2)
+	assert.Equal(t, CostFromInt(7), Manhattan(a, b))
+	assert.Equal(t, CostFromInt(5), Euclidean(a, b))
+	assert.InDelta(t, 1+3*math.Sqrt2, Octile(a, b).Float64(), 1e-6)
+	assert.Equal(t, Octile(a, b), Octile(b, a))
+
+	// The heuristics are consistent with the edge costs of a grid.
+	assert.Equal(t, CostStraight, OctileCells(0, -1))
+	assert.Equal(t, CostDiagonal, OctileCells(1, 1))
+	assert.Equal(t, CostFromInt(3).Add(CostDiagonal.Mul(fixpoint.Q24FromInt32(2))), OctileCells(-5, 2))
+	assert.Equal(t, CostFromInt(7), ManhattanCells(-5, 2))
+	assert.Equal(t, CostFromInt(5), EuclideanCells(3, -4))
+	assert.Equal(t, CostDiagonal, EuclideanCells(1, 1))
+
+	// Far apart points and cells don't overflow.
+	assert.InDelta(t, 128*math.Sqrt2, Euclidean(fixpoint.Vec2Q24{X: fixpoint.MinQ24, Y: fixpoint.MinQ24}, fixpoint.Vec2Q24{X: fixpoint.MaxQ24, Y: fixpoint.MaxQ24}).Float64()/2, 1e-6)
+	assert.InDelta(t, 256, Manhattan(fixpoint.Vec2Q24{X: fixpoint.MinQ24}, fixpoint.Vec2Q24{X: fixpoint.MaxQ24}).Float64(), 1e-6)
+	big := float64(math.MaxInt32)
+	assert.InDelta(t, big*math.Sqrt2, EuclideanCells(math.MinInt32+1, math.MaxInt32).Float64(), big*1e-9)
+	assert.InDelta(t, big*math.Sqrt2, OctileCells(math.MaxInt32, math.MinInt32+1).Float64(), big*1e-7)
+}
@@ -0,0 +1,67 @@
+package nav
+
+import (
+	"github.com/aykevl/fixpoint"
+)
+
+// PotentialField calculates a steering vector using the potential field
+// method: the robot is attracted to the goal and repelled by nearby obstacles.
+// The sum of these forces is the direction in which the robot should move.
+//
+// Note that potential fields can have local minima (for example, directly
+// behind an obstacle) in which the robot can get stuck.
+type PotentialField struct {
+	// AttractGain is multiplied with the vector towards the goal to get the
+	// attractive force.
+	AttractGain fixpoint.Q24
+
+	// RepulseGain is the repulsive force of an obstacle when the robot is
+	// right on top of it. The force decreases linearly with distance and is
+	// zero at Radius.
+	RepulseGain fixpoint.Q24
+
+	// Radius is the distance at which obstacles start to affect the robot.
+	Radius fixpoint.Q24
+
+	// MaxForce limits the length of the resulting steering vector. If it is
+	// zero, the length is not limited.
+	MaxForce fixpoint.Q24
+}
+
+// Steer returns the steering vector for a robot at the given position moving
+// towards the goal, while avoiding the given obstacles.
+func (f *PotentialField) Steer(position, goal fixpoint.Vec2Q24, obstacles []fixpoint.Vec2Q24) fixpoint.Vec2Q24 {
+	force := goal.Sub(position).Mul(f.AttractGain)
+	for _, obstacle := range obstacles {
+		force = force.Add(f.Repulse(position, obstacle))
+	}
+	if f.MaxForce.N > 0 {
+		if length := force.Len(); length.N > f.MaxForce.N {
+			force = scale(force, length, f.MaxForce)
+		}
+	}
+	return force
+}
+
+// Repulse returns the repulsive force of a single obstacle on a robot at the
+// given position. It points away from the obstacle.
+func (f *PotentialField) Repulse(position, obstacle fixpoint.Vec2Q24) fixpoint.Vec2Q24 {
+	d := position.Sub(obstacle)
+	dist := d.Len()
+	if dist.N >= f.Radius.N || dist.N == 0 {
+		// Out of range, or exactly on top of the obstacle in which case the
+		// direction is unknown.
+		return fixpoint.Vec2Q24{}
+	}
+	strength := f.RepulseGain.Mul(f.Radius.Sub(dist)).Div(f.Radius)
+	return scale(d, dist, strength)
+}
+
+// scale returns the vector v with length length scaled to the new length.
+func scale(v fixpoint.Vec2Q24, length, newLength fixpoint.Q24) fixpoint.Vec2Q24 {
+	// Divide first: the elements of a unit vector cannot overflow.
+	return fixpoint.Vec2Q24{
+		X: v.X.Div(length).Mul(newLength),
+		Y: v.Y.Div(length).Mul(newLength),
+	}
+}
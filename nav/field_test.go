@@ -0,0 +1,39 @@
+package nav
+
+import (
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPotentialField(t *testing.T) {
+	f := PotentialField{
+		AttractGain: fixpoint.Q24FromFloat(0.5),
+		RepulseGain: fixpoint.Q24FromInt32(2),
+		Radius:      fixpoint.Q24FromInt32(1),
+	}
+	position := fixpoint.Vec2Q24FromFloat(0, 0)
+	goal := fixpoint.Vec2Q24FromFloat(4, 0)
+
+	// No obstacles: go straight to the goal.
+	force := f.Steer(position, goal, nil)
+	assert.InDelta(t, 2, force.X.Float(), 0.0001)
+	assert.InDelta(t, 0, force.Y.Float(), 0.0001)
+
+	// Obstacle out of range.
+	force = f.Steer(position, goal, []fixpoint.Vec2Q24{fixpoint.Vec2Q24FromFloat(0, 2)})
+	assert.InDelta(t, 2, force.X.Float(), 0.0001)
+	assert.InDelta(t, 0, force.Y.Float(), 0.0001)
+
+	// Obstacle to the left at half the radius: push to the right.
+	force = f.Steer(position, goal, []fixpoint.Vec2Q24{fixpoint.Vec2Q24FromFloat(0, 0.5)})
+	assert.InDelta(t, 2, force.X.Float(), 0.0001)
+	assert.InDelta(t, -1, force.Y.Float(), 0.0001)
+
+	// Limit the force.
+	f.MaxForce = fixpoint.Q24FromInt32(1)
+	force = f.Steer(position, goal, nil)
+	assert.InDelta(t, 1, force.X.Float(), 0.0001)
+	assert.InDelta(t, 0, force.Y.Float(), 0.0001)
+}
@@ -0,0 +1,121 @@
+package nav
+
+import (
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/fixmath"
+)
+
+// Path is a sequence of waypoints that keeps track of the progress of a robot
+// following it. Progress only moves forward: once the robot has passed a
+// segment of the path, it won't be considered anymore.
+//
+// To combine it with a PurePursuit controller, only pass the remaining part of
+// the path:
+//
+//	target, ok := follower.Target(path.Points[path.Segment():], position)
+type Path struct {
+	// Points are the waypoints of the path.
+	Points []fixpoint.Vec2Q24
+
+	// ArrivalRadius is the distance to the last waypoint at which the robot
+	// is considered to have arrived.
+	ArrivalRadius fixpoint.Q24
+
+	segment    int
+	projection fixpoint.Vec2Q24
+}
+
+// Update projects the robot position on the nearest segment of the path (not
+// before the current segment) and advances the progress accordingly. It
+// returns the projected point.
+func (p *Path) Update(position fixpoint.Vec2Q24) fixpoint.Vec2Q24 {
+	if len(p.Points) == 0 {
+		return position
+	}
+	if len(p.Points) == 1 {
+		p.projection = p.Points[0]
+		return p.projection
+	}
+	bestDist := int64(-1)
+	for i := p.segment; i+1 < len(p.Points); i++ {
+		point := projectSegment(p.Points[i], p.Points[i+1], position)
+		dist := dist2(point, position)
+		if bestDist < 0 || dist < bestDist {
+			bestDist = dist
+			p.segment = i
+			p.projection = point
+		}
+	}
+	return p.projection
+}
+
+// Segment returns the index of the segment the robot was last projected on.
+// The segment goes from Points[Segment()] to Points[Segment()+1].
+func (p *Path) Segment() int {
+	return p.segment
+}
+
+// Remaining returns the distance along the path from the last projected point
+// (see Update) to the end of the path. Note that the result overflows when it
+// is larger than 128. A path with less than two points has no distance left.
+func (p *Path) Remaining() fixpoint.Q24 {
+	if len(p.Points) < 2 {
+		return fixpoint.Q24{}
+	}
+	remaining := p.Points[p.segment+1].Sub(p.projection).Len()
+	for i := p.segment + 1; i+1 < len(p.Points); i++ {
+		remaining = remaining.Add(p.Points[i+1].Sub(p.Points[i]).Len())
+	}
+	return remaining
+}
+
+// Arrived returns whether the robot has arrived at the end of the path: it is
+// on the last segment and within ArrivalRadius of the last waypoint.
+func (p *Path) Arrived(position fixpoint.Vec2Q24) bool {
+	if len(p.Points) == 0 {
+		return true
+	}
+	if p.segment+2 < len(p.Points) {
+		return false
+	}
+	radius := int64(p.ArrivalRadius.N)
+	return dist2(p.Points[len(p.Points)-1], position) <= radius*radius
+}
+
+// Reset resets the progress to the start of the path.
+func (p *Path) Reset() {
+	p.segment = 0
+	p.projection = fixpoint.Vec2Q24{}
+}
+
+// projectSegment returns the point on the segment from a to b that is closest
+// to the given point.
+func projectSegment(a, b, point fixpoint.Vec2Q24) fixpoint.Vec2Q24 {
+	// t = dot(point-a, b-a) / |b-a|². The differences are reduced in
+	// precision when needed so that the products don't overflow.
+	dx, dy := int64(b.X.N)-int64(a.X.N), int64(b.Y.N)-int64(a.Y.N)
+	px, py := int64(point.X.N)-int64(a.X.N), int64(point.Y.N)-int64(a.Y.N)
+	sdx, sdy, spx, spy := dx, dy, px, py
+	for fixmath.Abs(sdx)|fixmath.Abs(sdy)|fixmath.Abs(spx)|fixmath.Abs(spy) >= 1<<30 {
+		sdx, sdy, spx, spy = sdx>>1, sdy>>1, spx>>1, spy>>1
+	}
+	num := spx*sdx + spy*sdy
+	den := sdx*sdx + sdy*sdy
+	if num <= 0 || den == 0 {
+		return a
+	}
+	if num >= den {
+		return b
+	}
+	// Reduce precision of the numerator and denominator equally until the
+	// shift by 24 bits doesn't overflow.
+	for num >= 1<<38 {
+		num >>= 1
+		den >>= 1
+	}
+	t := (num << 24) / den
+	return fixpoint.Vec2Q24{
+		X: fixpoint.Q24{N: a.X.N + int32((dx*t)>>24)},
+		Y: fixpoint.Q24{N: a.Y.N + int32((dy*t)>>24)},
+	}
+}
@@ -0,0 +1,60 @@
+package nav
+
+import (
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPath(t *testing.T) {
+	path := Path{
+		Points: []fixpoint.Vec2Q24{
+			fixpoint.Vec2Q24FromFloat(0, 0),
+			fixpoint.Vec2Q24FromFloat(4, 0),
+			fixpoint.Vec2Q24FromFloat(4, 3),
+		},
+		ArrivalRadius: fixpoint.Q24FromFloat(0.5),
+	}
+
+	projection := path.Update(fixpoint.Vec2Q24FromFloat(1, 0.5))
+	assert.Equal(t, fixpoint.Vec2Q24FromFloat(1, 0), projection)
+	assert.Equal(t, 0, path.Segment())
+	assert.InDelta(t, 6, path.Remaining().Float(), 0.0001)
+	assert.False(t, path.Arrived(fixpoint.Vec2Q24FromFloat(1, 0.5)))
+
+	projection = path.Update(fixpoint.Vec2Q24FromFloat(3.5, 1))
+	assert.InDelta(t, 4, projection.X.Float(), 0.0001)
+	assert.InDelta(t, 1, projection.Y.Float(), 0.0001)
+	assert.Equal(t, 1, path.Segment())
+	assert.InDelta(t, 2, path.Remaining().Float(), 0.0001)
+
+	// Progress doesn't go backwards, even when the robot is closer to an
+	// earlier segment.
+	projection = path.Update(fixpoint.Vec2Q24FromFloat(2, -0.1))
+	assert.Equal(t, 1, path.Segment())
+	assert.Equal(t, fixpoint.Vec2Q24FromFloat(4, 0), projection)
+
+	path.Update(fixpoint.Vec2Q24FromFloat(4.1, 2.8))
+	assert.True(t, path.Arrived(fixpoint.Vec2Q24FromFloat(4.1, 2.8)))
+	assert.False(t, path.Arrived(fixpoint.Vec2Q24FromFloat(4.1, 2)))
+
+	path.Reset()
+	assert.Equal(t, 0, path.Segment())
+
+	// A path of a single point has no segments.
+	single := Path{Points: []fixpoint.Vec2Q24{fixpoint.Vec2Q24FromFloat(2, 1)}}
+	assert.Equal(t, fixpoint.Q24{}, single.Remaining())
+	assert.Equal(t, single.Points[0], single.Update(fixpoint.Vec2Q24FromFloat(1, 1)))
+	assert.Equal(t, fixpoint.Q24{}, single.Remaining())
+	assert.Equal(t, fixpoint.Q24{}, (&Path{}).Remaining())
+}
+
+func TestProjectSegment(t *testing.T) {
+	// Long segments should not overflow.
+	a := fixpoint.Vec2Q24FromFloat(-100, -100)
+	b := fixpoint.Vec2Q24FromFloat(100, 100)
+	p := projectSegment(a, b, fixpoint.Vec2Q24FromFloat(10, -10))
+	assert.InDelta(t, 0, p.X.Float(), 0.0001)
+	assert.InDelta(t, 0, p.Y.Float(), 0.0001)
+}
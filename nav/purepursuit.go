@@ -0,0 +1,109 @@
+// Package nav implements path following and related navigation algorithms for
+// small robots, using fixed point arithmetic.
+package nav
+
+import (
+	"github.com/aykevl/fixpoint"
+)
+
+// PurePursuit is a pure pursuit path follower. It steers the robot along an arc
+// towards a target point on the path that lies a fixed distance (the lookahead
+// distance) in front of the robot.
+//
+// Useful link:
+// https://www.ri.cmu.edu/pub_files/pub3/coulter_r_craig_1992_1/coulter_r_craig_1992_1.pdf
+type PurePursuit struct {
+	// Lookahead is the lookahead distance. A larger distance results in
+	// smoother but less accurate path following.
+	Lookahead fixpoint.Q24
+}
+
+// Curvature returns the curvature (1/radius) of the arc that goes from the
+// robot to the target point. The target is in robot coordinates: X points
+// forward and Y points to the left. A positive curvature means a left turn.
+func (p *PurePursuit) Curvature(target fixpoint.Vec2Q24) fixpoint.Q24 {
+	// The curvature is 2y/L², where L is the distance to the target. L² is
+	// calculated in 64 bits so that it doesn't overflow for targets further
+	// away than about 11 units.
+	dist2 := dist2(target, fixpoint.Vec2Q24{}) >> 24
+	if dist2 == 0 {
+		return fixpoint.Q24{}
+	}
+	return fixpoint.Q24{N: int32((int64(target.Y.N) << 25) / dist2)}
+}
+
+// Target returns the point on the path where the robot should be heading: the
+// point furthest along the path that is exactly the lookahead distance away
+// from the robot position. When the end of the path is within the lookahead
+// distance, the end of the path is returned. The second return value is false
+// if no such point exists, for example when the robot has strayed too far from
+// the path.
+func (p *PurePursuit) Target(path []fixpoint.Vec2Q24, position fixpoint.Vec2Q24) (fixpoint.Vec2Q24, bool) {
+	if len(path) == 0 {
+		return fixpoint.Vec2Q24{}, false
+	}
+	lookahead2 := int64(p.Lookahead.N) * int64(p.Lookahead.N)
+	found := false
+	var target fixpoint.Vec2Q24
+	for i := 0; i+1 < len(path); i++ {
+		start, end := path[i], path[i+1]
+		if dist2(start, position) <= lookahead2 && dist2(end, position) > lookahead2 {
+			// The segment leaves the lookahead circle. Find the crossing
+			// point using bisection, which avoids a square root.
+			lo, hi := fixpoint.Q24{}, fixpoint.Q24FromInt32(1)
+			for j := 0; j < 24; j++ {
+				mid := fixpoint.Q24{N: (lo.N + hi.N) / 2}
+				if dist2(lerp(start, end, mid), position) <= lookahead2 {
+					lo = mid
+				} else {
+					hi = mid
+				}
+			}
+			target = lerp(start, end, lo)
+			found = true
+		}
+	}
+	if end := path[len(path)-1]; dist2(end, position) <= lookahead2 {
+		return end, true
+	}
+	return target, found
+}
+
+// Local converts a point in world coordinates into robot coordinates, given
+// the position and heading (in radians, counterclockwise from the X axis) of
+// the robot.
+func Local(point, position fixpoint.Vec2Q24, heading fixpoint.Q24) fixpoint.Vec2Q24 {
+	d := point.Sub(position)
+	sin, cos := fixpoint.SinCos(heading)
+	return fixpoint.Vec2Q24{
+		X: d.X.Mul(cos).Add(d.Y.Mul(sin)),
+		Y: d.Y.Mul(cos).Sub(d.X.Mul(sin)),
+	}
+}
+
+// SteeringAngle returns the front wheel angle (in radians) for an Ackermann
+// steered vehicle with the given wheelbase to follow an arc with the given
+// curvature.
+func SteeringAngle(curvature, wheelbase fixpoint.Q24) fixpoint.Q24 {
+	return fixpoint.Atan(curvature.Mul(wheelbase))
+}
+
+// WheelSpeeds returns the left and right wheel speeds for a differential drive
+// robot with the given track width to follow an arc with the given curvature
+// at the given speed.
+func WheelSpeeds(speed, curvature, trackWidth fixpoint.Q24) (left, right fixpoint.Q24) {
+	diff := speed.Mul(curvature).Mul(trackWidth.Div(fixpoint.Q24FromInt32(2)))
+	return speed.Sub(diff), speed.Add(diff)
+}
+
+// dist2 returns the squared distance between two points in Q48 format.
+func dist2(a, b fixpoint.Vec2Q24) int64 {
+	dx := int64(a.X.N) - int64(b.X.N)
+	dy := int64(a.Y.N) - int64(b.Y.N)
+	return dx*dx + dy*dy
+}
+
+// lerp returns the point at fraction t between a and b.
+func lerp(a, b fixpoint.Vec2Q24, t fixpoint.Q24) fixpoint.Vec2Q24 {
+	return a.Add(b.Sub(a).Mul(t))
+}
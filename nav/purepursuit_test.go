@@ -0,0 +1,77 @@
+package nav
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurvature(t *testing.T) {
+	p := PurePursuit{Lookahead: fixpoint.Q24FromInt32(1)}
+	for _, tc := range []struct {
+		x, y      float32
+		curvature float32
+	}{
+		{1, 0, 0},
+		{1, 1, 1},
+		{0, 1, 2},
+		{3, -4, -0.32},
+		{-20, 20, 0.05},
+	} {
+		got := p.Curvature(fixpoint.Vec2Q24FromFloat(tc.x, tc.y)).Float()
+		assert.InDelta(t, tc.curvature, got, 0.00001, "curvature of (%f, %f)", tc.x, tc.y)
+	}
+	assert.Equal(t, fixpoint.Q24{}, p.Curvature(fixpoint.Vec2Q24{}))
+}
+
+func TestTarget(t *testing.T) {
+	p := PurePursuit{Lookahead: fixpoint.Q24FromInt32(1)}
+	path := []fixpoint.Vec2Q24{
+		fixpoint.Vec2Q24FromFloat(0, 0),
+		fixpoint.Vec2Q24FromFloat(2, 0),
+		fixpoint.Vec2Q24FromFloat(2, 2),
+	}
+
+	target, ok := p.Target(path, fixpoint.Vec2Q24FromFloat(0.5, 0))
+	assert.True(t, ok)
+	assert.InDelta(t, 1.5, target.X.Float(), 0.0001)
+	assert.InDelta(t, 0, target.Y.Float(), 0.0001)
+
+	// Near the corner, the point on the next segment should be picked.
+	target, ok = p.Target(path, fixpoint.Vec2Q24FromFloat(1.5, 0))
+	assert.True(t, ok)
+	assert.InDelta(t, 2, target.X.Float(), 0.0001)
+	assert.InDelta(t, math.Sqrt(0.75), target.Y.Float(), 0.0001)
+
+	// Near the end, the end point should be returned.
+	target, ok = p.Target(path, fixpoint.Vec2Q24FromFloat(2, 1.5))
+	assert.True(t, ok)
+	assert.Equal(t, path[2], target)
+
+	// Too far away from the path.
+	_, ok = p.Target(path, fixpoint.Vec2Q24FromFloat(5, -5))
+	assert.False(t, ok)
+}
+
+func TestLocal(t *testing.T) {
+	// Robot at (1, 1) facing along the Y axis: a point at (1, 3) is straight
+	// ahead and a point at (0, 1) is to the left.
+	position := fixpoint.Vec2Q24FromFloat(1, 1)
+	heading := fixpoint.HalfPi
+	local := Local(fixpoint.Vec2Q24FromFloat(1, 3), position, heading)
+	assert.InDelta(t, 2, local.X.Float(), 0.0001)
+	assert.InDelta(t, 0, local.Y.Float(), 0.0001)
+	local = Local(fixpoint.Vec2Q24FromFloat(0, 1), position, heading)
+	assert.InDelta(t, 0, local.X.Float(), 0.0001)
+	assert.InDelta(t, 1, local.Y.Float(), 0.0001)
+}
+
+func TestWheelSpeeds(t *testing.T) {
+	left, right := WheelSpeeds(fixpoint.Q24FromInt32(1), fixpoint.Q24FromFloat(0.5), fixpoint.Q24FromFloat(0.2))
+	assert.InDelta(t, 0.95, left.Float(), 0.0001)
+	assert.InDelta(t, 1.05, right.Float(), 0.0001)
+	angle := SteeringAngle(fixpoint.Q24FromFloat(0.5), fixpoint.Q24FromInt32(2))
+	assert.InDelta(t, math.Pi/4, angle.Float(), 0.0001)
+}
@@ -0,0 +1,116 @@
+package nav
+
+import (
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/fixmath"
+)
+
+// Slip is the traction state reported by a SlipDetector.
+type Slip int8
+
+const (
+	// Traction means the wheels and the robot accelerate alike.
+	Traction Slip = iota
+
+	// WheelSpin means the wheels accelerate faster than the robot, for
+	// example when spinning on a slippery floor.
+	WheelSpin
+
+	// WheelSkid means the wheels decelerate faster than the robot, for
+	// example when they lock up while braking or when the robot is pushed.
+	WheelSkid
+)
+
+// String returns the name of the traction state.
+func (s Slip) String() string {
+	switch s {
+	case Traction:
+		return "traction"
+	case WheelSpin:
+		return "spin"
+	case WheelSkid:
+		return "skid"
+	default:
+		return "unknown"
+	}
+}
+
+// SlipDetector detects wheel slip by comparing the acceleration derived from
+// the wheel encoders with the forward acceleration measured by an IMU. While
+// the wheels have traction both are the same, but when a wheel slips the
+// encoders report an acceleration the robot doesn't make. Odometry should not
+// be trusted (or be corrected from other sensors) while the wheels slip.
+//
+// Speeds are in meters per second and accelerations in meters per second
+// squared. The IMU acceleration must have gravity removed and be along the
+// forward axis of the robot.
+//
+// The zero value flags any difference as slip: at least Threshold must be
+// set.
+type SlipDetector struct {
+	// Threshold is the difference between the wheel and the IMU
+	// acceleration above which the wheels are considered to slip.
+	Threshold fixpoint.Q24
+
+	// Alpha is the smoothing factor of the low-pass filter on the difference
+	// in the range (0, 1], to reject encoder quantization and vibration. A
+	// smaller value rejects more noise but detects slip later. Zero disables
+	// the filter, like 1.
+	Alpha fixpoint.Q24
+
+	initialized bool
+	speed       fixpoint.Q24
+	diff        fixpoint.Q24
+}
+
+// Update compares the wheel speed from the encoders and the IMU acceleration,
+// taken dt seconds after the previous update, and returns the traction state.
+// The first update only stores the wheel speed and reports traction.
+func (d *SlipDetector) Update(wheelSpeed, accel, dt fixpoint.Q24) Slip {
+	if !d.initialized || dt.N <= 0 {
+		d.speed = wheelSpeed
+		d.initialized = true
+		return d.Slip()
+	}
+	wheelAccel := fixpoint.Q24{N: fixmath.Saturate(divQ24(int64(wheelSpeed.N)-int64(d.speed.N), int64(dt.N)))}
+	d.speed = wheelSpeed
+	diff := int64(wheelAccel.N) - int64(accel.N)
+	if d.Alpha.N > 0 && d.Alpha.N < 1<<24 {
+		diff = int64(d.diff.N) + (((diff-int64(d.diff.N))*int64(d.Alpha.N) + 1<<23) >> 24)
+	}
+	d.diff = fixpoint.Q24{N: fixmath.Saturate(diff)}
+	return d.Slip()
+}
+
+// Slip returns the traction state of the last update.
+func (d *SlipDetector) Slip() Slip {
+	switch {
+	case d.diff.N > d.Threshold.N:
+		return WheelSpin
+	case -int64(d.diff.N) > int64(d.Threshold.N):
+		return WheelSkid
+	default:
+		return Traction
+	}
+}
+
+// Difference returns the (filtered) wheel acceleration minus the IMU
+// acceleration: positive when the wheels spin and negative when they skid.
+func (d *SlipDetector) Difference() fixpoint.Q24 {
+	return d.diff
+}
+
+// Reset forgets the previous wheel speed and the filtered difference.
+func (d *SlipDetector) Reset() {
+	*d = SlipDetector{Threshold: d.Threshold, Alpha: d.Alpha}
+}
+
+// divQ24 returns n/d for a divisor d in Q24 format, rounded to the nearest
+// value.
+func divQ24(n, d int64) int64 {
+	n <<= 24
+	if n < 0 {
+		return (n - d/2) / d
+	}
+	return (n + d/2) / d
+}
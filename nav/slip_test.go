@@ -0,0 +1,48 @@
+package nav
+
+import (
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlipDetector(t *testing.T) {
+	d := SlipDetector{Threshold: fixpoint.Q24FromFloat(0.5), Alpha: fixpoint.Q24FromFloat(0.5)}
+	dt := fixpoint.Q24FromFloat(0.01)
+
+	// Accelerating at 1m/s² with traction.
+	speed := 0.0
+	for i := 0; i < 50; i++ {
+		speed += 0.01
+		assert.Equal(t, Traction, d.Update(fixpoint.Q24FromFloat64(speed), fixpoint.Q24FromInt32(1), dt))
+	}
+	assert.InDelta(t, 0, d.Difference().Float(), 0.01)
+
+	// The wheels spin up at 5m/s² while the robot keeps accelerating at
+	// 1m/s². The filter delays the detection by a few updates.
+	var slip Slip
+	for i := 0; i < 10; i++ {
+		speed += 0.05
+		slip = d.Update(fixpoint.Q24FromFloat64(speed), fixpoint.Q24FromInt32(1), dt)
+		if i == 0 {
+			assert.Equal(t, WheelSpin, slip)
+		}
+	}
+	assert.Equal(t, WheelSpin, slip)
+	assert.InDelta(t, 4, d.Difference().Float(), 0.01)
+
+	// The wheels brake at 6m/s² while the robot only slows down at 2m/s².
+	for i := 0; i < 10; i++ {
+		speed -= 0.06
+		slip = d.Update(fixpoint.Q24FromFloat64(speed), fixpoint.Q24FromInt32(-2), dt)
+	}
+	assert.Equal(t, WheelSkid, slip)
+	assert.Equal(t, "skid", slip.String())
+
+	// Reset forgets the previous speed, so the jump in speed doesn't count.
+	d.Reset()
+	assert.Equal(t, Traction, d.Update(fixpoint.Q24FromInt32(3), fixpoint.Q24{}, dt))
+	assert.Equal(t, Traction, d.Update(fixpoint.Q24FromInt32(3), fixpoint.Q24{}, dt))
+	assert.Equal(t, fixpoint.Q24FromFloat(0.5), d.Threshold)
+}
@@ -0,0 +1,129 @@
+package nav
+
+import (
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/fixmath"
+)
+
+// Vehicle is a point mass that moves with the classic steering behaviors by
+// Craig Reynolds: each behavior returns a steering force towards a desired
+// velocity, and forces of several behaviors can be added (and weighted)
+// before they're applied with Update. Forces are accelerations, as the mass
+// is 1.
+//
+// The zero value doesn't move: MaxSpeed and MaxForce must be set.
+//
+// See: https://www.red3d.com/cwr/steer/
+type Vehicle struct {
+	Position fixpoint.Vec2Q24
+	Velocity fixpoint.Vec2Q24
+
+	// MaxSpeed is the largest speed of the vehicle.
+	MaxSpeed fixpoint.Q24
+
+	// MaxForce is the largest steering force (acceleration), which limits
+	// how quickly the vehicle can turn and change speed.
+	MaxForce fixpoint.Q24
+}
+
+// Seek returns the steering force towards the target at full speed.
+func (v *Vehicle) Seek(target fixpoint.Vec2Q24) fixpoint.Vec2Q24 {
+	return v.steer(target.Sub(v.Position), v.MaxSpeed)
+}
+
+// Flee returns the steering force away from the target at full speed. The
+// force is zero when the vehicle is exactly at the target.
+func (v *Vehicle) Flee(target fixpoint.Vec2Q24) fixpoint.Vec2Q24 {
+	return v.steer(v.Position.Sub(target), v.MaxSpeed)
+}
+
+// Arrive returns the steering force towards the target like Seek, but the
+// vehicle slows down within the given radius of the target so that it stops
+// there instead of overshooting.
+func (v *Vehicle) Arrive(target fixpoint.Vec2Q24, radius fixpoint.Q24) fixpoint.Vec2Q24 {
+	offset := target.Sub(v.Position)
+	speed := v.MaxSpeed
+	if dist := offset.Len(); dist.N < radius.N {
+		speed = fixpoint.Q24{N: int32(int64(speed.N) * int64(dist.N) / int64(radius.N))}
+	}
+	return v.steer(offset, speed)
+}
+
+// steer returns the steering force that changes the velocity to the given
+// speed in the direction of offset, limited to MaxForce.
+func (v *Vehicle) steer(offset fixpoint.Vec2Q24, speed fixpoint.Q24) fixpoint.Vec2Q24 {
+	var desired fixpoint.Vec2Q24
+	if length := offset.Len(); length.N != 0 {
+		desired = scale(offset, length, speed)
+	}
+	return truncate(desired.Sub(v.Velocity), v.MaxForce)
+}
+
+// Update applies the steering force during dt seconds: it changes the
+// velocity (limited to MaxSpeed) and then moves the vehicle. The force is
+// limited to MaxForce.
+func (v *Vehicle) Update(force fixpoint.Vec2Q24, dt fixpoint.Q24) {
+	force = truncate(force, v.MaxForce)
+	v.Velocity = truncate(v.Velocity.Add(force.Mul(dt)), v.MaxSpeed)
+	v.Position = v.Position.Add(v.Velocity.Mul(dt))
+}
+
+// Heading returns the direction of the velocity as a unit vector, or the zero
+// vector when the vehicle stands still.
+func (v *Vehicle) Heading() fixpoint.Vec2Q24 {
+	return v.Velocity.Normalize()
+}
+
+// Wander steers a vehicle in a random but smooth way, by seeking a target
+// that moves randomly on a circle in front of the vehicle.
+//
+// The zero value doesn't wander: Distance, Radius and Jitter must be set.
+type Wander struct {
+	// Distance is the distance of the center of the circle in front of the
+	// vehicle.
+	Distance fixpoint.Q24
+
+	// Radius is the radius of the circle. A larger radius relative to the
+	// distance results in sharper turns.
+	Radius fixpoint.Q24
+
+	// Jitter is the largest random change of the angle of the target on the
+	// circle per update, in radians.
+	Jitter fixpoint.Q24
+
+	angle fixpoint.Q24
+}
+
+// Steer returns the steering force of the vehicle for this update, using r
+// for the random changes.
+func (w *Wander) Steer(v *Vehicle, r *fixpoint.Rand) fixpoint.Vec2Q24 {
+	if w.Jitter.N > 0 {
+		w.angle = fixmath.WrapAngle(int64(w.angle.N) + int64(r.Q24Range(w.Jitter.Neg(), w.Jitter).N))
+	}
+	// The circle is in front of the vehicle, or ahead along the X axis
+	// when it stands still.
+	heading := v.Heading()
+	if heading == (fixpoint.Vec2Q24{}) {
+		heading = fixpoint.Vec2Q24{X: fixpoint.Q24FromInt32(1)}
+	}
+	onCircle := heading.Rotate(w.angle).Mul(w.Radius)
+	target := v.Position.Add(heading.Mul(w.Distance)).Add(onCircle)
+	return v.Seek(target)
+}
+
+// Angle returns the current angle of the target on the circle, relative to
+// the heading of the vehicle.
+func (w *Wander) Angle() fixpoint.Q24 {
+	return w.angle
+}
+
+// truncate returns v, shortened to the given length if it is longer.
+func truncate(v fixpoint.Vec2Q24, max fixpoint.Q24) fixpoint.Vec2Q24 {
+	if length := v.Len(); length.N > max.N {
+		if max.N <= 0 {
+			return fixpoint.Vec2Q24{}
+		}
+		return scale(v, length, max)
+	}
+	return v
+}
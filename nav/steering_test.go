@@ -0,0 +1,82 @@
+package nav
+
+import (
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVehicle(t *testing.T) {
+	v := Vehicle{MaxSpeed: fixpoint.Q24FromInt32(2), MaxForce: fixpoint.Q24FromInt32(1)}
+	target := fixpoint.Vec2Q24FromFloat(3, 4)
+
+	// Standing still, seeking accelerates towards the target at the
+	// maximum force, and fleeing away from it.
+	force := v.Seek(target)
+	assert.InDelta(t, 0.6, force.X.Float(), 1e-5)
+	assert.InDelta(t, 0.8, force.Y.Float(), 1e-5)
+	assert.Equal(t, force.Neg(), v.Flee(target))
+	assert.Equal(t, fixpoint.Vec2Q24{}, v.Flee(v.Position))
+
+	// Moving at full speed towards the target: no force needed.
+	v.Velocity = fixpoint.Vec2Q24FromFloat(1.2, 1.6)
+	force = v.Seek(target)
+	assert.InDelta(t, 0, force.Len().Float(), 1e-5)
+
+	// Arrive slows down close to the target: at half the radius, to half
+	// the speed.
+	v.Position = fixpoint.Vec2Q24FromFloat(3, 3.5)
+	v.Velocity = fixpoint.Vec2Q24FromFloat(0, 2)
+	force = v.Arrive(target, fixpoint.Q24FromInt32(1))
+	assert.InDelta(t, 0, force.X.Float(), 1e-3)
+	assert.InDelta(t, -1, force.Y.Float(), 1e-3)
+
+	// Update limits the force and the speed.
+	v = Vehicle{MaxSpeed: fixpoint.Q24FromInt32(2), MaxForce: fixpoint.Q24FromInt32(1)}
+	dt := fixpoint.Q24FromFloat(0.1)
+	v.Update(fixpoint.Vec2Q24FromFloat(10, 0), dt)
+	assert.InDelta(t, 0.1, v.Velocity.X.Float(), 1e-5)
+	assert.InDelta(t, 0.01, v.Position.X.Float(), 1e-5)
+	for i := 0; i < 100; i++ {
+		v.Update(fixpoint.Vec2Q24FromFloat(1, 0), dt)
+	}
+	assert.InDelta(t, 2, v.Velocity.X.Float(), 1e-5)
+	assert.InDelta(t, 1, v.Heading().X.Float(), 1e-5)
+}
+
+func TestVehicleArrive(t *testing.T) {
+	v := Vehicle{MaxSpeed: fixpoint.Q24FromInt32(1), MaxForce: fixpoint.Q24FromInt32(2)}
+	target := fixpoint.Vec2Q24FromFloat(-3, 2)
+	dt := fixpoint.Q24FromFloat(0.05)
+	for i := 0; i < 400; i++ {
+		v.Update(v.Arrive(target, fixpoint.Q24FromInt32(1)), dt)
+	}
+	assert.InDelta(t, 0, v.Position.Sub(target).Len().Float(), 0.01)
+	assert.InDelta(t, 0, v.Velocity.Len().Float(), 0.01)
+}
+
+func TestWander(t *testing.T) {
+	var r fixpoint.Rand
+	r.Seed(3)
+	v := Vehicle{MaxSpeed: fixpoint.Q24FromInt32(1), MaxForce: fixpoint.Q24FromFloat(0.5)}
+	w := Wander{Distance: fixpoint.Q24FromInt32(2), Radius: fixpoint.Q24FromInt32(1), Jitter: fixpoint.Q24FromFloat(0.3)}
+	dt := fixpoint.Q24FromFloat(0.1)
+	for i := 0; i < 500; i++ {
+		v.Update(w.Steer(&v, &r), dt)
+		if w.Angle().N < -fixpoint.Pi.N || w.Angle().N >= fixpoint.Pi.N {
+			t.Fatalf("angle out of range: %v", w.Angle())
+		}
+	}
+	// The vehicle keeps moving, as the target is always ahead of it.
+	if speed := v.Velocity.Len().Float(); speed < 0.7 {
+		t.Errorf("expected the vehicle to keep moving, got a speed of %f", speed)
+	}
+
+	// Without jitter, the target is straight ahead.
+	v = Vehicle{MaxSpeed: fixpoint.Q24FromInt32(1), MaxForce: fixpoint.Q24FromFloat(0.5)}
+	w = Wander{Distance: fixpoint.Q24FromInt32(2), Radius: fixpoint.Q24FromInt32(1)}
+	force := w.Steer(&v, &r)
+	assert.InDelta(t, 0.5, force.X.Float(), 1e-5)
+	assert.Equal(t, fixpoint.Q24{}, force.Y)
+}
@@ -0,0 +1,184 @@
+package nav
+
+import (
+	"sort"
+
+	"github.com/aykevl/fixpoint"
+)
+
+// Segment is a line segment from A to B, for example a wall.
+type Segment struct {
+	A, B fixpoint.Vec2Q24
+}
+
+// Visibility calculates the area that is visible from a point between
+// segment obstacles, as a polygon. It uses an angular sweep: it casts a ray
+// towards both ends of every segment (and just past them, to see around
+// corners) and connects the points where the rays hit an obstacle in order of
+// their angle. This can be used to simulate a range sensor or a camera of a
+// robot, or for the field of view of a player in a game.
+//
+// Calculating a polygon takes O(n²) time for n segments, which is fast enough
+// for a room or a small level. All positions must be within 128 units of the
+// viewpoint.
+//
+// The zero value is not usable: Range must be set. The rays are kept between
+// calls to avoid allocations.
+type Visibility struct {
+	// Range is the maximum viewing distance. Rays that don't hit an obstacle
+	// within this distance end at this distance.
+	Range fixpoint.Q24
+
+	// Steps is the number of additional rays cast in evenly spaced
+	// directions, so that the polygon follows the circle of Range where
+	// there are no obstacles. If it is zero, 32 is used.
+	Steps int
+
+	rays visibilityRays
+}
+
+type visibilityRay struct {
+	angle fixpoint.Q24
+	point fixpoint.Vec2Q24
+}
+
+// visibilityRays implements sort.Interface, sorting by angle. The methods are
+// on a pointer so that sorting doesn't allocate.
+type visibilityRays []visibilityRay
+
+func (r *visibilityRays) Len() int           { return len(*r) }
+func (r *visibilityRays) Less(i, j int) bool { return (*r)[i].angle.N < (*r)[j].angle.N }
+func (r *visibilityRays) Swap(i, j int)      { (*r)[i], (*r)[j] = (*r)[j], (*r)[i] }
+
+// visibilityEpsilon is the angle in radians between a ray towards the end of a
+// segment and the rays just past it.
+const visibilityEpsilon = 1 << 10 // about 0.00006 radians
+
+// Polygon appends the vertices of the polygon visible from the origin to dst
+// in counterclockwise order, and returns the result. The first vertex is the
+// one with the smallest angle, starting at -π.
+func (v *Visibility) Polygon(dst []fixpoint.Vec2Q24, origin fixpoint.Vec2Q24, segments []Segment) []fixpoint.Vec2Q24 {
+	v.rays = v.rays[:0]
+	steps := v.Steps
+	if steps <= 0 {
+		steps = 32
+	}
+	for i := 0; i < steps; i++ {
+		angle := int64(fixpoint.TwoPi.N)*int64(i)/int64(steps) - int64(fixpoint.Pi.N)
+		v.cast(origin, fixpoint.Q24{N: int32(angle)}, segments)
+	}
+	for _, s := range segments {
+		for _, end := range [2]fixpoint.Vec2Q24{s.A, s.B} {
+			angle := end.Sub(origin).Angle()
+			v.cast(origin, angle, segments)
+			v.cast(origin, fixpoint.Q24{N: angle.N - visibilityEpsilon}, segments)
+			v.cast(origin, fixpoint.Q24{N: angle.N + visibilityEpsilon}, segments)
+		}
+	}
+	sort.Sort(&v.rays)
+	for i, r := range v.rays {
+		if i > 0 && r.point == v.rays[i-1].point {
+			continue
+		}
+		dst = append(dst, r.point)
+	}
+	return dst
+}
+
+// cast adds the ray from the origin in the given direction, up to the closest
+// obstacle.
+func (v *Visibility) cast(origin fixpoint.Vec2Q24, angle fixpoint.Q24, segments []Segment) {
+	// Keep the angles in the range [-π, π) so that they sort correctly.
+	if angle.N < -fixpoint.Pi.N {
+		angle.N += fixpoint.TwoPi.N
+	} else if angle.N >= fixpoint.Pi.N {
+		angle.N -= fixpoint.TwoPi.N
+	}
+	sin, cos := fixpoint.SinCos(angle)
+	dir := fixpoint.Vec2Q24{X: cos, Y: sin}
+	dist := fixpoint.Q32FromQ24(v.Range)
+	for _, s := range segments {
+		if t, ok := intersectRay(origin, dir, s); ok && t.N < dist.N {
+			dist = t
+		}
+	}
+	d := dist.Q24()
+	v.rays = append(v.rays, visibilityRay{angle, origin.Add(dir.Mul(d))})
+}
+
+// Visible returns whether the target can be seen from the origin: whether the
+// line between them doesn't cross any of the segments. Touching the end of a
+// segment counts as crossing it.
+func Visible(origin, target fixpoint.Vec2Q24, segments []Segment) bool {
+	for _, s := range segments {
+		if segmentsIntersect(origin, target, s.A, s.B) {
+			return false
+		}
+	}
+	return true
+}
+
+// intersectRay returns the distance t along the ray from origin in the
+// direction dir (a unit vector) to the segment s, and whether the ray hits the
+// segment at all.
+func intersectRay(origin, dir fixpoint.Vec2Q24, s Segment) (fixpoint.Q32, bool) {
+	// Solve origin + t·dir = s.A + u·(s.B-s.A) for t >= 0 and 0 <= u <= 1
+	// with cross products, which are calculated exactly as a Q32.
+	e := s.B.Sub(s.A)
+	w := s.A.Sub(origin)
+	den := cross32(dir, e)
+	numT := cross32(w, e)
+	numU := cross32(w, dir)
+	if den.N < 0 {
+		den, numT, numU = den.Neg(), numT.Neg(), numU.Neg()
+	}
+	if den.N == 0 || numT.N < 0 || numU.N < 0 || numU.N > den.N {
+		// Parallel, behind the origin or missing the segment.
+		return fixpoint.Q32{}, false
+	}
+	return numT.Div(den), true
+}
+
+// segmentsIntersect returns whether the segments p1-p2 and p3-p4 intersect.
+func segmentsIntersect(p1, p2, p3, p4 fixpoint.Vec2Q24) bool {
+	d1 := sign(cross32(p4.Sub(p3), p1.Sub(p3)))
+	d2 := sign(cross32(p4.Sub(p3), p2.Sub(p3)))
+	d3 := sign(cross32(p2.Sub(p1), p3.Sub(p1)))
+	d4 := sign(cross32(p2.Sub(p1), p4.Sub(p1)))
+	if d1 == 0 && d2 == 0 {
+		// Collinear.
+		return overlaps(p1, p2, p3, p4)
+	}
+	return d1*d2 <= 0 && d3*d4 <= 0
+}
+
+// sign returns -1, 0 or 1 depending on the sign of n.
+func sign(n fixpoint.Q32) int {
+	switch {
+	case n.N < 0:
+		return -1
+	case n.N > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// overlaps returns whether the collinear segments p1-p2 and p3-p4 overlap.
+func overlaps(p1, p2, p3, p4 fixpoint.Vec2Q24) bool {
+	overlap1 := func(a1, a2, b1, b2 int32) bool {
+		if a1 > a2 {
+			a1, a2 = a2, a1
+		}
+		if b1 > b2 {
+			b1, b2 = b2, b1
+		}
+		return a1 <= b2 && b1 <= a2
+	}
+	return overlap1(p1.X.N, p2.X.N, p3.X.N, p4.X.N) && overlap1(p1.Y.N, p2.Y.N, p3.Y.N, p4.Y.N)
+}
+
+// cross32 returns the cross product a×b as a Q32, which doesn't overflow.
+func cross32(a, b fixpoint.Vec2Q24) fixpoint.Q32 {
+	return fixpoint.Q32{}.MulAcc(a.X, b.Y).Sub(fixpoint.Q32{}.MulAcc(a.Y, b.X))
+}
@@ -0,0 +1,75 @@
+package nav
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+// polygonArea returns the area of a polygon with the shoelace formula.
+func polygonArea(polygon []fixpoint.Vec2Q24) float64 {
+	var area float64
+	for i, p := range polygon {
+		q := polygon[(i+1)%len(polygon)]
+		area += p.X.Float64()*q.Y.Float64() - q.X.Float64()*p.Y.Float64()
+	}
+	return area / 2
+}
+
+func TestVisibility(t *testing.T) {
+	// A closed 4×4 room, seen from its center: the polygon is the room.
+	room := []Segment{
+		{fixpoint.Vec2Q24FromFloat(-2, -2), fixpoint.Vec2Q24FromFloat(2, -2)},
+		{fixpoint.Vec2Q24FromFloat(2, -2), fixpoint.Vec2Q24FromFloat(2, 2)},
+		{fixpoint.Vec2Q24FromFloat(2, 2), fixpoint.Vec2Q24FromFloat(-2, 2)},
+		{fixpoint.Vec2Q24FromFloat(-2, 2), fixpoint.Vec2Q24FromFloat(-2, -2)},
+	}
+	v := Visibility{Range: fixpoint.Q24FromInt32(10)}
+	polygon := v.Polygon(nil, fixpoint.Vec2Q24{}, room)
+	assert.InDelta(t, 16, polygonArea(polygon), 1e-3)
+	for _, p := range polygon {
+		if math.Abs(math.Max(math.Abs(p.X.Float64()), math.Abs(p.Y.Float64()))-2) > 1e-5 {
+			t.Errorf("vertex %v is not on a wall", p)
+		}
+	}
+
+	// Once the buffers have grown, it doesn't allocate.
+	allocs := testing.AllocsPerRun(10, func() {
+		polygon = v.Polygon(polygon[:0], fixpoint.Vec2Q24{}, room)
+	})
+	if allocs != 0 {
+		t.Errorf("expected Polygon to reuse its buffers, got %v allocations", allocs)
+	}
+
+	// Without obstacles, the polygon approximates the circle of the range.
+	v = Visibility{Range: fixpoint.Q24FromInt32(1), Steps: 64}
+	polygon = v.Polygon(polygon[:0], fixpoint.Vec2Q24FromFloat(3, 1), nil)
+	assert.Len(t, polygon, 64)
+	assert.InDelta(t, 32*math.Sin(2*math.Pi/64), polygonArea(polygon), 1e-4)
+
+	// A pillar in the room casts a shadow: the area behind it is not
+	// visible.
+	pillar := append(room,
+		Segment{fixpoint.Vec2Q24FromFloat(1, -0.5), fixpoint.Vec2Q24FromFloat(1, 0.5)})
+	v = Visibility{Range: fixpoint.Q24FromInt32(10)}
+	polygon = v.Polygon(nil, fixpoint.Vec2Q24{}, pillar)
+	// The shadow is the trapezoid between x=1 and x=2, from y=±0.5 to ±1.
+	assert.InDelta(t, 16-1.5, polygonArea(polygon), 1e-3)
+}
+
+func TestVisible(t *testing.T) {
+	wall := []Segment{{fixpoint.Vec2Q24FromFloat(1, -1), fixpoint.Vec2Q24FromFloat(1, 1)}}
+	origin := fixpoint.Vec2Q24{}
+	assert.False(t, Visible(origin, fixpoint.Vec2Q24FromFloat(2, 0), wall))
+	assert.False(t, Visible(origin, fixpoint.Vec2Q24FromFloat(2, 2), wall))
+	assert.True(t, Visible(origin, fixpoint.Vec2Q24FromFloat(2, 2.5), wall))
+	assert.True(t, Visible(origin, fixpoint.Vec2Q24FromFloat(0.5, 0), wall))
+	assert.True(t, Visible(origin, fixpoint.Vec2Q24FromFloat(-3, 0), wall))
+
+	// Touching the wall or running along it.
+	assert.False(t, Visible(origin, fixpoint.Vec2Q24FromFloat(1, 0), wall))
+	assert.False(t, Visible(fixpoint.Vec2Q24FromFloat(1, -2), fixpoint.Vec2Q24FromFloat(1, 3), wall))
+	assert.True(t, Visible(fixpoint.Vec2Q24FromFloat(1, 2), fixpoint.Vec2Q24FromFloat(1, 3), wall))
+}
@@ -0,0 +1,125 @@
+// Package pipeline wires sample sources, processing blocks and sinks together,
+// so that the path from a sensor through filters and sensor fusion to a
+// display or radio can be declared once instead of written as a loop in each
+// firmware. Samples are either Q24 scalars or Vec3Q24 vectors.
+//
+// A Pipe connects a source, a chain of blocks and a sink. It can pull samples
+// from its source (for a sensor that is polled) or have samples pushed into
+// it (for a sensor that delivers samples from an interrupt or a callback).
+// Because a Pipe is itself a Sink, pipes can be connected to each other.
+//
+// The filters in the filters package are blocks. Plain functions can be used
+// as sources, blocks and sinks with SourceFunc, BlockFunc and SinkFunc.
+package pipeline
+
+import (
+	"github.com/aykevl/fixpoint"
+)
+
+// Source produces samples, for example by reading a sensor.
+type Source interface {
+	// Next returns the next sample. It returns false when no sample is
+	// available, for example because the sensor doesn't have new data yet.
+	Next() (fixpoint.Q24, bool)
+}
+
+// Block processes a stream of samples, one sample at a time.
+type Block interface {
+	Update(x fixpoint.Q24) fixpoint.Q24
+}
+
+// Sink consumes samples, for example by showing them on a display.
+type Sink interface {
+	Write(x fixpoint.Q24)
+}
+
+// SourceFunc adapts a function to a Source.
+type SourceFunc func() (fixpoint.Q24, bool)
+
+// Next calls f.
+func (f SourceFunc) Next() (fixpoint.Q24, bool) {
+	return f()
+}
+
+// BlockFunc adapts a function to a Block, for stateless operations such as
+// scaling or clamping.
+type BlockFunc func(x fixpoint.Q24) fixpoint.Q24
+
+// Update calls f.
+func (f BlockFunc) Update(x fixpoint.Q24) fixpoint.Q24 {
+	return f(x)
+}
+
+// SinkFunc adapts a function to a Sink.
+type SinkFunc func(x fixpoint.Q24)
+
+// Write calls f.
+func (f SinkFunc) Write(x fixpoint.Q24) {
+	f(x)
+}
+
+// Chain is a number of blocks in series. It is itself a Block.
+//
+// The zero value passes samples through unmodified.
+type Chain []Block
+
+// Update passes x through each block in order.
+func (c Chain) Update(x fixpoint.Q24) fixpoint.Q24 {
+	for _, b := range c {
+		x = b.Update(x)
+	}
+	return x
+}
+
+// Tee is a sink that writes each sample to all of its sinks, in order.
+type Tee []Sink
+
+// Write writes x to each sink.
+func (t Tee) Write(x fixpoint.Q24) {
+	for _, s := range t {
+		s.Write(x)
+	}
+}
+
+// Pipe connects a source through a chain of blocks to a sink.
+//
+// The zero value drops all samples pushed into it. Source is only needed to
+// pull samples, and Sink may be nil to only run the blocks (for example when
+// the last block has the side effect that matters).
+type Pipe struct {
+	Source Source
+	Blocks Chain
+	Sink   Sink
+}
+
+// Write pushes a sample into the pipe: it is processed by the blocks and
+// written to the sink. This makes a Pipe a Sink of another pipe.
+func (p *Pipe) Write(x fixpoint.Q24) {
+	x = p.Blocks.Update(x)
+	if p.Sink != nil {
+		p.Sink.Write(x)
+	}
+}
+
+// Pull reads a sample from the source and pushes it through the pipe. It
+// returns false when the source has no sample available.
+func (p *Pipe) Pull() bool {
+	x, ok := p.Source.Next()
+	if !ok {
+		return false
+	}
+	p.Write(x)
+	return true
+}
+
+// Run pulls up to n samples through the pipe, and returns the number of
+// samples it processed. It stops early when the source has no sample
+// available.
+func (p *Pipe) Run(n int) int {
+	for i := 0; i < n; i++ {
+		if !p.Pull() {
+			return i
+		}
+	}
+	return n
+}
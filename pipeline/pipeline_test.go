@@ -0,0 +1,71 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/filters"
+	"github.com/stretchr/testify/assert"
+)
+
+// sliceSource returns a source that produces the given samples, and then
+// reports that no samples are available.
+func sliceSource(samples ...fixpoint.Q24) SourceFunc {
+	return func() (fixpoint.Q24, bool) {
+		if len(samples) == 0 {
+			return fixpoint.Q24{}, false
+		}
+		x := samples[0]
+		samples = samples[1:]
+		return x, true
+	}
+}
+
+func TestPipe(t *testing.T) {
+	one, two := fixpoint.Q24FromInt32(1), fixpoint.Q24FromInt32(2)
+	var out []fixpoint.Q24
+	p := Pipe{
+		Source: sliceSource(one, two, one, two, one),
+		Blocks: Chain{
+			&filters.LowPass{Alpha: fixpoint.Q24FromFloat(0.5)},
+			BlockFunc(func(x fixpoint.Q24) fixpoint.Q24 { return x.Add(x) }),
+		},
+		Sink: SinkFunc(func(x fixpoint.Q24) { out = append(out, x) }),
+	}
+
+	// Pull samples until the source runs dry.
+	assert.True(t, p.Pull())
+	assert.Equal(t, 4, p.Run(10))
+	assert.False(t, p.Pull())
+	assert.Equal(t, []fixpoint.Q24{
+		fixpoint.Q24FromFloat(2), fixpoint.Q24FromFloat(3), fixpoint.Q24FromFloat(2.5),
+		fixpoint.Q24FromFloat(3.25), fixpoint.Q24FromFloat(2.625),
+	}, out)
+
+	// Pipes can push into each other, and a tee writes to several sinks.
+	var first, second []fixpoint.Q24
+	last := &Pipe{
+		Blocks: Chain{BlockFunc(fixpoint.Q24.Neg)},
+		Sink:   SinkFunc(func(x fixpoint.Q24) { second = append(second, x) }),
+	}
+	p = Pipe{
+		Source: sliceSource(one, two),
+		Sink:   Tee{SinkFunc(func(x fixpoint.Q24) { first = append(first, x) }), last},
+	}
+	assert.Equal(t, 2, p.Run(3))
+	assert.Equal(t, []fixpoint.Q24{one, two}, first)
+	assert.Equal(t, []fixpoint.Q24{one.Neg(), two.Neg()}, second)
+
+	// Without a sink, only the blocks run.
+	var sum fixpoint.Q24
+	p = Pipe{Blocks: Chain{BlockFunc(func(x fixpoint.Q24) fixpoint.Q24 {
+		sum = sum.Add(x)
+		return x
+	})}}
+	p.Write(one)
+	p.Write(two)
+	assert.Equal(t, fixpoint.Q24FromInt32(3), sum)
+
+	// An empty chain passes samples through.
+	assert.Equal(t, two, Chain(nil).Update(two))
+}
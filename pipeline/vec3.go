@@ -0,0 +1,142 @@
+package pipeline
+
+import (
+	"github.com/aykevl/fixpoint"
+)
+
+// Vec3Source produces vector samples, for example by reading a 3-axis
+// accelerometer.
+type Vec3Source interface {
+	// Next returns the next sample. It returns false when no sample is
+	// available.
+	Next() (fixpoint.Vec3Q24, bool)
+}
+
+// Vec3Block processes a stream of vector samples, one sample at a time.
+type Vec3Block interface {
+	Update(v fixpoint.Vec3Q24) fixpoint.Vec3Q24
+}
+
+// Vec3Sink consumes vector samples.
+type Vec3Sink interface {
+	Write(v fixpoint.Vec3Q24)
+}
+
+// Vec3SourceFunc adapts a function to a Vec3Source.
+type Vec3SourceFunc func() (fixpoint.Vec3Q24, bool)
+
+// Next calls f.
+func (f Vec3SourceFunc) Next() (fixpoint.Vec3Q24, bool) {
+	return f()
+}
+
+// Vec3BlockFunc adapts a function to a Vec3Block, for example to rotate each
+// sample by a fixed quaternion or to apply a calibration.
+type Vec3BlockFunc func(v fixpoint.Vec3Q24) fixpoint.Vec3Q24
+
+// Update calls f.
+func (f Vec3BlockFunc) Update(v fixpoint.Vec3Q24) fixpoint.Vec3Q24 {
+	return f(v)
+}
+
+// Vec3SinkFunc adapts a function to a Vec3Sink.
+type Vec3SinkFunc func(v fixpoint.Vec3Q24)
+
+// Write calls f.
+func (f Vec3SinkFunc) Write(v fixpoint.Vec3Q24) {
+	f(v)
+}
+
+// PerAxis is a Vec3Block that runs a separate scalar block for each axis, for
+// example a low-pass filter from the filters package. A nil block passes its
+// axis through unmodified.
+type PerAxis [3]Block
+
+// Update passes each element of v through the block of its axis.
+func (p *PerAxis) Update(v fixpoint.Vec3Q24) fixpoint.Vec3Q24 {
+	if p[0] != nil {
+		v.X = p[0].Update(v.X)
+	}
+	if p[1] != nil {
+		v.Y = p[1].Update(v.Y)
+	}
+	if p[2] != nil {
+		v.Z = p[2].Update(v.Z)
+	}
+	return v
+}
+
+// Vec3Chain is a number of vector blocks in series. It is itself a Vec3Block.
+//
+// The zero value passes samples through unmodified.
+type Vec3Chain []Vec3Block
+
+// Update passes v through each block in order.
+func (c Vec3Chain) Update(v fixpoint.Vec3Q24) fixpoint.Vec3Q24 {
+	for _, b := range c {
+		v = b.Update(v)
+	}
+	return v
+}
+
+// Vec3Tee is a sink that writes each sample to all of its sinks, in order.
+type Vec3Tee []Vec3Sink
+
+// Write writes v to each sink.
+func (t Vec3Tee) Write(v fixpoint.Vec3Q24) {
+	for _, s := range t {
+		s.Write(v)
+	}
+}
+
+// Vec3Pipe is like Pipe, for vector samples.
+//
+// The zero value drops all samples pushed into it.
+type Vec3Pipe struct {
+	Source Vec3Source
+	Blocks Vec3Chain
+	Sink   Vec3Sink
+}
+
+// Write pushes a sample into the pipe: it is processed by the blocks and
+// written to the sink.
+func (p *Vec3Pipe) Write(v fixpoint.Vec3Q24) {
+	v = p.Blocks.Update(v)
+	if p.Sink != nil {
+		p.Sink.Write(v)
+	}
+}
+
+// Pull reads a sample from the source and pushes it through the pipe. It
+// returns false when the source has no sample available.
+func (p *Vec3Pipe) Pull() bool {
+	v, ok := p.Source.Next()
+	if !ok {
+		return false
+	}
+	p.Write(v)
+	return true
+}
+
+// Run pulls up to n samples through the pipe, and returns the number of
+// samples it processed.
+func (p *Vec3Pipe) Run(n int) int {
+	for i := 0; i < n; i++ {
+		if !p.Pull() {
+			return i
+		}
+	}
+	return n
+}
+
+// Magnitude converts vector samples to scalar samples by writing the length
+// of each sample to Sink, for example to show the total acceleration. It is a
+// Vec3Sink.
+type Magnitude struct {
+	Sink Sink
+}
+
+// Write writes the length of v to the sink.
+func (m *Magnitude) Write(v fixpoint.Vec3Q24) {
+	m.Sink.Write(v.Len())
+}
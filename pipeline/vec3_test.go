@@ -0,0 +1,51 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/filters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVec3Pipe(t *testing.T) {
+	// A simulated accelerometer that reads (3, 0, 4) three times.
+	samples := 3
+	source := Vec3SourceFunc(func() (fixpoint.Vec3Q24, bool) {
+		if samples == 0 {
+			return fixpoint.Vec3Q24{}, false
+		}
+		samples--
+		return fixpoint.Vec3Q24FromFloat(3, 0, 4), true
+	})
+
+	// Low-pass filter the X axis only, scale the result, and show both the
+	// vector and its magnitude.
+	var vectors []fixpoint.Vec3Q24
+	var magnitudes []fixpoint.Q24
+	p := Vec3Pipe{
+		Source: source,
+		Blocks: Vec3Chain{
+			&PerAxis{&filters.LowPass{Alpha: fixpoint.Q24FromFloat(0.5)}},
+			Vec3BlockFunc(func(v fixpoint.Vec3Q24) fixpoint.Vec3Q24 { return v.Mul(fixpoint.Q24FromFloat(0.5)) }),
+		},
+		Sink: Vec3Tee{
+			Vec3SinkFunc(func(v fixpoint.Vec3Q24) { vectors = append(vectors, v) }),
+			&Magnitude{Sink: SinkFunc(func(x fixpoint.Q24) { magnitudes = append(magnitudes, x) })},
+		},
+	}
+	assert.Equal(t, 3, p.Run(5))
+	assert.False(t, p.Pull())
+	assert.Equal(t, []fixpoint.Vec3Q24{
+		fixpoint.Vec3Q24FromFloat(1.5, 0, 2),
+		fixpoint.Vec3Q24FromFloat(1.5, 0, 2),
+		fixpoint.Vec3Q24FromFloat(1.5, 0, 2),
+	}, vectors)
+	assert.Equal(t, []fixpoint.Q24{fixpoint.Q24FromFloat(2.5), fixpoint.Q24FromFloat(2.5), fixpoint.Q24FromFloat(2.5)}, magnitudes)
+
+	// Samples can also be pushed.
+	vectors = nil
+	p.Write(fixpoint.Vec3Q24FromFloat(1, 2, 2))
+	assert.Equal(t, []fixpoint.Vec3Q24{fixpoint.Vec3Q24FromFloat(1, 1, 1)}, vectors)
+	assert.InDelta(t, 1.7320508, magnitudes[3].Float64(), 1e-7)
+}
@@ -0,0 +1,63 @@
+// Code generated by fixgen -name Q15 -frac 15 -bits 16 -scalar; DO NOT EDIT.
+
+package fixpoint
+
+// Q15 is a Q0.15 fixed point integer type that has 15 bits of
+// precision to the right of the fixed point. It can hold numbers in the range
+// [-1, 1).
+type Q15 struct {
+	N int16
+}
+
+// Q15FromFloat converts a float32 to the same number in fixed point format.
+// Inverse of .Float().
+func Q15FromFloat(x float32) Q15 {
+	return Q15{int16(x * (1 << 15))}
+}
+
+// Q15FromConstFloat converts a float64 to the nearest number in fixed point
+// format, with ties rounded away from zero. It is meant for constant
+// arguments: it is small enough to be inlined, after which the compiler folds
+// the conversion into a constant.
+func Q15FromConstFloat(x float64) Q15 {
+	if x < 0 {
+		return Q15{int16(x*(1<<15) - 0.5)}
+	}
+	return Q15{int16(x*(1<<15) + 0.5)}
+}
+
+// Float returns the floating point version of this fixed point number. Inverse
+// of Q15FromFloat.
+func (q Q15) Float() float32 {
+	return float32(q.N) / (1 << 15)
+}
+
+// Int32Scaled returns the underlying fixed point number multiplied by scale.
+func (q Q15) Int32Scaled(scale int32) int32 {
+	return int32(q.N) / (1 << 15 / scale)
+}
+
+// Add returns the argument plus this number.
+func (q1 Q15) Add(q2 Q15) Q15 {
+	return Q15{q1.N + q2.N}
+}
+
+// Sub returns the argument minus this number.
+func (q1 Q15) Sub(q2 Q15) Q15 {
+	return Q15{q1.N - q2.N}
+}
+
+// Neg returns the inverse of this number.
+func (q1 Q15) Neg() Q15 {
+	return Q15{-q1.N}
+}
+
+// Mul returns this number multiplied by the argument.
+func (q1 Q15) Mul(q2 Q15) Q15 {
+	return Q15{int16((int32(q1.N) * int32(q2.N)) >> 15)}
+}
+
+// Div returns this number divided by the argument.
+func (q1 Q15) Div(q2 Q15) Q15 {
+	return Q15{int16((int32(q1.N) << 15) / int32(q2.N))}
+}
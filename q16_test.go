@@ -0,0 +1,55 @@
+package fixpoint
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQ16(t *testing.T) {
+	two := Q16FromFloat(2)
+	for _, f := range []float32{0.25, 1, 10, 0.125, 1000, -50} {
+		q := Q16FromFloat(f)
+		assert.Equal(t, f, q.Float(), "float32 roundtrip failed")
+		assert.Equal(t, Q16FromFloat(f*2), q.Mul(two), "multiply by 2")
+		assert.Equal(t, Q16FromFloat(f+2), q.Add(two), "add 2")
+		assert.Equal(t, Q16FromFloat(f-2), q.Sub(two), "sub 2")
+		assert.Equal(t, Q16FromFloat(-f), q.Neg(), "neg")
+		if f*f < 32768 {
+			square := q.Mul(q)
+			assert.Equal(t, Q16FromFloat(f*f), square, "square")
+			assert.Equal(t, Q16FromFloat(f), square.Div(q), "div")
+		}
+	}
+	assert.Equal(t, int32(640), Q16FromFloat(2.5).Int32Scaled(256))
+}
+
+func TestVec3Q16(t *testing.T) {
+	v1 := Vec3Q16FromFloat(100, 200, -300)
+	v2 := Vec3Q16FromFloat(0.5, 2, 4)
+	assert.Equal(t, Vec3Q16FromFloat(100.5, 202, -296), v1.Add(v2))
+	assert.Equal(t, Vec3Q16FromFloat(50, 100, -150), v1.Mul(Q16FromFloat(0.5)))
+	assert.Equal(t, Q16FromFloat(-750), v1.Dot(v2))
+	f1 := mgl32.Vec3{100, 200, -300}
+	f2 := mgl32.Vec3{0.5, 2, 4}
+	cross := f1.Cross(f2)
+	assert.Equal(t, Vec3Q16FromFloat(cross[0], cross[1], cross[2]), v1.Cross(v2))
+}
+
+func TestQuatQ16(t *testing.T) {
+	q := QuatFromAxisAngle(Vec3Q24FromFloat(1, 2, 3), Q24FromFloat(0.7)).QuatQ16()
+	qf := mgl32.QuatRotate(0.7, mgl32.Vec3{1, 2, 3}.Normalize())
+	v := qf.Rotate(mgl32.Vec3{100, -20, 3000})
+	rotated := q.Rotate(Vec3Q16FromFloat(100, -20, 3000))
+	assert.InDelta(t, v.X(), rotated.X.Float(), 0.5)
+	assert.InDelta(t, v.Y(), rotated.Y.Float(), 0.5)
+	assert.InDelta(t, v.Z(), rotated.Z.Float(), 0.5)
+
+	product := q.Mul(q)
+	productf := qf.Mul(qf)
+	assert.InDelta(t, productf.W, product.W.Float(), 0.0001)
+	assert.InDelta(t, productf.X(), product.V.X.Float(), 0.0001)
+	assert.InDelta(t, productf.Y(), product.V.Y.Float(), 0.0001)
+	assert.InDelta(t, productf.Z(), product.V.Z.Float(), 0.0001)
+}
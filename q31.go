@@ -0,0 +1,63 @@
+// Code generated by fixgen -name Q31 -frac 31 -scalar; DO NOT EDIT.
+
+package fixpoint
+
+// Q31 is a Q0.31 fixed point integer type that has 31 bits of
+// precision to the right of the fixed point. It can hold numbers in the range
+// [-1, 1).
+type Q31 struct {
+	N int32
+}
+
+// Q31FromFloat converts a float32 to the same number in fixed point format.
+// Inverse of .Float().
+func Q31FromFloat(x float32) Q31 {
+	return Q31{int32(x * (1 << 31))}
+}
+
+// Q31FromConstFloat converts a float64 to the nearest number in fixed point
+// format, with ties rounded away from zero. It is meant for constant
+// arguments: it is small enough to be inlined, after which the compiler folds
+// the conversion into a constant.
+func Q31FromConstFloat(x float64) Q31 {
+	if x < 0 {
+		return Q31{int32(x*(1<<31) - 0.5)}
+	}
+	return Q31{int32(x*(1<<31) + 0.5)}
+}
+
+// Float returns the floating point version of this fixed point number. Inverse
+// of Q31FromFloat.
+func (q Q31) Float() float32 {
+	return float32(q.N) / (1 << 31)
+}
+
+// Int32Scaled returns the underlying fixed point number multiplied by scale.
+func (q Q31) Int32Scaled(scale int32) int32 {
+	return int32((int64(q.N) * int64(scale)) >> 31)
+}
+
+// Add returns the argument plus this number.
+func (q1 Q31) Add(q2 Q31) Q31 {
+	return Q31{q1.N + q2.N}
+}
+
+// Sub returns the argument minus this number.
+func (q1 Q31) Sub(q2 Q31) Q31 {
+	return Q31{q1.N - q2.N}
+}
+
+// Neg returns the inverse of this number.
+func (q1 Q31) Neg() Q31 {
+	return Q31{-q1.N}
+}
+
+// Mul returns this number multiplied by the argument.
+func (q1 Q31) Mul(q2 Q31) Q31 {
+	return Q31{int32((int64(q1.N) * int64(q2.N)) >> 31)}
+}
+
+// Div returns this number divided by the argument.
+func (q1 Q31) Div(q2 Q31) Q31 {
+	return Q31{int32((int64(q1.N) << 31) / int64(q2.N))}
+}
@@ -0,0 +1,144 @@
+package fixpoint
+
+// Q32 is a Q32.32 fixed point number backed by an int64. It has a lot more
+// range and precision than Q24, which makes it suitable as an accumulator: for
+// example to integrate acceleration into velocity and position over a long
+// time, or to calculate dot products of many terms. Results are usually
+// converted back to Q24 at the end.
+type Q32 struct {
+	N int64
+}
+
+// Q32FromInt32 returns a fixed point integer with all decimals set to zero.
+func Q32FromInt32(x int32) Q32 {
+	return Q32{int64(x) << 32}
+}
+
+// Q32FromFloat64 converts a float64 to the same number in fixed point format.
+// Inverse of .Float64().
+func Q32FromFloat64(x float64) Q32 {
+	return Q32{int64(x * (1 << 32))}
+}
+
+// Q32FromQ24 converts a Q24 to a Q32, which is always exact.
+func Q32FromQ24(q Q24) Q32 {
+	return Q32{int64(q.N) << 8}
+}
+
+// Float64 returns the floating point version of this fixed point number.
+// Inverse of Q32FromFloat64.
+func (q Q32) Float64() float64 {
+	return float64(q.N) / (1 << 32)
+}
+
+// Q24 returns this number as a Q24, rounded to the nearest value and saturated
+// to the Q24 range.
+func (q Q32) Q24() Q24 {
+	return saturate((q.N >> 8) + (q.N>>7)&1)
+}
+
+// Add returns the argument plus this number.
+func (q1 Q32) Add(q2 Q32) Q32 {
+	return Q32{q1.N + q2.N}
+}
+
+// Sub returns the argument minus this number.
+func (q1 Q32) Sub(q2 Q32) Q32 {
+	return Q32{q1.N - q2.N}
+}
+
+// Neg returns the inverse of this number.
+func (q1 Q32) Neg() Q32 {
+	return Q32{-q1.N}
+}
+
+// Mul returns this number multiplied by the argument, rounded to the nearest
+// representable value. The intermediate result has 128 bits, so it only
+// overflows if the result doesn't fit in a Q32.
+func (q1 Q32) Mul(q2 Q32) Q32 {
+	hi, lo := mul128(q1.N, q2.N)
+	// Add 0.5 for rounding, then shift right by 32.
+	lo += 1 << 31
+	if lo < 1<<31 {
+		hi++
+	}
+	return Q32{int64(uint64(hi)<<32 | lo>>32)}
+}
+
+// Div returns this number divided by the argument, rounded towards zero. It
+// panics when dividing by zero.
+func (q1 Q32) Div(q2 Q32) Q32 {
+	a, b := uint64(q1.N), uint64(q2.N)
+	neg := false
+	if q1.N < 0 {
+		a = -a
+		neg = !neg
+	}
+	if q2.N < 0 {
+		b = -b
+		neg = !neg
+	}
+	// Long division of a<<32 by b: the integer part first, and then the
+	// fraction one bit at a time.
+	quo := a / b
+	rem := a % b
+	for i := 0; i < 32; i++ {
+		quo <<= 1
+		// rem < b <= 2^63, so shifting it can't overflow.
+		rem <<= 1
+		if rem >= b {
+			rem -= b
+			quo |= 1
+		}
+	}
+	if neg {
+		return Q32{-int64(quo)}
+	}
+	return Q32{int64(quo)}
+}
+
+// MulAcc returns this number plus the product of a and b. The product is
+// calculated without overflow and rounded to the nearest Q32 value, so many
+// products can be summed without losing precision or overflowing Q24.
+func (q Q32) MulAcc(a, b Q24) Q32 {
+	return Q32{q.N + (int64(a.N)*int64(b.N)+1<<15)>>16}
+}
+
+// DotQ32 returns the dot product of two slices, accumulated in a Q32. The
+// slices must have the same length.
+func DotQ32(a, b []Q24) Q32 {
+	var acc Q32
+	for i, x := range a {
+		acc = acc.MulAcc(x, b[i])
+	}
+	return acc
+}
+
+// DotQ32 returns the dot product between this vector and the argument as a
+// Q32, which does not overflow for long vectors.
+func (v1 Vec3Q24) DotQ32(v2 Vec3Q24) Q32 {
+	return Q32{}.MulAcc(v1.X, v2.X).MulAcc(v1.Y, v2.Y).MulAcc(v1.Z, v2.Z)
+}
+
+// mul128 returns the 128-bit signed product of a and b as a high and low
+// part.
+func mul128(a, b int64) (hi int64, lo uint64) {
+	// Unsigned multiplication of the 32-bit halves.
+	ua, ub := uint64(a), uint64(b)
+	a0, a1 := ua&(1<<32-1), ua>>32
+	b0, b1 := ub&(1<<32-1), ub>>32
+	w0 := a0 * b0
+	t := a1*b0 + w0>>32
+	w1 := t&(1<<32-1) + a0*b1
+	uhi := a1*b1 + t>>32 + w1>>32
+	lo = ua * ub
+
+	// Correct the high part for signed inputs.
+	if a < 0 {
+		uhi -= ub
+	}
+	if b < 0 {
+		uhi -= ua
+	}
+	return int64(uhi), lo
+}
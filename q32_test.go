@@ -0,0 +1,82 @@
+package fixpoint
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQ32(t *testing.T) {
+	assert.Equal(t, 1.5, Q32FromFloat64(1.5).Float64())
+	assert.Equal(t, Q32FromInt32(-3), Q32FromQ24(Q24FromInt32(-3)))
+	third := Q24FromInt32(1).DivRound(Q24FromInt32(3))
+	assert.Equal(t, third, Q32FromQ24(third).Q24())
+	assert.Equal(t, Q24{1}, Q32{1 << 7}.Q24())
+	assert.Equal(t, Q24{0}, Q32{1<<7 - 1}.Q24())
+	assert.Equal(t, Q24{-1}, Q32{-1<<7 - 1}.Q24())
+	assert.Equal(t, Q24{maxN}, Q32FromInt32(1000).Q24())
+	assert.Equal(t, Q24{minN}, Q32FromInt32(-1000).Q24())
+
+	a, b := Q32FromFloat64(1000.5), Q32FromFloat64(-0.25)
+	assert.Equal(t, Q32FromFloat64(1000.25), a.Add(b))
+	assert.Equal(t, Q32FromFloat64(1000.75), a.Sub(b))
+	assert.Equal(t, Q32FromFloat64(-1000.5), a.Neg())
+	assert.Equal(t, Q32FromFloat64(-250.125), a.Mul(b))
+	assert.Equal(t, Q32FromFloat64(-4002), a.Div(b))
+	assert.Equal(t, Q32FromFloat64(1000.5*1000.5), a.Mul(a))
+
+	// Compare Mul and Div against math/big for random numbers.
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		x := Q32{r.Int63n(1<<50) - 1<<49}
+		y := Q32{r.Int63n(1<<40) - 1<<39}
+		if y.N == 0 {
+			continue
+		}
+		bx, by := big.NewInt(x.N), big.NewInt(y.N)
+
+		// Mul: floor((x*y + 2^31) / 2^32)
+		prod := new(big.Int).Mul(bx, by)
+		prod.Add(prod, big.NewInt(1<<31))
+		prod.Rsh(prod, 32)
+		if got := x.Mul(y); got.N != prod.Int64() {
+			t.Errorf("%d * %d: got %d, want %d", x.N, y.N, got.N, prod.Int64())
+		}
+
+		// Div: truncate((x << 32) / y)
+		quo := new(big.Int).Lsh(bx, 32)
+		quo.Quo(quo, by)
+		if quo.IsInt64() {
+			if got := x.Div(y); got.N != quo.Int64() {
+				t.Errorf("%d / %d: got %d, want %d", x.N, y.N, got.N, quo.Int64())
+			}
+		}
+	}
+}
+
+func TestDotQ32(t *testing.T) {
+	// The sum of the products overflows Q24, but the result doesn't.
+	a := make([]Q24, 100)
+	b := make([]Q24, 100)
+	for i := range a {
+		a[i] = Q24FromInt32(10)
+		b[i] = Q24FromFloat(0.5)
+		if i%2 == 1 {
+			b[i] = b[i].Neg()
+		}
+	}
+	b[0] = Q24FromInt32(1)
+	assert.Equal(t, Q24FromInt32(5), DotQ32(a, b).Q24())
+	assert.Equal(t, Q32FromInt32(100*100), DotQ32(a, a))
+
+	// Products are not rounded to Q24 before summing: each product is 2^-28
+	// here, which rounds to zero in Q24 but not in Q32.
+	small := []Q24{{1 << 10}, {1 << 10}, {1 << 10}, {1 << 10}}
+	assert.Equal(t, Q24{}, small[0].MulRound(small[0]))
+	assert.Equal(t, Q32{4 * 16}, DotQ32(small, small))
+
+	v := Vec3Q24FromFloat(10, 10, 10)
+	assert.Equal(t, Q32FromInt32(300), v.DotQ32(v))
+}
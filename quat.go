@@ -0,0 +1,159 @@
+package fixpoint
+
+// QuatFromAxisAngle returns the quaternion that rotates by the given angle (in
+// radians) around the given axis. The axis does not need to be normalized.
+func QuatFromAxisAngle(axis Vec3Q24, angle Q24) QuatQ24 {
+	// Copied from go-gl/mathgl and modified.
+	sin, cos := SinCos(Q24{angle.N >> 1})
+	return QuatQ24{cos, axis.Normalize().Mul(sin)}
+}
+
+// QuatFromSmallAngles returns the quaternion that rotates by the small angles
+// dx, dy and dz (in radians) around the X, Y and Z axes, such as the gyro
+// rate multiplied by the time step. It is the usual first-order
+// approximation without sine and cosine: the vector part is half the
+// angles and W = 1 - ½|v|², which makes the quaternion unit length to second
+// order. Note that the vector part is half the angle: using the angles
+// directly (a common mistake) rotates twice as far.
+//
+// The rotation axis is exact, but the rotation angle θ = |(dx, dy, dz)| is
+// too large by about θ³/24 and the length of the quaternion is about
+// 1 + θ⁴/128. For θ below 0.01 radians both errors are below a ULP. For
+// larger angles, use QuatFromAxisAngle or normalize the result.
+func QuatFromSmallAngles(dx, dy, dz Q24) QuatQ24 {
+	x, y, z := uint64(int64(dx.N)*int64(dx.N)), uint64(int64(dy.N)*int64(dy.N)), uint64(int64(dz.N)*int64(dz.N))
+	// |v|² = θ²/4 in Q48, so ½|v|² = θ²/8 in Q24 after a shift by 27.
+	w := int64(1<<24) - int64((x+y+z+1<<26)>>27)
+	return QuatQ24{saturate(w), Vec3Q24{half(dx), half(dy), half(dz)}}
+}
+
+// half returns q/2, rounded to the nearest value.
+func half(q Q24) Q24 {
+	return Q24{int32((int64(q.N) + 1) >> 1)}
+}
+
+// ToAxisAngle returns the rotation axis and angle (in radians, in the range
+// [0, 2π]) of the rotation this quaternion represents. The returned axis is
+// normalized. When there is no rotation, the X axis is returned.
+func (q QuatQ24) ToAxisAngle() (axis Vec3Q24, angle Q24) {
+	if q.V == (Vec3Q24{}) {
+		return Vec3Q24{X: Q24FromInt32(1)}, Q24{}
+	}
+	// Using atan2 instead of acos(W) avoids the need for a normalized
+	// quaternion and is more precise for small angles.
+	return q.V.Normalize(), Q24{Atan2(q.V.Len(), q.W).N << 1}
+}
+
+// Conjugate returns the conjugate of this quaternion. For unit quaternions,
+// this is the same as the inverse but much faster to calculate.
+func (q QuatQ24) Conjugate() QuatQ24 {
+	return QuatQ24{q.W, q.V.Neg()}
+}
+
+// RotateInv returns the vector from the argument rotated by the inverse of the
+// rotation this unit quaternion represents. It is the same as rotating by the
+// conjugate.
+func (q QuatQ24) RotateInv(v Vec3Q24) Vec3Q24 {
+	return q.Conjugate().Rotate(v)
+}
+
+// Inverse returns the inverse of this quaternion. The inverse of the zero
+// quaternion is the zero quaternion.
+func (q QuatQ24) Inverse() QuatQ24 {
+	len2 := q.len2Q48()
+	if len2 == 0 {
+		return q
+	}
+	// The inverse is the conjugate divided by the squared length, so divide
+	// by the length twice.
+	r, shift := recipSqrtQ48(len2)
+	c := q.Conjugate()
+	return QuatQ24{
+		mulRecip(mulRecip(c.W, r, shift), r, shift),
+		Vec3Q24{
+			mulRecip(mulRecip(c.V.X, r, shift), r, shift),
+			mulRecip(mulRecip(c.V.Y, r, shift), r, shift),
+			mulRecip(mulRecip(c.V.Z, r, shift), r, shift),
+		},
+	}
+}
+
+// Len returns the length (or norm) of this quaternion.
+func (q QuatQ24) Len() Q24 {
+	return Q24{int32(sqrt64(q.len2Q48()))}
+}
+
+// Normalize returns this quaternion scaled to unit length. The zero quaternion
+// is returned unmodified.
+func (q QuatQ24) Normalize() QuatQ24 {
+	len2 := q.len2Q48()
+	if len2 == 0 {
+		return q
+	}
+	r, shift := recipSqrtQ48(len2)
+	return QuatQ24{
+		mulRecip(q.W, r, shift),
+		Vec3Q24{mulRecip(q.V.X, r, shift), mulRecip(q.V.Y, r, shift), mulRecip(q.V.Z, r, shift)},
+	}
+}
+
+// len2Q48 returns the squared length of this quaternion in Q48 format.
+func (q QuatQ24) len2Q48() uint64 {
+	w := int64(q.W.N)
+	return uint64(w*w) + q.V.len2Q48()
+}
+
+// neg returns this quaternion with all elements negated, which represents the
+// same rotation.
+func (q QuatQ24) neg() QuatQ24 {
+	return QuatQ24{q.W.Neg(), q.V.Neg()}
+}
+
+// Dot returns the dot product between this quaternion and the argument.
+func (q1 QuatQ24) Dot(q2 QuatQ24) Q24 {
+	return q1.W.MulRound(q2.W).Add(q1.V.Dot(q2.V))
+}
+
+// QuatNlerp returns the normalized linear interpolation between two
+// quaternions, where t is in the range [0, 1]. It always takes the shortest
+// path. It is faster than QuatSlerp but the rotation speed is not constant.
+func QuatNlerp(q1, q2 QuatQ24, t Q24) QuatQ24 {
+	if q1.Dot(q2).N < 0 {
+		q2 = q2.neg()
+	}
+	// q1 + (q2 - q1) * t
+	return QuatQ24{
+		q1.W.Add(q2.W.Sub(q1.W).MulRound(t)),
+		q1.V.Add(q2.V.Sub(q1.V).Mul(t)),
+	}.Normalize()
+}
+
+// QuatSlerp returns the spherical linear interpolation between two unit
+// quaternions, where t is in the range [0, 1]. It always takes the shortest
+// path and rotates at a constant speed.
+func QuatSlerp(q1, q2 QuatQ24, t Q24) QuatQ24 {
+	// Copied from go-gl/mathgl and modified.
+	dot := q1.Dot(q2)
+	if dot.N < 0 {
+		q2 = q2.neg()
+		dot = dot.Neg()
+	}
+
+	// If the inputs are too close, linearly interpolate instead to avoid
+	// dividing by a very small number.
+	if dot.N > Q24FromConstFloat(0.9995).N {
+		return QuatNlerp(q1, q2, t)
+	}
+
+	theta := Acos(dot).MulRound(t)
+	sin, cos := SinCos(theta)
+	// q3 is the part of q2 that is orthogonal to q1.
+	q3 := QuatQ24{
+		q2.W.Sub(q1.W.MulRound(dot)),
+		q2.V.Sub(q1.V.Mul(dot)),
+	}.Normalize()
+	return QuatQ24{
+		q1.W.MulRound(cos).Add(q3.W.MulRound(sin)),
+		q1.V.Mul(cos).Add(q3.V.Mul(sin)),
+	}
+}
@@ -0,0 +1,115 @@
+package fixpoint
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/stretchr/testify/assert"
+)
+
+// assertQuat checks whether the fixed point quaternion is close to the
+// floating point quaternion.
+func assertQuat(t *testing.T, expected mgl32.Quat, actual QuatQ24, msgAndArgs ...interface{}) {
+	t.Helper()
+	const delta = 0.00001
+	assert.InDelta(t, expected.W, actual.W.Float(), delta, msgAndArgs...)
+	assert.InDelta(t, expected.X(), actual.X().Float(), delta, msgAndArgs...)
+	assert.InDelta(t, expected.Y(), actual.Y().Float(), delta, msgAndArgs...)
+	assert.InDelta(t, expected.Z(), actual.Z().Float(), delta, msgAndArgs...)
+}
+
+func TestQuatAxisAngle(t *testing.T) {
+	for _, angle := range []float32{0.1, 1, -1, 3} {
+		axis := mgl32.Vec3{1, 2, 3}
+		q1 := mgl32.QuatRotate(angle, axis.Normalize())
+		q2 := QuatFromAxisAngle(Vec3Q24FromFloat(1, 2, 3), Q24FromFloat(angle))
+		assertQuat(t, q1, q2, "angle %f", angle)
+
+		axis2, angle2 := q2.ToAxisAngle()
+		if angle < 0 {
+			// The rotation is returned around the inverse axis.
+			angle = -angle
+			axis = axis.Mul(-1)
+		}
+		axis = axis.Normalize()
+		assert.InDelta(t, angle, angle2.Float(), 0.00001)
+		assert.InDelta(t, axis.X(), axis2.X.Float(), 0.00001)
+		assert.InDelta(t, axis.Y(), axis2.Y.Float(), 0.00001)
+		assert.InDelta(t, axis.Z(), axis2.Z.Float(), 0.00001)
+	}
+
+	axis, angle := QuatIdent().ToAxisAngle()
+	assert.Equal(t, Q24{}, angle)
+	assert.Equal(t, Vec3Q24FromFloat(1, 0, 0), axis)
+}
+
+func TestQuatFromSmallAngles(t *testing.T) {
+	axis := Vec3Q24FromFloat(1, -2, 3).Normalize()
+	for _, theta := range []float64{0, 0.001, 0.01, 0.05, 0.1} {
+		angles := axis.Mul(Q24FromFloat64(theta))
+		q := QuatFromSmallAngles(angles.X, angles.Y, angles.Z)
+
+		// The angle is too large by about θ³/24 and the length is too
+		// large by about θ⁴/128, apart from rounding.
+		_, angle := q.ToAxisAngle()
+		assert.InDelta(t, theta+theta*theta*theta/24, angle.Float64(), 4.0/(1<<24), "θ=%g", theta)
+		assert.InDelta(t, 1+math.Pow(theta, 4)/128, math.Sqrt(float64(q.len2Q48()))/(1<<24), 2.0/(1<<24), "θ=%g", theta)
+
+		// It rotates around the given axis.
+		if theta != 0 {
+			assert.InDelta(t, 1, q.V.Normalize().Dot(axis).Float64(), 1e-6, "θ=%g", theta)
+		}
+	}
+
+	// Below 0.01 radians it is as good as the exact rotation.
+	angles := Vec3Q24FromFloat(0.002, -0.004, 0.006)
+	exact := QuatFromAxisAngle(angles, angles.Len())
+	q := QuatFromSmallAngles(angles.X, angles.Y, angles.Z)
+	assert.InDelta(t, exact.W.Float64(), q.W.Float64(), 2.0/(1<<24))
+	assert.InDelta(t, exact.V.X.Float64(), q.V.X.Float64(), 2.0/(1<<24))
+	assert.InDelta(t, exact.V.Y.Float64(), q.V.Y.Float64(), 2.0/(1<<24))
+	assert.InDelta(t, exact.V.Z.Float64(), q.V.Z.Float64(), 2.0/(1<<24))
+}
+
+func TestQuatInverse(t *testing.T) {
+	q := QuatQ24{Q24FromFloat(0.5), Vec3Q24FromFloat(1, -2, 0.25)}
+	q1 := mgl32.Quat{W: 0.5, V: mgl32.Vec3{1, -2, 0.25}}
+	assertQuat(t, q1.Conjugate(), q.Conjugate())
+	assertQuat(t, q1.Inverse(), q.Inverse())
+	assertQuat(t, q1.Normalize(), q.Normalize())
+	assert.InDelta(t, q1.Len(), q.Len().Float(), 0.00001)
+	assertQuat(t, mgl32.QuatIdent(), q.Mul(q.Inverse()))
+	assert.Equal(t, QuatQ24{}, QuatQ24{}.Inverse())
+	assert.Equal(t, QuatQ24{}, QuatQ24{}.Normalize())
+}
+
+func TestQuatRotateInv(t *testing.T) {
+	v := Vec3Q24FromFloat(3, -1, 0.5)
+	unit := QuatQ24{Q24FromFloat(0.5), Vec3Q24FromFloat(1, -2, 0.25)}.Normalize()
+	assert.Equal(t, unit.Conjugate().Rotate(v), unit.RotateInv(v))
+	assert.InDeltaSlice(t, []float32{3, -1, 0.5}, []float32{
+		unit.RotateInv(unit.Rotate(v)).X.Float(),
+		unit.RotateInv(unit.Rotate(v)).Y.Float(),
+		unit.RotateInv(unit.Rotate(v)).Z.Float(),
+	}, 0.00001)
+}
+
+func TestQuatInterpolation(t *testing.T) {
+	q1f := mgl32.QuatRotate(0.3, mgl32.Vec3{0, 0, 1})
+	q2f := mgl32.QuatRotate(2.5, mgl32.Vec3{0, 1, 0})
+	q1 := QuatFromAxisAngle(Vec3Q24FromFloat(0, 0, 1), Q24FromFloat(0.3))
+	q2 := QuatFromAxisAngle(Vec3Q24FromFloat(0, 1, 0), Q24FromFloat(2.5))
+	for _, amount := range []float32{0, 0.25, 0.5, 0.9, 1} {
+		assertQuat(t, mgl32.QuatSlerp(q1f, q2f, amount), QuatSlerp(q1, q2, Q24FromFloat(amount)), "slerp %f", amount)
+		assertQuat(t, mgl32.QuatNlerp(q1f, q2f, amount), QuatNlerp(q1, q2, Q24FromFloat(amount)), "nlerp %f", amount)
+	}
+
+	// Slerp must take the shortest path.
+	half := QuatSlerp(q1, q2.neg(), Q24FromFloat(0.5))
+	assertQuat(t, mgl32.QuatSlerp(q1f, q2f, 0.5), half)
+
+	// Very close quaternions.
+	q3 := QuatFromAxisAngle(Vec3Q24FromFloat(0, 0, 1), Q24FromFloat(0.31))
+	assertQuat(t, mgl32.QuatRotate(0.305, mgl32.Vec3{0, 0, 1}), QuatSlerp(q1, q3, Q24FromFloat(0.5)))
+}
@@ -0,0 +1,103 @@
+package fixpoint
+
+import (
+	"math/bits"
+)
+
+// Rand is a small deterministic pseudo-random number generator, using the
+// xoshiro128** algorithm. It only needs 32-bit operations, has a period of
+// 2^128-1 and returns the same sequence on every platform, so simulations can
+// be reproduced exactly.
+//
+// Independent streams (for example one per particle or per synthesizer voice)
+// can be derived from a single seeded generator with Jump: copy the generator
+// and jump the copy ahead, which gives 2^64 numbers before the streams
+// overlap.
+//
+// The zero value is not usable: call Seed first.
+//
+// See: https://prng.di.unimi.it/
+type Rand struct {
+	s [4]uint32
+}
+
+// Seed initializes the generator from a 64-bit seed. Every seed (including
+// zero) results in a different, well-mixed state.
+func (r *Rand) Seed(seed uint64) {
+	// Use SplitMix64 to expand the seed, as recommended by the authors of
+	// xoshiro.
+	for i := 0; i < 4; i += 2 {
+		seed += 0x9e3779b97f4a7c15
+		z := seed
+		z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+		z = (z ^ z>>27) * 0x94d049bb133111eb
+		z ^= z >> 31
+		r.s[i], r.s[i+1] = uint32(z), uint32(z>>32)
+	}
+}
+
+// Uint32 returns a random 32-bit number.
+func (r *Rand) Uint32() uint32 {
+	result := bits.RotateLeft32(r.s[1]*5, 7) * 9
+	t := r.s[1] << 9
+	r.s[2] ^= r.s[0]
+	r.s[3] ^= r.s[1]
+	r.s[1] ^= r.s[2]
+	r.s[0] ^= r.s[3]
+	r.s[2] ^= t
+	r.s[3] = bits.RotateLeft32(r.s[3], 11)
+	return result
+}
+
+// Q24 returns a random number in the range [0, 1).
+func (r *Rand) Q24() Q24 {
+	return Q24{int32(r.Uint32() >> 8)}
+}
+
+// Q24Range returns a random number in the range [min, max). The result is
+// uniform within the precision of Q24, and max must be above min.
+func (r *Rand) Q24Range(min, max Q24) Q24 {
+	span := uint64(int64(max.N) - int64(min.N))
+	return Q24{int32(int64(min.N) + int64((uint64(r.Uint32())*span)>>32))}
+}
+
+// Q15 returns a random number in the range [-1, 1), for example for white
+// noise.
+func (r *Rand) Q15() Q15 {
+	return Q15{int16(r.Uint32() >> 16)}
+}
+
+// Q31 returns a random number in the range [-1, 1).
+func (r *Rand) Q31() Q31 {
+	return Q31{int32(r.Uint32())}
+}
+
+// Jump advances the generator by 2^64 steps. It can be used to create 2^64
+// non-overlapping streams of 2^64 numbers each.
+func (r *Rand) Jump() {
+	r.jump([4]uint32{0x8764000b, 0xf542d2d3, 0x6fa035c3, 0x77f2db5b})
+}
+
+// LongJump advances the generator by 2^96 steps. It can be used to create
+// 2^32 starting points, which can each be split further with Jump.
+func (r *Rand) LongJump() {
+	r.jump([4]uint32{0xb523952e, 0x0b6f099f, 0xccf5a0ef, 0x1c580662})
+}
+
+// jump advances the generator by the number of steps that corresponds to the
+// given polynomial.
+func (r *Rand) jump(poly [4]uint32) {
+	var s [4]uint32
+	for _, p := range poly {
+		for b := uint(0); b < 32; b++ {
+			if p&(1<<b) != 0 {
+				s[0] ^= r.s[0]
+				s[1] ^= r.s[1]
+				s[2] ^= r.s[2]
+				s[3] ^= r.s[3]
+			}
+			r.Uint32()
+		}
+	}
+	r.s = s
+}
@@ -0,0 +1,75 @@
+package fixpoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRand(t *testing.T) {
+	// Reference output of xoshiro128** for the state {1, 2, 3, 4}.
+	r := Rand{[4]uint32{1, 2, 3, 4}}
+	for _, expected := range []uint32{11520, 0, 5927040, 70819200} {
+		assert.Equal(t, expected, r.Uint32())
+	}
+
+	// Seeding is deterministic, and different seeds give different streams.
+	var r1, r2, r3 Rand
+	r1.Seed(0)
+	r2.Seed(0)
+	r3.Seed(1)
+	assert.NotEqual(t, [4]uint32{}, r1.s)
+	for i := 0; i < 10; i++ {
+		n := r1.Uint32()
+		assert.Equal(t, n, r2.Uint32())
+		assert.NotEqual(t, n, r3.Uint32())
+	}
+
+	// The outputs are in range and roughly uniform.
+	var sum24, sum15 int64
+	const n = 10000
+	for i := 0; i < n; i++ {
+		q := r1.Q24()
+		if q.N < 0 || q.N >= 1<<24 {
+			t.Errorf("Q24 out of range: %v", q)
+		}
+		sum24 += int64(q.N)
+		sum15 += int64(r1.Q15().N)
+		q = r1.Q24Range(Q24FromInt32(-3), Q24FromInt32(5))
+		if q.N < -3<<24 || q.N >= 5<<24 {
+			t.Errorf("Q24Range out of range: %v", q)
+		}
+	}
+	assert.InDelta(t, 0.5, float64(sum24)/n/(1<<24), 0.01)
+	assert.InDelta(t, 0, float64(sum15)/n/(1<<15), 0.02)
+}
+
+func TestRandJump(t *testing.T) {
+	// A jump polynomial with only bit k set advances by k steps, as long as
+	// k is below the degree of the characteristic polynomial.
+	var r1 Rand
+	r1.Seed(42)
+	r2 := r1
+	r2.jump([4]uint32{0, 1 << 5, 0, 0})
+	for i := 0; i < 32+5; i++ {
+		r1.Uint32()
+	}
+	assert.Equal(t, r1, r2)
+
+	// Jumping and stepping commute.
+	r1.Seed(42)
+	r2 = r1
+	r1.Jump()
+	r1.Uint32()
+	r2.Uint32()
+	r2.Jump()
+	assert.Equal(t, r1, r2)
+
+	r2 = r1
+	r2.LongJump()
+	assert.NotEqual(t, r1, r2)
+	r3 := r1
+	r3.Jump()
+	assert.NotEqual(t, r3, r2)
+	assert.NotEqual(t, r1.Uint32(), r3.Uint32())
+}
@@ -0,0 +1,71 @@
+package fixpoint
+
+import (
+	"math/bits"
+)
+
+// Division without a hardware divider. Cores like the Cortex-M0 don't have a
+// divide instruction, so a 64-bit division is done in software and takes
+// hundreds of cycles. The reciprocal below is calculated with Newton-Raphson
+// iteration instead, which only needs a few multiplications.
+
+// Recip returns 1/q. It is a lot faster than dividing by q on cores without a
+// hardware divider, and has the same error bound as DivFast. The result
+// saturates for inputs with a magnitude of at most 2^-7 (including zero).
+func (q Q24) Recip() Q24 {
+	return Q24FromInt32(1).DivFast(q)
+}
+
+// DivFast returns this number divided by the argument, calculated as a
+// multiplication with the reciprocal of the argument. The result differs from
+// DivRound by at most one least significant bit for results with a magnitude
+// below 64, and by at most two for larger results. It saturates on overflow
+// and on division by zero.
+func (q1 Q24) DivFast(q2 Q24) Q24 {
+	if q2.N == 0 {
+		if q1.N < 0 {
+			return Q24{minN}
+		}
+		return Q24{maxN}
+	}
+	d := uint32(q2.N)
+	if q2.N < 0 {
+		d = -d
+	}
+	// 1/d = 2^s/(d<<s) = r * 2^(s-62), so the quotient in Q24 format is
+	// q1*r >> (38-s). The shift is at least 7.
+	s := uint(bits.LeadingZeros32(d))
+	r := int64(recip32(d << s))
+	if q2.N < 0 {
+		r = -r
+	}
+	shift := 38 - s
+	return saturate((int64(q1.N)*r + 1<<(shift-1)) >> shift)
+}
+
+// recip32 returns 2^62/d rounded down, for d in the range [2^31, 2^32). The
+// result is in the range [2^30, 2^31].
+func recip32(d uint32) uint32 {
+	// Initial linear estimate of 1/D for D=d/2^32 in [0.5, 1), in Q30 format,
+	// with an error of at most 1/17:
+	//   48/17 - 32/17 * D
+	// Each Newton-Raphson step squares the relative error, so three steps are
+	// enough to get close to 30 bits of precision.
+	r := int64(3031741621) - int64((uint64(2021161080)*uint64(d))>>32)
+	for i := 0; i < 3; i++ {
+		// e = 1 - D*r, in Q30 format.
+		e := 1<<30 - (int64(d)*r)>>32
+		r += (r * e) >> 30
+	}
+	// Correct the remaining error of a few units using the exact remainder.
+	rem := 1<<62 - int64(d)*r
+	for rem < 0 {
+		r--
+		rem += int64(d)
+	}
+	for rem >= int64(d) {
+		r++
+		rem -= int64(d)
+	}
+	return uint32(r)
+}
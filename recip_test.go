@@ -0,0 +1,55 @@
+package fixpoint
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecip32(t *testing.T) {
+	check := func(d uint32) {
+		if r := recip32(d); uint64(r) != (1<<62)/uint64(d) {
+			t.Errorf("recip32(%d): expected %d, got %d", d, (1<<62)/uint64(d), r)
+		}
+	}
+	for d := uint64(1 << 31); d < 1<<32; d += 1<<20 + 12345 {
+		check(uint32(d))
+	}
+	check(1 << 31)
+	check(1<<31 + 1)
+	check(1<<32 - 1)
+}
+
+func TestDivFast(t *testing.T) {
+	assert.Equal(t, Q24FromFloat(0.25), Q24FromInt32(4).Recip())
+	assert.Equal(t, Q24FromFloat(-2), Q24FromFloat(-0.5).Recip())
+	assert.Equal(t, Q24{5592405}, Q24FromInt32(3).Recip())
+	assert.Equal(t, Q24{maxN}, Q24{}.Recip())
+	assert.Equal(t, Q24{maxN}, Q24{1 << 17}.Recip())
+	assert.Equal(t, Q24{minN}, Q24{-1}.Recip())
+	assert.Equal(t, Q24{minN}, Q24FromInt32(-1).DivFast(Q24{}))
+	assert.Equal(t, Q24FromFloat(-2.5), Q24FromInt32(5).DivFast(Q24FromInt32(-2)))
+	assert.Equal(t, Q24FromFloat(0.5), Q24{minN / 2}.DivFast(Q24{minN}))
+
+	// Compare against DivRound for random values.
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100000; i++ {
+		q1 := Q24{int32(r.Uint32())}
+		q2 := Q24{int32(r.Uint32()) >> uint(r.Intn(31))}
+		exact := div64(q1, q2)
+		if exact < minN || exact > maxN {
+			continue
+		}
+		expected := q1.DivRound(q2).N
+		actual := q1.DivFast(q2).N
+		diff := int64(actual) - int64(expected)
+		limit := int64(1)
+		if exact >= 64<<24 || exact <= -64<<24 {
+			limit = 2
+		}
+		if diff > limit || diff < -limit {
+			t.Errorf("%d / %d: expected %d, got %d", q1.N, q2.N, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package fixpoint
+
+// Root finding for scalar functions, for example to invert a nonlinear sensor
+// response on the device. Both functions stop as specified by a Convergence,
+// where the residual is the function value at the returned root.
+
+// Bisect returns a root of f between a and b, where f(a) and f(b) must have
+// opposite signs (or one of them must be zero). It halves the interval until
+// it is one ULP wide, which takes up to 32 iterations, and returns the end
+// with the smallest function value. It is slow but robust: it always
+// converges for a continuous function. It returns false if f(a) and f(b) have
+// the same sign.
+func Bisect(f func(Q24) Q24, a, b Q24, c Convergence) (root Q24, iterations int, ok bool) {
+	fa, fb := f(a), f(b)
+	if fa.N == 0 {
+		return a, 0, true
+	}
+	if fb.N == 0 {
+		return b, 0, true
+	}
+	if (fa.N < 0) == (fb.N < 0) {
+		return Q24{}, 0, false
+	}
+	max := c.limit(32)
+	for iterations < max && abs64(int64(b.N)-int64(a.N)) > 1 {
+		mid := Q24{int32((int64(a.N) + int64(b.N)) >> 1)}
+		fm := f(mid)
+		iterations++
+		if fm.N == 0 || c.done(int64(fm.N), 24) {
+			return mid, iterations, true
+		}
+		if (fa.N < 0) == (fm.N < 0) {
+			a, fa = mid, fm
+		} else {
+			b, fb = mid, fm
+		}
+	}
+	if abs64(int64(fa.N)) <= abs64(int64(fb.N)) {
+		return a, iterations, true
+	}
+	return b, iterations, true
+}
+
+// Newton returns a root of f using Newton's method, starting at x0. The
+// derivative of f is given by df. It converges much faster than Bisect when
+// x0 is close to a root, but may not converge at all when it isn't. It stops
+// once a step is at most one ULP, which takes up to 20 iterations by default.
+// It returns false when the derivative is zero or when it didn't converge
+// within the maximum number of iterations, in which case root is the last
+// estimate.
+func Newton(f, df func(Q24) Q24, x0 Q24, c Convergence) (root Q24, iterations int, ok bool) {
+	x := x0
+	max := c.limit(20)
+	for iterations < max {
+		fx := f(x)
+		if fx.N == 0 || c.done(int64(fx.N), 24) {
+			return x, iterations, true
+		}
+		d := df(x)
+		if d.N == 0 {
+			return x, iterations, false
+		}
+		step := divRound64(fx, d)
+		x = saturate(int64(x.N) - step)
+		iterations++
+		if abs64(step) <= 1 {
+			return x, iterations, true
+		}
+	}
+	return x, iterations, false
+}
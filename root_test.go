@@ -0,0 +1,69 @@
+package fixpoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBisect(t *testing.T) {
+	// The square root of 2 is the root of x^2 - 2.
+	f := func(x Q24) Q24 { return x.MulRound(x).Sub(Q24FromInt32(2)) }
+	root, iterations, ok := Bisect(f, Q24{}, Q24FromInt32(2), Convergence{})
+	assert.True(t, ok)
+	assert.InDelta(t, Q24FromInt32(2).Sqrt().N, root.N, 1)
+	assert.True(t, iterations <= 32, "iterations: %d", iterations)
+
+	// The interval may be reversed.
+	root, _, ok = Bisect(f, Q24FromInt32(2), Q24{}, Convergence{})
+	assert.True(t, ok)
+	assert.InDelta(t, Q24FromInt32(2).Sqrt().N, root.N, 1)
+
+	// A root at one of the ends is returned immediately.
+	half := func(x Q24) Q24 { return x.Sub(Q24FromFloat(0.5)) }
+	root, iterations, ok = Bisect(half, Q24FromFloat(0.5), Q24FromInt32(1), Convergence{})
+	assert.True(t, ok)
+	assert.Equal(t, Q24FromFloat(0.5), root)
+	assert.Equal(t, 0, iterations)
+
+	// Without a sign change, there is no root to find.
+	_, _, ok = Bisect(f, Q24FromInt32(2), Q24FromInt32(3), Convergence{})
+	assert.False(t, ok)
+
+	// Fewer iterations with a larger epsilon.
+	root, iterations, ok = Bisect(f, Q24{}, Q24FromInt32(2), Convergence{Epsilon: Q24FromFloat(0.01)})
+	assert.True(t, ok)
+	assert.True(t, iterations < 12, "iterations: %d", iterations)
+	assert.InDelta(t, 0, f(root).Float64(), 0.01)
+	_, iterations, _ = Bisect(f, Q24{}, Q24FromInt32(2), Convergence{MaxIterations: 4})
+	assert.Equal(t, 4, iterations)
+}
+
+func TestNewton(t *testing.T) {
+	// Invert the sensor response y = x + x^3/4 for y = 1.5, which is
+	// solved by x = 1.2044...
+	response := func(x Q24) Q24 { return x.Add(x.MulRound(x).MulRound(x).Mul(Q24FromFloat(0.25))) }
+	f := func(x Q24) Q24 { return response(x).Sub(Q24FromFloat(1.5)) }
+	df := func(x Q24) Q24 { return Q24FromInt32(1).Add(x.MulRound(x).Mul(Q24FromFloat(0.75))) }
+	root, iterations, ok := Newton(f, df, Q24FromInt32(1), Convergence{})
+	assert.True(t, ok)
+	assert.True(t, iterations <= 6, "iterations: %d", iterations)
+	assert.InDelta(t, 0, f(root).N, 2)
+
+	// Bisection finds the same root, in more iterations.
+	broot, biterations, ok := Bisect(f, Q24{}, Q24FromInt32(2), Convergence{})
+	assert.True(t, ok)
+	assert.InDelta(t, root.N, broot.N, 2)
+	assert.True(t, biterations > iterations)
+
+	// A zero derivative is reported.
+	square := func(x Q24) Q24 { return x.MulRound(x).Add(Q24FromInt32(1)) }
+	twice := func(x Q24) Q24 { return x.Add(x) }
+	_, _, ok = Newton(square, twice, Q24{}, Convergence{})
+	assert.False(t, ok)
+
+	// And so is a function without a root.
+	_, iterations, ok = Newton(square, twice, Q24FromFloat(0.3), Convergence{})
+	assert.False(t, ok)
+	assert.True(t, iterations > 0)
+}
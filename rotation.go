@@ -0,0 +1,86 @@
+package fixpoint
+
+// CachedRotation is a rotation that is stored both as a quaternion and as a
+// rotation matrix. Rotations are composed with the quaternion, which is
+// cheaper and can be normalized, while vectors are rotated with the matrix,
+// which takes about half the multiplications of QuatQ24.Rotate. The matrix is
+// only rebuilt when it is needed after the quaternion changed, so a number of
+// updates followed by a number of rotations builds it only once.
+//
+// This is the usual setup for a renderer or a game object: the orientation is
+// updated from a gyroscope or from user input every frame, and then many
+// vertices are rotated with it.
+//
+// The zero value is the identity rotation.
+type CachedRotation struct {
+	q     QuatQ24
+	m     Mat3Q24
+	valid bool // whether m is the matrix of q
+}
+
+// Quat returns the rotation as a unit quaternion.
+func (r *CachedRotation) Quat() QuatQ24 {
+	if r.q == (QuatQ24{}) {
+		return QuatIdent()
+	}
+	return r.q
+}
+
+// Set sets the rotation to the given unit quaternion.
+func (r *CachedRotation) Set(q QuatQ24) {
+	r.q = q
+	r.valid = false
+}
+
+// Mul composes the rotation with q, so that q is applied first: the result is
+// the rotation Quat().Mul(q). For an orientation, q is a rotation in the body
+// frame. The quaternion is normalized afterwards, so that rounding errors
+// don't accumulate.
+func (r *CachedRotation) Mul(q QuatQ24) {
+	r.Set(r.Quat().Mul(q).Normalize())
+}
+
+// Integrate rotates by the angular rate gyro (in rad/s, in the body frame)
+// during dt seconds, using QuatFromSmallAngles. The time step should be small
+// enough that the rotation during one step is below about 0.01 radians.
+func (r *CachedRotation) Integrate(gyro Vec3Q24, dt Q24) {
+	r.Mul(QuatFromSmallAngles(gyro.X.MulRound(dt), gyro.Y.MulRound(dt), gyro.Z.MulRound(dt)))
+}
+
+// Mat3 returns the rotation matrix, building it if the rotation changed since
+// the last call.
+func (r *CachedRotation) Mat3() Mat3Q24 {
+	if !r.valid {
+		r.m = r.Quat().Mat3()
+		r.valid = true
+	}
+	return r.m
+}
+
+// Rotate returns v rotated by this rotation, using the matrix.
+func (r *CachedRotation) Rotate(v Vec3Q24) Vec3Q24 {
+	m := r.Mat3()
+	return m.MulVec(v)
+}
+
+// RotateInv returns v rotated by the inverse of this rotation, using the
+// transposed matrix.
+func (r *CachedRotation) RotateInv(v Vec3Q24) Vec3Q24 {
+	m := r.Mat3()
+	x, y, z := int64(v.X.N), int64(v.Y.N), int64(v.Z.N)
+	return Vec3Q24{
+		Q24{int32((int64(m[0].N)*x + int64(m[1].N)*y + int64(m[2].N)*z + 1<<23) >> 24)},
+		Q24{int32((int64(m[3].N)*x + int64(m[4].N)*y + int64(m[5].N)*z + 1<<23) >> 24)},
+		Q24{int32((int64(m[6].N)*x + int64(m[7].N)*y + int64(m[8].N)*z + 1<<23) >> 24)},
+	}
+}
+
+// RotateVec3s rotates all vectors in src by this rotation and stores them in
+// dst, like the function RotateVec3s but using the matrix.
+func (r *CachedRotation) RotateVec3s(dst, src []Vec3Q24) {
+	m := r.Mat3()
+	dst = dst[:len(src)]
+	for i, v := range src {
+		dst[i] = m.MulVec(v)
+	}
+}
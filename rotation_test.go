@@ -0,0 +1,61 @@
+package fixpoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachedRotation(t *testing.T) {
+	// The zero value is the identity.
+	var r CachedRotation
+	v := Vec3Q24FromFloat(0.3, -0.5, 0.8)
+	assert.Equal(t, QuatIdent(), r.Quat())
+	assert.Equal(t, Mat3Ident(), r.Mat3())
+	assert.Equal(t, v, r.Rotate(v))
+
+	// Rotating with the matrix gives the same result as with the quaternion,
+	// apart from rounding.
+	q := QuatFromAxisAngle(Vec3Q24FromFloat(1, 2, -2), Q24FromFloat(1.1))
+	r.Set(q)
+	assert.Equal(t, q, r.Quat())
+	assert.Equal(t, q.Mat3(), r.Mat3())
+	assertVec3Near(t, q.Rotate(v), r.Rotate(v), 4)
+	assertVec3Near(t, q.RotateInv(v), r.RotateInv(v), 4)
+	assertVec3Near(t, v, r.RotateInv(r.Rotate(v)), 8)
+
+	// Updates are applied to the quaternion, and the matrix follows.
+	q2 := QuatFromAxisAngle(Vec3Q24FromFloat(0, 0, 1), Q24FromFloat(0.2))
+	r.Mul(q2)
+	assert.Equal(t, q.Mul(q2).Normalize(), r.Quat())
+	assert.Equal(t, r.Quat().Mat3(), r.Mat3())
+
+	// Integrating a constant rate for a second rotates by the rate.
+	r.Set(QuatIdent())
+	gyro := Vec3Q24FromFloat(0, 0.5, 0)
+	dt := Q24FromFloat(0.001)
+	for i := 0; i < 1000; i++ {
+		r.Integrate(gyro, dt)
+	}
+	axis, angle := r.Quat().ToAxisAngle()
+	assert.InDelta(t, 0.5, angle.Float64(), 1e-4)
+	assert.InDelta(t, 1, axis.Y.Float64(), 1e-4)
+	assertVec3Near(t, r.Quat().Rotate(v), r.Rotate(v), 4)
+
+	// A batch of vectors.
+	src := []Vec3Q24{v, v.Neg(), Vec3Q24FromFloat(1, 0, 0)}
+	dst := make([]Vec3Q24, len(src))
+	r.RotateVec3s(dst, src)
+	for i := range src {
+		assert.Equal(t, r.Rotate(src[i]), dst[i])
+	}
+}
+
+// assertVec3Near checks that two vectors differ at most ulps in each element.
+func assertVec3Near(t *testing.T, expected, actual Vec3Q24, ulps int32) {
+	t.Helper()
+	d := expected.Sub(actual)
+	if d.X.Abs().N > ulps || d.Y.Abs().N > ulps || d.Z.Abs().N > ulps {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
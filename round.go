@@ -0,0 +1,129 @@
+package fixpoint
+
+import (
+	"math"
+)
+
+// RoundingMode selects how a float is rounded when it is converted to fixed
+// point. Q24FromFloat truncates (RoundTowardZero) and Q24FromFloat64 rounds to
+// the nearest value (RoundNearest); the constructors in this file let the
+// caller choose. Rounding to the nearest value is usually what you want for
+// vectors, quaternions and matrices: truncation moves each element towards
+// zero by up to a whole ULP, so elements that are nearly equal as floats (such
+// as the elements of (1, 1, 1)/√3 after normalizing in float32) can end up
+// different in fixed point, which breaks symmetry.
+type RoundingMode int8
+
+const (
+	// RoundTowardZero truncates, like Q24FromFloat.
+	RoundTowardZero RoundingMode = iota
+
+	// RoundNearest rounds to the nearest value, with ties rounded up
+	// (towards positive infinity), like Q24FromFloat64.
+	RoundNearest
+
+	// RoundNearestEven rounds to the nearest value, with ties rounded to the
+	// value with an even underlying integer. It doesn't introduce a bias for
+	// values that are exactly halfway.
+	RoundNearestEven
+
+	// RoundDown rounds towards negative infinity.
+	RoundDown
+
+	// RoundUp rounds towards positive infinity.
+	RoundUp
+)
+
+// Q24FromFloat64Round converts a float64 to fixed point, rounded according to
+// the given mode. Like Q24FromFloat64, the result is undefined for values
+// outside the range of Q24.
+func Q24FromFloat64Round(x float64, mode RoundingMode) Q24 {
+	x *= 1 << 24
+	switch mode {
+	case RoundNearest:
+		x = math.Floor(x + 0.5)
+	case RoundNearestEven:
+		x = math.RoundToEven(x)
+	case RoundDown:
+		x = math.Floor(x)
+	case RoundUp:
+		x = math.Ceil(x)
+	default:
+		x = math.Trunc(x)
+	}
+	return Q24{int32(x)}
+}
+
+// Vec2Q24FromFloatRound is like Vec2Q24FromFloat, but rounds each element
+// according to the given mode.
+func Vec2Q24FromFloatRound(x, y float32, mode RoundingMode) Vec2Q24 {
+	return Vec2Q24{Q24FromFloat64Round(float64(x), mode), Q24FromFloat64Round(float64(y), mode)}
+}
+
+// Vec3Q24FromFloatRound is like Vec3Q24FromFloat, but rounds each element
+// according to the given mode.
+func Vec3Q24FromFloatRound(x, y, z float32, mode RoundingMode) Vec3Q24 {
+	return Vec3Q24{Q24FromFloat64Round(float64(x), mode), Q24FromFloat64Round(float64(y), mode), Q24FromFloat64Round(float64(z), mode)}
+}
+
+// QuatQ24FromFloatRound returns the quaternion with the given elements, each
+// rounded according to the given mode. It doesn't normalize the quaternion.
+func QuatQ24FromFloatRound(w, x, y, z float32, mode RoundingMode) QuatQ24 {
+	return QuatQ24{Q24FromFloat64Round(float64(w), mode), Vec3Q24FromFloatRound(x, y, z, mode)}
+}
+
+// Mat3Q24FromFloatRound returns the matrix with the given elements (in
+// column-major order, like Mat3Q24), each rounded according to the given
+// mode.
+func Mat3Q24FromFloatRound(m [9]float32, mode RoundingMode) Mat3Q24 {
+	var result Mat3Q24
+	for i, e := range m {
+		result[i] = Q24FromFloat64Round(float64(e), mode)
+	}
+	return result
+}
+
+// Mat4Q24FromFloatRound returns the matrix with the given elements (in
+// column-major order, like Mat4Q24), each rounded according to the given
+// mode.
+func Mat4Q24FromFloatRound(m [16]float32, mode RoundingMode) Mat4Q24 {
+	var result Mat4Q24
+	for i, e := range m {
+		result[i] = Q24FromFloat64Round(float64(e), mode)
+	}
+	return result
+}
+
+// Vec2Q24FromFloatNormalized returns the unit vector in the direction of
+// (x, y). The vector is normalized in float64 and each element is then
+// rounded to the nearest value, so the result is within a ULP of unit length:
+// unlike Vec2Q24FromFloat(x, y).Normalize(), which rounds twice. The zero
+// vector is returned as is.
+func Vec2Q24FromFloatNormalized(x, y float64) Vec2Q24 {
+	l := math.Sqrt(x*x + y*y)
+	if l == 0 {
+		return Vec2Q24{}
+	}
+	return Vec2Q24{Q24FromFloat64(x / l), Q24FromFloat64(y / l)}
+}
+
+// Vec3Q24FromFloatNormalized returns the unit vector in the direction of
+// (x, y, z), like Vec2Q24FromFloatNormalized.
+func Vec3Q24FromFloatNormalized(x, y, z float64) Vec3Q24 {
+	l := math.Sqrt(x*x + y*y + z*z)
+	if l == 0 {
+		return Vec3Q24{}
+	}
+	return Vec3Q24{Q24FromFloat64(x / l), Q24FromFloat64(y / l), Q24FromFloat64(z / l)}
+}
+
+// QuatQ24FromFloatNormalized returns the unit quaternion with the given
+// elements scaled to unit length, like Vec2Q24FromFloatNormalized. The zero
+// quaternion is returned as is.
+func QuatQ24FromFloatNormalized(w, x, y, z float64) QuatQ24 {
+	l := math.Sqrt(w*w + x*x + y*y + z*z)
+	if l == 0 {
+		return QuatQ24{}
+	}
+	return QuatQ24{Q24FromFloat64(w / l), Vec3Q24{Q24FromFloat64(x / l), Q24FromFloat64(y / l), Q24FromFloat64(z / l)}}
+}
@@ -0,0 +1,90 @@
+package fixpoint
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQ24FromFloat64Round(t *testing.T) {
+	tests := []struct {
+		x                                    float64
+		zero, nearest, nearestEven, down, up int32
+	}{
+		{0.1, 1677721, 1677722, 1677722, 1677721, 1677722},
+		{-0.1, -1677721, -1677722, -1677722, -1677722, -1677721},
+		{2.5 / (1 << 24), 2, 3, 2, 2, 3},
+		{-2.5 / (1 << 24), -2, -2, -2, -3, -2},
+		{3.5 / (1 << 24), 3, 4, 4, 3, 4},
+		{1, 1 << 24, 1 << 24, 1 << 24, 1 << 24, 1 << 24},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, Q24{tc.zero}, Q24FromFloat64Round(tc.x, RoundTowardZero), "x=%g", tc.x)
+		assert.Equal(t, Q24{tc.nearest}, Q24FromFloat64Round(tc.x, RoundNearest), "x=%g", tc.x)
+		assert.Equal(t, Q24{tc.nearestEven}, Q24FromFloat64Round(tc.x, RoundNearestEven), "x=%g", tc.x)
+		assert.Equal(t, Q24{tc.down}, Q24FromFloat64Round(tc.x, RoundDown), "x=%g", tc.x)
+		assert.Equal(t, Q24{tc.up}, Q24FromFloat64Round(tc.x, RoundUp), "x=%g", tc.x)
+
+		// The default modes of the existing constructors.
+		assert.Equal(t, Q24FromFloat64(tc.x), Q24FromFloat64Round(tc.x, RoundNearest), "x=%g", tc.x)
+	}
+	assert.Equal(t, Q24FromFloat(0.3), Q24FromFloat64Round(float64(float32(0.3)), RoundTowardZero))
+}
+
+func TestFromFloatRound(t *testing.T) {
+	// Two elements that are slightly below and above the same fixed point
+	// value end up one ULP apart when truncated, but equal when rounded.
+	n := Q24FromFloat(0.2).N + 1000
+	below, above := float32((float64(n)-0.25)/(1<<24)), float32((float64(n)+0.25)/(1<<24))
+	v := Vec3Q24FromFloat(below, above, above)
+	assert.NotEqual(t, v.X, v.Y)
+	v = Vec3Q24FromFloatRound(below, above, above, RoundNearest)
+	assert.Equal(t, Vec3Q24{Q24{n}, Q24{n}, Q24{n}}, v)
+
+	assert.Equal(t, Vec2Q24{Q24{n}, Q24{n}}, Vec2Q24FromFloatRound(below, above, RoundNearestEven))
+	assert.Equal(t, Vec2Q24{Q24{n - 1}, Q24{n}}, Vec2Q24FromFloatRound(below, above, RoundDown))
+	assert.Equal(t, QuatQ24{Q24{n}, Vec3Q24{Q24{n}, Q24{n + 1}, Q24{-n}}}, QuatQ24FromFloatRound(below, above, above+1.0/(1<<24), -below, RoundNearest))
+
+	m3 := Mat3Q24FromFloatRound([9]float32{1, 0, 0, 0, below, 0, 0, 0, -above}, RoundNearest)
+	assert.Equal(t, Mat3Q24{Q24FromInt32(1), {}, {}, {}, Q24{n}, {}, {}, {}, Q24{-n}}, m3)
+	m4 := Mat4Q24FromFloatRound([16]float32{0: 1, 5: 1, 10: 1, 12: below, 15: 1}, RoundUp)
+	expected := Mat4Ident()
+	expected[12] = Q24{n}
+	assert.Equal(t, expected, m4)
+}
+
+func TestFromFloatNormalized(t *testing.T) {
+	// The result is within a ULP of unit length.
+	r := rand.New(rand.NewSource(1))
+	unit := int64(1) << 48
+	for i := 0; i < 1000; i++ {
+		x, y, z, w := r.NormFloat64(), r.NormFloat64(), r.NormFloat64(), r.NormFloat64()
+		v := Vec3Q24FromFloatNormalized(x, y, z)
+		if d := abs64(int64(v.len2Q48()) - unit); d > 2<<24 {
+			t.Errorf("(%g, %g, %g): length %v too far from 1", x, y, z, v.Len())
+		}
+		q := QuatQ24FromFloatNormalized(w, x, y, z)
+		if d := abs64(int64(q.len2Q48()) - unit); d > 2<<24 {
+			t.Errorf("(%g, %g, %g, %g): length %v too far from 1", w, x, y, z, q.Len())
+		}
+		v2 := Vec2Q24FromFloatNormalized(x, y)
+		if d := abs64(int64(v2.len2Q48()) - unit); d > 2<<24 {
+			t.Errorf("(%g, %g): length %v too far from 1", x, y, v2.Len())
+		}
+	}
+
+	// Equal elements stay equal.
+	v := Vec3Q24FromFloatNormalized(1, 1, 1)
+	assert.Equal(t, Q24FromFloat64(1/math.Sqrt(3)), v.X)
+	assert.Equal(t, v.X, v.Y)
+	assert.Equal(t, v.X, v.Z)
+	assert.Equal(t, QuatQ24{Q24FromFloat64(0.5), Vec3Q24{Q24FromFloat64(0.5), Q24FromFloat64(-0.5), Q24FromFloat64(0.5)}}, QuatQ24FromFloatNormalized(2, 2, -2, 2))
+	assert.Equal(t, Vec2Q24{Q24FromFloat64(0.6), Q24FromFloat64(-0.8)}, Vec2Q24FromFloatNormalized(3, -4))
+
+	// Zero stays zero.
+	assert.Equal(t, Vec2Q24{}, Vec2Q24FromFloatNormalized(0, 0))
+	assert.Equal(t, Vec3Q24{}, Vec3Q24FromFloatNormalized(0, 0, 0))
+	assert.Equal(t, QuatQ24{}, QuatQ24FromFloatNormalized(0, 0, 0, 0))
+}
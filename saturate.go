@@ -0,0 +1,184 @@
+package fixpoint
+
+// Saturating and overflow-checked arithmetic. The regular operations silently
+// wrap around on overflow, which is fast but can lead to very surprising
+// results. The saturating operations clamp the result to the representable
+// range instead, and the checked operations report whether an overflow
+// happened.
+
+// Range of the underlying integer of a Q24.
+const (
+	maxN = 1<<31 - 1
+	minN = -1 << 31
+)
+
+// saturate clamps n to the range of a Q24.
+func saturate(n int64) Q24 {
+	if n > maxN {
+		return Q24{maxN}
+	}
+	if n < minN {
+		return Q24{minN}
+	}
+	return Q24{int32(n)}
+}
+
+// checked returns n as a Q24 (wrapped around on overflow) and whether it fits.
+func checked(n int64) (Q24, bool) {
+	return Q24{int32(n)}, n >= minN && n <= maxN
+}
+
+// mul64 returns the product of two Q24 numbers as a Q24 in an int64, without
+// overflow.
+func mul64(q1, q2 Q24) int64 {
+	return (int64(q1.N) * int64(q2.N)) >> 24
+}
+
+// mulRound64 is like mul64, but rounds to the nearest value like MulRound.
+func mulRound64(q1, q2 Q24) int64 {
+	return (int64(q1.N)*int64(q2.N) + 1<<23) >> 24
+}
+
+// div64 returns the quotient of two Q24 numbers as a Q24 in an int64. Division
+// by zero results in a number that is out of range (with the sign of the
+// dividend), or zero if the dividend is zero as well.
+func div64(q1, q2 Q24) int64 {
+	if q2.N == 0 {
+		if q1.N > 0 {
+			return maxN + 1
+		}
+		if q1.N < 0 {
+			return minN - 1
+		}
+		return 0
+	}
+	return (int64(q1.N) << 24) / int64(q2.N)
+}
+
+// AddSat returns the argument plus this number, saturated to the
+// representable range.
+func (q1 Q24) AddSat(q2 Q24) Q24 {
+	return saturate(int64(q1.N) + int64(q2.N))
+}
+
+// SubSat returns the argument minus this number, saturated to the
+// representable range.
+func (q1 Q24) SubSat(q2 Q24) Q24 {
+	return saturate(int64(q1.N) - int64(q2.N))
+}
+
+// NegSat returns the inverse of this number, saturated to the representable
+// range. It only differs from Neg for the smallest representable number.
+func (q1 Q24) NegSat() Q24 {
+	return saturate(-int64(q1.N))
+}
+
+// MulSat returns this number multiplied by the argument, saturated to the
+// representable range.
+func (q1 Q24) MulSat(q2 Q24) Q24 {
+	return saturate(mul64(q1, q2))
+}
+
+// DivSat returns this number divided by the argument, saturated to the
+// representable range. Division by zero saturates as well (0/0 returns 0).
+func (q1 Q24) DivSat(q2 Q24) Q24 {
+	return saturate(div64(q1, q2))
+}
+
+// AddChecked returns the argument plus this number, and whether the result
+// fits in a Q24. On overflow, the result is the same as Add.
+func (q1 Q24) AddChecked(q2 Q24) (Q24, bool) {
+	return checked(int64(q1.N) + int64(q2.N))
+}
+
+// SubChecked returns the argument minus this number, and whether the result
+// fits in a Q24. On overflow, the result is the same as Sub.
+func (q1 Q24) SubChecked(q2 Q24) (Q24, bool) {
+	return checked(int64(q1.N) - int64(q2.N))
+}
+
+// MulChecked returns this number multiplied by the argument, and whether the
+// result fits in a Q24. On overflow, the result is the same as Mul.
+func (q1 Q24) MulChecked(q2 Q24) (Q24, bool) {
+	return checked(mul64(q1, q2))
+}
+
+// DivChecked returns this number divided by the argument, and whether the
+// result fits in a Q24. Division by zero is reported as an overflow instead of
+// causing a panic.
+func (q1 Q24) DivChecked(q2 Q24) (Q24, bool) {
+	if q2.N == 0 {
+		return Q24{}, false
+	}
+	return checked(div64(q1, q2))
+}
+
+// AddSat returns this vector added to the argument, saturating each element to
+// the representable range.
+func (v1 Vec3Q24) AddSat(v2 Vec3Q24) Vec3Q24 {
+	return Vec3Q24{v1.X.AddSat(v2.X), v1.Y.AddSat(v2.Y), v1.Z.AddSat(v2.Z)}
+}
+
+// MulSat returns this vector multiplied by the argument, saturating each
+// element to the representable range.
+func (v1 Vec3Q24) MulSat(c Q24) Vec3Q24 {
+	return Vec3Q24{v1.X.MulSat(c), v1.Y.MulSat(c), v1.Z.MulSat(c)}
+}
+
+// DotSat returns the dot product between this vector and the argument,
+// saturated to the representable range. Intermediate results don't overflow.
+func (v1 Vec3Q24) DotSat(v2 Vec3Q24) Q24 {
+	return saturate(v1.dot64(v2))
+}
+
+// CrossSat returns the cross product between this vector and the argument,
+// saturating each element to the representable range.
+func (v1 Vec3Q24) CrossSat(v2 Vec3Q24) Vec3Q24 {
+	x, y, z := v1.cross64(v2)
+	return Vec3Q24{saturate(x), saturate(y), saturate(z)}
+}
+
+func (v1 Vec3Q24) dot64(v2 Vec3Q24) int64 {
+	return mulRound64(v1.X, v2.X) + mulRound64(v1.Y, v2.Y) + mulRound64(v1.Z, v2.Z)
+}
+
+func (v1 Vec3Q24) cross64(v2 Vec3Q24) (x, y, z int64) {
+	x = mulRound64(v1.Y, v2.Z) - mulRound64(v1.Z, v2.Y)
+	y = mulRound64(v1.Z, v2.X) - mulRound64(v1.X, v2.Z)
+	z = mulRound64(v1.X, v2.Y) - mulRound64(v1.Y, v2.X)
+	return
+}
+
+// MulSat returns this quaternion multiplied by the argument, saturating each
+// element to the representable range. Intermediate results don't overflow.
+func (q1 QuatQ24) MulSat(q2 QuatQ24) QuatQ24 {
+	x, y, z := q1.V.cross64(q2.V)
+	return QuatQ24{
+		saturate(mulRound64(q1.W, q2.W) - q1.V.dot64(q2.V)),
+		Vec3Q24{
+			saturate(x + mulRound64(q2.V.X, q1.W) + mulRound64(q1.V.X, q2.W)),
+			saturate(y + mulRound64(q2.V.Y, q1.W) + mulRound64(q1.V.Y, q2.W)),
+			saturate(z + mulRound64(q2.V.Z, q1.W) + mulRound64(q1.V.Z, q2.W)),
+		},
+	}
+}
+
+// RotateSat returns the vector from the argument rotated by the rotation this
+// quaternion represents, saturating each element of the result to the
+// representable range. For a unit quaternion, this only makes a difference
+// when rounding errors push an element over the edge of the representable
+// range.
+func (q1 QuatQ24) RotateSat(v Vec3Q24) Vec3Q24 {
+	// v + 2q_w * (q_v x v) + 2q_v x (q_v x v)
+	// The factor 2 is applied before the shift, so that the result is the
+	// same as Rotate when there is no overflow.
+	mul2 := func(q1, q2 Q24) int64 {
+		return (2*int64(q1.N)*int64(q2.N) + 1<<23) >> 24
+	}
+	cross := q1.V.CrossSat(v)
+	return Vec3Q24{
+		saturate(int64(v.X.N) + mul2(cross.X, q1.W) + mul2(q1.V.Y, cross.Z) - mul2(q1.V.Z, cross.Y)),
+		saturate(int64(v.Y.N) + mul2(cross.Y, q1.W) + mul2(q1.V.Z, cross.X) - mul2(q1.V.X, cross.Z)),
+		saturate(int64(v.Z.N) + mul2(cross.Z, q1.W) + mul2(q1.V.X, cross.Y) - mul2(q1.V.Y, cross.X)),
+	}
+}
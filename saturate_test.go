@@ -0,0 +1,86 @@
+package fixpoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaturate(t *testing.T) {
+	max := Q24{maxN}
+	min := Q24{minN}
+	big := Q24FromInt32(100)
+
+	assert.Equal(t, Q24FromInt32(3), Q24FromInt32(1).AddSat(Q24FromInt32(2)))
+	assert.Equal(t, max, big.AddSat(big))
+	assert.Equal(t, min, big.Neg().AddSat(big.Neg()))
+	assert.Equal(t, Q24FromInt32(-1), Q24FromInt32(1).SubSat(Q24FromInt32(2)))
+	assert.Equal(t, min, big.Neg().SubSat(big))
+	assert.Equal(t, max, min.NegSat())
+	assert.Equal(t, big.Neg(), big.NegSat())
+	assert.Equal(t, Q24FromInt32(6), Q24FromInt32(2).MulSat(Q24FromInt32(3)))
+	assert.Equal(t, max, big.MulSat(big))
+	assert.Equal(t, min, big.MulSat(big.Neg()))
+	assert.Equal(t, Q24FromFloat(0.5), Q24FromInt32(1).DivSat(Q24FromInt32(2)))
+	assert.Equal(t, max, big.DivSat(Q24FromFloat(0.5)))
+	assert.Equal(t, max, big.DivSat(Q24{}))
+	assert.Equal(t, min, big.Neg().DivSat(Q24{}))
+	assert.Equal(t, Q24{}, Q24{}.DivSat(Q24{}))
+}
+
+func TestChecked(t *testing.T) {
+	big := Q24FromInt32(100)
+	check := func(expected Q24, expectedOk bool, actual Q24, ok bool) {
+		t.Helper()
+		assert.Equal(t, expectedOk, ok)
+		if expectedOk {
+			assert.Equal(t, expected, actual)
+		}
+	}
+	q, ok := big.AddChecked(Q24FromInt32(1))
+	check(Q24FromInt32(101), true, q, ok)
+	q, ok = big.AddChecked(big)
+	check(big.Add(big), false, q, ok)
+	q, ok = big.Neg().SubChecked(big)
+	check(Q24{}, false, q, ok)
+	q, ok = Q24FromInt32(10).MulChecked(Q24FromInt32(12))
+	check(Q24FromInt32(120), true, q, ok)
+	q, ok = Q24FromInt32(12).MulChecked(Q24FromInt32(12))
+	check(Q24{}, false, q, ok)
+	q, ok = Q24FromInt32(3).DivChecked(Q24FromInt32(2))
+	check(Q24FromFloat(1.5), true, q, ok)
+	q, ok = big.DivChecked(Q24{})
+	check(Q24{}, false, q, ok)
+	q, ok = big.DivChecked(Q24FromFloat(0.25))
+	check(Q24{}, false, q, ok)
+}
+
+func TestVec3Sat(t *testing.T) {
+	v1 := Vec3Q24FromFloat(1, -2, 3)
+	v2 := Vec3Q24FromFloat(0.5, 4, -0.25)
+	assert.Equal(t, v1.Add(v2), v1.AddSat(v2))
+	assert.Equal(t, v1.Mul(Q24FromFloat(1.5)), v1.MulSat(Q24FromFloat(1.5)))
+	assert.Equal(t, v1.Dot(v2), v1.DotSat(v2))
+	assert.Equal(t, v1.Cross(v2), v1.CrossSat(v2))
+
+	big := Vec3Q24FromFloat(100, -100, 10)
+	assert.Equal(t, Vec3Q24{Q24{maxN}, Q24{minN}, Q24FromInt32(20)}, big.AddSat(big))
+	assert.Equal(t, Vec3Q24{Q24{maxN}, Q24{minN}, Q24FromInt32(20)}, big.MulSat(Q24FromInt32(2)))
+	assert.Equal(t, Q24{maxN}, big.DotSat(big))
+	assert.Equal(t, Vec3Q24{Q24{minN}, Q24{minN}, Q24{maxN}}, big.CrossSat(Vec3Q24FromFloat(10, 10, 100)))
+}
+
+func TestQuatSat(t *testing.T) {
+	q1 := QuatFromAxisAngle(Vec3Q24FromFloat(1, 2, 3), Q24FromFloat(0.7))
+	q2 := QuatFromEuler(Q24FromFloat(0.1), Q24FromFloat(-1.2), Q24FromFloat(3))
+	assert.Equal(t, q1.Mul(q2), q1.MulSat(q2))
+	v := Vec3Q24FromFloat(1, -2, 3)
+	assert.Equal(t, q1.Rotate(v), q1.RotateSat(v))
+
+	// Rotating a vector near the edge of the representable range.
+	q := QuatFromAxisAngle(Vec3Q24FromFloat(0, 0, 1), Q24FromFloat(0.7))
+	v = Vec3Q24FromFloat(120, 120, 0)
+	rotated := q.RotateSat(v)
+	assert.Equal(t, Q24{maxN}, rotated.Y)
+	assert.InDelta(t, 14.475, rotated.X.Float(), 0.001)
+}
@@ -0,0 +1,217 @@
+package slam
+
+import (
+	"math"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/fixmath"
+)
+
+// LogOdds is the log-odds ln(p/(1-p)) of a probability p in Q8.8 format. Log
+// odds are convenient for occupancy grids: the Bayesian update of a cell with
+// a new measurement is a simple addition, and the values are symmetric around
+// zero (a probability of 0.5).
+type LogOdds int16
+
+// LogOddsFromProbability returns the log-odds of the probability p, which
+// must be in the range (0, 1). It uses floating point, so it is meant to
+// calculate constants, for example the Hit and Miss of an OccupancyGrid.
+func LogOddsFromProbability(p float64) LogOdds {
+	return LogOdds(math.Floor(math.Log(p/(1-p))*256 + 0.5))
+}
+
+// log2eQ24 is log2(e) in Q24 format.
+const log2eQ24 = 24204406
+
+// Probability returns the probability p that corresponds to the log-odds l:
+// p = 1/(1+e^-l).
+func (l LogOdds) Probability() fixpoint.Q24 {
+	if l < 0 {
+		if l == math.MinInt16 {
+			// -l would overflow, and the probability is zero anyway.
+			l++
+		}
+		return fixpoint.Q24{N: 1<<24 - (-l).Probability().N}
+	}
+	// e^-l = 2^(-l·log2(e)), which is at most 1 for a positive l. The
+	// exponent is limited to the range of a Q24, where 2^x is zero anyway.
+	x := -(int64(l)<<16*log2eQ24 + 1<<23) >> 24
+	if x < -64<<24 {
+		x = -64 << 24
+	}
+	e := fixpoint.Exp2(fixpoint.Q24{N: int32(x)})
+	return fixpoint.Q24{N: int32((1<<48 + (1<<24+int64(e.N))/2) / (1<<24 + int64(e.N)))}
+}
+
+// Default log-odds of an OccupancyGrid.
+var (
+	defaultHit   = LogOddsFromProbability(0.7)
+	defaultMiss  = LogOddsFromProbability(0.4)
+	defaultLimit = LogOdds(3.5 * 256)
+)
+
+// OccupancyGrid is a map of the environment as a grid of cells that are
+// either free or occupied by an obstacle, where each cell stores the
+// probability that it is occupied as log-odds. It is updated with range
+// measurements: the cells along the ray from the sensor to the measured point
+// become more likely to be free, and the cell at the point more likely to be
+// occupied. At 2 bytes per cell, a 10m×10m grid with cells of 5cm takes 80kB.
+//
+// The zero value is not usable: Width, Height and Resolution must be set. The
+// cells are allocated on the first update, all at a probability of 0.5.
+type OccupancyGrid struct {
+	// Width and Height are the size of the grid in cells.
+	Width, Height int
+
+	// Resolution is the size of a cell, for example 0.05 for cells of 5cm.
+	Resolution fixpoint.Q24
+
+	// Origin is the position of the corner of cell (0, 0), the cell with the
+	// lowest coordinates.
+	Origin fixpoint.Vec2Q24
+
+	// Hit and Miss are the log-odds added to a cell when a measurement shows
+	// it's occupied or free. Miss must be negative. If they're zero, the
+	// log-odds of the probabilities 0.7 and 0.4 are used.
+	Hit, Miss LogOdds
+
+	// Limit bounds the log-odds of each cell to [-Limit, Limit], so that
+	// the map can still adapt when the environment changes. If it is zero,
+	// 3.5 is used (a probability of about 0.03 to 0.97).
+	Limit LogOdds
+
+	// Cells contains the log-odds of all cells, row by row, starting at cell
+	// (0, 0).
+	Cells []LogOdds
+}
+
+// Cell returns the grid cell that contains the point p, and whether it is
+// inside the grid.
+func (g *OccupancyGrid) Cell(p fixpoint.Vec2Q24) (x, y int, ok bool) {
+	size := int64(g.Resolution.N)
+	x = int(floorDiv(int64(p.X.N)-int64(g.Origin.X.N), size))
+	y = int(floorDiv(int64(p.Y.N)-int64(g.Origin.Y.N), size))
+	return x, y, g.inside(x, y)
+}
+
+// Center returns the position of the center of cell (x, y).
+func (g *OccupancyGrid) Center(x, y int) fixpoint.Vec2Q24 {
+	size := int64(g.Resolution.N)
+	return fixpoint.Vec2Q24{
+		X: fixpoint.Q24{N: fixmath.Saturate(int64(g.Origin.X.N) + int64(x)*size + size/2)},
+		Y: fixpoint.Q24{N: fixmath.Saturate(int64(g.Origin.Y.N) + int64(y)*size + size/2)},
+	}
+}
+
+// LogOdds returns the log-odds that cell (x, y) is occupied. Cells outside
+// the grid are unknown: they have log-odds 0.
+func (g *OccupancyGrid) LogOdds(x, y int) LogOdds {
+	if !g.inside(x, y) || g.Cells == nil {
+		return 0
+	}
+	return g.Cells[y*g.Width+x]
+}
+
+// Probability returns the probability that cell (x, y) is occupied.
+func (g *OccupancyGrid) Probability(x, y int) fixpoint.Q24 {
+	return g.LogOdds(x, y).Probability()
+}
+
+// Update updates cell (x, y) with a measurement that shows it's occupied (hit)
+// or free. Cells outside the grid are ignored.
+func (g *OccupancyGrid) Update(x, y int, hit bool) {
+	if !g.inside(x, y) {
+		return
+	}
+	if g.Cells == nil {
+		g.Cells = make([]LogOdds, g.Width*g.Height)
+	}
+	delta, limit := g.Miss, g.Limit
+	if hit {
+		delta = g.Hit
+		if delta == 0 {
+			delta = defaultHit
+		}
+	} else if delta == 0 {
+		delta = defaultMiss
+	}
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	l := int32(g.Cells[y*g.Width+x]) + int32(delta)
+	if l > int32(limit) {
+		l = int32(limit)
+	} else if l < -int32(limit) {
+		l = -int32(limit)
+	}
+	g.Cells[y*g.Width+x] = LogOdds(l)
+}
+
+// Insert updates the grid with a range measurement from a sensor at position
+// from, which detected an obstacle at position to: the cells on the line
+// between them are updated as free, and the cell at to as occupied. Pass
+// false for hit when the sensor didn't detect anything up to its maximum
+// range at to, so that the last cell is updated as free as well.
+func (g *OccupancyGrid) Insert(from, to fixpoint.Vec2Q24, hit bool) {
+	x0, y0, _ := g.Cell(from)
+	x1, y1, _ := g.Cell(to)
+	Line(x0, y0, x1, y1, func(x, y int) bool {
+		if x == x1 && y == y1 {
+			g.Update(x, y, hit)
+		} else {
+			g.Update(x, y, false)
+		}
+		return true
+	})
+}
+
+// Cast follows the line from the position from to the position to, and
+// returns the first cell with log-odds above the threshold: the obstacle a
+// range sensor would detect, for example to compare a measurement with the
+// map. It returns false if there is no such cell.
+func (g *OccupancyGrid) Cast(from, to fixpoint.Vec2Q24, threshold LogOdds) (x, y int, ok bool) {
+	x0, y0, _ := g.Cell(from)
+	x1, y1, _ := g.Cell(to)
+	Line(x0, y0, x1, y1, func(cx, cy int) bool {
+		if g.LogOdds(cx, cy) > threshold {
+			x, y, ok = cx, cy, true
+			return false
+		}
+		return true
+	})
+	return x, y, ok
+}
+
+// inside returns whether cell (x, y) is inside the grid.
+func (g *OccupancyGrid) inside(x, y int) bool {
+	return x >= 0 && y >= 0 && x < g.Width && y < g.Height
+}
+
+// Line calls fn for each cell on the line from cell (x0, y0) to cell (x1, y1),
+// including both ends, using Bresenham's line algorithm. It stops early when
+// fn returns false.
+func Line(x0, y0, x1, y1 int, fn func(x, y int) bool) {
+	dx, dy := x1-x0, y1-y0
+	sx, sy := 1, 1
+	if dx < 0 {
+		dx, sx = -dx, -1
+	}
+	if dy < 0 {
+		dy, sy = -dy, -1
+	}
+	err := dx - dy
+	for {
+		if !fn(x0, y0) || x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * err
+		if e2 > -dy {
+			err -= dy
+			x0 += sx
+		}
+		if e2 < dx {
+			err += dx
+			y0 += sy
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package slam
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogOdds(t *testing.T) {
+	assert.Equal(t, LogOdds(0), LogOddsFromProbability(0.5))
+	assert.Equal(t, LogOdds(217), LogOddsFromProbability(0.7))
+	assert.Equal(t, LogOdds(-104), LogOddsFromProbability(0.4))
+	for _, l := range []LogOdds{0, 1, -1, 217, -104, 896, -896, 2000, math.MaxInt16, math.MinInt16 + 1, math.MinInt16} {
+		expected := 1 / (1 + math.Exp(-float64(l)/256))
+		if p := l.Probability().Float64(); math.Abs(p-expected) > 1e-6 {
+			t.Errorf("probability of %d: expected %f, got %f", l, expected, p)
+		}
+	}
+}
+
+func TestLine(t *testing.T) {
+	var cells [][2]int
+	collect := func(x, y int) bool {
+		cells = append(cells, [2]int{x, y})
+		return true
+	}
+	Line(0, 0, 4, 2, collect)
+	assert.Equal(t, [][2]int{{0, 0}, {1, 0}, {2, 1}, {3, 1}, {4, 2}}, cells)
+	cells = nil
+	Line(1, 3, 1, -1, collect)
+	assert.Equal(t, [][2]int{{1, 3}, {1, 2}, {1, 1}, {1, 0}, {1, -1}}, cells)
+	cells = nil
+	Line(-2, 2, 0, 0, collect)
+	assert.Equal(t, [][2]int{{-2, 2}, {-1, 1}, {0, 0}}, cells)
+	cells = nil
+	Line(5, 5, 5, 5, collect)
+	assert.Equal(t, [][2]int{{5, 5}}, cells)
+
+	// Stop early.
+	n := 0
+	Line(0, 0, 10, 0, func(x, y int) bool {
+		n++
+		return x < 3
+	})
+	assert.Equal(t, 4, n)
+}
+
+func TestOccupancyGrid(t *testing.T) {
+	// A 2m×2m grid of 10cm cells, centered on the origin.
+	g := OccupancyGrid{
+		Width:      20,
+		Height:     20,
+		Resolution: fixpoint.Q24FromFloat(0.1),
+		Origin:     fixpoint.Vec2Q24FromFloat(-1, -1),
+	}
+	assert.Equal(t, fixpoint.Q24FromFloat(0.5), g.Probability(3, 3))
+	x, y, ok := g.Cell(fixpoint.Vec2Q24FromFloat(0.05, -0.95))
+	assert.Equal(t, []interface{}{10, 0, true}, []interface{}{x, y, ok})
+	_, _, ok = g.Cell(fixpoint.Vec2Q24FromFloat(1.05, 0))
+	assert.False(t, ok)
+	center := g.Center(10, 0)
+	assert.InDelta(t, 0.05, center.X.Float(), 1e-6)
+	assert.InDelta(t, -0.95, center.Y.Float(), 1e-6)
+
+	// A wall at x=0.55 seen a few times from the origin.
+	origin := fixpoint.Vec2Q24{}
+	wall := fixpoint.Vec2Q24FromFloat(0.55, 0.05)
+	for i := 0; i < 3; i++ {
+		g.Insert(origin, wall, true)
+	}
+	assert.Equal(t, 3*LogOddsFromProbability(0.7), g.LogOdds(15, 10))
+	assert.Equal(t, 3*LogOddsFromProbability(0.4), g.LogOdds(12, 10))
+	assert.Equal(t, 3*LogOddsFromProbability(0.4), g.LogOdds(10, 10))
+	assert.Equal(t, LogOdds(0), g.LogOdds(16, 10))
+	assert.True(t, g.Probability(15, 10).N > fixpoint.Q24FromFloat(0.9).N)
+
+	// The log-odds are limited.
+	for i := 0; i < 20; i++ {
+		g.Insert(origin, wall, true)
+	}
+	assert.Equal(t, LogOdds(896), g.LogOdds(15, 10))
+	assert.Equal(t, LogOdds(-896), g.LogOdds(11, 10))
+
+	// A measurement at the maximum range marks everything free, and rays
+	// leaving the grid are clipped.
+	g.Insert(origin, fixpoint.Vec2Q24FromFloat(-3, 0.05), false)
+	assert.Equal(t, LogOddsFromProbability(0.4), g.LogOdds(0, 10))
+
+	// Casting a ray finds the wall.
+	x, y, ok = g.Cast(origin, fixpoint.Vec2Q24FromFloat(0.95, 0.05), 0)
+	assert.Equal(t, []interface{}{15, 10, true}, []interface{}{x, y, ok})
+	_, _, ok = g.Cast(origin, fixpoint.Vec2Q24FromFloat(0.05, 0.95), 0)
+	assert.False(t, ok)
+
+	// Cells outside the grid are unknown and can't be updated.
+	g.Update(-1, 5, true)
+	g.Update(20, 5, true)
+	assert.Equal(t, LogOdds(0), g.LogOdds(-1, 5))
+	assert.Len(t, g.Cells, 400)
+}
@@ -0,0 +1,196 @@
+package slam
+
+import (
+	"sort"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/fixmath"
+)
+
+// ICP aligns two 2D point sets, like two consecutive sweeps of a rotating
+// distance sensor, with the iterative closest point algorithm: it pairs each
+// point of the scan with the closest point of the reference, calculates the
+// rigid transformation that best aligns the pairs (point-to-point, in the
+// least squares sense), and repeats this for a fixed number of iterations.
+// The closest points are found with a grid over the reference points, so that
+// an iteration takes O(n log n) time instead of O(n²).
+//
+// Like every ICP variant, it only converges to the right alignment when the
+// initial guess is close enough, for example from odometry. Because points are
+// paired with points, the result is only accurate to about the spacing of the
+// points: the scan can get stuck one point further along a straight wall.
+//
+// The zero value is not usable: CellSize must be set. The grid and the
+// pairs are kept between calls to Align to avoid allocations.
+type ICP struct {
+	// Iterations is the number of iterations. If it is zero, 10 iterations
+	// are done.
+	Iterations int
+
+	// CellSize is the size of the grid cells used to find the closest
+	// points. Points are only paired when they're in the same or a
+	// neighboring cell, so it should be about the largest expected distance
+	// between two corresponding points.
+	CellSize fixpoint.Q24
+
+	// MaxDistance is the largest distance between paired points, to reject
+	// outliers. If it is zero, CellSize is used.
+	MaxDistance fixpoint.Q24
+
+	cells gridEntries
+	pairs [][2]fixpoint.Vec2Q24
+}
+
+// gridEntry is a reference point sorted into a grid cell.
+type gridEntry struct {
+	key   int64
+	index int
+}
+
+// Align returns the pose that transforms the points of scan (as seen from the
+// pose of the scan) to the frame of the reference points, starting from the
+// initial guess. It also returns the number of point pairs used in the last
+// iteration, as a measure of the quality of the match: a small number means
+// the sets don't overlap much. At least two pairs are needed to calculate an
+// alignment; with fewer, the initial guess is returned.
+func (m *ICP) Align(reference, scan []fixpoint.Vec2Q24, initial Pose) (pose Pose, pairs int) {
+	if m.CellSize.N <= 0 {
+		return initial, 0
+	}
+	m.buildGrid(reference)
+	maxDistance := m.MaxDistance
+	if maxDistance.N <= 0 {
+		maxDistance = m.CellSize
+	}
+	iterations := m.Iterations
+	if iterations <= 0 {
+		iterations = 10
+	}
+
+	pose = initial
+	for iter := 0; iter < iterations; iter++ {
+		// Find the pairs and their centroids.
+		var sumP, sumQ [2]int64
+		m.pairs = m.pairs[:0]
+		for _, s := range scan {
+			p := pose.Transform(s)
+			j := m.closest(reference, p, int64(maxDistance.N))
+			if j < 0 {
+				continue
+			}
+			q := reference[j]
+			sumP[0] += int64(p.X.N)
+			sumP[1] += int64(p.Y.N)
+			sumQ[0] += int64(q.X.N)
+			sumQ[1] += int64(q.Y.N)
+			m.pairs = append(m.pairs, [2]fixpoint.Vec2Q24{p, q})
+		}
+		pairs = len(m.pairs)
+		if pairs < 2 {
+			return pose, pairs
+		}
+		n := int64(pairs)
+		meanP := fixpoint.Vec2Q24{X: fixpoint.Q24{N: int32(divRound(sumP[0], n))}, Y: fixpoint.Q24{N: int32(divRound(sumP[1], n))}}
+		meanQ := fixpoint.Vec2Q24{X: fixpoint.Q24{N: int32(divRound(sumQ[0], n))}, Y: fixpoint.Q24{N: int32(divRound(sumQ[1], n))}}
+
+		// The rotation that best aligns the centered pairs is the angle of
+		// the sum of their complex products q·conj(p). The centered points
+		// are reduced to Q20 so that the products can't overflow.
+		var dot, cross int64
+		for _, pair := range m.pairs {
+			p, q := pair[0], pair[1]
+			px, py := (int64(p.X.N)-int64(meanP.X.N))>>4, (int64(p.Y.N)-int64(meanP.Y.N))>>4
+			qx, qy := (int64(q.X.N)-int64(meanQ.X.N))>>4, (int64(q.Y.N)-int64(meanQ.Y.N))>>4
+			dot += (px*qx + py*qy) >> 16
+			cross += (px*qy - py*qx) >> 16
+		}
+		for fixmath.Abs(dot) >= 1<<30 || fixmath.Abs(cross) >= 1<<30 {
+			dot >>= 1
+			cross >>= 1
+		}
+		angle := fixpoint.Atan2(fixpoint.Q24{N: int32(cross)}, fixpoint.Q24{N: int32(dot)})
+
+		// Rotate around the centroid of the scan and move it onto the
+		// centroid of the reference.
+		delta := Pose{Position: meanQ.Sub(meanP.Rotate(angle)), Heading: angle}
+		if delta == (Pose{}) {
+			break
+		}
+		pose = delta.Compose(pose)
+	}
+	return pose, pairs
+}
+
+// gridEntries implements sort.Interface. The methods are on a pointer so that
+// sorting doesn't allocate.
+type gridEntries []gridEntry
+
+func (g *gridEntries) Len() int           { return len(*g) }
+func (g *gridEntries) Less(i, j int) bool { return (*g)[i].key < (*g)[j].key }
+func (g *gridEntries) Swap(i, j int)      { (*g)[i], (*g)[j] = (*g)[j], (*g)[i] }
+
+// buildGrid sorts the reference points into grid cells.
+func (m *ICP) buildGrid(reference []fixpoint.Vec2Q24) {
+	m.cells = m.cells[:0]
+	for i, p := range reference {
+		cx, cy := m.cell(p)
+		m.cells = append(m.cells, gridEntry{cellKey(cx, cy), i})
+	}
+	sort.Sort(&m.cells)
+}
+
+// closest returns the index of the reference point closest to p in the same
+// or a neighboring grid cell, or -1 if there is none within the maximum
+// distance.
+func (m *ICP) closest(reference []fixpoint.Vec2Q24, p fixpoint.Vec2Q24, maxDist int64) int {
+	cx, cy := m.cell(p)
+	best, bestDist2 := -1, maxDist*maxDist
+	for x := cx - 1; x <= cx+1; x++ {
+		for y := cy - 1; y <= cy+1; y++ {
+			key := cellKey(x, y)
+			i := sort.Search(len(m.cells), func(i int) bool {
+				return m.cells[i].key >= key
+			})
+			for ; i < len(m.cells) && m.cells[i].key == key; i++ {
+				q := reference[m.cells[i].index]
+				dx, dy := int64(q.X.N)-int64(p.X.N), int64(q.Y.N)-int64(p.Y.N)
+				// Points in a neighboring cell can be up to two cells
+				// apart, which would overflow when squared.
+				if fixmath.Abs(dx) > maxDist || fixmath.Abs(dy) > maxDist {
+					continue
+				}
+				if d2 := dx*dx + dy*dy; d2 <= bestDist2 {
+					best, bestDist2 = m.cells[i].index, d2
+				}
+			}
+		}
+	}
+	return best
+}
+
+// cell returns the grid cell of the point p.
+func (m *ICP) cell(p fixpoint.Vec2Q24) (x, y int64) {
+	size := int64(m.CellSize.N)
+	return floorDiv(int64(p.X.N), size), floorDiv(int64(p.Y.N), size)
+}
+
+// cellKey returns a sort key for the grid cell (x, y).
+func cellKey(x, y int64) int64 {
+	return x<<32 | int64(uint32(y))
+}
+
+// floorDiv returns n/d rounded towards negative infinity, for a positive d.
+func floorDiv(n, d int64) int64 {
+	if n < 0 {
+		return -((-n + d - 1) / d)
+	}
+	return n / d
+}
+
+// divRound returns n/d rounded to the nearest integer, for a positive d.
+func divRound(n, d int64) int64 {
+	if n < 0 {
+		return -((-n + d/2) / d)
+	}
+	return (n + d/2) / d
+}
@@ -0,0 +1,87 @@
+package slam
+
+import (
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+// room returns points on the walls of a room with a corner, like a sweep of
+// a distance sensor.
+func room() []fixpoint.Vec2Q24 {
+	var points []fixpoint.Vec2Q24
+	for i := 0; i < 160; i++ {
+		f := float32(i) * 0.025
+		points = append(points,
+			fixpoint.Vec2Q24FromFloat(f-1, 2),      // wall in front
+			fixpoint.Vec2Q24FromFloat(3, f-2),      // wall to the right
+			fixpoint.Vec2Q24FromFloat(-1, f*0.5-2)) // short wall to the left
+	}
+	return points
+}
+
+func TestICP(t *testing.T) {
+	reference := room()
+
+	// The robot moved and turned a bit between the sweeps, so it sees the
+	// room through the inverse of its motion.
+	motion := pose(0.05, -0.03, 0.03)
+	inverse := motion.Inverse()
+	var scan []fixpoint.Vec2Q24
+	for _, p := range reference {
+		scan = append(scan, inverse.Transform(p))
+	}
+
+	// Point-to-point matching is only accurate to about the spacing of the
+	// points, which is 2.5cm.
+	m := ICP{CellSize: fixpoint.Q24FromFloat(0.5), Iterations: 30}
+	found, pairs := m.Align(reference, scan, Pose{})
+	assertPose(t, motion, found, 0.01)
+	assert.Equal(t, len(scan), pairs)
+
+	// With a good initial guess, it converges right away.
+	found, _ = m.Align(reference, scan, motion)
+	assertPose(t, motion, found, 1e-4)
+
+	// Once the buffers have grown, aligning doesn't allocate.
+	allocs := testing.AllocsPerRun(10, func() {
+		m.Align(reference, scan, motion)
+	})
+	if allocs != 0 {
+		t.Errorf("expected Align to reuse its buffers, got %v allocations", allocs)
+	}
+
+	// Without overlap, the initial guess is returned.
+	far := pose(50, 50, 0)
+	found, pairs = m.Align(reference, scan, far)
+	assert.Equal(t, far, found)
+	assert.Equal(t, 0, pairs)
+
+	// The zero value is not configured.
+	var zero ICP
+	found, _ = zero.Align(reference, scan, motion)
+	assert.Equal(t, motion, found)
+}
+
+func TestICPOutliers(t *testing.T) {
+	// Points that only exist in one of the sweeps are not paired.
+	reference := room()
+	scan := append(room(), fixpoint.Vec2Q24FromFloat(10, 10), fixpoint.Vec2Q24FromFloat(-8, 3))
+	m := ICP{CellSize: fixpoint.Q24FromFloat(0.5), MaxDistance: fixpoint.Q24FromFloat(0.2)}
+	found, pairs := m.Align(reference, scan, pose(0.01, 0, 0.005))
+	assert.Equal(t, len(reference), pairs)
+	assertPose(t, Pose{}, found, 0.01)
+}
+
+func TestICPClosestFar(t *testing.T) {
+	// A point two cells away from a reference point in a neighboring cell
+	// is too far away to be paired, even though its squared distance
+	// doesn't fit in an int64.
+	m := ICP{CellSize: fixpoint.Q24FromInt32(100)}
+	reference := []fixpoint.Vec2Q24{fixpoint.Vec2Q24FromFloat(90, 0)}
+	m.buildGrid(reference)
+	p := fixpoint.Vec2Q24FromFloat(-100, 0)
+	assert.Equal(t, -1, m.closest(reference, p, int64(m.CellSize.N)))
+	assert.Equal(t, 0, m.closest(reference, fixpoint.Vec2Q24FromFloat(10, 0), int64(m.CellSize.N)))
+}
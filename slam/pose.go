@@ -0,0 +1,149 @@
+// Package slam implements building blocks for 2D simultaneous localization and
+// mapping on small robots, using fixed point arithmetic: poses and chains of
+// odometry poses, scan matching and occupancy grid maps.
+//
+// Positions are in meters (or any other unit, as long as it is the same
+// everywhere) and angles in radians, counterclockwise from the X axis.
+package slam
+
+import (
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/fixmath"
+)
+
+// Pose is the position and heading of a robot in the plane. It is also used
+// as a transformation, from the frame of the robot to the frame the pose is
+// expressed in: a point in front of the robot is at (d, 0) in robot
+// coordinates.
+type Pose struct {
+	Position fixpoint.Vec2Q24
+	Heading  fixpoint.Q24 // in the range [-π, π)
+}
+
+// Compose returns the pose q (relative to this pose) as a pose in the frame of
+// this pose, for example to add an odometry step q to the current pose p. The
+// heading of the result is wrapped to the range [-π, π).
+func (p Pose) Compose(q Pose) Pose {
+	return Pose{
+		Position: p.Position.Add(q.Position.Rotate(p.Heading)),
+		Heading:  fixmath.WrapAngle(int64(p.Heading.N) + int64(q.Heading.N)),
+	}
+}
+
+// Inverse returns the inverse transformation: the pose of the origin relative
+// to this pose. Composing a pose with its inverse results in the zero pose
+// (apart from rounding).
+func (p Pose) Inverse() Pose {
+	return Pose{
+		Position: p.Position.Neg().Rotate(p.Heading.Neg()),
+		Heading:  fixmath.WrapAngle(-int64(p.Heading.N)),
+	}
+}
+
+// Between returns the pose q relative to this pose, so that
+// p.Compose(p.Between(q)) equals q. This is the relative motion between two
+// poses, for example the measurement of an edge in a pose graph.
+func (p Pose) Between(q Pose) Pose {
+	return Pose{
+		Position: q.Position.Sub(p.Position).Rotate(p.Heading.Neg()),
+		Heading:  fixmath.WrapAngle(int64(q.Heading.N) - int64(p.Heading.N)),
+	}
+}
+
+// Transform returns the point v in the frame of the robot as a point in the
+// frame of the pose, for example to add a LIDAR measurement to a map.
+func (p Pose) Transform(v fixpoint.Vec2Q24) fixpoint.Vec2Q24 {
+	return p.Position.Add(v.Rotate(p.Heading))
+}
+
+// PoseChain is a chain of relative poses, like the steps of wheel odometry,
+// with the absolute pose of each node and how uncertain it is. The
+// uncertainty is modeled as a single variance that grows with the distance
+// and the rotation of each step, which is a coarse but useful weight for
+// experiments with loop closures in an odometry graph.
+//
+// The zero value is an empty chain starting at the origin, without
+// uncertainty.
+type PoseChain struct {
+	// DistanceVariance is the variance added per unit of distance travelled.
+	DistanceVariance fixpoint.Q24
+
+	// RotationVariance is the variance added per radian of rotation.
+	RotationVariance fixpoint.Q24
+
+	poses     []Pose
+	deltas    []Pose
+	variances []int64 // accumulated, in Q24
+}
+
+// Len returns the number of nodes in the chain, including the start.
+func (c *PoseChain) Len() int {
+	if len(c.poses) == 0 {
+		return 1
+	}
+	return len(c.poses)
+}
+
+// Start sets the pose of the first node and removes all other nodes.
+func (c *PoseChain) Start(pose Pose) {
+	c.poses = append(c.poses[:0], pose)
+	c.deltas = append(c.deltas[:0], Pose{})
+	c.variances = append(c.variances[:0], 0)
+}
+
+// Add adds a node at the given pose relative to the last node, and returns its
+// index.
+func (c *PoseChain) Add(delta Pose) int {
+	if len(c.poses) == 0 {
+		c.Start(Pose{})
+	}
+	last := len(c.poses) - 1
+	heading := int64(delta.Heading.N)
+	if heading < 0 {
+		heading = -heading
+	}
+	variance := c.variances[last] +
+		(int64(delta.Position.Len().N)*int64(c.DistanceVariance.N)+1<<23)>>24 +
+		(heading*int64(c.RotationVariance.N)+1<<23)>>24
+	c.poses = append(c.poses, c.poses[last].Compose(delta))
+	c.deltas = append(c.deltas, delta)
+	c.variances = append(c.variances, variance)
+	return last + 1
+}
+
+// Pose returns the absolute pose of node i.
+func (c *PoseChain) Pose(i int) Pose {
+	if len(c.poses) == 0 && i == 0 {
+		return Pose{}
+	}
+	return c.poses[i]
+}
+
+// Delta returns the pose of node i relative to the node before it, as it was
+// added. The delta of the first node is the zero pose.
+func (c *PoseChain) Delta(i int) Pose {
+	if len(c.deltas) == 0 && i == 0 {
+		return Pose{}
+	}
+	return c.deltas[i]
+}
+
+// Variance returns the accumulated variance of node i relative to the start
+// of the chain, saturated to the range of a Q24.
+func (c *PoseChain) Variance(i int) fixpoint.Q24 {
+	if len(c.variances) == 0 && i == 0 {
+		return fixpoint.Q24{}
+	}
+	return fixpoint.Q24{N: fixmath.Saturate(c.variances[i])}
+}
+
+// Relative returns the pose of node j relative to node i and the variance of
+// that relative pose, which is the variance accumulated between the two
+// nodes.
+func (c *PoseChain) Relative(i, j int) (Pose, fixpoint.Q24) {
+	variance := int64(c.Variance(j).N) - int64(c.Variance(i).N)
+	if variance < 0 {
+		variance = -variance
+	}
+	return c.Pose(i).Between(c.Pose(j)), fixpoint.Q24{N: fixmath.Saturate(variance)}
+}
@@ -0,0 +1,74 @@
+package slam
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func assertPose(t *testing.T, expected, actual Pose, delta float64) {
+	t.Helper()
+	assert.InDelta(t, expected.Position.X.Float64(), actual.Position.X.Float64(), delta)
+	assert.InDelta(t, expected.Position.Y.Float64(), actual.Position.Y.Float64(), delta)
+	angle := actual.Heading.Float64() - expected.Heading.Float64()
+	angle = math.Remainder(angle, 2*math.Pi)
+	assert.InDelta(t, 0, angle, delta)
+}
+
+func pose(x, y, heading float64) Pose {
+	return Pose{fixpoint.Vec2Q24{X: fixpoint.Q24FromFloat64(x), Y: fixpoint.Q24FromFloat64(y)}, fixpoint.Q24FromFloat64(heading)}
+}
+
+func TestPose(t *testing.T) {
+	// Facing north (Y) at (1, 2), then driving 1m forward and turning left.
+	p := pose(1, 2, math.Pi/2)
+	step := pose(1, 0, math.Pi/2)
+	assertPose(t, pose(1, 3, math.Pi), p.Compose(step), 1e-6)
+	// One meter to the left of the robot is west.
+	assert.InDelta(t, 0, p.Transform(fixpoint.Vec2Q24FromFloat(0, 1)).X.Float64(), 1e-6)
+	assert.InDelta(t, 2, p.Transform(fixpoint.Vec2Q24FromFloat(0, 1)).Y.Float64(), 1e-6)
+
+	// The heading wraps around.
+	q := pose(0, 0, 3).Compose(pose(0, 0, 1))
+	assert.InDelta(t, 4-2*math.Pi, q.Heading.Float64(), 1e-6)
+
+	// Inverse and Between.
+	a, b := pose(1, 2, 0.5), pose(-3, 0.5, -2.8)
+	assertPose(t, Pose{}, a.Compose(a.Inverse()), 1e-6)
+	assertPose(t, Pose{}, a.Inverse().Compose(a), 1e-6)
+	assertPose(t, b, a.Compose(a.Between(b)), 1e-6)
+	assertPose(t, a.Inverse().Compose(b), a.Between(b), 1e-6)
+}
+
+func TestPoseChain(t *testing.T) {
+	c := PoseChain{DistanceVariance: fixpoint.Q24FromFloat(0.01), RotationVariance: fixpoint.Q24FromFloat(0.1)}
+	assert.Equal(t, 1, c.Len())
+	assert.Equal(t, Pose{}, c.Pose(0))
+	assert.Equal(t, fixpoint.Q24{}, c.Variance(0))
+
+	// Drive around a 2m square: the robot ends up where it started.
+	for i := 0; i < 4; i++ {
+		c.Add(pose(2, 0, 0))
+		assert.Equal(t, 2*i+2, c.Add(pose(0, 0, math.Pi/2)))
+	}
+	assert.Equal(t, 9, c.Len())
+	assertPose(t, pose(2, 0, 0), c.Pose(1), 1e-6)
+	assertPose(t, pose(2, 2, math.Pi), c.Pose(4), 1e-6)
+	assertPose(t, Pose{}, c.Pose(8), 1e-5)
+	assertPose(t, pose(0, 0, math.Pi/2), c.Delta(2), 0)
+
+	// The variance grows with the distance and rotation.
+	assert.InDelta(t, 0.02, c.Variance(1).Float64(), 1e-6)
+	assert.InDelta(t, 4*0.02+4*0.1*math.Pi/2, c.Variance(8).Float64(), 1e-5)
+	rel, variance := c.Relative(8, 4)
+	assertPose(t, pose(2, 2, math.Pi), rel, 1e-5)
+	assert.InDelta(t, 2*0.02+2*0.1*math.Pi/2, variance.Float64(), 1e-5)
+
+	// Start over somewhere else.
+	c.Start(pose(5, 5, 1))
+	assert.Equal(t, 1, c.Len())
+	c.Add(pose(1, 0, 0))
+	assertPose(t, pose(5+math.Cos(1), 5+math.Sin(1), 1), c.Pose(1), 1e-6)
+}
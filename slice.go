@@ -0,0 +1,99 @@
+package fixpoint
+
+// Operations on slices of numbers and vectors, for processing buffered data
+// such as sensor samples. They write to a destination slice provided by the
+// caller so that they don't allocate, and they avoid the overhead of a method
+// call per element. All slices must have the same length. The destination may
+// be one of the source slices.
+
+// AddQ24s stores the element-wise sum of a and b in dst.
+func AddQ24s(dst, a, b []Q24) {
+	b = b[:len(a)]
+	dst = dst[:len(a)]
+	for i := range a {
+		dst[i].N = a[i].N + b[i].N
+	}
+}
+
+// SubQ24s stores the element-wise difference a - b in dst.
+func SubQ24s(dst, a, b []Q24) {
+	b = b[:len(a)]
+	dst = dst[:len(a)]
+	for i := range a {
+		dst[i].N = a[i].N - b[i].N
+	}
+}
+
+// ScaleQ24s stores each element of a multiplied by c in dst, rounded to the
+// nearest value.
+func ScaleQ24s(dst, a []Q24, c Q24) {
+	dst = dst[:len(a)]
+	for i := range a {
+		dst[i].N = int32((int64(a[i].N)*int64(c.N) + 1<<23) >> 24)
+	}
+}
+
+// AddVec3s stores the element-wise sum of a and b in dst.
+func AddVec3s(dst, a, b []Vec3Q24) {
+	b = b[:len(a)]
+	dst = dst[:len(a)]
+	for i := range a {
+		dst[i] = Vec3Q24{
+			Q24{a[i].X.N + b[i].X.N},
+			Q24{a[i].Y.N + b[i].Y.N},
+			Q24{a[i].Z.N + b[i].Z.N},
+		}
+	}
+}
+
+// SubVec3s stores the element-wise difference a - b in dst.
+func SubVec3s(dst, a, b []Vec3Q24) {
+	b = b[:len(a)]
+	dst = dst[:len(a)]
+	for i := range a {
+		dst[i] = Vec3Q24{
+			Q24{a[i].X.N - b[i].X.N},
+			Q24{a[i].Y.N - b[i].Y.N},
+			Q24{a[i].Z.N - b[i].Z.N},
+		}
+	}
+}
+
+// ScaleVec3s stores each vector of a multiplied by c in dst. It gives the same
+// result as calling Mul on each vector.
+func ScaleVec3s(dst, a []Vec3Q24, c Q24) {
+	dst = dst[:len(a)]
+	s := int64(c.N)
+	for i := range a {
+		dst[i] = Vec3Q24{
+			Q24{int32((int64(a[i].X.N)*s + 1<<23) >> 24)},
+			Q24{int32((int64(a[i].Y.N)*s + 1<<23) >> 24)},
+			Q24{int32((int64(a[i].Z.N)*s + 1<<23) >> 24)},
+		}
+	}
+}
+
+// DotVec3s stores the dot product of each pair of vectors from a and b in dst.
+// The products are summed in an int64 and rounded once, which is slightly more
+// accurate than Dot.
+func DotVec3s(dst []Q24, a, b []Vec3Q24) {
+	b = b[:len(a)]
+	dst = dst[:len(a)]
+	for i := range a {
+		v1, v2 := a[i], b[i]
+		sum := int64(v1.X.N)*int64(v2.X.N) + int64(v1.Y.N)*int64(v2.Y.N) + int64(v1.Z.N)*int64(v2.Z.N)
+		dst[i].N = int32((sum + 1<<23) >> 24)
+	}
+}
+
+// RotateVec3s stores each vector of src rotated by the quaternion q in dst. The
+// quaternion is converted to a rotation matrix once, which makes this a lot
+// faster than calling q.Rotate for each vector. The result may differ from
+// Rotate in the least significant bits.
+func RotateVec3s(dst, src []Vec3Q24, q QuatQ24) {
+	m := q.Mat3()
+	dst = dst[:len(src)]
+	for i := range src {
+		dst[i] = m.MulVec(src[i])
+	}
+}
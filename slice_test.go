@@ -0,0 +1,64 @@
+package fixpoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSliceQ24(t *testing.T) {
+	a := []Q24{Q24FromFloat(1), Q24FromFloat(-2.5), Q24{3}}
+	b := []Q24{Q24FromFloat(0.5), Q24FromFloat(4), Q24{-1}}
+	dst := make([]Q24, 3)
+	AddQ24s(dst, a, b)
+	assert.Equal(t, []Q24{Q24FromFloat(1.5), Q24FromFloat(1.5), Q24{2}}, dst)
+	SubQ24s(dst, a, b)
+	assert.Equal(t, []Q24{Q24FromFloat(0.5), Q24FromFloat(-6.5), Q24{4}}, dst)
+	ScaleQ24s(dst, a, Q24FromFloat(0.5))
+	assert.Equal(t, []Q24{Q24FromFloat(0.5), Q24FromFloat(-1.25), Q24{2}}, dst)
+
+	// The destination may be a source.
+	AddQ24s(a, a, a)
+	assert.Equal(t, []Q24{Q24FromFloat(2), Q24FromFloat(-5), Q24{6}}, a)
+}
+
+func TestSliceVec3(t *testing.T) {
+	a := []Vec3Q24{Vec3Q24FromFloat(1, -2, 3.5), Vec3Q24FromFloat(0, 0.25, -1)}
+	b := []Vec3Q24{Vec3Q24FromFloat(0.5, 4, -1), Vec3Q24{Q24{1}, Q24{-3}, Q24{5}}}
+	dst := make([]Vec3Q24, 2)
+
+	AddVec3s(dst, a, b)
+	for i := range a {
+		assert.Equal(t, a[i].Add(b[i]), dst[i])
+	}
+	SubVec3s(dst, a, b)
+	for i := range a {
+		assert.Equal(t, a[i].Sub(b[i]), dst[i])
+	}
+	ScaleVec3s(dst, b, Q24FromFloat(-0.5))
+	for i := range a {
+		assert.Equal(t, b[i].Mul(Q24FromFloat(-0.5)), dst[i])
+	}
+
+	dots := make([]Q24, 2)
+	DotVec3s(dots, a, b)
+	assert.Equal(t, Q24FromFloat(-11), dots[0])
+	assert.Equal(t, Q24{-6}, dots[1]) // -0.75-5 = -5.75, rounded once
+}
+
+func TestRotateVec3s(t *testing.T) {
+	q := QuatFromAxisAngle(Vec3Q24FromFloat(1, 2, 3).Normalize(), Q24FromFloat(0.8))
+	src := make([]Vec3Q24, 50)
+	for i := range src {
+		f := float32(i)
+		src[i] = Vec3Q24FromFloat(f/10-2, 3-f/7, f/20)
+	}
+	dst := make([]Vec3Q24, len(src))
+	RotateVec3s(dst, src, q)
+	for i := range src {
+		expected := q.Rotate(src[i])
+		assert.InDelta(t, expected.X.N, dst[i].X.N, 16, "vector %d", i)
+		assert.InDelta(t, expected.Y.N, dst[i].Y.N, 16, "vector %d", i)
+		assert.InDelta(t, expected.Z.N, dst[i].Z.N, 16, "vector %d", i)
+	}
+}
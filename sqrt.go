@@ -0,0 +1,126 @@
+package fixpoint
+
+import (
+	"math/bits"
+)
+
+// Sqrt returns the square root of this number, rounded to the nearest
+// representable value. It returns 0 for negative numbers.
+func (q Q24) Sqrt() Q24 {
+	if q.N <= 0 {
+		return Q24{}
+	}
+	// The square root of a Q48 number is a Q24 number.
+	return Q24{int32(sqrt64(uint64(q.N) << 24))}
+}
+
+// SqrtIter returns the square root of this number calculated with Newton's
+// method, stopping as specified by c. Every iteration doubles the number of
+// correct bits, so the default of 6 iterations gives the full precision (the
+// result is then rounded down, so it may be one less than Sqrt). It also
+// returns the number of iterations and the residual q - root², which is zero
+// for an exact square root. It returns 0 for negative numbers.
+func (q Q24) SqrtIter(c Convergence) (root Q24, iterations int, residual Q24) {
+	if q.N <= 0 {
+		return Q24{}, 0, Q24{}
+	}
+	// Start with a power of two above the square root, so that the estimate
+	// decreases monotonically.
+	n := uint64(q.N) << 24
+	x := uint64(1) << uint((bits.Len64(n)+1)/2)
+	max := c.limit(6)
+	for ; iterations < max; iterations++ {
+		if c.done((int64(n)-int64(x*x))>>24, 24) {
+			break
+		}
+		next := (x + n/x) / 2
+		if next >= x {
+			// Converged to the rounded down square root.
+			break
+		}
+		x = next
+	}
+	return Q24{int32(x)}, iterations, Q24{int32((int64(n) - int64(x*x)) >> 24)}
+}
+
+// InvSqrt returns 1/sqrt(q). The result saturates to the largest
+// representable number for inputs smaller than 2^-14 (including zero and
+// negative numbers).
+func (q Q24) InvSqrt() Q24 {
+	if q.N <= 0 {
+		return Q24{1<<31 - 1}
+	}
+	return Q24{invSqrtQ48(uint64(q.N) << 24)}
+}
+
+// invSqrtQ48 returns 1/sqrt(n) in Q24 format, where n is in Q48 format. The
+// result saturates to the largest representable number.
+func invSqrtQ48(n uint64) int32 {
+	if n == 0 {
+		return 1<<31 - 1
+	}
+	r, shift := recipSqrtQ48(n)
+	result := (r<<24 + 1<<(shift-1)) >> shift
+	if result > 1<<31-1 {
+		return 1<<31 - 1
+	}
+	return int32(result)
+}
+
+// recipSqrtQ48 returns a reciprocal r and shift such that x*r>>shift equals
+// x/sqrt(n) for Q24 numbers x, where n is a non-zero number in Q48 format. The
+// reciprocal has 31 bits of precision, and x*r does not overflow an int64.
+func recipSqrtQ48(n uint64) (r uint64, shift uint) {
+	// Scale n to the range [2^60, 2^62) by an even power of two 2^2s, so that
+	// the square root is in the range [2^30, 2^31) and equals
+	// sqrt(n) * 2^(24+s) for the original Q48 input.
+	s := 0
+	for n < 1<<60 {
+		n <<= 2
+		s++
+	}
+	for n >= 1<<62 {
+		n >>= 2
+		s--
+	}
+	// 2^61 / (sqrt(n) * 2^(24+s)) = 1/sqrt(n) * 2^(37-s)
+	// The square root is rounded, so it may be exactly 2^31.
+	root := sqrt64(n)
+	if root >= 1<<31 {
+		return 1 << 30, uint(37 - s)
+	}
+	return uint64(recip32(root << 1)), uint(37 - s)
+}
+
+// mulRecip returns q*r>>shift, rounded to the nearest value. See
+// recipSqrtQ48.
+func mulRecip(q Q24, r uint64, shift uint) Q24 {
+	return Q24{int32((int64(q.N)*int64(r) + 1<<(shift-1)) >> shift)}
+}
+
+// sqrt64 returns the square root of the argument, rounded to the nearest
+// integer.
+func sqrt64(n uint64) uint32 {
+	// Bit-by-bit method, see:
+	// https://en.wikipedia.org/wiki/Methods_of_computing_square_roots#Binary_numeral_system_(base_2)
+	var result uint64
+	bit := uint64(1) << 62
+	for bit > n {
+		bit >>= 2
+	}
+	for bit != 0 {
+		if n >= result+bit {
+			n -= result + bit
+			result = result>>1 + bit
+		} else {
+			result >>= 1
+		}
+		bit >>= 2
+	}
+	// The remainder n is now the input minus result². Round up when the input
+	// is above (result + 0.5)² = result² + result + 0.25.
+	if n > result {
+		result++
+	}
+	return uint32(result)
+}
@@ -0,0 +1,80 @@
+package fixpoint
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSqrt(t *testing.T) {
+	for _, f := range []float64{0, 0.25, 1, 2, 10, 100, 0.001, 127.9} {
+		q := Q24FromFloat(float32(f))
+		exact := float64(q.N) / (1 << 24)
+		want := Q24{int32(math.Round(math.Sqrt(exact) * (1 << 24)))}
+		assert.Equal(t, want, q.Sqrt(), "Sqrt(%f)", f)
+	}
+	assert.Equal(t, Q24{}, Q24FromInt32(-1).Sqrt(), "Sqrt of negative number")
+}
+
+func TestSqrtIter(t *testing.T) {
+	for n := int32(1); n > 0 && n < 1<<31-1; n += n/7 + 1 {
+		q := Q24{n}
+		root, iterations, residual := q.SqrtIter(Convergence{})
+		if d := q.Sqrt().N - root.N; d != 0 && d != 1 {
+			t.Errorf("SqrtIter(%d): expected %d, got %d", n, q.Sqrt().N, root.N)
+		}
+		if iterations > 6 || residual.N < 0 {
+			t.Errorf("SqrtIter(%d): %d iterations, residual %d", n, iterations, residual.N)
+		}
+	}
+	root, iterations, residual := Q24FromInt32(4).SqrtIter(Convergence{})
+	assert.Equal(t, Q24FromInt32(2), root)
+	assert.Equal(t, Q24{}, residual)
+	assert.True(t, iterations > 0)
+	root, _, _ = Q24FromInt32(-4).SqrtIter(Convergence{})
+	assert.Equal(t, Q24{}, root)
+
+	// Trade precision for fewer iterations.
+	q := Q24FromFloat(30)
+	_, full, _ := q.SqrtIter(Convergence{})
+	root, iterations, residual = q.SqrtIter(Convergence{MaxIterations: 2})
+	assert.Equal(t, 2, iterations)
+	assert.InDelta(t, 30, root.Float64()*root.Float64()+residual.Float64(), 1e-6)
+	assert.True(t, math.Abs(residual.Float64()) > 0.01)
+	root, iterations, residual = q.SqrtIter(Convergence{Epsilon: Q24FromFloat(0.001)})
+	assert.True(t, iterations < full, "%d < %d", iterations, full)
+	assert.True(t, math.Abs(residual.Float64()) < 0.001)
+	assert.InDelta(t, math.Sqrt(30), root.Float64(), 0.001)
+}
+
+func TestInvSqrt(t *testing.T) {
+	for _, f := range []float64{0.001, 0.25, 1, 2, 10, 100} {
+		q := Q24FromFloat(float32(f))
+		exact := float64(q.N) / (1 << 24)
+		assert.InDelta(t, 1/math.Sqrt(exact), float64(q.InvSqrt().N)/(1<<24), 1.0/(1<<23), "InvSqrt(%f)", f)
+	}
+	assert.Equal(t, Q24{1<<31 - 1}, Q24{}.InvSqrt(), "InvSqrt(0) should saturate")
+	assert.Equal(t, Q24{1<<31 - 1}, Q24{1}.InvSqrt(), "InvSqrt(2^-24) should saturate")
+}
+
+func TestVec3Len(t *testing.T) {
+	v := Vec3Q24FromFloat(2, 3, 6)
+	assert.Equal(t, Q24FromInt32(49), v.Len2())
+	assert.Equal(t, Q24FromInt32(7), v.Len())
+
+	// Len should not overflow for long vectors.
+	v = Vec3Q24FromFloat(40, 40, 70)
+	assert.Equal(t, Q24FromInt32(90), v.Len())
+
+	for _, v := range []Vec3Q24{
+		Vec3Q24FromFloat(2, 3, 6),
+		Vec3Q24FromFloat(40, 40, 70),
+		Vec3Q24FromFloat(0.001, -0.002, 0.003),
+		Vec3Q24FromFloat(1, 1, 1),
+	} {
+		n := v.Normalize()
+		assert.InDelta(t, 1, n.Len().Float(), 0.000001, "length of %v normalized", v)
+	}
+	assert.Equal(t, Vec3Q24{}, Vec3Q24{}.Normalize())
+}
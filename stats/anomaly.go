@@ -0,0 +1,158 @@
+package stats
+
+import (
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/fixmath"
+)
+
+// ZScore detects anomalies by how many standard deviations a sample is away
+// from the recent mean. The mean and variance are exponentially weighted
+// moving averages, so no sample history needs to be stored.
+//
+// The zero value is not usable: at least Alpha and Threshold must be set.
+type ZScore struct {
+	// Alpha is the weight of a new sample in the moving averages, in the range
+	// (0, 1]. A smaller value makes the averages follow the signal more
+	// slowly. It roughly corresponds to 2/(N+1) for a window of N samples.
+	Alpha fixpoint.Q24
+
+	// Threshold is the z-score (in standard deviations) above which a sample
+	// is considered an anomaly, for example 3.
+	Threshold fixpoint.Q24
+
+	// Warmup is the number of samples that are used to estimate the mean and
+	// variance before anomalies are reported.
+	Warmup int
+
+	count    int
+	mean     int64  // Q24
+	variance uint64 // Q48
+}
+
+// Update adds a new sample and returns its z-score relative to the samples
+// before it, and whether it is an anomaly. The z-score saturates at the
+// largest Q24 value, for example when the variance is still zero.
+func (z *ZScore) Update(x fixpoint.Q24) (score fixpoint.Q24, anomaly bool) {
+	diff := int64(x.N) - z.mean
+	if z.count == 0 {
+		z.mean = int64(x.N)
+		z.count++
+		return fixpoint.Q24{}, false
+	}
+
+	score = z.score(diff)
+	if z.count < z.Warmup {
+		z.count++
+	} else {
+		anomaly = score.N > z.Threshold.N || score.N < -z.Threshold.N
+	}
+
+	// Update the moving averages, see "Incremental calculation of weighted
+	// mean and variance" by Tony Finch.
+	incr := (diff*int64(z.Alpha.N) + 1<<23) >> 24
+	z.mean += incr
+	// The product is non-negative and below 2^64, so it fits in an uint64
+	// even when it overflows an int64.
+	variance := z.variance + uint64(diff*incr)
+	if variance < z.variance {
+		variance = 1<<64 - 1
+	}
+	z.variance = mulHi24(variance, uint64(1<<24-int64(z.Alpha.N)))
+	return score, anomaly
+}
+
+// score returns diff (in Q24 format) divided by the standard deviation.
+func (z *ZScore) score(diff int64) fixpoint.Q24 {
+	std := int64(fixmath.Sqrt(z.variance)) // Q24
+	if std == 0 {
+		if diff == 0 {
+			return fixpoint.Q24{}
+		}
+		if diff < 0 {
+			return fixpoint.Q24{N: -1 << 31}
+		}
+		return fixpoint.Q24{N: 1<<31 - 1}
+	}
+	return fixpoint.Q24{N: fixmath.Saturate((diff << 24) / std)}
+}
+
+// Mean returns the current moving average.
+func (z *ZScore) Mean() fixpoint.Q24 {
+	return fixpoint.Q24{N: int32(z.mean)}
+}
+
+// StdDev returns the current moving standard deviation.
+func (z *ZScore) StdDev() fixpoint.Q24 {
+	return fixpoint.Q24{N: fixmath.Saturate(int64(fixmath.Sqrt(z.variance)))}
+}
+
+// Reset forgets all samples.
+func (z *ZScore) Reset() {
+	z.count = 0
+	z.mean = 0
+	z.variance = 0
+}
+
+// CUSUM is a two-sided cumulative sum control chart. It detects small but
+// persistent shifts of the mean of a signal away from a target value, which a
+// z-score on individual samples would miss.
+//
+// The zero value is not usable: at least Threshold must be set.
+type CUSUM struct {
+	// Target is the expected mean of the signal.
+	Target fixpoint.Q24
+
+	// Slack is the deviation from the target that is tolerated without
+	// accumulating, usually half the shift that should be detected.
+	Slack fixpoint.Q24
+
+	// Threshold is the cumulative deviation at which a shift is reported.
+	Threshold fixpoint.Q24
+
+	high, low int64 // Q24
+}
+
+// Update adds a new sample and returns 1 if an upwards shift is detected, -1
+// if a downwards shift is detected and 0 otherwise. The sums are not reset
+// after detection, so a shift will keep being reported until Reset is called
+// or the signal returns to the target.
+func (c *CUSUM) Update(x fixpoint.Q24) int {
+	diff := int64(x.N) - int64(c.Target.N)
+	c.high = clampSum(c.high + diff - int64(c.Slack.N))
+	c.low = clampSum(c.low - diff - int64(c.Slack.N))
+	switch {
+	case c.high > int64(c.Threshold.N):
+		return 1
+	case c.low > int64(c.Threshold.N):
+		return -1
+	}
+	return 0
+}
+
+// Sums returns the current upper and lower cumulative sums.
+func (c *CUSUM) Sums() (high, low fixpoint.Q24) {
+	return fixpoint.Q24{N: fixmath.Saturate(c.high)}, fixpoint.Q24{N: fixmath.Saturate(c.low)}
+}
+
+// Reset clears the cumulative sums.
+func (c *CUSUM) Reset() {
+	c.high = 0
+	c.low = 0
+}
+
+// clampSum clamps a cumulative sum to the range [0, 2^31).
+func clampSum(n int64) int64 {
+	if n < 0 {
+		return 0
+	}
+	if n > 1<<31-1 {
+		return 1<<31 - 1
+	}
+	return n
+}
+
+// mulHi24 returns a*b>>24 for a Q24 number b in the range [0, 1], without
+// overflowing.
+func mulHi24(a, b uint64) uint64 {
+	return (a>>24)*b + ((a&(1<<24-1))*b+1<<23)>>24
+}
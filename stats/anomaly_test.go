@@ -0,0 +1,100 @@
+package stats
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestZScore(t *testing.T) {
+	z := ZScore{
+		Alpha:     fixpoint.Q24FromFloat(0.02),
+		Threshold: fixpoint.Q24FromInt32(4),
+		Warmup:    50,
+	}
+	// Uniform noise in [0.9, 1.1], with a standard deviation of 0.2/sqrt(12).
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		x := fixpoint.Q24FromFloat(0.9 + 0.2*r.Float32())
+		if _, anomaly := z.Update(x); anomaly {
+			t.Errorf("unexpected anomaly at sample %d: %s", i, x)
+		}
+	}
+	assert.InDelta(t, 1, z.Mean().Float(), 0.02)
+	assert.InDelta(t, 0.0577, z.StdDev().Float(), 0.01)
+
+	score, anomaly := z.Update(fixpoint.Q24FromFloat(1.5))
+	assert.True(t, anomaly)
+	assert.InDelta(t, 8.7, score.Float(), 1.5)
+	score, anomaly = z.Update(fixpoint.Q24FromFloat(0.5))
+	assert.True(t, anomaly)
+	assert.True(t, score.N < 0)
+
+	// Anomalies are not reported during warmup.
+	z.Reset()
+	for i := 0; i < 50; i++ {
+		_, anomaly = z.Update(fixpoint.Q24FromInt32(int32(i % 2 * 100)))
+		assert.False(t, anomaly)
+	}
+
+	// Zero variance.
+	z = ZScore{Alpha: fixpoint.Q24FromFloat(0.1), Threshold: fixpoint.Q24FromInt32(3)}
+	z.Update(fixpoint.Q24FromInt32(1))
+	score, anomaly = z.Update(fixpoint.Q24FromInt32(1))
+	assert.Equal(t, fixpoint.Q24{}, score)
+	assert.False(t, anomaly)
+	score, anomaly = z.Update(fixpoint.Q24FromInt32(-1))
+	assert.Equal(t, fixpoint.Q24{N: -1 << 31}, score)
+	assert.True(t, anomaly)
+
+	// Extreme values don't overflow.
+	z.Reset()
+	for i := 0; i < 100; i++ {
+		x := fixpoint.Q24{N: -1 << 31}
+		if i%2 == 0 {
+			x.N = 1<<31 - 1
+		}
+		z.Update(x)
+	}
+	assert.True(t, z.StdDev().N > 0)
+}
+
+func TestCUSUM(t *testing.T) {
+	c := CUSUM{
+		Target:    fixpoint.Q24FromFloat(1),
+		Slack:     fixpoint.Q24FromFloat(0.05),
+		Threshold: fixpoint.Q24FromFloat(0.5),
+	}
+	// Noise around the target is not detected.
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		x := fixpoint.Q24FromFloat(0.95 + 0.1*r.Float32())
+		if c.Update(x) != 0 {
+			t.Errorf("unexpected shift at sample %d", i)
+		}
+	}
+
+	// A small upwards shift of 0.1 is detected after about 0.5/0.05 = 10
+	// samples.
+	detected := -1
+	for i := 0; i < 100; i++ {
+		x := fixpoint.Q24FromFloat(1.05 + 0.1*r.Float32())
+		if c.Update(x) == 1 {
+			detected = i
+			break
+		}
+	}
+	assert.InDelta(t, 10, detected, 4)
+	high, low := c.Sums()
+	assert.True(t, high.N > c.Threshold.N)
+	assert.Equal(t, fixpoint.Q24{}, low)
+
+	// Downwards shift.
+	c.Reset()
+	for i := 0; i < 20; i++ {
+		c.Update(fixpoint.Q24FromFloat(0.8))
+	}
+	assert.Equal(t, -1, c.Update(fixpoint.Q24FromFloat(0.8)))
+}
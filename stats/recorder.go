@@ -0,0 +1,203 @@
+// Package stats implements streaming statistics over fixed point samples, for
+// monitoring and debugging on small devices.
+package stats
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/aykevl/fixpoint"
+)
+
+// Ring is a ring buffer of Q24 samples with a fixed capacity, set by the
+// length of Buf. When the buffer is full, new samples overwrite the oldest
+// ones.
+type Ring struct {
+	// Buf is the storage for the ring buffer. It must not be resized after
+	// the first sample has been added.
+	Buf []fixpoint.Q24
+
+	next int  // index where the next sample will be stored
+	full bool // whether the buffer has wrapped around
+}
+
+// Push adds a sample to the ring buffer, overwriting the oldest sample if the
+// buffer is full.
+func (r *Ring) Push(v fixpoint.Q24) {
+	if len(r.Buf) == 0 {
+		return
+	}
+	r.Buf[r.next] = v
+	r.next++
+	if r.next == len(r.Buf) {
+		r.next = 0
+		r.full = true
+	}
+}
+
+// Len returns the number of samples currently stored.
+func (r *Ring) Len() int {
+	if r.full {
+		return len(r.Buf)
+	}
+	return r.next
+}
+
+// At returns the sample at the given index, where 0 is the oldest sample and
+// Len()-1 the newest.
+func (r *Ring) At(i int) fixpoint.Q24 {
+	if r.full {
+		i += r.next
+		if i >= len(r.Buf) {
+			i -= len(r.Buf)
+		}
+	}
+	return r.Buf[i]
+}
+
+// Reset removes all samples.
+func (r *Ring) Reset() {
+	r.next = 0
+	r.full = false
+}
+
+// Tier is a decimation tier of a Recorder. Each entry summarizes Factor
+// entries of the previous tier (or Factor samples for the first tier) by
+// their minimum and maximum, so that a long history can be kept in little
+// memory without losing peaks.
+type Tier struct {
+	// Factor is the number of entries of the previous tier that are combined
+	// into one entry of this tier.
+	Factor int
+
+	// Min and Max store the minimum and maximum of each entry. They should
+	// have buffers of the same length.
+	Min, Max Ring
+
+	count    int
+	min, max fixpoint.Q24
+}
+
+// add adds a minimum and maximum of the previous tier. It returns true when a
+// new entry was completed.
+func (t *Tier) add(min, max fixpoint.Q24) bool {
+	if t.count == 0 || min.N < t.min.N {
+		t.min = min
+	}
+	if t.count == 0 || max.N > t.max.N {
+		t.max = max
+	}
+	t.count++
+	if t.count < t.Factor {
+		return false
+	}
+	t.Min.Push(t.min)
+	t.Max.Push(t.max)
+	t.count = 0
+	return true
+}
+
+// Recorder records the most recent samples of a signal, for example to dump
+// the history that led up to a fault over a serial connection on devices
+// without a filesystem. Optionally, longer-term history can be kept at a lower
+// resolution in decimation tiers.
+//
+// The zero value records nothing: at least a buffer must be provided in
+// Samples.
+type Recorder struct {
+	// Samples stores the most recent samples at full rate.
+	Samples Ring
+
+	// Tiers contains optional decimation tiers, each one summarizing the
+	// previous one.
+	Tiers []Tier
+
+	frozen bool
+}
+
+// Add records a new sample. It is ignored while the recorder is frozen.
+func (r *Recorder) Add(v fixpoint.Q24) {
+	if r.frozen {
+		return
+	}
+	r.Samples.Push(v)
+	min, max := v, v
+	for i := range r.Tiers {
+		t := &r.Tiers[i]
+		if !t.add(min, max) {
+			break
+		}
+		min, max = t.min, t.max
+	}
+}
+
+// Freeze stops recording, so that the history can be inspected or dumped
+// while new samples keep arriving.
+func (r *Recorder) Freeze() {
+	r.frozen = true
+}
+
+// Unfreeze resumes recording.
+func (r *Recorder) Unfreeze() {
+	r.frozen = false
+}
+
+// Frozen returns whether the recorder is currently frozen.
+func (r *Recorder) Frozen() bool {
+	return r.frozen
+}
+
+// Reset removes all recorded history.
+func (r *Recorder) Reset() {
+	r.Samples.Reset()
+	for i := range r.Tiers {
+		t := &r.Tiers[i]
+		t.Min.Reset()
+		t.Max.Reset()
+		t.count = 0
+	}
+}
+
+// Dump writes the recorded history as text, oldest first. The full-rate
+// samples are written as one decimal number per line, followed by each tier
+// as one "min max" pair per line. Each section starts with a header line
+// starting with '#'. The recorder should usually be frozen while dumping.
+func (r *Recorder) Dump(w io.Writer) error {
+	buf := make([]byte, 0, 32)
+	buf = append(buf, "# samples "...)
+	buf = strconv.AppendInt(buf, int64(r.Samples.Len()), 10)
+	buf = append(buf, '\n')
+	for i := 0; i < r.Samples.Len(); i++ {
+		buf = r.Samples.At(i).AppendText(buf, -1)
+		buf = append(buf, '\n')
+		if _, err := w.Write(buf); err != nil {
+			return err
+		}
+		buf = buf[:0]
+	}
+	for i := range r.Tiers {
+		t := &r.Tiers[i]
+		buf = append(buf, "# tier "...)
+		buf = strconv.AppendInt(buf, int64(i+1), 10)
+		buf = append(buf, " factor "...)
+		buf = strconv.AppendInt(buf, int64(t.Factor), 10)
+		buf = append(buf, " entries "...)
+		buf = strconv.AppendInt(buf, int64(t.Min.Len()), 10)
+		buf = append(buf, '\n')
+		for j := 0; j < t.Min.Len(); j++ {
+			buf = t.Min.At(j).AppendText(buf, -1)
+			buf = append(buf, ' ')
+			buf = t.Max.At(j).AppendText(buf, -1)
+			buf = append(buf, '\n')
+			if _, err := w.Write(buf); err != nil {
+				return err
+			}
+			buf = buf[:0]
+		}
+	}
+	if len(buf) != 0 {
+		_, err := w.Write(buf)
+		return err
+	}
+	return nil
+}
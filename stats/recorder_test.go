@@ -0,0 +1,65 @@
+package stats
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRing(t *testing.T) {
+	r := Ring{Buf: make([]fixpoint.Q24, 3)}
+	assert.Equal(t, 0, r.Len())
+	for i := int32(1); i <= 5; i++ {
+		r.Push(fixpoint.Q24FromInt32(i))
+	}
+	assert.Equal(t, 3, r.Len())
+	assert.Equal(t, fixpoint.Q24FromInt32(3), r.At(0))
+	assert.Equal(t, fixpoint.Q24FromInt32(5), r.At(2))
+	r.Reset()
+	assert.Equal(t, 0, r.Len())
+
+	// A ring without a buffer ignores all samples.
+	var empty Ring
+	empty.Push(fixpoint.Q24FromInt32(1))
+	assert.Equal(t, 0, empty.Len())
+}
+
+func TestRecorder(t *testing.T) {
+	r := Recorder{
+		Samples: Ring{Buf: make([]fixpoint.Q24, 4)},
+		Tiers: []Tier{
+			{Factor: 2, Min: Ring{Buf: make([]fixpoint.Q24, 3)}, Max: Ring{Buf: make([]fixpoint.Q24, 3)}},
+			{Factor: 2, Min: Ring{Buf: make([]fixpoint.Q24, 2)}, Max: Ring{Buf: make([]fixpoint.Q24, 2)}},
+		},
+	}
+	for _, v := range []float32{1, -1, 2, 0.5, 3, 4, -2, 0.25, 7} {
+		r.Add(fixpoint.Q24FromFloat(v))
+	}
+	r.Freeze()
+	assert.True(t, r.Frozen())
+	r.Add(fixpoint.Q24FromInt32(100)) // ignored
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, r.Dump(buf))
+	assert.Equal(t, `# samples 4
+4
+-2
+0.25
+7
+# tier 1 factor 2 entries 3
+0.5 2
+3 4
+-2 0.25
+# tier 2 factor 2 entries 2
+-1 2
+-2 4
+`, buf.String())
+
+	r.Unfreeze()
+	r.Reset()
+	buf.Reset()
+	assert.NoError(t, r.Dump(buf))
+	assert.Equal(t, "# samples 0\n# tier 1 factor 2 entries 0\n# tier 2 factor 2 entries 0\n", buf.String())
+}
@@ -0,0 +1,117 @@
+package stats
+
+import (
+	"math/bits"
+
+	"github.com/aykevl/fixpoint"
+)
+
+// Layout of the buckets of QuantileSketch. Each power of two is split in
+// 2^sketchSubBits buckets, which limits the relative error to 2^-(sketchSubBits+1)
+// (6.25%). Small values, below 2^sketchSubBits in raw Q24 units, have a bucket
+// of their own.
+const (
+	sketchSubBits = 3
+	sketchSub     = 1 << sketchSubBits
+	sketchBuckets = (31 - sketchSubBits + 1) * sketchSub
+)
+
+// QuantileSketch is a compact histogram with exponentially sized buckets, to
+// estimate quantiles (like the median or the 99th percentile) of a stream of
+// non-negative values without storing the samples themselves. The estimated
+// quantiles have a relative error of at most 6.25%. It takes a little under a
+// kilobyte of memory.
+//
+// The zero value is an empty sketch, ready to use.
+type QuantileSketch struct {
+	counts [sketchBuckets]uint32
+	total  uint32
+}
+
+// Add adds a sample to the sketch. Negative values are counted as zero.
+// Counts saturate after 2^32-1 samples.
+func (s *QuantileSketch) Add(x fixpoint.Q24) {
+	if s.total == 1<<32-1 {
+		return
+	}
+	s.counts[sketchIndex(x)]++
+	s.total++
+}
+
+// Count returns the number of samples added to the sketch.
+func (s *QuantileSketch) Count() uint32 {
+	return s.total
+}
+
+// Quantile returns an estimate of the given quantile, which must be in the
+// range [0, 1]. For example, 0.5 returns the median and 0.99 returns the 99th
+// percentile. It returns zero if the sketch is empty.
+func (s *QuantileSketch) Quantile(q fixpoint.Q24) fixpoint.Q24 {
+	if s.total == 0 {
+		return fixpoint.Q24{}
+	}
+	if q.N < 0 {
+		q.N = 0
+	} else if q.N > 1<<24 {
+		q.N = 1 << 24
+	}
+	// The rank of the requested sample, starting at 1.
+	rank := (uint64(q.N)*uint64(s.total) + 1<<24 - 1) >> 24
+	if rank == 0 {
+		rank = 1
+	}
+	var cumulative uint64
+	for i, count := range s.counts {
+		cumulative += uint64(count)
+		if cumulative >= rank {
+			return sketchValue(i)
+		}
+	}
+	return sketchValue(sketchBuckets - 1) // unreachable
+}
+
+// Merge adds all samples of another sketch to this sketch, for example to
+// combine the sketches of several sensors or time periods.
+func (s *QuantileSketch) Merge(other *QuantileSketch) {
+	for i, count := range other.counts {
+		sum := uint64(s.counts[i]) + uint64(count)
+		if sum > 1<<32-1 {
+			sum = 1<<32 - 1
+		}
+		s.counts[i] = uint32(sum)
+	}
+	total := uint64(s.total) + uint64(other.total)
+	if total > 1<<32-1 {
+		total = 1<<32 - 1
+	}
+	s.total = uint32(total)
+}
+
+// Reset removes all samples.
+func (s *QuantileSketch) Reset() {
+	*s = QuantileSketch{}
+}
+
+// sketchIndex returns the bucket index for the given value.
+func sketchIndex(x fixpoint.Q24) int {
+	if x.N < sketchSub {
+		if x.N < 0 {
+			return 0
+		}
+		return int(x.N)
+	}
+	e := uint(bits.Len32(uint32(x.N))) - 1 // e >= sketchSubBits
+	sub := int(x.N>>(e-sketchSubBits)) & (sketchSub - 1)
+	return int(e-sketchSubBits+1)*sketchSub + sub
+}
+
+// sketchValue returns the value in the middle of the given bucket.
+func sketchValue(i int) fixpoint.Q24 {
+	if i < sketchSub {
+		return fixpoint.Q24{N: int32(i)}
+	}
+	e := uint(i/sketchSub) + sketchSubBits - 1
+	lower := int64(sketchSub+i%sketchSub) << (e - sketchSubBits)
+	width := int64(1) << (e - sketchSubBits)
+	return fixpoint.Q24{N: int32(lower + width/2)}
+}
@@ -0,0 +1,61 @@
+package stats
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuantileSketch(t *testing.T) {
+	var s QuantileSketch
+	assert.Equal(t, fixpoint.Q24{}, s.Quantile(fixpoint.Q24FromFloat(0.5)))
+
+	// Every value maps to a bucket that contains it, and the buckets are in
+	// increasing order.
+	for _, n := range []int32{0, 1, 7, 8, 9, 15, 16, 17, 1000, 1 << 24, 1<<31 - 1} {
+		i := sketchIndex(fixpoint.Q24{N: n})
+		assert.True(t, i >= 0 && i < sketchBuckets, "n=%d", n)
+		v := sketchValue(i).N
+		assert.InDelta(t, n, v, float64(n)/16+0.5, "n=%d", n)
+		if i+1 < sketchBuckets {
+			assert.True(t, sketchValue(i+1).N > v)
+		}
+	}
+	assert.Equal(t, sketchBuckets-1, sketchIndex(fixpoint.Q24{N: 1<<31 - 1}))
+	assert.Equal(t, 0, sketchIndex(fixpoint.Q24FromInt32(-1)))
+
+	// Compare against exact quantiles of an exponential distribution.
+	r := rand.New(rand.NewSource(1))
+	var samples []float64
+	for i := 0; i < 10000; i++ {
+		x := fixpoint.Q24FromFloat(float32(r.ExpFloat64()))
+		s.Add(x)
+		samples = append(samples, float64(x.Float()))
+	}
+	sort.Float64s(samples)
+	assert.Equal(t, uint32(10000), s.Count())
+	for _, q := range []float64{0, 0.1, 0.5, 0.95, 0.99, 1} {
+		index := int(q*float64(len(samples))+0.999999) - 1
+		if index < 0 {
+			index = 0
+		}
+		exact := samples[index]
+		estimate := float64(s.Quantile(fixpoint.Q24FromFloat(float32(q))).Float())
+		assert.InDelta(t, exact, estimate, exact/16+1e-6, "q=%f", q)
+	}
+
+	// Merge.
+	var s2 QuantileSketch
+	for i := 0; i < 10000; i++ {
+		s2.Add(fixpoint.Q24FromInt32(100))
+	}
+	s.Merge(&s2)
+	assert.Equal(t, uint32(20000), s.Count())
+	assert.InDelta(t, 100, s.Quantile(fixpoint.Q24FromFloat(0.75)).Float(), 100.0/16)
+
+	s.Reset()
+	assert.Equal(t, uint32(0), s.Count())
+}
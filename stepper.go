@@ -0,0 +1,68 @@
+package fixpoint
+
+// FixedStepper converts variable frame durations into a whole number of fixed
+// timesteps, the usual game loop for a deterministic simulation: the
+// simulation always advances by the same Step, no matter how long a frame
+// takes, and the time that is left over is carried to the next frame. The
+// interpolation alpha that is returned with the steps tells how far the
+// current time is between the last two simulation states, to render
+// smoothly at any frame rate:
+//
+//	steps, alpha := stepper.Advance(frameTime)
+//	for i := 0; i < steps; i++ {
+//		previous = state
+//		state = simulate(state, stepper.Step)
+//	}
+//	render(interpolate(previous, state, alpha))
+//
+// The accumulated time is kept exactly, so no time is lost to rounding.
+//
+// The zero value is not usable: Step must be set.
+type FixedStepper struct {
+	// Step is the duration of one simulation step, for example 1/60 of a
+	// second.
+	Step Q24
+
+	// MaxSteps limits the number of steps per frame. When the simulation
+	// falls further behind (for example because the simulation itself is
+	// too slow, or the program was paused) the time above it is dropped, so
+	// the simulation slows down instead of taking ever longer to catch up.
+	// Zero means no limit.
+	MaxSteps int
+
+	accumulated int64 // Q24 time that has not been simulated yet
+}
+
+// Advance adds the duration of a frame and returns the number of steps to
+// simulate and the interpolation alpha in the range [0, 1): the time that is
+// left over as a fraction of Step. Negative durations are ignored.
+func (s *FixedStepper) Advance(frame Q24) (steps int, alpha Q24) {
+	if s.Step.N <= 0 {
+		return 0, Q24{}
+	}
+	if frame.N > 0 {
+		s.accumulated += int64(frame.N)
+	}
+	step := int64(s.Step.N)
+	n := s.accumulated / step
+	if s.MaxSteps > 0 && n > int64(s.MaxSteps) {
+		n = int64(s.MaxSteps)
+		s.accumulated = n*step + s.accumulated%step
+	}
+	s.accumulated -= n * step
+	return int(n), s.Alpha()
+}
+
+// Alpha returns the time that has not been simulated yet as a fraction of
+// Step, in the range [0, 1).
+func (s *FixedStepper) Alpha() Q24 {
+	if s.Step.N <= 0 {
+		return Q24{}
+	}
+	return Q24{int32(s.accumulated << 24 / int64(s.Step.N))}
+}
+
+// Reset drops the accumulated time, for example after loading a saved game.
+func (s *FixedStepper) Reset() {
+	s.accumulated = 0
+}
@@ -0,0 +1,66 @@
+package fixpoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixedStepper(t *testing.T) {
+	s := FixedStepper{Step: Q24FromFloat(0.25)}
+
+	// Frames shorter than a step accumulate.
+	steps, alpha := s.Advance(Q24FromFloat(0.125))
+	assert.Equal(t, 0, steps)
+	assert.Equal(t, Q24FromFloat(0.5), alpha)
+	steps, alpha = s.Advance(Q24FromFloat(0.375))
+	assert.Equal(t, 2, steps)
+	assert.Equal(t, Q24{}, alpha)
+	steps, alpha = s.Advance(Q24FromFloat(0.3125))
+	assert.Equal(t, 1, steps)
+	assert.Equal(t, Q24FromFloat(0.25), alpha)
+	assert.Equal(t, alpha, s.Alpha())
+
+	// Negative durations are ignored.
+	steps, alpha = s.Advance(Q24FromFloat(-1))
+	assert.Equal(t, 0, steps)
+	assert.Equal(t, Q24FromFloat(0.25), alpha)
+
+	// No time is lost to rounding: 60 frames of 1/60 second are 60 steps of
+	// 1/60 second, even though neither is exact, and thousands of frames of
+	// an unrelated length add up to the exact total.
+	s = FixedStepper{Step: Q24FromFloat(1.0 / 60)}
+	total := 0
+	for i := 0; i < 60; i++ {
+		steps, _ = s.Advance(s.Step)
+		total += steps
+	}
+	assert.Equal(t, 60, total)
+	frame := Q24FromFloat(1.0 / 144)
+	total = 0
+	for i := 0; i < 10000; i++ {
+		steps, _ = s.Advance(frame)
+		total += steps
+	}
+	assert.Equal(t, int(int64(frame.N)*10000/int64(s.Step.N)), total)
+
+	// MaxSteps drops the time that can't be caught up, but keeps the
+	// fraction of a step.
+	s = FixedStepper{Step: Q24FromFloat(0.25), MaxSteps: 3}
+	steps, alpha = s.Advance(Q24FromFloat(2.125))
+	assert.Equal(t, 3, steps)
+	assert.Equal(t, Q24FromFloat(0.5), alpha)
+	steps, _ = s.Advance(Q24FromFloat(0.125))
+	assert.Equal(t, 1, steps)
+
+	// Reset drops the accumulated time.
+	s.Advance(Q24FromFloat(0.125))
+	s.Reset()
+	assert.Equal(t, Q24{}, s.Alpha())
+
+	// The zero value never steps.
+	var zero FixedStepper
+	steps, alpha = zero.Advance(Q24FromInt32(1))
+	assert.Equal(t, 0, steps)
+	assert.Equal(t, Q24{}, alpha)
+}
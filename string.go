@@ -0,0 +1,191 @@
+package fixpoint
+
+import "errors"
+
+// Errors returned by ParseQ24.
+var (
+	ErrSyntax = errors.New("fixpoint: invalid syntax")
+	ErrRange  = errors.New("fixpoint: value out of range")
+)
+
+const fracMask = 1<<24 - 1
+
+// String returns the shortest decimal representation of this number that
+// parses back to exactly the same value with ParseQ24. It does not use any
+// floating point arithmetic.
+func (q Q24) String() string {
+	return string(q.AppendText(nil, -1))
+}
+
+// Text returns the decimal representation of this number with the given
+// number of decimal places, rounded to the nearest value (halfway cases are
+// rounded away from zero). A negative number of decimals returns the shortest
+// representation, like String. The exact value of a Q24 number never needs
+// more than 24 decimal places.
+func (q Q24) Text(decimals int) string {
+	return string(q.AppendText(nil, decimals))
+}
+
+// AppendText appends the decimal representation of this number to buf and
+// returns the extended buffer. See Text for the meaning of decimals.
+func (q Q24) AppendText(buf []byte, decimals int) []byte {
+	mag := uint32(q.N)
+	if q.N < 0 {
+		buf = append(buf, '-')
+		mag = uint32(-int64(q.N))
+	}
+	if decimals < 0 {
+		decimals = shortestDecimals(mag & fracMask)
+	}
+
+	// All decimals after the 24th are zero.
+	n := decimals
+	if n > 24 {
+		n = 24
+	}
+	var digits [24]byte
+	intPart := mag >> 24
+	if roundDecimals(mag&fracMask, digits[:n]) {
+		intPart++
+	}
+
+	buf = appendUint32(buf, intPart)
+	if decimals > 0 {
+		buf = append(buf, '.')
+		buf = append(buf, digits[:n]...)
+		for i := n; i < decimals; i++ {
+			buf = append(buf, '0')
+		}
+	}
+	return buf
+}
+
+// roundDecimals writes the decimal digits of the Q24 fraction frac to digits,
+// rounded to the length of digits. It returns true if rounding overflowed into
+// the integer part.
+func roundDecimals(frac uint32, digits []byte) (carry bool) {
+	for i := range digits {
+		frac *= 10
+		digits[i] = '0' + byte(frac>>24)
+		frac &= fracMask
+	}
+	if frac < 1<<23 {
+		return false
+	}
+	// Round up, and propagate the carry.
+	for i := len(digits) - 1; i >= 0; i-- {
+		if digits[i] != '9' {
+			digits[i]++
+			return false
+		}
+		digits[i] = '0'
+	}
+	return true
+}
+
+// shortestDecimals returns the smallest number of decimal places needed to
+// represent the Q24 fraction frac without loss. Eight decimals are always
+// enough, because 10^-8 is well below the precision of Q24.
+func shortestDecimals(frac uint32) int {
+	pow := uint64(1)
+	for d := 0; d < 8; d++ {
+		var digits [8]byte
+		f := pow // if the rounding carried, the fraction is exactly 1
+		if !roundDecimals(frac, digits[:d]) {
+			f = 0
+			for _, c := range digits[:d] {
+				f = f*10 + uint64(c-'0')
+			}
+		}
+		// Parse the value back, the same way ParseQ24 does.
+		if (f<<24+pow/2)/pow == uint64(frac) {
+			return d
+		}
+		pow *= 10
+	}
+	return 8
+}
+
+// appendUint32 appends the decimal representation of n to buf.
+func appendUint32(buf []byte, n uint32) []byte {
+	var digits [10]byte
+	i := len(digits)
+	for {
+		i--
+		digits[i] = '0' + byte(n%10)
+		n /= 10
+		if n == 0 {
+			break
+		}
+	}
+	return append(buf, digits[i:]...)
+}
+
+// ParseQ24 parses a decimal number like "-12.375" and returns the nearest Q24
+// value, rounding halfway cases away from zero. Any number of decimal places
+// is accepted and the result is always correctly rounded. No floating point
+// arithmetic is used.
+//
+// If the string is not a valid decimal number, ErrSyntax is returned. If the
+// value does not fit in a Q24, ErrRange is returned together with the largest
+// or smallest representable value.
+func ParseQ24(s string) (Q24, error) {
+	i := 0
+	neg := false
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		neg = s[i] == '-'
+		i++
+	}
+
+	// Integer part. Values that are too big are clamped (but the rest of the
+	// string is still checked for syntax errors).
+	var intPart uint64
+	intDigits := 0
+	for ; i < len(s) && s[i] >= '0' && s[i] <= '9'; i++ {
+		intPart = intPart*10 + uint64(s[i]-'0')
+		if intPart > 1<<7 {
+			intPart = 1<<7 + 1
+		}
+		intDigits++
+	}
+
+	// Fractional part.
+	var frac []byte
+	if i < len(s) && s[i] == '.' {
+		i++
+		start := i
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+		frac = []byte(s[start:i])
+	}
+	if i != len(s) || intDigits+len(frac) == 0 {
+		return Q24{}, ErrSyntax
+	}
+
+	// Multiply the decimal fraction by 2^24, starting at the least significant
+	// digit. What carries out of the first digit is the fraction in Q24
+	// format, and the digits that remain are the part that is lost, which
+	// determines the rounding.
+	var carry uint32
+	for j := len(frac) - 1; j >= 0; j-- {
+		x := uint32(frac[j]-'0')<<24 + carry
+		frac[j] = '0' + byte(x%10)
+		carry = x / 10
+	}
+	mag := intPart<<24 + uint64(carry)
+	if len(frac) > 0 && frac[0] >= '5' {
+		mag++
+	}
+
+	if neg {
+		if mag > 1<<31 {
+			return Q24{-1 << 31}, ErrRange
+		}
+		return Q24{int32(-int64(mag))}, nil
+	}
+	if mag > 1<<31-1 {
+		return Q24{1<<31 - 1}, ErrRange
+	}
+	return Q24{int32(mag)}, nil
+}
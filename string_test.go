@@ -0,0 +1,89 @@
+package fixpoint
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQ24String(t *testing.T) {
+	for _, tc := range []struct {
+		q Q24
+		s string
+	}{
+		{Q24{}, "0"},
+		{Q24FromInt32(1), "1"},
+		{Q24FromInt32(-3), "-3"},
+		{Q24FromFloat(0.5), "0.5"},
+		{Q24FromFloat(-12.375), "-12.375"},
+		{Q24{1}, "0.00000006"},
+		{Q24{-1}, "-0.00000006"},
+		{Q24FromInt32(1).DivRound(Q24FromInt32(3)), "0.3333333"},
+		{Q24{1<<31 - 1}, "127.99999994"},
+		{Q24{-1 << 31}, "-128"},
+	} {
+		assert.Equal(t, tc.s, tc.q.String())
+		assert.Equal(t, tc.s, fmt.Sprint(tc.q))
+		q, err := ParseQ24(tc.s)
+		assert.NoError(t, err, tc.s)
+		assert.Equal(t, tc.q, q, tc.s)
+	}
+
+	third := Q24FromInt32(1).DivRound(Q24FromInt32(3))
+	assert.Equal(t, "0.333333313465118408203125", third.Text(24))
+	assert.Equal(t, "0.33333331346511840820312500", third.Text(26))
+	assert.Equal(t, "0.33", third.Text(2))
+	assert.Equal(t, "0", third.Text(0))
+	assert.Equal(t, "1.000", Q24FromFloat(0.9999).Text(3))
+	assert.Equal(t, "-2.50", Q24FromFloat(-2.4999).Text(2))
+	assert.Equal(t, "-1", Q24FromFloat(-0.5).Text(0))
+	assert.Equal(t, "x=1.5", string(Q24FromFloat(1.5).AppendText([]byte("x="), -1)))
+
+	// Random values must round-trip exactly, both in the shortest and in the
+	// exact representation.
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		q := Q24{int32(r.Uint32())}
+		for _, decimals := range []int{-1, 24} {
+			s := q.Text(decimals)
+			parsed, err := ParseQ24(s)
+			if err != nil || parsed != q {
+				t.Errorf("%d: %s parsed as %d (err: %v)", q.N, s, parsed.N, err)
+			}
+		}
+	}
+}
+
+func TestParseQ24(t *testing.T) {
+	for _, tc := range []struct {
+		s   string
+		q   Q24
+		err error
+	}{
+		{"+1.5", Q24FromFloat(1.5), nil},
+		{".25", Q24FromFloat(0.25), nil},
+		{"-3.", Q24FromInt32(-3), nil},
+		{"007", Q24FromInt32(7), nil},
+		{"0.000000029802322387695312", Q24{0}, nil},  // just below half a step
+		{"0.0000000298023223876953125", Q24{1}, nil}, // exactly half a step
+		{"-0.0000000298023223876953125", Q24{-1}, nil},
+		{"0.00000002980232238769531250000000000001", Q24{1}, nil},
+		{"127.99999997", Q24{1<<31 - 1}, nil},
+		{"127.99999998", Q24{1<<31 - 1}, ErrRange},
+		{"-128", Q24{-1 << 31}, nil},
+		{"-128.00000003", Q24{-1 << 31}, ErrRange},
+		{"1000000000000000000000", Q24{1<<31 - 1}, ErrRange},
+		{"", Q24{}, ErrSyntax},
+		{"-", Q24{}, ErrSyntax},
+		{".", Q24{}, ErrSyntax},
+		{"1.2.3", Q24{}, ErrSyntax},
+		{"1e3", Q24{}, ErrSyntax},
+		{" 1", Q24{}, ErrSyntax},
+	} {
+		q, err := ParseQ24(tc.s)
+		assert.Equal(t, tc.err, err, tc.s)
+		assert.Equal(t, tc.q, q, tc.s)
+	}
+}
@@ -0,0 +1,261 @@
+package fixpoint
+
+// Trigonometric functions, implemented with CORDIC. All angles are in
+// radians.
+//
+// Useful link:
+// https://en.wikipedia.org/wiki/CORDIC
+
+// Constants commonly used together with the trigonometric functions.
+var (
+	Pi     = Q24{piN}     // π
+	HalfPi = Q24{halfPiN} // π/2
+	TwoPi  = Q24{twoPiN}  // 2π
+)
+
+const (
+	piN     = 52707179
+	halfPiN = 26353589
+	twoPiN  = 105414357
+)
+
+// The CORDIC implementation works internally with more bits of precision than
+// Q24 provides: angles are stored in Q3.28 format and vector elements are
+// stored in Q1.30 format.
+const (
+	cordicIterations = 28
+	cordicGainQ30    = 652032874 // 1/K, where K is the CORDIC gain
+)
+
+// cordicAtan contains atan(2^-i) in Q3.28 format.
+var cordicAtan = [cordicIterations]int32{
+	210828714, 124459457, 65760959, 33381290, 16755422, 8385879, 4193963,
+	2097109, 1048571, 524287, 262144, 131072, 65536, 32768, 16384, 8192, 4096,
+	2048, 1024, 512, 256, 128, 64, 32, 16, 8, 4, 2,
+}
+
+// sinCosQ30 returns the sine and cosine of the given angle in Q1.30 format.
+func sinCosQ30(angle Q24) (sin, cos int32) {
+	sin, cos, _, _ = sinCosIterQ30(angle, Convergence{})
+	return
+}
+
+// sinCosIterQ30 returns the sine and cosine of the given angle in Q1.30
+// format, together with the residual angle (that hasn't been rotated) in
+// Q3.28 format and the number of iterations.
+func sinCosIterQ30(angle Q24, c Convergence) (sin, cos, residual int32, iterations int) {
+	// Reduce the angle to the range [-π, π].
+	z := angle.N % twoPiN
+	if z > piN {
+		z -= twoPiN
+	} else if z < -piN {
+		z += twoPiN
+	}
+
+	// CORDIC only converges in the range [-π/2, π/2], so rotate by π if the
+	// angle is outside of that range.
+	negate := false
+	if z > halfPiN {
+		z -= piN
+		negate = true
+	} else if z < -halfPiN {
+		z += piN
+		negate = true
+	}
+
+	// Rotation mode: rotate the vector (1/K, 0) by the angle. The gain is
+	// that of all iterations, which is close enough when stopping early: the
+	// relative difference is about 2^-2n after n iterations, while the
+	// residual angle is about 2^-n.
+	x := int32(cordicGainQ30)
+	y := int32(0)
+	z <<= 4
+	n := c.limit(cordicIterations)
+	for ; iterations < n && !c.done(int64(z), 28); iterations++ {
+		i := uint(iterations)
+		if z >= 0 {
+			x, y, z = x-y>>i, y+x>>i, z-cordicAtan[i]
+		} else {
+			x, y, z = x+y>>i, y-x>>i, z+cordicAtan[i]
+		}
+	}
+	if negate {
+		x, y = -x, -y
+	}
+	return y, x, z, iterations
+}
+
+// roundQ30 converts a Q1.30 number to Q24, rounding to the nearest value.
+func roundQ30(n int32) Q24 {
+	return Q24{(n + 1<<5) >> 6}
+}
+
+// SinCos returns the sine and cosine of the given angle. It is faster than
+// calling Sin and Cos separately. The absolute error is at most 2^-23 for
+// angles in the range [-4π, 4π].
+func SinCos(angle Q24) (sin, cos Q24) {
+	s, c := sinCosQ30(angle)
+	return roundQ30(s), roundQ30(c)
+}
+
+// SinCosIter is like SinCos, but stops the CORDIC iterations as specified by
+// c. Each iteration adds about one bit of precision, up to 28 iterations. It
+// also returns the number of iterations and the residual: the part of the
+// angle that hasn't been rotated, which is the approximate error of the
+// result.
+func SinCosIter(angle Q24, c Convergence) (sin, cos Q24, iterations int, residual Q24) {
+	s, co, z, n := sinCosIterQ30(angle, c)
+	return roundQ30(s), roundQ30(co), n, Q24{(z + 1<<3) >> 4}
+}
+
+// Sin returns the sine of the given angle. See SinCos for the error bound.
+func Sin(angle Q24) Q24 {
+	s, _ := sinCosQ30(angle)
+	return roundQ30(s)
+}
+
+// Cos returns the cosine of the given angle. See SinCos for the error bound.
+func Cos(angle Q24) Q24 {
+	_, c := sinCosQ30(angle)
+	return roundQ30(c)
+}
+
+// Tan returns the tangent of the given angle. Results that do not fit in a Q24
+// (near odd multiples of π/2) saturate to the largest or smallest
+// representable value. The absolute error is at most 2 ULP (2^-23) for
+// results in the range [-1, 1], and grows with the square of the result above
+// that as the cosine gets small.
+func Tan(angle Q24) Q24 {
+	s, c := sinCosQ30(angle)
+	if c == 0 {
+		if s < 0 {
+			return Q24{-1 << 31}
+		}
+		return Q24{1<<31 - 1}
+	}
+	t := (int64(s) << 24) / int64(c)
+	if t > 1<<31-1 {
+		return Q24{1<<31 - 1}
+	}
+	if t < -1<<31 {
+		return Q24{-1 << 31}
+	}
+	return Q24{int32(t)}
+}
+
+// Atan2 returns the angle of the vector (x, y) in the range [-π, π], just
+// like math.Atan2. The absolute error is at most 2^-23. Atan2(0, 0) returns 0.
+func Atan2(y, x Q24) Q24 {
+	z, _, _, _ := atan2Q28(y, x, Convergence{})
+	return Q24{(z + 1<<3) >> 4}
+}
+
+// Atan2Iter is like Atan2, but stops the CORDIC iterations as specified by c.
+// Each iteration adds about one bit of precision, up to 28 iterations. It also
+// returns the number of iterations and the residual: the angle between the
+// rotated vector and the X axis, which is the approximate error of the result.
+func Atan2Iter(y, x Q24, c Convergence) (angle Q24, iterations int, residual Q24) {
+	z, vx, vy, n := atan2Q28(y, x, c)
+	if vx > 0 {
+		residual = Q24{int32((int64(vy) << 24) / int64(vx))}
+	}
+	return Q24{(z + 1<<3) >> 4}, n, residual
+}
+
+// atan2Q28 returns the angle of the vector (x, y) in Q3.28 format, together
+// with the rotated vector and the number of iterations.
+func atan2Q28(y, x Q24, c Convergence) (z, vx, vy int32, iterations int) {
+	if x.N == 0 && y.N == 0 {
+		return 0, 0, 0, 0
+	}
+
+	// Scale the vector so the largest element is in the range [2^28, 2^29).
+	// This avoids overflow during the CORDIC iterations (which grow the
+	// vector by a factor 1.65) while keeping as much precision as possible.
+	x64, y64 := int64(x.N), int64(y.N)
+	max := abs64(x64)
+	if abs64(y64) > max {
+		max = abs64(y64)
+	}
+	for max >= 1<<29 {
+		x64 >>= 1
+		y64 >>= 1
+		max >>= 1
+	}
+	for max < 1<<28 {
+		x64 <<= 1
+		y64 <<= 1
+		max <<= 1
+	}
+	vx, vy = int32(x64), int32(y64)
+
+	// CORDIC only converges for vectors in the right half plane, so rotate
+	// the vector by π if needed.
+	if vx < 0 {
+		if vy >= 0 {
+			z = piN << 4
+		} else {
+			z = -piN << 4
+		}
+		vx, vy = -vx, -vy
+	}
+
+	// Vectoring mode: rotate the vector to the X axis and accumulate the
+	// angle. The residual angle is about vy/vx.
+	n := c.limit(cordicIterations)
+	for ; iterations < n; iterations++ {
+		if c.Epsilon.N > 0 && vx > 0 && c.done((int64(vy)<<24)/int64(vx), 24) {
+			break
+		}
+		i := uint(iterations)
+		if vy > 0 {
+			vx, vy, z = vx+vy>>i, vy-vx>>i, z+cordicAtan[i]
+		} else {
+			vx, vy, z = vx-vy>>i, vy+vx>>i, z-cordicAtan[i]
+		}
+	}
+	return z, vx, vy, iterations
+}
+
+// Atan returns the arctangent of the argument, in the range [-π/2, π/2]. See
+// Atan2 for the error bound.
+func Atan(q Q24) Q24 {
+	return Atan2(q, Q24FromInt32(1))
+}
+
+// Asin returns the arcsine of the argument, in the range [-π/2, π/2]. Inputs
+// outside of the range [-1, 1] are clamped to that range. The absolute error is
+// at most 2^-23.
+func Asin(q Q24) Q24 {
+	if q.N >= 1<<24 {
+		return HalfPi
+	}
+	if q.N <= -1<<24 {
+		return HalfPi.Neg()
+	}
+	// asin(x) = atan2(x, sqrt(1 - x²)), where 1 - x² is calculated in Q48 to
+	// avoid losing precision.
+	cos := sqrt64(1<<48 - uint64(int64(q.N)*int64(q.N)))
+	return Atan2(q, Q24{int32(cos)})
+}
+
+// Acos returns the arccosine of the argument, in the range [0, π]. Inputs
+// outside of the range [-1, 1] are clamped to that range. See Asin for the
+// error bound.
+func Acos(q Q24) Q24 {
+	return HalfPi.Sub(Asin(q))
+}
+
+func abs64(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func abs32(n int32) int32 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
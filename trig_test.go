@@ -0,0 +1,147 @@
+package fixpoint
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSinCos(t *testing.T) {
+	const maxErr = 1.0 / (1 << 23)
+	for f := -4 * math.Pi; f <= 4*math.Pi; f += 0.001 {
+		angle := Q24FromFloat(float32(f))
+		// Use the exact angle the fixed point number represents.
+		exact := float64(angle.N) / (1 << 24)
+		sin, cos := SinCos(angle)
+		if diff := math.Abs(float64(sin.N)/(1<<24) - math.Sin(exact)); diff > maxErr {
+			t.Errorf("Sin(%f): error %g too big", exact, diff)
+		}
+		if diff := math.Abs(float64(cos.N)/(1<<24) - math.Cos(exact)); diff > maxErr {
+			t.Errorf("Cos(%f): error %g too big", exact, diff)
+		}
+		if Sin(angle) != sin || Cos(angle) != cos {
+			t.Errorf("Sin/Cos(%f) differs from SinCos", exact)
+		}
+	}
+}
+
+func TestSinCosIter(t *testing.T) {
+	for f := -4.0; f <= 4; f += 0.01 {
+		angle := Q24FromFloat64(f)
+		sin, cos := SinCos(angle)
+		s, c, iterations, residual := SinCosIter(angle, Convergence{})
+		if s != sin || c != cos || iterations != cordicIterations || residual.N > 1 || residual.N < -1 {
+			t.Errorf("SinCosIter(%f): got %v %v %d %v", f, s, c, iterations, residual)
+		}
+	}
+
+	angle := Q24FromFloat(1)
+	for _, n := range []int{4, 8, 12, 16} {
+		s, c, iterations, residual := SinCosIter(angle, Convergence{MaxIterations: n})
+		maxErr := math.Ldexp(2, -n)
+		if iterations != n || math.Abs(residual.Float64()) > maxErr {
+			t.Errorf("SinCosIter(1, %d): %d iterations, residual %f", n, iterations, residual.Float64())
+		}
+		// The residual is the angle that hasn't been rotated. The remaining
+		// error is caused by the CORDIC gain, which is that of all iterations.
+		done := 1 - residual.Float64()
+		gainErr := math.Ldexp(1, -2*n+1) + math.Ldexp(1, -23)
+		if math.Abs(math.Sin(done)-s.Float64()) > gainErr || math.Abs(math.Cos(done)-c.Float64()) > gainErr {
+			t.Errorf("SinCosIter(1, %d): got %f %f, expected %f %f", n, s.Float64(), c.Float64(), math.Sin(done), math.Cos(done))
+		}
+	}
+	_, _, iterations, residual := SinCosIter(angle, Convergence{Epsilon: Q24FromFloat(0.001)})
+	if iterations >= cordicIterations || math.Abs(residual.Float64()) >= 0.001 {
+		t.Errorf("SinCosIter(1, eps=0.001): %d iterations, residual %f", iterations, residual.Float64())
+	}
+}
+
+func TestTan(t *testing.T) {
+	for f := -1.5; f <= 1.5; f += 0.001 {
+		angle := Q24FromFloat(float32(f))
+		exact := float64(angle.N) / (1 << 24)
+		want := math.Tan(exact)
+		got := float64(Tan(angle).N) / (1 << 24)
+		if diff := math.Abs(got - want); diff > math.Max(1, math.Abs(want))/(1<<20) {
+			t.Errorf("Tan(%f): got %f, want %f", exact, got, want)
+		}
+	}
+	// Check the error bound in ULPs for results in [-1, 1], with every
+	// 97th angle up to π/4.
+	for n := -HalfPi.N / 2; n <= HalfPi.N/2; n += 97 {
+		want := math.Tan(float64(n)/(1<<24)) * (1 << 24)
+		if diff := math.Abs(float64(Tan(Q24{n}).N) - want); diff > 2 {
+			t.Errorf("Tan(%d): error of %f ULP", n, diff)
+		}
+	}
+	if Tan(HalfPi).N < 1<<30 {
+		t.Errorf("Tan(π/2) should be very large, got %f", Tan(HalfPi).Float())
+	}
+}
+
+func TestAtan2(t *testing.T) {
+	const maxErr = 1.0 / (1 << 23)
+	for _, radius := range []float32{0.001, 0.5, 1, 100} {
+		for f := -math.Pi; f <= math.Pi; f += 0.001 {
+			x := Q24FromFloat(radius * float32(math.Cos(f)))
+			y := Q24FromFloat(radius * float32(math.Sin(f)))
+			want := math.Atan2(float64(y.N), float64(x.N))
+			got := float64(Atan2(y, x).N) / (1 << 24)
+			if diff := math.Abs(got - want); diff > maxErr && diff < 2*math.Pi-maxErr {
+				t.Errorf("Atan2(%f, %f): got %f, want %f", y.Float(), x.Float(), got, want)
+			}
+		}
+	}
+	if Atan2(Q24{}, Q24{}) != (Q24{}) {
+		t.Errorf("Atan2(0, 0) should be 0")
+	}
+	if got := Atan2(Q24{}, Q24FromInt32(-1)); got != Pi {
+		t.Errorf("Atan2(0, -1): got %f, want π", got.Float())
+	}
+}
+
+func TestAtan2Iter(t *testing.T) {
+	for f := -3.0; f <= 3; f += 0.01 {
+		y, x := Q24FromFloat64(math.Sin(f)*3), Q24FromFloat64(math.Cos(f)*3)
+		angle, iterations, residual := Atan2Iter(y, x, Convergence{})
+		if angle != Atan2(y, x) || iterations != cordicIterations || math.Abs(residual.Float64()) > 1e-7 {
+			t.Errorf("Atan2Iter(%f): got %v %d %v", f, angle, iterations, residual)
+		}
+	}
+
+	y, x := Q24FromFloat(0.6), Q24FromFloat(-0.8)
+	for _, n := range []int{4, 8, 12} {
+		angle, iterations, residual := Atan2Iter(y, x, Convergence{MaxIterations: n})
+		exact := math.Atan2(y.Float64(), x.Float64())
+		if iterations != n || math.Abs(angle.Float64()+math.Atan(residual.Float64())-exact) > 1e-6 {
+			t.Errorf("Atan2Iter(%d): got %f + %f, expected %f", n, angle.Float64(), residual.Float64(), exact)
+		}
+	}
+	angle, iterations, residual := Atan2Iter(y, x, Convergence{Epsilon: Q24FromFloat(0.01)})
+	if iterations >= 10 || math.Abs(residual.Float64()) >= 0.01 || math.Abs(angle.Float64()-math.Atan2(0.6, -0.8)) > 0.011 {
+		t.Errorf("Atan2Iter(eps=0.01): got %f after %d iterations, residual %f", angle.Float64(), iterations, residual.Float64())
+	}
+	angle, iterations, _ = Atan2Iter(Q24{}, Q24{}, Convergence{})
+	if angle.N != 0 || iterations != 0 {
+		t.Errorf("Atan2Iter(0, 0): got %v after %d iterations", angle, iterations)
+	}
+}
+
+func TestAsinAcos(t *testing.T) {
+	const maxErr = 1.0 / (1 << 23)
+	for f := -1.0; f <= 1; f += 0.0005 {
+		q := Q24FromFloat(float32(f))
+		exact := float64(q.N) / (1 << 24)
+		if diff := math.Abs(float64(Asin(q).N)/(1<<24) - math.Asin(exact)); diff > maxErr {
+			t.Errorf("Asin(%f): error %g too big", exact, diff)
+		}
+		if diff := math.Abs(float64(Acos(q).N)/(1<<24) - math.Acos(exact)); diff > maxErr {
+			t.Errorf("Acos(%f): error %g too big", exact, diff)
+		}
+	}
+	if Asin(Q24FromInt32(1)) != HalfPi || Asin(Q24FromInt32(2)) != HalfPi {
+		t.Errorf("Asin(1) should be π/2")
+	}
+	if Asin(Q24FromInt32(-1)) != HalfPi.Neg() {
+		t.Errorf("Asin(-1) should be -π/2")
+	}
+}
@@ -0,0 +1,246 @@
+// Package units provides thin wrapper types over fixpoint.Q24 for physical
+// quantities. Fixed point values all look like an int32, which makes it easy
+// to mix up units without noticing. The types in this package can't be mixed
+// up by accident: they only convert to each other through methods that do
+// the right calculation.
+//
+// All types are defined as a fixpoint.Q24, so they have the same size and a
+// value can be converted to and from a Q24 with a type conversion:
+//
+//	d := units.Meters(fixpoint.Q24FromFloat(1.5))
+//	q := fixpoint.Q24(d)
+package units
+
+import (
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/fixmath"
+)
+
+// Meters is a distance in meters.
+type Meters fixpoint.Q24
+
+// Seconds is a duration in seconds.
+type Seconds fixpoint.Q24
+
+// MetersPerSecond is a speed in meters per second.
+type MetersPerSecond fixpoint.Q24
+
+// MetersPerSecond2 is an acceleration in meters per second squared.
+type MetersPerSecond2 fixpoint.Q24
+
+// Gs is an acceleration in units of standard gravity (9.80665 m/s²), the unit
+// used by most accelerometers.
+type Gs fixpoint.Q24
+
+// Radians is an angle in radians.
+type Radians fixpoint.Q24
+
+// Conversion factors in fixed point format.
+const (
+	standardGravityQ28 = 2632452565 // 9.80665 m/s² per g
+	gPerMS2Q32         = 437964779  // 1/9.80665 g per m/s²
+	degPerRadQ24       = 961263669  // 180/π
+	radPerDegQ36       = 1199381129 // π/180
+)
+
+// Q24 returns this distance as a plain number.
+func (d Meters) Q24() fixpoint.Q24 {
+	return fixpoint.Q24(d)
+}
+
+// Add returns the sum of both distances.
+func (d Meters) Add(d2 Meters) Meters {
+	return Meters{N: d.N + d2.N}
+}
+
+// Sub returns this distance minus the argument.
+func (d Meters) Sub(d2 Meters) Meters {
+	return Meters{N: d.N - d2.N}
+}
+
+// Mul returns this distance scaled by the argument.
+func (d Meters) Mul(c fixpoint.Q24) Meters {
+	return Meters(fixpoint.Q24(d).MulRound(c))
+}
+
+// Div returns the speed needed to travel this distance in the given time.
+func (d Meters) Div(t Seconds) MetersPerSecond {
+	return MetersPerSecond(fixpoint.Q24(d).DivRound(fixpoint.Q24(t)))
+}
+
+// String returns this distance with the unit, like "1.5m".
+func (d Meters) String() string {
+	return fixpoint.Q24(d).String() + "m"
+}
+
+// Q24 returns this duration as a plain number.
+func (t Seconds) Q24() fixpoint.Q24 {
+	return fixpoint.Q24(t)
+}
+
+// Add returns the sum of both durations.
+func (t Seconds) Add(t2 Seconds) Seconds {
+	return Seconds{N: t.N + t2.N}
+}
+
+// Sub returns this duration minus the argument.
+func (t Seconds) Sub(t2 Seconds) Seconds {
+	return Seconds{N: t.N - t2.N}
+}
+
+// Mul returns this duration scaled by the argument.
+func (t Seconds) Mul(c fixpoint.Q24) Seconds {
+	return Seconds(fixpoint.Q24(t).MulRound(c))
+}
+
+// String returns this duration with the unit, like "0.01s".
+func (t Seconds) String() string {
+	return fixpoint.Q24(t).String() + "s"
+}
+
+// Q24 returns this speed as a plain number.
+func (v MetersPerSecond) Q24() fixpoint.Q24 {
+	return fixpoint.Q24(v)
+}
+
+// Add returns the sum of both speeds.
+func (v MetersPerSecond) Add(v2 MetersPerSecond) MetersPerSecond {
+	return MetersPerSecond{N: v.N + v2.N}
+}
+
+// Sub returns this speed minus the argument.
+func (v MetersPerSecond) Sub(v2 MetersPerSecond) MetersPerSecond {
+	return MetersPerSecond{N: v.N - v2.N}
+}
+
+// Mul returns this speed scaled by the argument.
+func (v MetersPerSecond) Mul(c fixpoint.Q24) MetersPerSecond {
+	return MetersPerSecond(fixpoint.Q24(v).MulRound(c))
+}
+
+// Distance returns the distance traveled at this speed in the given time.
+func (v MetersPerSecond) Distance(t Seconds) Meters {
+	return Meters(fixpoint.Q24(v).MulRound(fixpoint.Q24(t)))
+}
+
+// Div returns the acceleration needed to reach this speed in the given time.
+func (v MetersPerSecond) Div(t Seconds) MetersPerSecond2 {
+	return MetersPerSecond2(fixpoint.Q24(v).DivRound(fixpoint.Q24(t)))
+}
+
+// String returns this speed with the unit, like "2.5m/s".
+func (v MetersPerSecond) String() string {
+	return fixpoint.Q24(v).String() + "m/s"
+}
+
+// Q24 returns this acceleration as a plain number.
+func (a MetersPerSecond2) Q24() fixpoint.Q24 {
+	return fixpoint.Q24(a)
+}
+
+// Add returns the sum of both accelerations.
+func (a MetersPerSecond2) Add(a2 MetersPerSecond2) MetersPerSecond2 {
+	return MetersPerSecond2{N: a.N + a2.N}
+}
+
+// Sub returns this acceleration minus the argument.
+func (a MetersPerSecond2) Sub(a2 MetersPerSecond2) MetersPerSecond2 {
+	return MetersPerSecond2{N: a.N - a2.N}
+}
+
+// Mul returns this acceleration scaled by the argument.
+func (a MetersPerSecond2) Mul(c fixpoint.Q24) MetersPerSecond2 {
+	return MetersPerSecond2(fixpoint.Q24(a).MulRound(c))
+}
+
+// Speed returns the change in speed caused by this acceleration during the
+// given time.
+func (a MetersPerSecond2) Speed(t Seconds) MetersPerSecond {
+	return MetersPerSecond(fixpoint.Q24(a).MulRound(fixpoint.Q24(t)))
+}
+
+// Gs converts this acceleration to units of standard gravity, rounded to the
+// nearest value.
+func (a MetersPerSecond2) Gs() Gs {
+	return Gs{N: int32((int64(a.N)*gPerMS2Q32 + 1<<31) >> 32)}
+}
+
+// String returns this acceleration with the unit, like "9.81m/s²".
+func (a MetersPerSecond2) String() string {
+	return fixpoint.Q24(a).String() + "m/s²"
+}
+
+// Q24 returns this acceleration as a plain number.
+func (g Gs) Q24() fixpoint.Q24 {
+	return fixpoint.Q24(g)
+}
+
+// Add returns the sum of both accelerations.
+func (g Gs) Add(g2 Gs) Gs {
+	return Gs{N: g.N + g2.N}
+}
+
+// Sub returns this acceleration minus the argument.
+func (g Gs) Sub(g2 Gs) Gs {
+	return Gs{N: g.N - g2.N}
+}
+
+// Mul returns this acceleration scaled by the argument.
+func (g Gs) Mul(c fixpoint.Q24) Gs {
+	return Gs(fixpoint.Q24(g).MulRound(c))
+}
+
+// MetersPerSecond2 converts this acceleration to meters per second squared,
+// rounded to the nearest value. The result saturates for accelerations above
+// about 13g, which don't fit in a Q24 in m/s².
+func (g Gs) MetersPerSecond2() MetersPerSecond2 {
+	return MetersPerSecond2(fixpoint.Q24{N: fixmath.Saturate((int64(g.N)*standardGravityQ28 + 1<<27) >> 28)})
+}
+
+// String returns this acceleration with the unit, like "1g".
+func (g Gs) String() string {
+	return fixpoint.Q24(g).String() + "g"
+}
+
+// RadiansFromDegrees converts an angle in degrees to radians, rounded to the
+// nearest value. The result saturates for angles above about ±7333°, which
+// don't fit in a Q24 in radians.
+func RadiansFromDegrees(deg fixpoint.Q16) Radians {
+	return Radians(fixpoint.Q24{N: fixmath.Saturate((int64(deg.N)*radPerDegQ36 + 1<<27) >> 28)})
+}
+
+// Q24 returns this angle as a plain number.
+func (r Radians) Q24() fixpoint.Q24 {
+	return fixpoint.Q24(r)
+}
+
+// Add returns the sum of both angles.
+func (r Radians) Add(r2 Radians) Radians {
+	return Radians{N: r.N + r2.N}
+}
+
+// Sub returns this angle minus the argument.
+func (r Radians) Sub(r2 Radians) Radians {
+	return Radians{N: r.N - r2.N}
+}
+
+// Mul returns this angle scaled by the argument.
+func (r Radians) Mul(c fixpoint.Q24) Radians {
+	return Radians(fixpoint.Q24(r).MulRound(c))
+}
+
+// Degrees converts this angle to degrees, rounded to the nearest value. A Q16
+// is used as degrees don't fit in a Q24.
+func (r Radians) Degrees() fixpoint.Q16 {
+	return fixpoint.Q16{N: int32((int64(r.N)*degPerRadQ24 + 1<<31) >> 32)}
+}
+
+// SinCos returns the sine and cosine of this angle, see fixpoint.SinCos.
+func (r Radians) SinCos() (sin, cos fixpoint.Q24) {
+	return fixpoint.SinCos(fixpoint.Q24(r))
+}
+
+// String returns this angle with the unit, like "1.5708rad".
+func (r Radians) String() string {
+	return fixpoint.Q24(r).String() + "rad"
+}
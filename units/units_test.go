@@ -0,0 +1,74 @@
+package units
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMotion(t *testing.T) {
+	d := Meters(fixpoint.Q24FromFloat(10))
+	dt := Seconds(fixpoint.Q24FromFloat(4))
+	v := d.Div(dt)
+	assert.Equal(t, MetersPerSecond(fixpoint.Q24FromFloat(2.5)), v)
+	assert.Equal(t, d, v.Distance(dt))
+	a := v.Div(dt)
+	assert.Equal(t, MetersPerSecond2(fixpoint.Q24FromFloat(0.625)), a)
+	assert.Equal(t, v, a.Speed(dt))
+
+	assert.Equal(t, Meters(fixpoint.Q24FromFloat(15)), d.Add(d.Mul(fixpoint.Q24FromFloat(0.5))))
+	assert.Equal(t, Meters{}, d.Sub(d))
+	assert.Equal(t, Seconds(fixpoint.Q24FromFloat(6)), dt.Add(dt).Sub(dt.Mul(fixpoint.Q24FromFloat(0.5))))
+	assert.Equal(t, MetersPerSecond(fixpoint.Q24FromFloat(5)), v.Add(v).Sub(v.Mul(fixpoint.Q24{})))
+	assert.Equal(t, fixpoint.Q24FromFloat(10), d.Q24())
+	assert.Equal(t, fixpoint.Q24FromFloat(4), dt.Q24())
+	assert.Equal(t, fixpoint.Q24FromFloat(2.5), v.Q24())
+
+	assert.Equal(t, "10m", d.String())
+	assert.Equal(t, "4s", dt.String())
+	assert.Equal(t, "2.5m/s", v.String())
+	assert.Equal(t, "0.625m/s²", a.String())
+}
+
+func TestGs(t *testing.T) {
+	g := Gs(fixpoint.Q24FromInt32(1))
+	assert.Equal(t, MetersPerSecond2(fixpoint.Q24FromFloat64(9.80665)), g.MetersPerSecond2())
+	assert.Equal(t, g, g.MetersPerSecond2().Gs())
+	for _, f := range []float64{-12, -0.5, 0.001, 3.3, 13} {
+		g := Gs(fixpoint.Q24FromFloat64(f))
+		assert.InDelta(t, g.Q24().Float64()*9.80665, g.MetersPerSecond2().Q24().Float64(), 1.0/(1<<24))
+		assert.Equal(t, g, g.MetersPerSecond2().Gs())
+	}
+	assert.Equal(t, MetersPerSecond2(fixpoint.MaxQ24), Gs(fixpoint.Q24FromInt32(14)).MetersPerSecond2())
+	assert.Equal(t, MetersPerSecond2(fixpoint.MinQ24), Gs(fixpoint.Q24FromInt32(-14)).MetersPerSecond2())
+
+	assert.Equal(t, Gs(fixpoint.Q24FromFloat(1.5)), g.Add(g.Mul(fixpoint.Q24FromFloat(0.5))))
+	assert.Equal(t, Gs{}, g.Sub(g))
+	assert.Equal(t, fixpoint.Q24FromInt32(1), g.Q24())
+	assert.Equal(t, "1g", g.String())
+	a := MetersPerSecond2(fixpoint.Q24FromInt32(2))
+	assert.Equal(t, MetersPerSecond2(fixpoint.Q24FromInt32(3)), a.Add(a.Mul(fixpoint.Q24FromFloat(0.5))))
+	assert.Equal(t, MetersPerSecond2{}, a.Sub(a))
+}
+
+func TestRadians(t *testing.T) {
+	for _, deg := range []float64{-7000, -180, -45, 0, 0.01, 90, 359.5} {
+		q := fixpoint.Q16FromFloat(float32(deg))
+		r := RadiansFromDegrees(q)
+		assert.InDelta(t, float64(q.N)/(1<<16)*math.Pi/180, r.Q24().Float64(), 1.0/(1<<24))
+		assert.InDelta(t, deg, float64(r.Degrees().N)/(1<<16), 1.0/(1<<16))
+	}
+	assert.Equal(t, Radians(fixpoint.Pi), RadiansFromDegrees(fixpoint.Q16FromInt32(180)))
+	assert.Equal(t, Radians(fixpoint.MaxQ24), RadiansFromDegrees(fixpoint.Q16FromInt32(8000)))
+	assert.Equal(t, Radians(fixpoint.MinQ24), RadiansFromDegrees(fixpoint.Q16FromInt32(-8000)))
+
+	r := Radians(fixpoint.HalfPi)
+	sin, cos := r.SinCos()
+	assert.Equal(t, fixpoint.Q24FromInt32(1), sin)
+	assert.Equal(t, fixpoint.Q24{}, cos)
+	assert.Equal(t, Radians{N: 4 * fixpoint.HalfPi.N}, r.Add(r).Add(r.Mul(fixpoint.Q24FromInt32(2))))
+	assert.Equal(t, Radians{}, r.Sub(r))
+	assert.Equal(t, "1.5707963rad", r.String())
+}
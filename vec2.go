@@ -0,0 +1,102 @@
+package fixpoint
+
+// Vec2Q24 is a 2-dimensional vector with Q24 fixed point elements.
+type Vec2Q24 struct {
+	X Q24
+	Y Q24
+}
+
+// Vec2Q24FromFloat returns the fixed-point vector of the given 2 floats.
+func Vec2Q24FromFloat(x, y float32) Vec2Q24 {
+	return Vec2Q24{Q24FromFloat(x), Q24FromFloat(y)}
+}
+
+// Add returns this vector added to the argument.
+func (v1 Vec2Q24) Add(v2 Vec2Q24) Vec2Q24 {
+	return Vec2Q24{v1.X.Add(v2.X), v1.Y.Add(v2.Y)}
+}
+
+// Sub returns the argument subtracted from this vector.
+func (v1 Vec2Q24) Sub(v2 Vec2Q24) Vec2Q24 {
+	return Vec2Q24{v1.X.Sub(v2.X), v1.Y.Sub(v2.Y)}
+}
+
+// Neg returns the inverse of this vector.
+func (v Vec2Q24) Neg() Vec2Q24 {
+	return Vec2Q24{v.X.Neg(), v.Y.Neg()}
+}
+
+// Mul returns this vector multiplied by the argument.
+func (v1 Vec2Q24) Mul(c Q24) Vec2Q24 {
+	return Vec2Q24{v1.X.MulRound(c), v1.Y.MulRound(c)}
+}
+
+// Div returns this vector divided by the argument, rounded to the nearest
+// representable value.
+func (v1 Vec2Q24) Div(c Q24) Vec2Q24 {
+	return Vec2Q24{v1.X.DivRound(c), v1.Y.DivRound(c)}
+}
+
+// MulComponents returns the component-wise product of this vector and the
+// argument.
+func (v1 Vec2Q24) MulComponents(v2 Vec2Q24) Vec2Q24 {
+	return Vec2Q24{v1.X.MulRound(v2.X), v1.Y.MulRound(v2.Y)}
+}
+
+// Dot returns the dot product between this vector and the argument.
+func (v1 Vec2Q24) Dot(v2 Vec2Q24) Q24 {
+	return v1.X.MulRound(v2.X).Add(v1.Y.MulRound(v2.Y))
+}
+
+// Cross returns the Z component of the cross product of this vector and the
+// argument, when both are treated as 3D vectors in the XY plane. It is positive
+// when the argument is counter-clockwise from this vector.
+func (v1 Vec2Q24) Cross(v2 Vec2Q24) Q24 {
+	return Q24{int32((int64(v1.X.N)*int64(v2.Y.N) - int64(v1.Y.N)*int64(v2.X.N) + 1<<23) >> 24)}
+}
+
+// Len2 returns the squared length of this vector. Note that it overflows for
+// vectors longer than about 11.3, use Len for those.
+func (v Vec2Q24) Len2() Q24 {
+	return v.Dot(v)
+}
+
+// Len returns the length of this vector.
+func (v Vec2Q24) Len() Q24 {
+	return Q24{int32(sqrt64(v.len2Q48()))}
+}
+
+// Normalize returns this vector scaled to unit length. The zero vector is
+// returned unmodified.
+func (v Vec2Q24) Normalize() Vec2Q24 {
+	len2 := v.len2Q48()
+	if len2 == 0 {
+		return v
+	}
+	r, shift := recipSqrtQ48(len2)
+	return Vec2Q24{mulRecip(v.X, r, shift), mulRecip(v.Y, r, shift)}
+}
+
+// Rotate returns this vector rotated counter-clockwise by the given angle (in
+// radians).
+func (v Vec2Q24) Rotate(angle Q24) Vec2Q24 {
+	sin, cos := SinCos(angle)
+	x, y := int64(v.X.N), int64(v.Y.N)
+	s, c := int64(sin.N), int64(cos.N)
+	return Vec2Q24{
+		Q24{int32((x*c - y*s + 1<<23) >> 24)},
+		Q24{int32((x*s + y*c + 1<<23) >> 24)},
+	}
+}
+
+// Angle returns the angle of this vector relative to the X axis, in the range
+// [-π, π].
+func (v Vec2Q24) Angle() Q24 {
+	return Atan2(v.Y, v.X)
+}
+
+// len2Q48 returns the squared length of this vector in Q48 format.
+func (v Vec2Q24) len2Q48() uint64 {
+	x, y := int64(v.X.N), int64(v.Y.N)
+	return uint64(x*x) + uint64(y*y)
+}
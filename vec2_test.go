@@ -0,0 +1,36 @@
+package fixpoint
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVec2(t *testing.T) {
+	v1 := Vec2Q24FromFloat(3, -4)
+	v2 := Vec2Q24FromFloat(0.5, 2)
+	assert.Equal(t, Vec2Q24FromFloat(3.5, -2), v1.Add(v2))
+	assert.Equal(t, Vec2Q24FromFloat(2.5, -6), v1.Sub(v2))
+	assert.Equal(t, Vec2Q24FromFloat(-3, 4), v1.Neg())
+	assert.Equal(t, Vec2Q24FromFloat(1.5, -2), v1.Mul(Q24FromFloat(0.5)))
+	assert.Equal(t, Vec2Q24FromFloat(1.5, -2), v1.Div(Q24FromInt32(2)))
+	assert.Equal(t, Vec2Q24FromFloat(1.5, -8), v1.MulComponents(v2))
+	assert.Equal(t, Q24FromFloat(-6.5), v1.Dot(v2))
+	assert.Equal(t, Q24FromFloat(8), v1.Cross(v2))
+	assert.Equal(t, Q24FromFloat(25), v1.Len2())
+	assert.Equal(t, Q24FromFloat(5), v1.Len())
+	assert.Equal(t, Vec2Q24FromFloat(0.6, -0.8), v1.Normalize())
+	assert.Equal(t, Vec2Q24{}, Vec2Q24{}.Normalize())
+
+	// Compare rotation against mathgl.
+	v := mgl32.Vec2{1.5, -0.25}
+	for _, angle := range []float32{0, 0.1, 1, -2, 3} {
+		expected := mgl32.Rotate2D(angle).Mul2x1(v)
+		actual := Vec2Q24FromFloat(v[0], v[1]).Rotate(Q24FromFloat(angle))
+		assert.InDelta(t, expected.X(), actual.X.Float(), 0.00001, "angle %f", angle)
+		assert.InDelta(t, expected.Y(), actual.Y.Float(), 0.00001, "angle %f", angle)
+		assert.InDelta(t, math.Atan2(float64(expected.Y()), float64(expected.X())), actual.Angle().Float(), 0.00001, "angle %f", angle)
+	}
+}
@@ -0,0 +1,233 @@
+// Package verlet simulates particles connected by distance constraints with
+// Verlet integration, for rope and cloth effects on LED matrices and small
+// displays. Each particle only stores its current and previous position: the
+// velocity is implied by the difference, so constraints can move particles
+// around without breaking the integration.
+//
+// The particles are stored in slices of Vec2Q24 or Vec3Q24, and a constraint
+// refers to two particles by their index. All functions work in place and
+// don't allocate, and the result only depends on the inputs and the order of
+// the constraints, so a simulation is the same on every system.
+package verlet
+
+import (
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/internal/fixmath"
+)
+
+// Constraint keeps two particles at a fixed distance from each other, like a
+// stick between them. The particles must stay less than 128 apart, as their
+// distance is calculated as a Q24.
+type Constraint struct {
+	A, B   int
+	Length fixpoint.Q24
+}
+
+// Solver integrates particles and relaxes the constraints between them.
+//
+// The zero value doesn't enforce any constraints: Iterations and Stiffness
+// must be set.
+type Solver struct {
+	// Iterations is the number of times all constraints are relaxed per
+	// step. More iterations make the constraints stiffer, at the cost of
+	// more computation: a long rope needs more iterations to not stretch.
+	Iterations int
+
+	// Stiffness is the part of the error of a constraint that is corrected
+	// per iteration, between 0 and 1. With a stiffness of 1 each constraint
+	// is exactly satisfied after it has been relaxed (until the next
+	// constraint moves one of its particles again).
+	Stiffness fixpoint.Q24
+
+	// Damping is the part of the velocity that is lost each step, between 0
+	// (no damping) and 1 (particles stop immediately).
+	Damping fixpoint.Q24
+}
+
+// Step2 advances the simulation by dt seconds: it integrates all particles
+// with the given acceleration (for example gravity) and then relaxes the
+// constraints. Particles for which pinned is true don't move. The pinned
+// slice may be nil or shorter than positions, in which case the remaining
+// particles are free.
+func (s *Solver) Step2(positions, previous []fixpoint.Vec2Q24, pinned []bool, constraints []Constraint, accel fixpoint.Vec2Q24, dt fixpoint.Q24) {
+	s.Integrate2(positions, previous, pinned, accel, dt)
+	s.Relax2(positions, pinned, constraints)
+}
+
+// Step3 is like Step2, for particles in three dimensions.
+func (s *Solver) Step3(positions, previous []fixpoint.Vec3Q24, pinned []bool, constraints []Constraint, accel fixpoint.Vec3Q24, dt fixpoint.Q24) {
+	s.Integrate3(positions, previous, pinned, accel, dt)
+	s.Relax3(positions, pinned, constraints)
+}
+
+// Integrate2 moves all free particles by their (damped) velocity plus the
+// acceleration times dt², and stores the old positions in previous. Both
+// slices must have the same length.
+func (s *Solver) Integrate2(positions, previous []fixpoint.Vec2Q24, pinned []bool, accel fixpoint.Vec2Q24, dt fixpoint.Q24) {
+	previous = previous[:len(positions)]
+	keep := int64(1<<24 - s.Damping.N)
+	ax, ay := step(accel.X, dt), step(accel.Y, dt)
+	for i, p := range positions {
+		if isPinned(pinned, i) {
+			previous[i] = p
+			continue
+		}
+		q := previous[i]
+		previous[i] = p
+		positions[i].X.N = integrate(p.X.N, q.X.N, keep, ax)
+		positions[i].Y.N = integrate(p.Y.N, q.Y.N, keep, ay)
+	}
+}
+
+// Integrate3 is like Integrate2, for particles in three dimensions.
+func (s *Solver) Integrate3(positions, previous []fixpoint.Vec3Q24, pinned []bool, accel fixpoint.Vec3Q24, dt fixpoint.Q24) {
+	previous = previous[:len(positions)]
+	keep := int64(1<<24 - s.Damping.N)
+	ax, ay, az := step(accel.X, dt), step(accel.Y, dt), step(accel.Z, dt)
+	for i, p := range positions {
+		if isPinned(pinned, i) {
+			previous[i] = p
+			continue
+		}
+		q := previous[i]
+		previous[i] = p
+		positions[i].X.N = integrate(p.X.N, q.X.N, keep, ax)
+		positions[i].Y.N = integrate(p.Y.N, q.Y.N, keep, ay)
+		positions[i].Z.N = integrate(p.Z.N, q.Z.N, keep, az)
+	}
+}
+
+// Relax2 moves the particles of each constraint towards (or away from) each
+// other to restore its length, Iterations times. Both particles move by the
+// same amount, unless one of them is pinned: then the other particle makes
+// the whole correction. Constraints between two pinned particles and between
+// particles at the same position are skipped.
+func (s *Solver) Relax2(positions []fixpoint.Vec2Q24, pinned []bool, constraints []Constraint) {
+	for iter := 0; iter < s.Iterations; iter++ {
+		for _, c := range constraints {
+			a, b := &positions[c.A], &positions[c.B]
+			ka, kb, ok := weights(pinned, c)
+			if !ok {
+				continue
+			}
+			dx, dy := int64(b.X.N)-int64(a.X.N), int64(b.Y.N)-int64(a.Y.N)
+			d := int64(b.Sub(*a).Len().N)
+			f, ok := s.factor(d, c.Length)
+			if !ok {
+				continue
+			}
+			cx, cy := mulRound(dx, f), mulRound(dy, f)
+			a.X.N = fixmath.Saturate(int64(a.X.N) + mulRound(cx, ka))
+			a.Y.N = fixmath.Saturate(int64(a.Y.N) + mulRound(cy, ka))
+			b.X.N = fixmath.Saturate(int64(b.X.N) - mulRound(cx, kb))
+			b.Y.N = fixmath.Saturate(int64(b.Y.N) - mulRound(cy, kb))
+		}
+	}
+}
+
+// Relax3 is like Relax2, for particles in three dimensions.
+func (s *Solver) Relax3(positions []fixpoint.Vec3Q24, pinned []bool, constraints []Constraint) {
+	for iter := 0; iter < s.Iterations; iter++ {
+		for _, c := range constraints {
+			a, b := &positions[c.A], &positions[c.B]
+			ka, kb, ok := weights(pinned, c)
+			if !ok {
+				continue
+			}
+			dx, dy, dz := int64(b.X.N)-int64(a.X.N), int64(b.Y.N)-int64(a.Y.N), int64(b.Z.N)-int64(a.Z.N)
+			d := int64(b.Sub(*a).Len().N)
+			f, ok := s.factor(d, c.Length)
+			if !ok {
+				continue
+			}
+			cx, cy, cz := mulRound(dx, f), mulRound(dy, f), mulRound(dz, f)
+			a.X.N = fixmath.Saturate(int64(a.X.N) + mulRound(cx, ka))
+			a.Y.N = fixmath.Saturate(int64(a.Y.N) + mulRound(cy, ka))
+			a.Z.N = fixmath.Saturate(int64(a.Z.N) + mulRound(cz, ka))
+			b.X.N = fixmath.Saturate(int64(b.X.N) - mulRound(cx, kb))
+			b.Y.N = fixmath.Saturate(int64(b.Y.N) - mulRound(cy, kb))
+			b.Z.N = fixmath.Saturate(int64(b.Z.N) - mulRound(cz, kb))
+		}
+	}
+}
+
+// Rope appends the constraints of a rope of n particles, starting at index
+// first, to dst. Neighboring particles are the given length apart.
+func Rope(dst []Constraint, first, n int, length fixpoint.Q24) []Constraint {
+	for i := first; i < first+n-1; i++ {
+		dst = append(dst, Constraint{A: i, B: i + 1, Length: length})
+	}
+	return dst
+}
+
+// Cloth appends the constraints of a rectangular cloth of width by height
+// particles, starting at index first, to dst. The particles are stored row
+// by row, and neighboring particles in a row or column are the given length
+// apart. For each particle, the constraint to its right neighbor comes
+// before the constraint to the particle below it.
+func Cloth(dst []Constraint, first, width, height int, length fixpoint.Q24) []Constraint {
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			i := first + y*width + x
+			if x+1 < width {
+				dst = append(dst, Constraint{A: i, B: i + 1, Length: length})
+			}
+			if y+1 < height {
+				dst = append(dst, Constraint{A: i, B: i + width, Length: length})
+			}
+		}
+	}
+	return dst
+}
+
+// factor returns the part of the difference vector between two particles
+// that is d apart to correct, in Q24 format: (d - length) / d * Stiffness.
+// It returns false when the particles are at the same position, as the
+// direction to correct in is unknown.
+func (s *Solver) factor(d int64, length fixpoint.Q24) (int64, bool) {
+	if d == 0 {
+		return 0, false
+	}
+	f := ((d - int64(length.N)) << 24) / d
+	return (f*int64(s.Stiffness.N) + 1<<23) >> 24, true
+}
+
+// weights returns the part of the correction of a constraint that each of its
+// particles makes, in Q24 format.
+func weights(pinned []bool, c Constraint) (ka, kb int64, ok bool) {
+	pa, pb := isPinned(pinned, c.A), isPinned(pinned, c.B)
+	switch {
+	case pa && pb:
+		return 0, 0, false
+	case pa:
+		return 0, 1 << 24, true
+	case pb:
+		return 1 << 24, 0, true
+	default:
+		return 1 << 23, 1 << 23, true
+	}
+}
+
+// isPinned returns whether particle i is pinned.
+func isPinned(pinned []bool, i int) bool {
+	return i < len(pinned) && pinned[i]
+}
+
+// step returns accel*dt² in Q24 format as an int64.
+func step(accel, dt fixpoint.Q24) int64 {
+	n := (int64(accel.N)*int64(dt.N) + 1<<23) >> 24
+	return (n*int64(dt.N) + 1<<23) >> 24
+}
+
+// integrate returns the next position of a particle at p with previous
+// position q: p + (p-q)*keep + step, where keep is in Q24 format.
+func integrate(p, q int32, keep, step int64) int32 {
+	v := int64(p) - int64(q)
+	return fixmath.Saturate(int64(p) + mulRound(v, keep) + step)
+}
+
+// mulRound returns n multiplied by the Q24 number f, rounded to the nearest
+// value.
+func mulRound(n, f int64) int64 {
+	return (n*f + 1<<23) >> 24
+}
@@ -0,0 +1,129 @@
+package verlet
+
+import (
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelax(t *testing.T) {
+	s := Solver{Iterations: 1, Stiffness: fixpoint.Q24FromInt32(1)}
+
+	// Two free particles both move half the error.
+	positions := []fixpoint.Vec2Q24{fixpoint.Vec2Q24FromFloat(0, 0), fixpoint.Vec2Q24FromFloat(2, 0)}
+	constraints := []Constraint{{A: 0, B: 1, Length: fixpoint.Q24FromInt32(1)}}
+	s.Relax2(positions, nil, constraints)
+	assert.Equal(t, []fixpoint.Vec2Q24{fixpoint.Vec2Q24FromFloat(0.5, 0), fixpoint.Vec2Q24FromFloat(1.5, 0)}, positions)
+
+	// A pinned particle doesn't move, the other one makes the whole
+	// correction.
+	positions = []fixpoint.Vec2Q24{fixpoint.Vec2Q24FromFloat(0, 0), fixpoint.Vec2Q24FromFloat(0, 0.5)}
+	s.Relax2(positions, []bool{true}, constraints)
+	assert.Equal(t, []fixpoint.Vec2Q24{fixpoint.Vec2Q24FromFloat(0, 0), fixpoint.Vec2Q24FromFloat(0, 1)}, positions)
+
+	// Two pinned particles and particles at the same position are left
+	// alone.
+	positions[1] = fixpoint.Vec2Q24FromFloat(0, 3)
+	s.Relax2(positions, []bool{true, true}, constraints)
+	assert.Equal(t, fixpoint.Vec2Q24FromFloat(0, 3), positions[1])
+	positions[1] = positions[0]
+	s.Relax2(positions, nil, constraints)
+	assert.Equal(t, positions[0], positions[1])
+
+	// A lower stiffness corrects part of the error.
+	s.Stiffness = fixpoint.Q24FromFloat(0.5)
+	positions3 := []fixpoint.Vec3Q24{fixpoint.Vec3Q24FromFloat(0, 0, 0), fixpoint.Vec3Q24FromFloat(0, 0, 3)}
+	s.Relax3(positions3, nil, constraints)
+	assert.Equal(t, []fixpoint.Vec3Q24{fixpoint.Vec3Q24FromFloat(0, 0, 0.5), fixpoint.Vec3Q24FromFloat(0, 0, 2.5)}, positions3)
+}
+
+func TestIntegrate(t *testing.T) {
+	var s Solver
+	dt := fixpoint.Q24FromFloat(0.5)
+
+	// A particle keeps its velocity and accelerates by accel*dt².
+	positions := []fixpoint.Vec2Q24{fixpoint.Vec2Q24FromFloat(1, 1), fixpoint.Vec2Q24FromFloat(1, 1)}
+	previous := []fixpoint.Vec2Q24{fixpoint.Vec2Q24FromFloat(0, 1), fixpoint.Vec2Q24FromFloat(0, 1)}
+	s.Integrate2(positions, previous, []bool{false, true}, fixpoint.Vec2Q24FromFloat(0, -4), dt)
+	assert.Equal(t, fixpoint.Vec2Q24FromFloat(2, 0), positions[0])
+	assert.Equal(t, fixpoint.Vec2Q24FromFloat(1, 1), previous[0])
+
+	// Pinned particles stand still.
+	assert.Equal(t, fixpoint.Vec2Q24FromFloat(1, 1), positions[1])
+	assert.Equal(t, fixpoint.Vec2Q24FromFloat(1, 1), previous[1])
+
+	// Damping removes part of the velocity.
+	s.Damping = fixpoint.Q24FromFloat(0.25)
+	positions3 := []fixpoint.Vec3Q24{fixpoint.Vec3Q24FromFloat(1, 0, 0)}
+	previous3 := []fixpoint.Vec3Q24{fixpoint.Vec3Q24FromFloat(0, 0, 0)}
+	s.Integrate3(positions3, previous3, nil, fixpoint.Vec3Q24{}, dt)
+	assert.Equal(t, fixpoint.Vec3Q24FromFloat(1.75, 0, 0), positions3[0])
+}
+
+func TestRope(t *testing.T) {
+	// A horizontal rope that is pinned at one end swings down and comes to
+	// rest hanging straight down, with about the length of its segments.
+	const n = 8
+	segment := fixpoint.Q24FromFloat(0.25)
+	positions := make([]fixpoint.Vec2Q24, n)
+	for i := range positions {
+		positions[i] = fixpoint.Vec2Q24{X: fixpoint.Q24{N: segment.N * int32(i)}}
+	}
+	previous := append([]fixpoint.Vec2Q24(nil), positions...)
+	pinned := []bool{true}
+	constraints := Rope(nil, 0, n, segment)
+	assert.Len(t, constraints, n-1)
+	assert.Equal(t, Constraint{A: 6, B: 7, Length: segment}, constraints[6])
+
+	s := Solver{Iterations: 8, Stiffness: fixpoint.Q24FromInt32(1), Damping: fixpoint.Q24FromFloat(0.05)}
+	gravity := fixpoint.Vec2Q24FromFloat(0, -9.81)
+	for i := 0; i < 600; i++ {
+		s.Step2(positions, previous, pinned, constraints, gravity, fixpoint.Q24FromFloat(1.0/60))
+	}
+	assert.Equal(t, fixpoint.Vec2Q24{}, positions[0])
+	end := positions[n-1]
+	assert.InDelta(t, 0, end.X.Float(), 0.01)
+	assert.InDelta(t, -1.75, end.Y.Float(), 0.02)
+}
+
+func TestCloth(t *testing.T) {
+	constraints := Cloth(nil, 2, 3, 2, fixpoint.Q24FromInt32(1))
+	one := fixpoint.Q24FromInt32(1)
+	assert.Equal(t, []Constraint{
+		{2, 3, one}, {2, 5, one},
+		{3, 4, one}, {3, 6, one},
+		{4, 7, one},
+		{5, 6, one},
+		{6, 7, one},
+	}, constraints)
+
+	// A cloth hanging from its two upper corners stays symmetric and
+	// doesn't tear.
+	const width, height = 5, 4
+	spacing := fixpoint.Q24FromFloat(0.25)
+	positions := make([]fixpoint.Vec3Q24, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			positions[y*width+x] = fixpoint.Vec3Q24{X: fixpoint.Q24{N: spacing.N * int32(x)}, Y: fixpoint.Q24{N: -spacing.N * int32(y)}}
+		}
+	}
+	previous := append([]fixpoint.Vec3Q24(nil), positions...)
+	pinned := make([]bool, width)
+	pinned[0], pinned[width-1] = true, true
+	constraints = Cloth(nil, 0, width, height, spacing)
+	s := Solver{Iterations: 8, Stiffness: fixpoint.Q24FromInt32(1), Damping: fixpoint.Q24FromFloat(0.05)}
+	wind := fixpoint.Vec3Q24FromFloat(0, -9.81, 2)
+	for i := 0; i < 300; i++ {
+		s.Step3(positions, previous, pinned, constraints, wind, fixpoint.Q24FromFloat(1.0/60))
+	}
+	for _, c := range constraints {
+		d := positions[c.B].Sub(positions[c.A]).Len().Float()
+		assert.InDelta(t, spacing.Float(), d, 0.03, "constraint %d-%d", c.A, c.B)
+	}
+	left, right := positions[width*(height-1)], positions[width*height-1]
+	assert.InDelta(t, 1, left.X.Float()+right.X.Float(), 0.01)
+	if left.Z.N <= 0 {
+		t.Errorf("expected the wind to blow the cloth away: %v", left)
+	}
+}
@@ -0,0 +1,177 @@
+// Package vibration extracts features from accelerometer data for condition
+// monitoring of machines, such as the vibration energy in frequency bands and
+// the crest factor. These are the usual inputs for anomaly detection (see the
+// stats package) or for a classifier that recognizes faults like imbalance
+// or bearing wear.
+package vibration
+
+import (
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/filters"
+	"github.com/aykevl/fixpoint/internal/fixmath"
+)
+
+// Features are the vibration features of one window of accelerometer samples.
+// The energies are mean squares in the squared unit of the accelerometer
+// (for example g²) and saturate at the largest Q24 value.
+type Features struct {
+	// Bands contains the energy per frequency band, in the same order as
+	// Extractor.Bands.
+	Bands []BandEnergy
+
+	// RMS is the root mean square of the magnitude of the vibration (the
+	// acceleration after the high-pass filter).
+	RMS fixpoint.Q24
+
+	// Peak is the largest magnitude of the vibration.
+	Peak fixpoint.Q24
+
+	// CrestFactor is Peak divided by RMS. It is about 1.4 for a sine wave and
+	// increases with impacts, as produced by damaged bearings or gears. It is
+	// zero when there is no vibration at all.
+	CrestFactor fixpoint.Q24
+}
+
+// BandEnergy is the vibration energy in one frequency band.
+type BandEnergy struct {
+	// Axis is the energy along each axis of the accelerometer.
+	Axis fixpoint.Vec3Q24
+
+	// Total is the sum of the energy of all axes: the mean square of the
+	// magnitude of the band-filtered vibration. Because the filters are
+	// linear, it doesn't depend on the orientation of the sensor.
+	Total fixpoint.Q24
+}
+
+// Extractor calculates vibration features over consecutive windows of
+// accelerometer samples.
+//
+// The zero value is not usable: HighPass and Window must be set. The filters
+// are allocated on the first call to Update.
+type Extractor struct {
+	// Bands contains the band-pass filters of the frequency bands to
+	// measure, usually created with filters.BiquadBandPass. It must not be
+	// changed after the first call to Update.
+	Bands []filters.BiquadCoeffs
+
+	// HighPass is a high-pass filter that removes gravity and the sensor
+	// offset before calculating RMS, Peak and CrestFactor, usually created
+	// with filters.BiquadHighPass with a cutoff well below the lowest
+	// frequency of interest.
+	HighPass filters.BiquadCoeffs
+
+	// Window is the number of samples per window. The energies are exact
+	// (apart from rounding) and saturate correctly for windows of up to 2^25
+	// samples.
+	Window int
+
+	bands    []filters.Biquad // three per band, one for each axis
+	highPass [3]filters.Biquad
+	energy   []uint64 // Q32, three per band
+	sum      uint64   // Q32, squared magnitude after the high-pass filter
+	peak     uint64   // Q48
+	count    int
+	features Features
+}
+
+// Update adds a new accelerometer sample. When it completes a window, it
+// returns the features of that window and true. The returned features are
+// only valid until the next window is completed.
+func (e *Extractor) Update(accel fixpoint.Vec3Q24) (Features, bool) {
+	if e.bands == nil {
+		e.bands = make([]filters.Biquad, 3*len(e.Bands))
+		for i := range e.bands {
+			e.bands[i].Coeffs = e.Bands[i/3]
+		}
+		e.energy = make([]uint64, len(e.bands))
+		e.features.Bands = make([]BandEnergy, len(e.Bands))
+		for i := range e.highPass {
+			e.highPass[i].Coeffs = e.HighPass
+		}
+	}
+
+	axes := [3]fixpoint.Q24{accel.X, accel.Y, accel.Z}
+	for i := range e.bands {
+		y := int64(e.bands[i].Update(axes[i%3]).N)
+		e.energy[i] = addSat(e.energy[i], q32(uint64(y*y)))
+	}
+	var magnitude uint64
+	for i, x := range axes {
+		y := int64(e.highPass[i].Update(x).N)
+		magnitude += uint64(y * y)
+	}
+	e.sum = addSat(e.sum, q32(magnitude))
+	if magnitude > e.peak {
+		e.peak = magnitude
+	}
+
+	e.count++
+	if e.count < e.Window {
+		return e.features, false
+	}
+	n := uint64(e.count)
+	for i := range e.features.Bands {
+		x, y, z := meanSquare(e.energy[3*i], n), meanSquare(e.energy[3*i+1], n), meanSquare(e.energy[3*i+2], n)
+		e.features.Bands[i] = BandEnergy{
+			Axis:  fixpoint.Vec3Q24{X: x, Y: y, Z: z},
+			Total: fixpoint.Q24{N: fixmath.Saturate(int64(x.N) + int64(y.N) + int64(z.N))},
+		}
+	}
+	e.features.RMS = fixpoint.MaxQ24
+	if mean := e.sum / n; mean < 1<<46 {
+		// Below 2^14 (an RMS of 128), so it fits in a Q48.
+		e.features.RMS = fixpoint.Q24{N: fixmath.Saturate(int64(fixmath.SqrtRound(mean << 16)))}
+	}
+	e.features.Peak = fixpoint.Q24{N: fixmath.Saturate(int64(fixmath.SqrtRound(e.peak)))}
+	e.features.CrestFactor = fixpoint.Q24{}
+	if e.features.RMS.N != 0 {
+		e.features.CrestFactor = e.features.Peak.DivSat(e.features.RMS)
+	}
+
+	// Start a new window. The filters keep running.
+	for i := range e.energy {
+		e.energy[i] = 0
+	}
+	e.sum, e.peak, e.count = 0, 0, 0
+	return e.features, true
+}
+
+// Reset clears the current window and the state of the filters.
+func (e *Extractor) Reset() {
+	for i := range e.bands {
+		e.bands[i].Reset(fixpoint.Q24{})
+	}
+	for i := range e.highPass {
+		e.highPass[i].Reset(fixpoint.Q24{})
+	}
+	for i := range e.energy {
+		e.energy[i] = 0
+	}
+	e.sum, e.peak, e.count = 0, 0, 0
+}
+
+// The sums of squares are kept in Q32 format instead of Q48, which is still
+// much more precise than the Q24 result but leaves enough headroom for long
+// windows with large amplitudes. A single square (up to 3·2^62 in Q48) always
+// fits, and the sums saturate instead of wrapping around: a saturated sum of up
+// to 2^25 samples has a mean of at least 128, so it saturates the result too.
+
+// q32 converts a square in Q48 format to Q32, rounded to the nearest value.
+func q32(square uint64) uint64 {
+	return square>>16 + square>>15&1
+}
+
+// addSat returns a+b, saturated to the largest uint64.
+func addSat(a, b uint64) uint64 {
+	if sum := a + b; sum >= a {
+		return sum
+	}
+	return 1<<64 - 1
+}
+
+// meanSquare returns sum/n, where sum is a sum of n squares in Q32 format, as
+// a Q24 rounded to the nearest value and saturated to the Q24 range.
+func meanSquare(sum, n uint64) fixpoint.Q24 {
+	mean := sum / n
+	return fixpoint.Q24{N: fixmath.Saturate(int64(mean>>8 + mean>>7&1))}
+}
@@ -0,0 +1,127 @@
+package vibration
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aykevl/fixpoint"
+	"github.com/aykevl/fixpoint/filters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractor(t *testing.T) {
+	// A sensor sampled at 1kHz, with bands at 50Hz and 200Hz.
+	freq := func(hz float32) fixpoint.Q24 { return fixpoint.Q24FromFloat(hz / 1000) }
+	e := Extractor{
+		Bands: []filters.BiquadCoeffs{
+			filters.BiquadBandPass(freq(50), fixpoint.Q24FromInt32(4)),
+			filters.BiquadBandPass(freq(200), fixpoint.Q24FromInt32(4)),
+		},
+		HighPass: filters.BiquadHighPass(freq(5), filters.Butterworth),
+		Window:   1000,
+	}
+
+	// Gravity along Z and a 200Hz vibration with an amplitude of 0.1g along
+	// an axis in the XY plane. A sine wave has a mean square of half the
+	// squared amplitude: 0.005g².
+	signal := func(i int, dir fixpoint.Vec3Q24) fixpoint.Vec3Q24 {
+		phase := fixpoint.Q24FromFloat(float32(2 * math.Pi * float64(i%5) / 5))
+		return dir.Mul(fixpoint.Sin(phase).Mul(fixpoint.Q24FromFloat(0.1))).Add(fixpoint.Vec3Q24FromFloat(0, 0, 1))
+	}
+	run := func(dir fixpoint.Vec3Q24) Features {
+		e.Reset()
+		var features Features
+		for i := 0; i < 3000; i++ {
+			f, ok := e.Update(signal(i, dir))
+			if ok {
+				features = f
+			}
+			assert.Equal(t, (i+1)%1000 == 0, ok)
+		}
+		return features
+	}
+	f := run(fixpoint.Vec3Q24FromFloat(1, 0, 0))
+	assert.InDelta(t, 0.005, f.Bands[1].Axis.X.Float(), 0.0002)
+	assert.InDelta(t, 0, f.Bands[1].Axis.Y.Float(), 1e-6)
+	assert.InDelta(t, 0, f.Bands[1].Axis.Z.Float(), 1e-6)
+	assert.InDelta(t, 0.005, f.Bands[1].Total.Float(), 0.0002)
+	assert.InDelta(t, 0, f.Bands[0].Total.Float(), 0.0002)
+	assert.InDelta(t, 0.1/math.Sqrt2, f.RMS.Float(), 0.002)
+	// With 5 samples per period, the largest sample is at 72°.
+	peak := 0.1 * math.Sin(0.4*math.Pi)
+	assert.InDelta(t, peak, f.Peak.Float(), 0.002)
+	assert.InDelta(t, peak/(0.1/math.Sqrt2), f.CrestFactor.Float(), 0.02)
+
+	// The total energy doesn't depend on the direction of the vibration.
+	diag := run(fixpoint.Vec3Q24FromFloat(0.6, 0.8, 0))
+	assert.InDelta(t, 0.36*0.005, diag.Bands[1].Axis.X.Float(), 0.0002)
+	assert.InDelta(t, 0.64*0.005, diag.Bands[1].Axis.Y.Float(), 0.0002)
+	assert.InDelta(t, f.Bands[1].Total.N, diag.Bands[1].Total.N, 200)
+	assert.InDelta(t, f.RMS.N, diag.RMS.N, 200)
+
+	// Impacts increase the crest factor.
+	e.Reset()
+	for i := 0; i < 2000; i++ {
+		accel := signal(i, fixpoint.Vec3Q24FromFloat(1, 0, 0))
+		if i%100 == 50 {
+			accel.Y = fixpoint.Q24FromFloat(0.5)
+		}
+		if g, ok := e.Update(accel); ok {
+			f = g
+		}
+	}
+	assert.True(t, f.CrestFactor.Float() > 3, "crest factor: %f", f.CrestFactor.Float())
+
+	// Without vibration, the crest factor is zero.
+	still := Extractor{HighPass: e.HighPass, Window: 10}
+	for i := 0; i < 10; i++ {
+		f, _ = still.Update(fixpoint.Vec3Q24{})
+	}
+	assert.Equal(t, fixpoint.Q24{}, f.CrestFactor)
+	assert.Empty(t, f.Bands)
+}
+
+func TestExtractorLongWindow(t *testing.T) {
+	// A long window with a large amplitude: the sums of squares would
+	// overflow in Q48 format.
+	freq := func(hz float32) fixpoint.Q24 { return fixpoint.Q24FromFloat(hz / 1000) }
+	run := func(signal func(i int) fixpoint.Vec3Q24) Features {
+		e := Extractor{
+			Bands:    []filters.BiquadCoeffs{filters.BiquadBandPass(freq(200), fixpoint.Q24FromInt32(4))},
+			HighPass: filters.BiquadHighPass(freq(5), filters.Butterworth),
+			Window:   100000,
+		}
+		for i := 0; ; i++ {
+			if f, ok := e.Update(signal(i)); ok {
+				return f
+			}
+		}
+	}
+	sine := func(amplitude float64) func(i int) fixpoint.Vec3Q24 {
+		return func(i int) fixpoint.Vec3Q24 {
+			phase := 2 * math.Pi * float64(i%5) / 5
+			return fixpoint.Vec3Q24{X: fixpoint.Q24FromFloat64(amplitude * math.Sin(phase)), Z: fixpoint.Q24FromInt32(1)}
+		}
+	}
+
+	// A mean square of 32g².
+	f := run(sine(8))
+	assert.InDelta(t, 32, f.Bands[0].Axis.X.Float(), 1)
+	assert.InDelta(t, 32, f.Bands[0].Total.Float(), 1)
+	assert.InDelta(t, 8/math.Sqrt2, f.RMS.Float(), 0.1)
+
+	// A mean square of 200g² saturates the energies, but not the RMS.
+	f = run(sine(20))
+	assert.Equal(t, fixpoint.MaxQ24, f.Bands[0].Axis.X)
+	assert.Equal(t, fixpoint.MaxQ24, f.Bands[0].Total)
+	assert.InDelta(t, 20/math.Sqrt2, f.RMS.Float(), 0.2)
+
+	// An RMS above 128 saturates as well: a vibration of 100g along all
+	// axes has a magnitude of about 173g.
+	f = run(func(i int) fixpoint.Vec3Q24 {
+		x := fixpoint.Q24FromInt32(int32(100 - 200*(i&1)))
+		return fixpoint.Vec3Q24{X: x, Y: x, Z: x}
+	})
+	assert.Equal(t, fixpoint.MaxQ24, f.RMS)
+	assert.Equal(t, fixpoint.MaxQ24, f.Peak)
+}